@@ -60,6 +60,13 @@ func (c *Client) AddTarget(ctx context.Context, target *platform.ScraperTarget,
 			Op:   OpPrefix + platform.OpAddTarget,
 		}
 	}
+	if err := target.ValidateOptions(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  err.Error(),
+			Op:   OpPrefix + platform.OpAddTarget,
+		}
+	}
 	err = c.db.Update(func(tx *bolt.Tx) error {
 		target.ID = c.IDGenerator.ID()
 		if err := c.putTarget(ctx, tx, target); err != nil {
@@ -124,6 +131,13 @@ func (c *Client) UpdateTarget(ctx context.Context, update *platform.ScraperTarge
 			Msg:  "provided scraper target ID has invalid format",
 		}
 	}
+	if err := update.ValidateOptions(); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Msg:  err.Error(),
+		}
+	}
 	err = c.db.Update(func(tx *bolt.Tx) error {
 		target, pe = c.findTargetByID(ctx, tx, update.ID)
 		if pe != nil {
@@ -35,6 +35,7 @@ func init() {
 	influxCmd.AddCommand(replCmd)
 	influxCmd.AddCommand(setupCmd)
 	influxCmd.AddCommand(taskCmd)
+	influxCmd.AddCommand(telegrafCmd)
 	influxCmd.AddCommand(userCmd)
 	influxCmd.AddCommand(writeCmd)
 	influxCmd.AddCommand(pingCmd)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/influxdata/flux/repl"
 	platform "github.com/influxdata/influxdb"
@@ -48,9 +49,20 @@ func runF(cmd *cobra.Command, args []string) {
 	cmd.Usage()
 }
 
+var backfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Backfill related commands",
+	Run:   backfillF,
+}
+
+func backfillF(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+}
+
 func init() {
 	taskCmd.AddCommand(runCmd)
 	taskCmd.AddCommand(logCmd)
+	taskCmd.AddCommand(backfillCmd)
 }
 
 // TaskCreateFlags define the Create Command
@@ -585,3 +597,289 @@ func runRetryF(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+type BackfillCreateFlags struct {
+	taskID      string
+	start       string
+	stop        string
+	concurrency int
+}
+
+var backfillCreateFlags BackfillCreateFlags
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "run a task for every time it was scheduled in a time range",
+		RunE:  wrapCheckSetup(backfillCreateF),
+	}
+
+	cmd.Flags().StringVarP(&backfillCreateFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.Flags().StringVarP(&backfillCreateFlags.start, "start", "", "", "start of the time range, RFC3339 (required)")
+	cmd.Flags().StringVarP(&backfillCreateFlags.stop, "stop", "", "", "stop of the time range, RFC3339; defaults to now")
+	cmd.Flags().IntVarP(&backfillCreateFlags.concurrency, "concurrency", "c", platform.BackfillDefaultConcurrency, "the most runs queued at once")
+	cmd.MarkFlagRequired("task-id")
+	cmd.MarkFlagRequired("start")
+
+	backfillCmd.AddCommand(cmd)
+}
+
+func backfillCreateF(cmd *cobra.Command, args []string) error {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var taskID platform.ID
+	if err := taskID.DecodeFromString(backfillCreateFlags.taskID); err != nil {
+		return err
+	}
+	start, err := time.Parse(time.RFC3339, backfillCreateFlags.start)
+	if err != nil {
+		return err
+	}
+	stop := time.Now()
+	if backfillCreateFlags.stop != "" {
+		stop, err = time.Parse(time.RFC3339, backfillCreateFlags.stop)
+		if err != nil {
+			return err
+		}
+	}
+
+	b, err := s.CreateBackfill(context.Background(), platform.BackfillCreate{
+		TaskID:      taskID,
+		Start:       start,
+		Stop:        stop,
+		Concurrency: backfillCreateFlags.concurrency,
+	})
+	if err != nil {
+		return err
+	}
+
+	writeBackfills(b)
+	return nil
+}
+
+type BackfillFindFlags struct {
+	taskID string
+	id     string
+}
+
+var backfillFindFlags BackfillFindFlags
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "find",
+		Short: "find backfills of a task and their progress",
+		RunE:  wrapCheckSetup(backfillFindF),
+	}
+
+	cmd.Flags().StringVarP(&backfillFindFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.Flags().StringVarP(&backfillFindFlags.id, "id", "", "", "backfill id")
+	cmd.MarkFlagRequired("task-id")
+
+	backfillCmd.AddCommand(cmd)
+}
+
+func backfillFindF(cmd *cobra.Command, args []string) error {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var taskID platform.ID
+	if err := taskID.DecodeFromString(backfillFindFlags.taskID); err != nil {
+		return err
+	}
+
+	var bs []*platform.Backfill
+	if backfillFindFlags.id != "" {
+		var id platform.ID
+		if err := id.DecodeFromString(backfillFindFlags.id); err != nil {
+			return err
+		}
+		b, err := s.FindBackfillByID(context.Background(), taskID, id)
+		if err != nil {
+			return err
+		}
+		bs = append(bs, b)
+	} else {
+		var err error
+		bs, err = s.FindBackfills(context.Background(), taskID)
+		if err != nil {
+			return err
+		}
+	}
+
+	writeBackfills(bs...)
+	return nil
+}
+
+type BackfillCancelFlags struct {
+	taskID string
+	id     string
+}
+
+var backfillCancelFlags BackfillCancelFlags
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "cancel a running backfill",
+		RunE:  wrapCheckSetup(backfillCancelF),
+	}
+
+	cmd.Flags().StringVarP(&backfillCancelFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.Flags().StringVarP(&backfillCancelFlags.id, "id", "", "", "backfill id (required)")
+	cmd.MarkFlagRequired("task-id")
+	cmd.MarkFlagRequired("id")
+
+	backfillCmd.AddCommand(cmd)
+}
+
+func backfillCancelF(cmd *cobra.Command, args []string) error {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var taskID, id platform.ID
+	if err := taskID.DecodeFromString(backfillCancelFlags.taskID); err != nil {
+		return err
+	}
+	if err := id.DecodeFromString(backfillCancelFlags.id); err != nil {
+		return err
+	}
+
+	if err := s.CancelBackfill(context.Background(), taskID, id); err != nil {
+		return err
+	}
+
+	fmt.Printf("Backfill %s of task %s canceled.\n", id, taskID)
+	return nil
+}
+
+func writeBackfills(bs ...*platform.Backfill) {
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"TaskID",
+		"Start",
+		"Stop",
+		"Status",
+		"Completed",
+		"Failed",
+		"Pending",
+	)
+	for _, b := range bs {
+		w.Write(map[string]interface{}{
+			"ID":        b.ID,
+			"TaskID":    b.TaskID,
+			"Start":     b.Start,
+			"Stop":      b.Stop,
+			"Status":    b.Status,
+			"Completed": b.Completed,
+			"Failed":    b.Failed,
+			"Pending":   b.Pending,
+		})
+	}
+	w.Flush()
+}
+
+type TaskTestFlags struct {
+	id           string
+	org          string
+	orgID        string
+	now          string
+	scheduledFor []string
+}
+
+var taskTestFlags TaskTestFlags
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "test [query literal or @/path/to/query.flux]",
+		Short: "run a task script without writing the data of its to() calls, and print that data",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  wrapCheckSetup(taskTestF),
+	}
+
+	cmd.Flags().StringVarP(&taskTestFlags.id, "id", "i", "", "id of the task to test instead of a script")
+	cmd.Flags().StringVarP(&taskTestFlags.org, "org", "", "", "name of the organization to run the script in")
+	cmd.Flags().StringVarP(&taskTestFlags.orgID, "org-id", "", "", "id of the organization to run the script in")
+	cmd.Flags().StringVarP(&taskTestFlags.now, "now", "", "", "simulated current time, RFC3339; defaults to now")
+	cmd.Flags().StringSliceVarP(&taskTestFlags.scheduledFor, "scheduled-for", "", nil, "times to run the script for, RFC3339; defaults to the latest time the task was scheduled for")
+
+	taskCmd.AddCommand(cmd)
+}
+
+func taskTestF(cmd *cobra.Command, args []string) error {
+	if (len(args) == 1) == (taskTestFlags.id != "") {
+		return fmt.Errorf("must specify exactly one of a script or id")
+	}
+	if taskTestFlags.org != "" && taskTestFlags.orgID != "" {
+		return fmt.Errorf("must specify exactly one of org or org-id")
+	}
+
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	tt := platform.TaskTest{Organization: taskTestFlags.org}
+	if taskTestFlags.id != "" {
+		if err := tt.TaskID.DecodeFromString(taskTestFlags.id); err != nil {
+			return err
+		}
+	} else {
+		flux, err := repl.LoadQuery(args[0])
+		if err != nil {
+			return fmt.Errorf("error parsing flux script: %s", err)
+		}
+		tt.Flux = flux
+	}
+	if taskTestFlags.orgID != "" {
+		if err := tt.OrganizationID.DecodeFromString(taskTestFlags.orgID); err != nil {
+			return fmt.Errorf("error parsing organization ID: %s", err)
+		}
+	}
+	if taskTestFlags.now != "" {
+		now, err := time.Parse(time.RFC3339, taskTestFlags.now)
+		if err != nil {
+			return err
+		}
+		tt.Now = now
+	}
+	for _, sf := range taskTestFlags.scheduledFor {
+		t, err := time.Parse(time.RFC3339, sf)
+		if err != nil {
+			return err
+		}
+		tt.ScheduledFor = append(tt.ScheduledFor, t)
+	}
+
+	runs, err := s.TestTask(context.Background(), tt)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, r := range runs {
+		fmt.Printf("Run scheduled for %s\n", r.ScheduledFor)
+		for _, l := range r.Logs {
+			fmt.Printf("  %s\n", l)
+		}
+		for _, res := range r.Results {
+			fmt.Printf("\nResult %s:\n%s\n", res.Name, res.CSV)
+		}
+		if r.Error != "" {
+			failed++
+		}
+	}
+
+	// Exit with an error if any run failed, so that task scripts can be checked in CI.
+	if failed > 0 {
+		return fmt.Errorf("%d of %d runs failed", failed, len(runs))
+	}
+	return nil
+}
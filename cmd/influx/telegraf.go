@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/cmd/influx/internal"
+	"github.com/influxdata/influxdb/http"
+	"github.com/spf13/cobra"
+)
+
+// Telegraf Command
+var telegrafCmd = &cobra.Command{
+	Use:   "telegraf",
+	Short: "Telegraf config management commands",
+	Run:   telegrafF,
+}
+
+func telegrafF(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+}
+
+// TelegrafImportFlags define the Import Command
+type TelegrafImportFlags struct {
+	file        string
+	name        string
+	description string
+	orgID       string
+}
+
+var telegrafImportFlags TelegrafImportFlags
+
+func init() {
+	telegrafImportCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create a telegraf config from a telegraf.conf file",
+		Long: `Create a telegraf config from a telegraf.conf file, read from stdin
+unless a file is given. Plugins without a typed config are kept as raw TOML.`,
+		RunE: wrapCheckSetup(telegrafImportF),
+	}
+
+	telegrafImportCmd.Flags().StringVarP(&telegrafImportFlags.file, "file", "f", "", "The path to the telegraf.conf file to import")
+	telegrafImportCmd.Flags().StringVarP(&telegrafImportFlags.name, "name", "n", "", "Name of the telegraf config that will be created")
+	telegrafImportCmd.Flags().StringVarP(&telegrafImportFlags.description, "description", "d", "", "Description of the telegraf config that will be created")
+	telegrafImportCmd.Flags().StringVarP(&telegrafImportFlags.orgID, "org-id", "", "", "The ID of the organization that owns the telegraf config")
+	telegrafImportCmd.MarkFlagRequired("name")
+	telegrafImportCmd.MarkFlagRequired("org-id")
+
+	telegrafCmd.AddCommand(telegrafImportCmd)
+}
+
+func telegrafImportF(cmd *cobra.Command, args []string) error {
+	orgID, err := platform.IDFromString(telegrafImportFlags.orgID)
+	if err != nil {
+		return fmt.Errorf("failed to decode org id %q: %v", telegrafImportFlags.orgID, err)
+	}
+
+	var config []byte
+	if telegrafImportFlags.file != "" {
+		config, err = ioutil.ReadFile(telegrafImportFlags.file)
+	} else {
+		config, err = ioutil.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read telegraf.conf: %v", err)
+	}
+
+	s := &http.TelegrafService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	tc, err := s.ImportTelegrafConfig(context.Background(), *orgID, telegrafImportFlags.name, telegrafImportFlags.description, string(config))
+	if err != nil {
+		return fmt.Errorf("failed to import telegraf config: %v", err)
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"Name",
+		"OrganizationID",
+		"Plugins",
+	)
+	w.Write(map[string]interface{}{
+		"ID":             tc.ID.String(),
+		"Name":           tc.Name,
+		"OrganizationID": tc.OrganizationID.String(),
+		"Plugins":        len(tc.Plugins),
+	})
+	w.Flush()
+
+	return nil
+}
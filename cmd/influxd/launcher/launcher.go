@@ -27,9 +27,9 @@ import (
 	"github.com/influxdata/influxdb/kit/tracing"
 	"github.com/influxdata/influxdb/kv"
 	influxlogger "github.com/influxdata/influxdb/logger"
-	"github.com/influxdata/influxdb/nats"
 	infprom "github.com/influxdata/influxdb/prometheus"
 	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/cache"
 	pcontrol "github.com/influxdata/influxdb/query/control"
 	"github.com/influxdata/influxdb/snowflake"
 	"github.com/influxdata/influxdb/source"
@@ -175,6 +175,76 @@ func buildLauncherCommand(l *Launcher, cmd *cobra.Command) {
 			Default: false,
 			Desc:    "disable sending telemetry data to https://telemetry.influxdata.com every 8 hours",
 		},
+		{
+			DestP:   &l.queryCacheConfig.MaxSize,
+			Flag:    "query-cache-max-size",
+			Default: 0,
+			Desc:    "maximum total size in bytes of cached query results; the cache is disabled when zero",
+		},
+		{
+			DestP:   &l.queryCacheConfig.MaxEntrySize,
+			Flag:    "query-cache-max-entry-size",
+			Default: 0,
+			Desc:    "maximum size in bytes of the results of a single cached query; defaults to the size of the cache",
+		},
+		{
+			DestP:   &l.queryCacheConfig.TTL,
+			Flag:    "query-cache-ttl",
+			Default: cache.DefaultTTL,
+			Desc:    "time query results are cached for unless the request sets a shorter max-age",
+		},
+		{
+			DestP:   &l.queryCacheConfig.NowResolution,
+			Flag:    "query-cache-now-resolution",
+			Default: cache.DefaultNowResolution,
+			Desc:    "resolution the time queries run at is truncated to when caching their results",
+		},
+		{
+			DestP:   &l.taskRetryBackoff,
+			Flag:    "task-retry-backoff",
+			Default: taskbackend.DefaultRetryBackoff,
+			Desc:    "time before the first retry of a failed task run, doubled for every following retry",
+		},
+		{
+			DestP:   &l.taskMaxRetryBackoff,
+			Flag:    "task-max-retry-backoff",
+			Default: taskbackend.DefaultMaxRetryBackoff,
+			Desc:    "maximum time between retries of a failed task run",
+		},
+		{
+			DestP: &l.taskLeaseOwner,
+			Flag:  "task-lease-owner",
+			Desc:  "unique name of this node among the nodes sharing task metadata and the kv store; if set, tasks are only scheduled while this node holds their lease",
+		},
+		{
+			DestP:   &l.taskLeaseTTL,
+			Flag:    "task-lease-ttl",
+			Default: coordinator.DefaultLeaseTTL,
+			Desc:    "time a task lease lasts without being renewed before another node may claim the task",
+		},
+		{
+			DestP: &l.scraperDiscoveryDir,
+			Flag:  "scraper-discovery-dir",
+			Desc:  "directory the files of scraper file discovery are read from; file discovery is disabled if unset",
+		},
+		{
+			DestP:   &l.scraperQueueType,
+			Flag:    "scraper-queue",
+			Default: "memory",
+			Desc:    "queue carrying scrape jobs and scraped metrics (memory or file); the file queue keeps them across restarts",
+		},
+		{
+			DestP:   &l.scraperQueuePath,
+			Flag:    "scraper-queue-path",
+			Default: filepath.Join(dir, "scraperqueue"),
+			Desc:    "directory of the file scraper queue",
+		},
+		{
+			DestP:   &l.scraperQueueSize,
+			Flag:    "scraper-queue-size",
+			Default: gather.DefaultQueueSize,
+			Desc:    "number of messages the scraper queue holds per subject before dropping new ones",
+		},
 	}
 
 	cli.BindOptions(cmd, opts)
@@ -199,6 +269,18 @@ type Launcher struct {
 	enginePath      string
 	secretStore     string
 
+	queryCacheConfig cache.Config
+
+	taskRetryBackoff    time.Duration
+	taskMaxRetryBackoff time.Duration
+	taskLeaseOwner      string
+	taskLeaseTTL        time.Duration
+
+	scraperDiscoveryDir string
+	scraperQueueType    string
+	scraperQueuePath    string
+	scraperQueueSize    int
+
 	boltClient    *bolt.Client
 	kvService     *kv.Service
 	engine        *storage.Engine
@@ -209,10 +291,11 @@ type Launcher struct {
 	httpPort   int
 	httpServer *nethttp.Server
 
-	natsServer *nats.Server
+	scraperQueue gather.Queue
 
-	scheduler *taskbackend.TickScheduler
-	taskStore taskbackend.Store
+	scheduler  *taskbackend.TickScheduler
+	backfiller *taskbackend.Backfiller
+	taskStore  taskbackend.Store
 
 	jaegerTracerCloser io.Closer
 	logger             *zap.Logger
@@ -270,10 +353,13 @@ func (m *Launcher) Shutdown(ctx context.Context) {
 	m.httpServer.Shutdown(ctx)
 
 	m.logger.Info("Stopping", zap.String("service", "task"))
+	m.backfiller.Stop()
 	m.scheduler.Stop()
 
-	m.logger.Info("Stopping", zap.String("service", "nats"))
-	m.natsServer.Close()
+	m.logger.Info("Stopping", zap.String("service", "scraper-queue"))
+	if err := m.scraperQueue.Close(); err != nil {
+		m.logger.Info("failed closing scraper queue", zap.Error(err))
+	}
 
 	m.logger.Info("Stopping", zap.String("service", "bolt"))
 	if err := m.boltClient.Close(); err != nil {
@@ -433,7 +519,9 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		orgLogSvc        platform.OrganizationOperationLogService = m.kvService
 		onboardingSvc    platform.OnboardingService               = m.kvService
 		scraperTargetSvc platform.ScraperTargetStoreService       = m.kvService
+		scraperStatusSvc platform.ScraperStatusService            = m.kvService
 		telegrafSvc      platform.TelegrafConfigStore             = m.kvService
+		telegrafAgentSvc platform.TelegrafAgentService            = m.kvService
 		userResourceSvc  platform.UserResourceMappingService      = m.kvService
 		labelSvc         platform.LabelService                    = m.kvService
 		secretSvc        platform.SecretService                   = m.kvService
@@ -464,9 +552,19 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		return err
 	}
 
-	var pointsWriter storage.PointsWriter
+	var (
+		pointsWriter storage.PointsWriter
+		queryCache   *cache.Cache
+	)
 	{
-		m.engine = storage.NewEngine(m.enginePath, m.StorageConfig, storage.WithRetentionEnforcer(bucketSvc))
+		engineOptions := []storage.Option{storage.WithRetentionEnforcer(bucketSvc)}
+		if m.queryCacheConfig.MaxSize > 0 {
+			// Writes and deletes invalidate the cached results of the ranges they modify.
+			queryCache = cache.New(m.queryCacheConfig)
+			m.reg.MustRegister(queryCache.PrometheusCollectors()...)
+			engineOptions = append(engineOptions, storage.WithWriteObserver(queryCache))
+		}
+		m.engine = storage.NewEngine(m.enginePath, m.StorageConfig, engineOptions...)
 		m.engine.WithLogger(m.logger)
 
 		if err := m.engine.Open(ctx); err != nil {
@@ -502,13 +600,23 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 	}
 
 	var storageQueryService = readservice.NewProxyQueryService(m.queryController)
-	var taskSvc platform.TaskService
+	if queryCache != nil {
+		storageQueryService = &cache.ProxyQueryService{
+			Cache:             queryCache,
+			ProxyQueryService: storageQueryService,
+			BucketService:     bucketSvc,
+		}
+	}
+	var (
+		taskSvc     platform.TaskService
+		taskTestSvc platform.TaskTestService
+	)
 	{
 		var (
 			store taskbackend.Store
 			err   error
 		)
-		store, err = taskbolt.New(m.boltClient.DB(), "tasks", taskbolt.NoCatchUp)
+		store, err = taskbolt.New(m.boltClient.DB(), "tasks")
 		if err != nil {
 			m.logger.Error("failed opening task bolt", zap.Error(err))
 			return err
@@ -524,48 +632,61 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		queryService := query.QueryServiceBridge{AsyncQueryService: m.queryController}
 		lr := taskbackend.NewQueryLogReader(queryService)
 		taskControlService := taskbackend.TaskControlAdaptor(store, lw, lr)
-		m.scheduler = taskbackend.NewScheduler(taskControlService, executor, time.Now().UTC().Unix(), taskbackend.WithTicker(ctx, 100*time.Millisecond), taskbackend.WithLogger(m.logger))
+		m.scheduler = taskbackend.NewScheduler(taskControlService, executor, time.Now().UTC().Unix(), taskbackend.WithTicker(ctx, 100*time.Millisecond), taskbackend.WithLogger(m.logger), taskbackend.WithRetryBackoff(m.taskRetryBackoff, m.taskMaxRetryBackoff))
 		m.scheduler.Start(ctx)
 		m.reg.MustRegister(m.scheduler.PrometheusCollectors()...)
 
 		taskSvc = task.PlatformAdapter(store, lr, m.scheduler, authSvc, userResourceSvc, orgSvc)
 		taskexecutor.AddTaskService(executor, taskSvc)
-		taskSvc = coordinator.New(m.logger.With(zap.String("service", "task-coordinator")), m.scheduler, taskSvc)
+		var coordinatorOpts []coordinator.Option
+		if m.taskLeaseOwner != "" {
+			// The leases are kept in the kv store, which the nodes sharing task metadata share as well.
+			coordinatorOpts = append(coordinatorOpts, coordinator.WithLeases(ctx, m.kvService, m.taskLeaseOwner, m.taskLeaseTTL))
+		}
+		taskSvc = coordinator.New(m.logger.With(zap.String("service", "task-coordinator")), m.scheduler, taskSvc, coordinatorOpts...)
 		taskSvc = task.NewValidator(m.logger.With(zap.String("service", "task-authz-validator")), taskSvc, bucketSvc)
+		m.backfiller = taskbackend.NewBackfiller(m.logger.With(zap.String("service", "task-backfiller")), taskSvc, store, lr, authSvc, snowflake.NewIDGenerator())
+		if err := m.backfiller.Resume(ctx); err != nil {
+			m.logger.Error("failed resuming task backfills", zap.Error(err))
+			return err
+		}
+		taskTestSvc = taskexecutor.NewTaskTester(m.logger.With(zap.String("service", "task-tester")), queryService, taskSvc)
 		m.taskStore = store
 	}
 
-	// NATS streaming server
-	m.natsServer = nats.NewServer()
-	if err := m.natsServer.Open(); err != nil {
-		m.logger.Error("failed to start nats streaming server", zap.Error(err))
-		return err
-	}
-
-	publisher := nats.NewAsyncPublisher("nats-publisher")
-	if err := publisher.Open(); err != nil {
-		m.logger.Error("failed to connect to streaming server", zap.Error(err))
-		return err
-	}
-
-	// TODO(jm): this is an example of using a subscriber to consume from the channel. It should be removed.
-	subscriber := nats.NewQueueSubscriber("nats-subscriber")
-	if err := subscriber.Open(); err != nil {
-		m.logger.Error("failed to connect to streaming server", zap.Error(err))
+	switch m.scraperQueueType {
+	case "memory":
+		m.scraperQueue = gather.NewChannelQueue(m.scraperQueueSize)
+	case "file":
+		q := gather.NewFileQueue(m.scraperQueuePath, m.scraperQueueSize, m.logger.With(zap.String("service", "scraper-queue")))
+		if err := q.Open(); err != nil {
+			m.logger.Error("failed to open scraper queue", zap.Error(err))
+			return err
+		}
+		m.scraperQueue = q
+	default:
+		err := fmt.Errorf("unknown scraper queue %q, expected \"memory\" or \"file\"", m.scraperQueueType)
+		m.logger.Error("failed setting scraper queue", zap.Error(err))
 		return err
 	}
+	m.reg.MustRegister(m.scraperQueue.PrometheusCollectors()...)
 
-	subscriber.Subscribe(gather.MetricsSubject, "metrics", &gather.RecorderHandler{
+	if err := m.scraperQueue.Subscribe(gather.MetricsSubject, "metrics", &gather.RecorderHandler{
 		Logger: m.logger,
 		Recorder: gather.PointWriter{
 			Writer: pointsWriter,
 		},
-	})
-	scraperScheduler, err := gather.NewScheduler(10, m.logger, scraperTargetSvc, publisher, subscriber, 10*time.Second, 30*time.Second)
+	}); err != nil {
+		m.logger.Error("failed to subscribe to scraped metrics", zap.Error(err))
+		return err
+	}
+	scraperScheduler, err := gather.NewScheduler(10, m.logger, scraperTargetSvc, secretSvc, scraperStatusSvc, m.scraperQueue, m.scraperQueue, 10*time.Second, 30*time.Second)
 	if err != nil {
 		m.logger.Error("failed to create scraper subscriber", zap.Error(err))
 		return err
 	}
+	scraperScheduler.DiscoveryDir = m.scraperDiscoveryDir
+	m.reg.MustRegister(scraperScheduler.PrometheusCollectors()...)
 
 	m.wg.Add(1)
 	go func(logger *zap.Logger) {
@@ -607,8 +728,13 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		InfluxQLService:                 nil, // No InfluxQL support
 		FluxService:                     storageQueryService,
 		TaskService:                     taskSvc,
+		BackfillService:                 m.backfiller,
+		TaskTestService:                 taskTestSvc,
 		TelegrafService:                 telegrafSvc,
+		TelegrafAgentService:            telegrafAgentSvc,
 		ScraperTargetStoreService:       scraperTargetSvc,
+		ScraperPreviewService:           gather.NewPreviewer(secretSvc),
+		ScraperStatusService:            scraperStatusSvc,
 		ChronografService:               chronografSvc,
 		SecretService:                   secretSvc,
 		LookupService:                   lookupSvc,
@@ -0,0 +1,30 @@
+package influxdb
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Duration is a time.Duration encoded in JSON as a duration string, like "1m30s".
+type Duration struct {
+	time.Duration
+}
+
+// MarshalJSON encodes d as a duration string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a duration string into d.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+	return nil
+}
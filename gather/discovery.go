@@ -0,0 +1,235 @@
+package gather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/influxdata/influxdb"
+)
+
+// defaultDiscoveryRefreshInterval is the time between the DNS lookups of the definitions without a refresh interval of their own.
+const defaultDiscoveryRefreshInterval = 30 * time.Second
+
+// Discovery tags, added to the metrics of the discovered targets.
+const (
+	// InstanceTag is the address of the discovered target the metric was scraped from.
+	InstanceTag = "instance"
+	// DNSNameTag is the DNS name the target was discovered from.
+	DNSNameTag = "dns_name"
+)
+
+// resolver looks up DNS records, like net.Resolver.
+type resolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// targetGroup is a group of target addresses sharing labels, as listed in discovery files.
+type targetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+// discoveryFile is a discovery file, as last read.
+type discoveryFile struct {
+	modTime time.Time
+	groups  []targetGroup
+}
+
+// dnsLookup are the groups of a definition, as last looked up in DNS.
+type dnsLookup struct {
+	expires time.Time
+	groups  []targetGroup
+}
+
+// discoverer expands scraper target definitions into the targets they discover.
+// It is not safe for concurrent use.
+type discoverer struct {
+	// dir is the directory discovery files are read from. File discovery is disabled if it is empty.
+	dir      string
+	resolver resolver
+	now      func() time.Time
+
+	files   map[string]*discoveryFile
+	lookups map[influxdb.ID]*dnsLookup
+}
+
+func newDiscoverer() *discoverer {
+	return &discoverer{
+		resolver: net.DefaultResolver,
+		now:      time.Now,
+		files:    make(map[string]*discoveryFile),
+		lookups:  make(map[influxdb.ID]*dnsLookup),
+	}
+}
+
+// targets returns the targets discovered by the definition def.
+// The discovered targets share the ID and options of def, and are tagged with their discovery labels,
+// which replace the tags of def of the same names, and with their address as InstanceTag.
+// Their scrapes are told apart by that address.
+func (d *discoverer) targets(ctx context.Context, def influxdb.ScraperTarget) ([]influxdb.ScraperTarget, error) {
+	var groups []targetGroup
+	var err error
+	switch def.Discovery.Type {
+	case influxdb.FileScraperDiscovery:
+		groups, err = d.fileGroups(def.Discovery)
+	case influxdb.DNSScraperDiscovery:
+		groups, err = d.dnsGroups(ctx, def.ID, def.Discovery)
+	default:
+		err = fmt.Errorf("unknown discovery type %q", def.Discovery.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := templateURL(def.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []influxdb.ScraperTarget
+	for _, g := range groups {
+		for _, addr := range g.Targets {
+			t := def
+			t.Discovery = nil
+			tu := *u
+			tu.Host = addr
+			t.URL = tu.String()
+			t.Tags = make(map[string]string, len(def.Tags)+len(g.Labels)+1)
+			for k, v := range def.Tags {
+				t.Tags[k] = v
+			}
+			for k, v := range g.Labels {
+				t.Tags[k] = v
+			}
+			t.Tags[InstanceTag] = addr
+			targets = append(targets, t)
+		}
+	}
+	return targets, nil
+}
+
+// forget drops the DNS lookups of the definitions not in ids, once they are removed.
+func (d *discoverer) forget(ids map[influxdb.ID]time.Time) {
+	for id := range d.lookups {
+		if _, ok := ids[id]; !ok {
+			delete(d.lookups, id)
+		}
+	}
+}
+
+// templateURL returns the URL the addresses of the discovered targets are set as the host of.
+func templateURL(s string) (*url.URL, error) {
+	if s == "" {
+		return &url.URL{Scheme: "http", Path: "/metrics"}, nil
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" {
+		u.Scheme = "http"
+	}
+	if u.Path == "" {
+		u.Path = "/metrics"
+	}
+	return u, nil
+}
+
+// fileGroups returns the groups of the discovery files of disc, reading the files that changed since they were last read.
+func (d *discoverer) fileGroups(disc *influxdb.ScraperDiscovery) ([]targetGroup, error) {
+	if d.dir == "" {
+		return nil, errors.New("file discovery is disabled, since no discovery directory is configured")
+	}
+
+	var groups []targetGroup
+	for _, name := range disc.Files {
+		path := filepath.Join(d.dir, filepath.Clean(name))
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		f, ok := d.files[path]
+		if !ok || !fi.ModTime().Equal(f.modTime) {
+			gs, err := readDiscoveryFile(path)
+			if err != nil {
+				return nil, err
+			}
+			f = &discoveryFile{modTime: fi.ModTime(), groups: gs}
+			d.files[path] = f
+		}
+		groups = append(groups, f.groups...)
+	}
+	return groups, nil
+}
+
+// readDiscoveryFile reads the groups of the JSON or YAML file path.
+func readDiscoveryFile(path string) ([]targetGroup, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []targetGroup
+	// JSON is YAML, so this reads both.
+	if err := yaml.Unmarshal(b, &groups); err != nil {
+		return nil, fmt.Errorf("invalid discovery file %s: %v", path, err)
+	}
+	for _, g := range groups {
+		for _, addr := range g.Targets {
+			if _, _, err := net.SplitHostPort(addr); err != nil {
+				return nil, fmt.Errorf("invalid target %q in discovery file %s: %v", addr, path, err)
+			}
+		}
+	}
+	return groups, nil
+}
+
+// dnsGroups returns the groups of the DNS names of disc, looking them up again once the last lookup expired.
+func (d *discoverer) dnsGroups(ctx context.Context, id influxdb.ID, disc *influxdb.ScraperDiscovery) ([]targetGroup, error) {
+	now := d.now()
+	if l, ok := d.lookups[id]; ok && now.Before(l.expires) {
+		return l.groups, nil
+	}
+
+	groups := make([]targetGroup, 0, len(disc.Names))
+	for _, name := range disc.Names {
+		var addrs []string
+		if disc.RecordType == "A" {
+			hosts, err := d.resolver.LookupHost(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			for _, h := range hosts {
+				addrs = append(addrs, net.JoinHostPort(h, strconv.Itoa(disc.Port)))
+			}
+		} else {
+			_, srvs, err := d.resolver.LookupSRV(ctx, "", "", name)
+			if err != nil {
+				return nil, err
+			}
+			for _, srv := range srvs {
+				addrs = append(addrs, net.JoinHostPort(strings.TrimSuffix(srv.Target, "."), strconv.Itoa(int(srv.Port))))
+			}
+		}
+		groups = append(groups, targetGroup{Targets: addrs, Labels: map[string]string{DNSNameTag: name}})
+	}
+
+	refresh := defaultDiscoveryRefreshInterval
+	if disc.RefreshInterval != nil {
+		refresh = disc.RefreshInterval.Duration
+	}
+	d.lookups[id] = &dnsLookup{expires: now.Add(refresh), groups: groups}
+	return groups, nil
+}
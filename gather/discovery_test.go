@@ -0,0 +1,218 @@
+package gather
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+)
+
+// stubResolver answers DNS lookups from fixed records, and counts them.
+type stubResolver struct {
+	srv     map[string][]*net.SRV
+	hosts   map[string][]string
+	lookups int
+}
+
+func (r *stubResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	r.lookups++
+	srvs, ok := r.srv[name]
+	if !ok {
+		return "", nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return name, srvs, nil
+}
+
+func (r *stubResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.lookups++
+	addrs, ok := r.hosts[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return addrs, nil
+}
+
+// discoveredTargets returns the tags of targets by URL.
+func discoveredTargets(targets []influxdb.ScraperTarget) map[string]map[string]string {
+	m := make(map[string]map[string]string, len(targets))
+	for _, t := range targets {
+		m[t.URL] = t.Tags
+	}
+	return m
+}
+
+func TestDiscoverer_Files(t *testing.T) {
+	dir, err := ioutil.TempDir("", "discovery")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "targets.yml")
+	write := func(content string, modTime time.Time) {
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(`
+- targets: ["node1:9100", "node2:9100"]
+  labels:
+    env: prod
+`, time.Unix(1000, 0))
+
+	d := newDiscoverer()
+	d.dir = dir
+	def := influxdb.ScraperTarget{
+		ID:   *orgID,
+		Type: influxdb.PrometheusScraperType,
+		URL:  "https://ignored:1234/probe?module=node",
+		Tags: map[string]string{"env": "dev", "team": "ops"},
+		Discovery: &influxdb.ScraperDiscovery{
+			Type:  influxdb.FileScraperDiscovery,
+			Files: []string{"targets.yml"},
+		},
+	}
+
+	targets, err := d.targets(context.Background(), def)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]map[string]string{
+		"https://node1:9100/probe?module=node": {"env": "prod", "team": "ops", "instance": "node1:9100"},
+		"https://node2:9100/probe?module=node": {"env": "prod", "team": "ops", "instance": "node2:9100"},
+	}
+	if diff := cmp.Diff(want, discoveredTargets(targets)); diff != "" {
+		t.Fatalf("unexpected targets: %s", diff)
+	}
+	for _, target := range targets {
+		if target.ID != def.ID || target.Discovery != nil {
+			t.Fatalf("expected a target of definition %s without discovery, got %+v", def.ID, target)
+		}
+	}
+
+	// Changes to the file are picked up.
+	write(`[{"targets": ["node3:9100"]}]`, time.Unix(2000, 0))
+	targets, err = d.targets(context.Background(), def)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = map[string]map[string]string{
+		"https://node3:9100/probe?module=node": {"env": "dev", "team": "ops", "instance": "node3:9100"},
+	}
+	if diff := cmp.Diff(want, discoveredTargets(targets)); diff != "" {
+		t.Fatalf("unexpected targets: %s", diff)
+	}
+
+	t.Run("invalid target", func(t *testing.T) {
+		write(`[{"targets": ["node3"]}]`, time.Unix(3000, 0))
+		if _, err := d.targets(context.Background(), def); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		if _, err := newDiscoverer().targets(context.Background(), def); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func TestDiscoverer_DNS(t *testing.T) {
+	r := &stubResolver{
+		srv: map[string][]*net.SRV{
+			"_metrics._tcp.example.com": {
+				{Target: "a.example.com.", Port: 9100},
+				{Target: "b.example.com.", Port: 9101},
+			},
+		},
+		hosts: map[string][]string{
+			"nodes.example.com": {"10.0.0.1", "10.0.0.2"},
+		},
+	}
+	now := time.Unix(1000, 0)
+	d := newDiscoverer()
+	d.resolver = r
+	d.now = func() time.Time { return now }
+
+	srv := influxdb.ScraperTarget{
+		ID:   *orgID,
+		Type: influxdb.PrometheusScraperType,
+		Discovery: &influxdb.ScraperDiscovery{
+			Type:            influxdb.DNSScraperDiscovery,
+			Names:           []string{"_metrics._tcp.example.com"},
+			RefreshInterval: &influxdb.Duration{Duration: time.Minute},
+		},
+	}
+	targets, err := d.targets(context.Background(), srv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]map[string]string{
+		"http://a.example.com:9100/metrics": {"dns_name": "_metrics._tcp.example.com", "instance": "a.example.com:9100"},
+		"http://b.example.com:9101/metrics": {"dns_name": "_metrics._tcp.example.com", "instance": "b.example.com:9101"},
+	}
+	if diff := cmp.Diff(want, discoveredTargets(targets)); diff != "" {
+		t.Fatalf("unexpected targets: %s", diff)
+	}
+
+	// The lookup is cached until the refresh interval passed.
+	r.srv["_metrics._tcp.example.com"] = r.srv["_metrics._tcp.example.com"][:1]
+	now = now.Add(30 * time.Second)
+	if targets, err = d.targets(context.Background(), srv); err != nil {
+		t.Fatal(err)
+	}
+	if len(targets) != 2 || r.lookups != 1 {
+		t.Fatalf("expected 2 cached targets and 1 lookup, got %d and %d", len(targets), r.lookups)
+	}
+	now = now.Add(time.Minute)
+	if targets, err = d.targets(context.Background(), srv); err != nil {
+		t.Fatal(err)
+	}
+	if len(targets) != 1 || r.lookups != 2 {
+		t.Fatalf("expected 1 target and 2 lookups, got %d and %d", len(targets), r.lookups)
+	}
+
+	a := influxdb.ScraperTarget{
+		ID:   *bucketID,
+		Type: influxdb.PrometheusScraperType,
+		Discovery: &influxdb.ScraperDiscovery{
+			Type:       influxdb.DNSScraperDiscovery,
+			Names:      []string{"nodes.example.com"},
+			RecordType: "A",
+			Port:       9100,
+		},
+	}
+	if targets, err = d.targets(context.Background(), a); err != nil {
+		t.Fatal(err)
+	}
+	var urls []string
+	for _, target := range targets {
+		urls = append(urls, target.URL)
+	}
+	sort.Strings(urls)
+	if diff := cmp.Diff([]string{"http://10.0.0.1:9100/metrics", "http://10.0.0.2:9100/metrics"}, urls); diff != "" {
+		t.Fatalf("unexpected targets: %s", diff)
+	}
+
+	// The lookups of removed definitions are dropped.
+	d.forget(map[influxdb.ID]time.Time{*bucketID: now})
+	if _, ok := d.lookups[*orgID]; ok || len(d.lookups) != 1 {
+		t.Fatalf("expected the lookup of %s to be dropped", *orgID)
+	}
+
+	a.Discovery.Names = []string{"missing.example.com"}
+	a.ID = *orgID
+	if _, err := d.targets(context.Background(), a); err == nil {
+		t.Fatal("expected an error")
+	}
+}
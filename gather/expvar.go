@@ -0,0 +1,104 @@
+package gather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+// expvarMeasurementPrefix prefixes the names of the statistics of InfluxDB 1.x, like the Telegraf influxdb input.
+const expvarMeasurementPrefix = "influxdb_"
+
+// expvarScraper parses the statistics of InfluxDB 1.x /debug/vars endpoints.
+// Every variable holding a statistic, like {"name": "httpd", "tags": {"bind": ":8086"}, "values": {"req": 3}},
+// is a metric, and so are the numeric values of the Go memstats variable.
+// Numbers are written as floats, since JSON does not tell an integer from a whole float.
+type expvarScraper struct {
+	// Secrets holds the credentials of the targets.
+	Secrets influxdb.SecretService
+}
+
+// expvarStatistic is a statistic of InfluxDB 1.x.
+type expvarStatistic struct {
+	Name   string                 `json:"name"`
+	Tags   map[string]string      `json:"tags"`
+	Values map[string]interface{} `json:"values"`
+}
+
+// Gather parses the statistics served by target.
+func (p *expvarScraper) Gather(ctx context.Context, target influxdb.ScraperTarget) (collected MetricsCollection, err error) {
+	resp, err := fetcher{Secrets: p.Secrets}.fetch(ctx, target, "application/json")
+	if err != nil {
+		return collected, err
+	}
+	defer resp.Body.Close()
+
+	var vars map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&vars); err != nil {
+		return collected, fmt.Errorf("reading expvar document failed: %v", err)
+	}
+
+	now := time.Now()
+	ms := make([]Metrics, 0, len(vars))
+	for key, raw := range vars {
+		if key == "memstats" {
+			var memstats map[string]interface{}
+			if err := json.Unmarshal(raw, &memstats); err != nil {
+				return collected, fmt.Errorf("reading expvar memstats failed: %v", err)
+			}
+			fields := make(map[string]interface{})
+			for k, v := range memstats {
+				// Arrays, like the recent pause times, are skipped.
+				if v, ok := v.(float64); ok {
+					fields[k] = v
+				}
+			}
+			if len(fields) > 0 {
+				ms = append(ms, Metrics{
+					Name:      expvarMeasurementPrefix + "memstats",
+					Tags:      map[string]string{},
+					Fields:    fields,
+					Timestamp: now,
+					Type:      MetricTypeUntyped,
+				})
+			}
+			continue
+		}
+
+		// Variables other than statistics, like cmdline, are skipped.
+		var stat expvarStatistic
+		if err := json.Unmarshal(raw, &stat); err != nil || stat.Name == "" {
+			continue
+		}
+		fields := make(map[string]interface{}, len(stat.Values))
+		for k, v := range stat.Values {
+			switch v := v.(type) {
+			case float64, bool, string:
+				fields[k] = v
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		tags := stat.Tags
+		if tags == nil {
+			tags = map[string]string{}
+		}
+		ms = append(ms, Metrics{
+			Name:      expvarMeasurementPrefix + stat.Name,
+			Tags:      tags,
+			Fields:    fields,
+			Timestamp: now,
+			Type:      MetricTypeUntyped,
+		})
+	}
+
+	return MetricsCollection{
+		MetricsSlice: ms,
+		OrgID:        target.OrgID,
+		BucketID:     target.BucketID,
+	}, nil
+}
@@ -0,0 +1,124 @@
+package gather
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+)
+
+// fetcher fetches the documents of scraper targets over HTTP, with their headers, credentials and TLS configuration.
+type fetcher struct {
+	// Secrets holds the credentials of the targets.
+	Secrets influxdb.SecretService
+}
+
+// fetch requests the URL of target, accepting the media types of accept.
+// The body of the response must be closed by the caller.
+func (f fetcher) fetch(ctx context.Context, target influxdb.ScraperTarget, accept string) (*http.Response, error) {
+	req, err := f.newRequest(ctx, target, accept)
+	if err != nil {
+		return nil, err
+	}
+	client, err := f.newClient(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, &statusError{URL: target.URL, StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	return resp, nil
+}
+
+// statusError is returned when a target responds with an HTTP status other than 200.
+type statusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("scraping %s returned status %s", e.URL, e.Status)
+}
+
+// newRequest returns the request scraping target, with its headers and credentials.
+func (f fetcher) newRequest(ctx context.Context, target influxdb.ScraperTarget, accept string) (*http.Request, error) {
+	req, err := http.NewRequest("GET", target.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", accept)
+	for k, v := range target.Headers {
+		req.Header.Set(k, v)
+	}
+
+	if target.BearerTokenSecret != "" {
+		token, err := f.loadSecret(ctx, target, target.BearerTokenSecret)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if target.BasicAuth != nil {
+		var password string
+		if target.BasicAuth.PasswordSecret != "" {
+			if password, err = f.loadSecret(ctx, target, target.BasicAuth.PasswordSecret); err != nil {
+				return nil, err
+			}
+		}
+		req.SetBasicAuth(target.BasicAuth.Username, password)
+	}
+	return req, nil
+}
+
+// newClient returns the client to scrape target with, connecting to it with its TLS configuration.
+func (f fetcher) newClient(ctx context.Context, target influxdb.ScraperTarget) (*http.Client, error) {
+	if target.TLS == nil {
+		return http.DefaultClient, nil
+	}
+
+	config := &tls.Config{InsecureSkipVerify: target.TLS.InsecureSkipVerify}
+	if target.TLS.CA != "" {
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM([]byte(target.TLS.CA)) {
+			return nil, fmt.Errorf("no certificate found in the TLS CA of target %s", target.ID)
+		}
+	}
+	if target.TLS.Cert != "" {
+		key, err := f.loadSecret(ctx, target, target.TLS.KeySecret)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := tls.X509KeyPair([]byte(target.TLS.Cert), []byte(key))
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: config,
+			// The client is only used for one scrape.
+			DisableKeepAlives: true,
+		},
+	}, nil
+}
+
+func (f fetcher) loadSecret(ctx context.Context, target influxdb.ScraperTarget, key string) (string, error) {
+	if f.Secrets == nil {
+		return "", fmt.Errorf("cannot load secret %q of target %s without a secret service", key, target.ID)
+	}
+	return f.Secrets.LoadSecret(ctx, target.OrgID, key)
+}
@@ -0,0 +1,502 @@
+package gather
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/influxdata/influxdb/nats"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// DefaultQueueSegmentSize is the size a segment of a FileQueue grows to before the next one is started.
+const DefaultQueueSegmentSize = 4 * 1024 * 1024
+
+const (
+	segmentExt = ".seg"
+	ackExt     = ".ack"
+
+	// recordHeaderSize is the size of the length and checksum preceding the data of every record of a segment.
+	recordHeaderSize = 8
+)
+
+// FileQueue is a durable queue, keeping the messages of every subject in a log of segment files until they are acked.
+// The messages not acked when it is closed, or when influxd stops, are delivered again once it is opened.
+//
+// Every subject is a directory of segments, holding records made of the length of a message,
+// its CRC-32 checksum and its data. The offsets of the acked records of a segment are appended to its ack file,
+// and a segment is removed once all of its records are acked.
+type FileQueue struct {
+	// SegmentSize is the size a segment grows to before the next one is started.
+	SegmentSize int64
+
+	dir     string
+	size    int
+	logger  *zap.Logger
+	metrics *queueMetrics
+
+	mu       sync.Mutex
+	subjects map[string]*fileSubject
+
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+var _ Queue = (*FileQueue)(nil)
+
+// NewFileQueue returns a FileQueue keeping its segments in dir, and holding up to size messages per subject.
+func NewFileQueue(dir string, size int, logger *zap.Logger) *FileQueue {
+	if size <= 0 {
+		size = DefaultQueueSize
+	}
+	return &FileQueue{
+		SegmentSize: DefaultQueueSegmentSize,
+		dir:         dir,
+		size:        size,
+		logger:      logger,
+		metrics:     newQueueMetrics(),
+		subjects:    make(map[string]*fileSubject),
+		done:        make(chan struct{}),
+	}
+}
+
+// Open creates the directory of the queue, and recovers the messages not acked when it was last closed.
+func (q *FileQueue) Open() error {
+	if err := os.MkdirAll(q.dir, 0700); err != nil {
+		return err
+	}
+
+	fis, err := ioutil.ReadDir(q.dir)
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, fi := range fis {
+		if !fi.IsDir() {
+			continue
+		}
+		subject, err := url.PathUnescape(fi.Name())
+		if err != nil {
+			continue
+		}
+		s := q.newSubject(subject)
+		if err := s.recover(q.logger); err != nil {
+			return err
+		}
+		q.subjects[subject] = s
+		q.metrics.depth.WithLabelValues(subject).Set(float64(len(s.msgs)))
+	}
+	return nil
+}
+
+func (q *FileQueue) newSubject(subject string) *fileSubject {
+	return &fileSubject{
+		name:     subject,
+		dir:      filepath.Join(q.dir, url.PathEscape(subject)),
+		ready:    make(chan struct{}, 1),
+		segments: make(map[uint64]*segment),
+	}
+}
+
+func (q *FileQueue) subject(subject string) (*fileSubject, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	s, ok := q.subjects[subject]
+	if !ok {
+		s = q.newSubject(subject)
+		if err := os.MkdirAll(s.dir, 0700); err != nil {
+			return nil, err
+		}
+		q.subjects[subject] = s
+	}
+	return s, nil
+}
+
+// Publish appends the message read from r to subject, or drops it if subject is full.
+func (q *FileQueue) Publish(subject string, r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-q.done:
+		return ErrQueueClosed
+	default:
+	}
+
+	s, err := q.subject(subject)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.msgs) >= q.size {
+		q.metrics.dropped.WithLabelValues(subject).Inc()
+		return ErrQueueFull
+	}
+	m, err := s.append(data, q.SegmentSize)
+	if err != nil {
+		return err
+	}
+	s.msgs = append(s.msgs, m)
+	s.signal()
+
+	q.metrics.published.WithLabelValues(subject).Inc()
+	q.metrics.depth.WithLabelValues(subject).Set(float64(len(s.msgs)))
+	return nil
+}
+
+// Subscribe handles the messages of subject with handler, until the queue is closed.
+func (q *FileQueue) Subscribe(subject, group string, handler nats.Handler) error {
+	select {
+	case <-q.done:
+		return ErrQueueClosed
+	default:
+	}
+
+	s, err := q.subject(subject)
+	if err != nil {
+		return err
+	}
+
+	sub := &queueSubscription{pending: s.pending}
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		for {
+			m := s.next(q.done)
+			if m == nil {
+				return
+			}
+			q.metrics.depth.WithLabelValues(subject).Set(float64(s.pending()))
+			atomic.AddInt64(&sub.delivered, 1)
+			handler.Process(sub, &queueMessage{data: m.data, ack: m.ack})
+		}
+	}()
+	return nil
+}
+
+// Close stops the delivery of messages, waits for the messages being handled, and closes the segments.
+func (q *FileQueue) Close() error {
+	q.closeOnce.Do(func() {
+		close(q.done)
+	})
+	q.wg.Wait()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var firstErr error
+	for _, s := range q.subjects {
+		if err := s.close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (q *FileQueue) PrometheusCollectors() []prometheus.Collector {
+	return q.metrics.PrometheusCollectors()
+}
+
+// fileSubject is the log of segments of a subject.
+type fileSubject struct {
+	name string
+	dir  string
+
+	mu sync.Mutex
+	// msgs are the messages waiting to be delivered.
+	msgs []*fileMessage
+	// ready is signaled when messages are waiting.
+	ready    chan struct{}
+	segments map[uint64]*segment
+	active   *segment
+	nextID   uint64
+}
+
+// segment is a segment file of a subject.
+type segment struct {
+	id   uint64
+	path string
+	// w appends to the segment, until it is sealed.
+	w *os.File
+	// acks appends to the ack file of the segment, once one of its records is acked.
+	acks    *os.File
+	size    int64
+	unacked int
+	sealed  bool
+}
+
+func (seg *segment) ackPath() string {
+	return strings.TrimSuffix(seg.path, segmentExt) + ackExt
+}
+
+// fileMessage is a record of a segment.
+type fileMessage struct {
+	s      *fileSubject
+	seg    *segment
+	offset int64
+	data   []byte
+	acked  bool
+}
+
+func (m *fileMessage) ack() error {
+	return m.s.ack(m)
+}
+
+func (s *fileSubject) pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.msgs)
+}
+
+// signal wakes up a subscriber waiting for messages. s.mu must be held.
+func (s *fileSubject) signal() {
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// next returns the next message to deliver, waiting for one until done is closed.
+func (s *fileSubject) next(done <-chan struct{}) *fileMessage {
+	for {
+		s.mu.Lock()
+		if len(s.msgs) > 0 {
+			m := s.msgs[0]
+			s.msgs[0] = nil
+			s.msgs = s.msgs[1:]
+			if len(s.msgs) > 0 {
+				// Wake up another subscriber for the messages left.
+				s.signal()
+			}
+			s.mu.Unlock()
+			return m
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-done:
+			return nil
+		case <-s.ready:
+		}
+	}
+}
+
+// append appends a record of data to the active segment, starting a new one if it is full. s.mu must be held.
+func (s *fileSubject) append(data []byte, segmentSize int64) (*fileMessage, error) {
+	if s.active != nil && s.active.size >= segmentSize {
+		if err := s.seal(s.active); err != nil {
+			return nil, err
+		}
+		s.active = nil
+	}
+	if s.active == nil {
+		seg := &segment{id: s.nextID, path: filepath.Join(s.dir, fmt.Sprintf("%020d%s", s.nextID, segmentExt))}
+		w, err := os.OpenFile(seg.path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		seg.w = w
+		s.nextID++
+		s.segments[seg.id] = seg
+		s.active = seg
+	}
+
+	seg := s.active
+	var buf bytes.Buffer
+	var header [recordHeaderSize]byte
+	binary.BigEndian.PutUint32(header[:4], uint32(len(data)))
+	binary.BigEndian.PutUint32(header[4:], crc32.ChecksumIEEE(data))
+	buf.Write(header[:])
+	buf.Write(data)
+	if _, err := seg.w.Write(buf.Bytes()); err != nil {
+		return nil, err
+	}
+
+	m := &fileMessage{s: s, seg: seg, offset: seg.size, data: data}
+	seg.size += int64(buf.Len())
+	seg.unacked++
+	return m, nil
+}
+
+// ack records that m was handled, and removes its segment once all of its records are.
+func (s *fileSubject) ack(m *fileMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if m.acked {
+		return nil
+	}
+	m.acked = true
+
+	seg := m.seg
+	seg.unacked--
+	if seg.sealed && seg.unacked == 0 {
+		return s.remove(seg)
+	}
+
+	if seg.acks == nil {
+		f, err := os.OpenFile(seg.ackPath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		seg.acks = f
+	}
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(m.offset))
+	_, err := seg.acks.Write(b[:])
+	return err
+}
+
+// seal stops appending to seg, and removes it if all of its records are acked. s.mu must be held.
+func (s *fileSubject) seal(seg *segment) error {
+	seg.sealed = true
+	if seg.w != nil {
+		if err := seg.w.Sync(); err != nil {
+			return err
+		}
+		if err := seg.w.Close(); err != nil {
+			return err
+		}
+		seg.w = nil
+	}
+	if seg.unacked == 0 {
+		return s.remove(seg)
+	}
+	return nil
+}
+
+// remove removes seg and its ack file. s.mu must be held.
+func (s *fileSubject) remove(seg *segment) error {
+	if seg.acks != nil {
+		seg.acks.Close()
+		seg.acks = nil
+	}
+	delete(s.segments, seg.id)
+	if s.active == seg {
+		s.active = nil
+	}
+	if err := os.Remove(seg.ackPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Remove(seg.path)
+}
+
+// close closes the files of the segments of s.
+func (s *fileSubject) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var firstErr error
+	for _, seg := range s.segments {
+		if seg.w != nil {
+			if err := seg.w.Sync(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+			if err := seg.w.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+			seg.w = nil
+		}
+		if seg.acks != nil {
+			if err := seg.acks.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+			seg.acks = nil
+		}
+	}
+	s.active = nil
+	return firstErr
+}
+
+// recover reads the records of the segments of s that were not acked.
+// A record cut short or corrupted, as when influxd crashed while appending it, ends its segment.
+func (s *fileSubject) recover(logger *zap.Logger) error {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*"+segmentExt))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		id, err := strconv.ParseUint(strings.TrimSuffix(filepath.Base(path), segmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		if id >= s.nextID {
+			s.nextID = id + 1
+		}
+
+		seg := &segment{id: id, path: path, sealed: true}
+		acked, err := readAcks(seg.ackPath())
+		if err != nil {
+			return err
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		var off int64
+		for off+recordHeaderSize <= int64(len(b)) {
+			n := int64(binary.BigEndian.Uint32(b[off : off+4]))
+			sum := binary.BigEndian.Uint32(b[off+4 : off+recordHeaderSize])
+			end := off + recordHeaderSize + n
+			if end > int64(len(b)) || crc32.ChecksumIEEE(b[off+recordHeaderSize:end]) != sum {
+				logger.Warn("Ignoring the corrupt end of a scraper queue segment", zap.String("path", path), zap.Int64("offset", off))
+				break
+			}
+			if !acked[off] {
+				s.msgs = append(s.msgs, &fileMessage{s: s, seg: seg, offset: off, data: b[off+recordHeaderSize : end]})
+				seg.unacked++
+			}
+			off = end
+		}
+		seg.size = off
+
+		if seg.unacked == 0 {
+			if err := s.remove(seg); err != nil {
+				return err
+			}
+			continue
+		}
+		s.segments[id] = seg
+	}
+	if len(s.msgs) > 0 {
+		s.signal()
+	}
+	return nil
+}
+
+// readAcks returns the offsets of the acked records listed in the ack file path.
+func readAcks(path string) (map[int64]bool, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	acked := make(map[int64]bool, len(b)/8)
+	for i := 0; i+8 <= len(b); i += 8 {
+		acked[int64(binary.BigEndian.Uint64(b[i:i+8]))] = true
+	}
+	return acked, nil
+}
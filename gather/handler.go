@@ -1,9 +1,10 @@
 package gather
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"net/http"
+	"time"
 
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/nats"
@@ -15,6 +16,9 @@ type handler struct {
 	Scraper   Scraper
 	Publisher nats.Publisher
 	Logger    *zap.Logger
+	// Status records the outcome of every scrape, if set.
+	Status  influxdb.ScraperStatusService
+	metrics *scrapeMetrics
 }
 
 // Process consumes scraper target from scraper target queue,
@@ -22,29 +26,78 @@ type handler struct {
 func (h *handler) Process(s nats.Subscription, m nats.Message) {
 	defer m.Ack()
 
-	req := new(influxdb.ScraperTarget)
+	req := new(scrapeRequest)
 	err := json.Unmarshal(m.Data(), req)
 	if err != nil {
 		h.Logger.Error("unable to unmarshal json", zap.Error(err))
 		return
 	}
 
-	ms, err := h.Scraper.Gather(context.TODO(), *req)
+	start := time.Now()
+	samples, err := h.scrape(req.ScraperTarget)
+	result := influxdb.ScrapeResult{
+		Time:     start.UTC(),
+		Duration: influxdb.Duration{Duration: time.Since(start)},
+		Samples:  samples,
+		Instance: req.Instance,
+	}
+	if err != nil {
+		result.Error = err.Error()
+		if serr, ok := err.(*statusError); ok {
+			result.StatusCode = serr.StatusCode
+		}
+	} else {
+		// Targets responding with anything but a 200 fail to be scraped.
+		result.StatusCode = http.StatusOK
+	}
+	h.record(req.ID, result)
+}
+
+// scrape gathers the metrics of target, and publishes them to the metrics queue.
+// It returns the number of metrics published.
+func (h *handler) scrape(target influxdb.ScraperTarget) (int, error) {
+	ctx := context.Background()
+	if target.Timeout != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, target.Timeout.Duration)
+		defer cancel()
+	}
+
+	ms, err := h.Scraper.Gather(ctx, target)
 	if err != nil {
 		h.Logger.Error("unable to gather", zap.Error(err))
-		return
+		return 0, err
+	}
+
+	if ms.MetricsSlice, err = Relabel(ms.MetricsSlice, target); err != nil {
+		h.Logger.Error("unable to relabel", zap.Error(err))
+		return 0, err
 	}
 
 	// send metrics to recorder queue
-	buf := new(bytes.Buffer)
-	if err := json.NewEncoder(buf).Encode(ms); err != nil {
-		h.Logger.Error("unable to marshal json", zap.Error(err))
-		return
+	buf, err := encodeMetrics(ms)
+	if err != nil {
+		h.Logger.Error("unable to encode metrics", zap.Error(err))
+		return 0, err
 	}
 
 	if err := h.Publisher.Publish(MetricsSubject, buf); err != nil {
 		h.Logger.Error("unable to publish scraper metrics", zap.Error(err))
-		return
+		return 0, err
 	}
 
+	return len(ms.MetricsSlice), nil
+}
+
+// record records the result r of a scrape of the target id, or of its discovered target r.Instance if it is set.
+func (h *handler) record(id influxdb.ID, r influxdb.ScrapeResult) {
+	if h.metrics != nil {
+		h.metrics.record(id, r)
+	}
+	if h.Status == nil {
+		return
+	}
+	if err := h.Status.RecordScrape(context.Background(), id, r); err != nil {
+		h.Logger.Error("unable to record scrape status", zap.Error(err), zap.Stringer("target_id", id))
+	}
 }
@@ -0,0 +1,108 @@
+package gather
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
+)
+
+// targetMessage is a nats message requesting a scrape of a target.
+type targetMessage struct {
+	data []byte
+}
+
+func (m *targetMessage) Data() []byte { return m.data }
+func (m *targetMessage) Ack() error   { return nil }
+
+type nopPublisher struct{}
+
+func (nopPublisher) Publish(subject string, r io.Reader) error { return nil }
+
+func TestHandler_RecordsStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(sampleRespSmall))
+	}))
+	defer ts.Close()
+
+	id := influxdbtesting.MustIDBase16("3a0d0a6365646120")
+	cases := []struct {
+		name   string
+		path   string
+		wantUp bool
+		want   influxdb.ScrapeResult
+	}{
+		{
+			name:   "success",
+			path:   "/metrics",
+			wantUp: true,
+			want:   influxdb.ScrapeResult{Samples: 1, StatusCode: http.StatusOK},
+		},
+		{
+			name: "wrong path",
+			path: "/metric",
+			want: influxdb.ScrapeResult{StatusCode: http.StatusNotFound},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got []influxdb.ScrapeResult
+			h := &handler{
+				Scraper:   &prometheusScraper{},
+				Publisher: nopPublisher{},
+				Logger:    zap.NewNop(),
+				Status: &mock.ScraperStatusService{
+					RecordScrapeFn: func(ctx context.Context, tid influxdb.ID, r influxdb.ScrapeResult) error {
+						if tid != id {
+							t.Errorf("expected the status of %s to be recorded, got %s", id, tid)
+						}
+						got = append(got, r)
+						return nil
+					},
+				},
+				metrics: newScrapeMetrics(),
+			}
+
+			data, err := json.Marshal(influxdb.ScraperTarget{
+				ID:       id,
+				Type:     influxdb.PrometheusScraperType,
+				URL:      ts.URL + c.path,
+				OrgID:    *orgID,
+				BucketID: *bucketID,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			h.Process(nil, &targetMessage{data: data})
+
+			if len(got) != 1 {
+				t.Fatalf("expected 1 scrape to be recorded, got %d", len(got))
+			}
+			r := got[0]
+			if r.Samples != c.want.Samples || r.StatusCode != c.want.StatusCode || (r.Error == "") != c.wantUp || r.Time.IsZero() {
+				t.Fatalf("unexpected scrape result %+v", r)
+			}
+
+			var up dto.Metric
+			if err := h.metrics.up.WithLabelValues(id.String(), "").(prometheus.Metric).Write(&up); err != nil {
+				t.Fatal(err)
+			}
+			if (up.GetGauge().GetValue() == 1) != c.wantUp {
+				t.Fatalf("expected up to be %t, got %v", c.wantUp, up.GetGauge().GetValue())
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package gather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/pkg/jsonpath"
+)
+
+// jsonScraper maps the values of the JSON documents of targets to the fields and tags of a metric,
+// as configured by each target.
+type jsonScraper struct {
+	// Secrets holds the credentials of the targets.
+	Secrets influxdb.SecretService
+}
+
+// Gather returns the metric mapped from the document served by target.
+func (p *jsonScraper) Gather(ctx context.Context, target influxdb.ScraperTarget) (collected MetricsCollection, err error) {
+	if target.JSON == nil {
+		return collected, fmt.Errorf("json target %s has no json configuration", target.ID)
+	}
+
+	resp, err := fetcher{Secrets: p.Secrets}.fetch(ctx, target, "application/json")
+	if err != nil {
+		return collected, err
+	}
+	defer resp.Body.Close()
+
+	var doc interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return collected, fmt.Errorf("reading json document failed: %v", err)
+	}
+
+	fields := make(map[string]interface{}, len(target.JSON.Fields))
+	for name, expr := range target.JSON.Fields {
+		v, ok, err := lookupJSON(doc, expr)
+		if err != nil {
+			return collected, err
+		}
+		if !ok {
+			continue
+		}
+		switch v := v.(type) {
+		case float64, bool, string:
+			fields[name] = v
+		default:
+			return collected, fmt.Errorf("value of json field %q at %s is not a number, boolean or string", name, expr)
+		}
+	}
+	if len(fields) == 0 {
+		return collected, fmt.Errorf("no json field found in the document of target %s", target.ID)
+	}
+
+	tags := make(map[string]string, len(target.JSON.Tags))
+	for name, expr := range target.JSON.Tags {
+		v, ok, err := lookupJSON(doc, expr)
+		if err != nil {
+			return collected, err
+		}
+		if !ok {
+			continue
+		}
+		switch v := v.(type) {
+		case string:
+			tags[name] = v
+		case float64:
+			tags[name] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			tags[name] = strconv.FormatBool(v)
+		default:
+			return collected, fmt.Errorf("value of json tag %q at %s is not a number, boolean or string", name, expr)
+		}
+	}
+
+	return MetricsCollection{
+		MetricsSlice: []Metrics{{
+			Name:      target.JSON.Measurement,
+			Tags:      tags,
+			Fields:    fields,
+			Timestamp: time.Now(),
+			Type:      MetricTypeUntyped,
+		}},
+		OrgID:    target.OrgID,
+		BucketID: target.BucketID,
+	}, nil
+}
+
+// lookupJSON returns the value of doc at the JSONPath expr, and false if there is none.
+// A null value is missing.
+func lookupJSON(doc interface{}, expr string) (interface{}, bool, error) {
+	path, err := jsonpath.Parse(expr)
+	if err != nil {
+		return nil, false, err
+	}
+	v, ok := path.Get(doc)
+	return v, ok && v != nil, nil
+}
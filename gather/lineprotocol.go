@@ -0,0 +1,60 @@
+package gather
+
+import (
+	"context"
+	"io/ioutil"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+)
+
+// lineProtocolScraper parses the points of targets serving line protocol.
+type lineProtocolScraper struct {
+	// Secrets holds the credentials of the targets.
+	Secrets influxdb.SecretService
+}
+
+// Gather parses the points served by target. Points without a timestamp are given the time of the scrape.
+func (p *lineProtocolScraper) Gather(ctx context.Context, target influxdb.ScraperTarget) (collected MetricsCollection, err error) {
+	resp, err := fetcher{Secrets: p.Secrets}.fetch(ctx, target, "text/plain")
+	if err != nil {
+		return collected, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return collected, err
+	}
+
+	precision := "ns"
+	if target.LineProtocol != nil && target.LineProtocol.Precision != "" {
+		precision = target.LineProtocol.Precision
+	}
+	points, err := models.ParsePointsWithPrecision(body, time.Now().UTC(), precision)
+	if err != nil {
+		return collected, err
+	}
+
+	ms := make([]Metrics, 0, len(points))
+	for _, pt := range points {
+		fields, err := pt.Fields()
+		if err != nil {
+			return collected, err
+		}
+		ms = append(ms, Metrics{
+			Name:      string(pt.Name()),
+			Tags:      pt.Tags().Map(),
+			Fields:    fields,
+			Timestamp: pt.Time(),
+			Type:      MetricTypeUntyped,
+		})
+	}
+
+	return MetricsCollection{
+		MetricsSlice: ms,
+		OrgID:        target.OrgID,
+		BucketID:     target.BucketID,
+	}, nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
 )
 
 func TestMetricsReader(t *testing.T) {
@@ -200,3 +201,44 @@ func TestMetricsMarshal(t *testing.T) {
 		}
 	}
 }
+
+func TestMetricsMessage(t *testing.T) {
+	collected := MetricsCollection{
+		OrgID:    influxdbtesting.MustIDBase16("020f755c3c082000"),
+		BucketID: influxdbtesting.MustIDBase16("020f755c3c082001"),
+		MetricsSlice: []Metrics{
+			{
+				Name: "httpd",
+				Tags: map[string]string{"bind": ":8086"},
+				Fields: map[string]interface{}{
+					"req":      int64(3),
+					"ratio":    float64(2),
+					"enabled":  true,
+					"hostname": "node1",
+				},
+				Timestamp: time.Unix(12345, 6789).UTC(),
+				Type:      MetricTypeUntyped,
+			},
+			{
+				Name:      "go_goroutines",
+				Tags:      map[string]string{},
+				Fields:    map[string]interface{}{"gauge": float64(36)},
+				Timestamp: time.Unix(12345, 0).UTC(),
+				Type:      MetricTypeGauge,
+			},
+		},
+	}
+
+	buf, err := encodeMetrics(collected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := decodeMetrics(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The fields keep their types through the queue.
+	if diff := cmp.Diff(collected, got); diff != "" {
+		t.Fatalf("unexpected metrics after the queue: %s", diff)
+	}
+}
@@ -0,0 +1,77 @@
+package gather
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+// defaultPreviewTimeout bounds the scrapes of the targets without a timeout of their own.
+const defaultPreviewTimeout = 10 * time.Second
+
+// Previewer scrapes targets once, and transforms their metrics without writing them.
+type Previewer struct {
+	scraper Scraper
+}
+
+var _ influxdb.ScraperPreviewService = (*Previewer)(nil)
+
+// NewPreviewer returns a Previewer loading the credentials of the targets from secrets.
+func NewPreviewer(secrets influxdb.SecretService) *Previewer {
+	return &Previewer{scraper: newTypeScraper(secrets)}
+}
+
+// PreviewTarget scrapes target once, and returns its metrics after applying its tags and relabel rules.
+func (p *Previewer) PreviewTarget(ctx context.Context, target influxdb.ScraperTarget) (*influxdb.ScraperPreview, error) {
+	if !influxdb.ValidScraperType(string(target.Type)) {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("unsupported target scrape type: %s", target.Type),
+		}
+	}
+
+	if target.Discovery != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "cannot preview a scraper target with discovery",
+		}
+	}
+
+	timeout := defaultPreviewTimeout
+	if target.Timeout != nil {
+		timeout = target.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ms, err := p.scraper.Gather(ctx, target)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EUnavailable,
+			Msg:  fmt.Sprintf("unable to scrape target: %v", err),
+		}
+	}
+
+	kept, err := Relabel(ms.MetricsSlice, target)
+	if err != nil {
+		return nil, &influxdb.Error{Code: influxdb.EInvalid, Msg: err.Error()}
+	}
+
+	r, err := kept.Reader()
+	if err != nil {
+		return nil, err
+	}
+	points, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &influxdb.ScraperPreview{
+		Scraped: len(ms.MetricsSlice),
+		Kept:    len(kept),
+		Points:  string(points),
+	}, nil
+}
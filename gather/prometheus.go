@@ -15,13 +15,19 @@ import (
 	"github.com/prometheus/common/expfmt"
 )
 
+// acceptHeader prefers the protocol buffer format to the text format, like Prometheus.
+const acceptHeader = `application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited;q=0.7,text/plain;version=0.0.4;q=0.3,*/*;q=0.1`
+
 // prometheusScraper handles parsing prometheus metrics.
 // implements Scraper interfaces.
-type prometheusScraper struct{}
+type prometheusScraper struct {
+	// Secrets holds the credentials of the targets.
+	Secrets influxdb.SecretService
+}
 
 // Gather parse metrics from a scraper target url.
 func (p *prometheusScraper) Gather(ctx context.Context, target influxdb.ScraperTarget) (collected MetricsCollection, err error) {
-	resp, err := http.Get(target.URL)
+	resp, err := fetcher{Secrets: p.Secrets}.fetch(ctx, target, acceptHeader)
 	if err != nil {
 		return collected, err
 	}
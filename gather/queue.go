@@ -0,0 +1,213 @@
+package gather
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"sync"
+	"sync/atomic"
+
+	"github.com/influxdata/influxdb/nats"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultQueueSize is the number of messages a queue holds per subject, before it drops the messages published to it.
+const DefaultQueueSize = 10000
+
+var (
+	// ErrQueueFull is returned when a message is published to a full queue, which drops it.
+	ErrQueueFull = errors.New("scraper queue is full, message dropped")
+	// ErrQueueClosed is returned when a message is published to a closed queue.
+	ErrQueueClosed = errors.New("scraper queue is closed")
+)
+
+// Queue carries the scrape requests of the scheduler to the scrapers, and the scraped metrics to the recorders.
+// The handlers subscribed to a subject share its messages, whatever their group.
+type Queue interface {
+	nats.Publisher
+	nats.Subscriber
+	// Close stops the delivery of messages, and waits for the messages being handled.
+	Close() error
+	// PrometheusCollectors returns the metrics of the queue.
+	PrometheusCollectors() []prometheus.Collector
+}
+
+// queueMetrics are the metrics of a queue.
+type queueMetrics struct {
+	depth     *prometheus.GaugeVec
+	published *prometheus.CounterVec
+	dropped   *prometheus.CounterVec
+}
+
+func newQueueMetrics() *queueMetrics {
+	const namespace = "scraper"
+	const subsystem = "queue"
+
+	return &queueMetrics{
+		depth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "depth",
+			Help:      "Number of messages waiting to be handled, by subject.",
+		}, []string{"subject"}),
+		published: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "messages_published",
+			Help:      "Number of messages published, by subject.",
+		}, []string{"subject"}),
+		dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "messages_dropped",
+			Help:      "Number of messages dropped because the queue was full, by subject.",
+		}, []string{"subject"}),
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (m *queueMetrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{m.depth, m.published, m.dropped}
+}
+
+// queueMessage is a message delivered by a queue.
+type queueMessage struct {
+	data []byte
+	ack  func() error
+}
+
+func (m *queueMessage) Data() []byte {
+	return m.data
+}
+
+func (m *queueMessage) Ack() error {
+	if m.ack == nil {
+		return nil
+	}
+	return m.ack()
+}
+
+// queueSubscription is the subscription of a handler to a subject of a queue.
+// Queues don't track the bytes pending.
+type queueSubscription struct {
+	pending   func() int
+	delivered int64
+}
+
+func (s *queueSubscription) Pending() (int64, int64, error) {
+	return int64(s.pending()), 0, nil
+}
+
+func (s *queueSubscription) Delivered() (int64, error) {
+	return atomic.LoadInt64(&s.delivered), nil
+}
+
+func (s *queueSubscription) Close() error {
+	return nil
+}
+
+// ChannelQueue is an in-process queue of bounded channels, for single node setups.
+// The messages it holds are lost when it is closed.
+type ChannelQueue struct {
+	size    int
+	metrics *queueMetrics
+
+	mu       sync.Mutex
+	subjects map[string]chan []byte
+
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+var _ Queue = (*ChannelQueue)(nil)
+
+// NewChannelQueue returns a ChannelQueue holding up to size messages per subject.
+func NewChannelQueue(size int) *ChannelQueue {
+	if size <= 0 {
+		size = DefaultQueueSize
+	}
+	return &ChannelQueue{
+		size:     size,
+		metrics:  newQueueMetrics(),
+		subjects: make(map[string]chan []byte),
+		done:     make(chan struct{}),
+	}
+}
+
+func (q *ChannelQueue) channel(subject string) chan []byte {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	ch, ok := q.subjects[subject]
+	if !ok {
+		ch = make(chan []byte, q.size)
+		q.subjects[subject] = ch
+	}
+	return ch
+}
+
+// Publish adds the message read from r to subject, or drops it if subject is full.
+func (q *ChannelQueue) Publish(subject string, r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-q.done:
+		return ErrQueueClosed
+	default:
+	}
+
+	ch := q.channel(subject)
+	select {
+	case ch <- data:
+		q.metrics.published.WithLabelValues(subject).Inc()
+		q.metrics.depth.WithLabelValues(subject).Set(float64(len(ch)))
+		return nil
+	default:
+		q.metrics.dropped.WithLabelValues(subject).Inc()
+		return ErrQueueFull
+	}
+}
+
+// Subscribe handles the messages of subject with handler, until the queue is closed.
+func (q *ChannelQueue) Subscribe(subject, group string, handler nats.Handler) error {
+	select {
+	case <-q.done:
+		return ErrQueueClosed
+	default:
+	}
+
+	ch := q.channel(subject)
+	sub := &queueSubscription{pending: func() int { return len(ch) }}
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		for {
+			select {
+			case <-q.done:
+				return
+			case data := <-ch:
+				q.metrics.depth.WithLabelValues(subject).Set(float64(len(ch)))
+				atomic.AddInt64(&sub.delivered, 1)
+				handler.Process(sub, &queueMessage{data: data})
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops the delivery of messages, and waits for the messages being handled.
+func (q *ChannelQueue) Close() error {
+	q.closeOnce.Do(func() {
+		close(q.done)
+	})
+	q.wg.Wait()
+	return nil
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (q *ChannelQueue) PrometheusCollectors() []prometheus.Collector {
+	return q.metrics.PrometheusCollectors()
+}
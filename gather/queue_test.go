@@ -0,0 +1,192 @@
+package gather
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/nats"
+	"go.uber.org/zap"
+)
+
+// collectingHandler collects the messages it handles, acking them unless told not to.
+type collectingHandler struct {
+	mu       sync.Mutex
+	data     []string
+	noAck    bool
+	received chan struct{}
+}
+
+func newCollectingHandler() *collectingHandler {
+	return &collectingHandler{received: make(chan struct{}, 100)}
+}
+
+func (h *collectingHandler) Process(s nats.Subscription, m nats.Message) {
+	h.mu.Lock()
+	h.data = append(h.data, string(m.Data()))
+	h.mu.Unlock()
+	if !h.noAck {
+		m.Ack()
+	}
+	h.received <- struct{}{}
+}
+
+// wait waits for n messages, and returns the messages handled, sorted.
+func (h *collectingHandler) wait(t *testing.T, n int) []string {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case <-h.received:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for message %d", i)
+		}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	data := append([]string(nil), h.data...)
+	sort.Strings(data)
+	return data
+}
+
+func publishN(t *testing.T, q Queue, subject string, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if err := q.Publish(subject, bytes.NewBufferString(fmt.Sprintf("m%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestChannelQueue(t *testing.T) {
+	q := NewChannelQueue(2)
+	publishN(t, q, "s", 2)
+	if err := q.Publish("s", bytes.NewBufferString("dropped")); err != ErrQueueFull {
+		t.Fatalf("expected a full queue, got %v", err)
+	}
+
+	h := newCollectingHandler()
+	if err := q.Subscribe("s", "group", h); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Subscribe("s", "group", h); err != nil {
+		t.Fatal(err)
+	}
+	publishN(t, q, "other", 1)
+	if diff := cmp.Diff([]string{"m0", "m1"}, h.wait(t, 2)); diff != "" {
+		t.Fatalf("unexpected messages: %s", diff)
+	}
+
+	if err := q.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Publish("s", bytes.NewBufferString("closed")); err != ErrQueueClosed {
+		t.Fatalf("expected a closed queue, got %v", err)
+	}
+}
+
+func TestFileQueue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scraperqueue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	open := func() *FileQueue {
+		q := NewFileQueue(dir, 10, zap.NewNop())
+		// Start a segment every 2 messages.
+		q.SegmentSize = 2*recordHeaderSize + 2
+		if err := q.Open(); err != nil {
+			t.Fatal(err)
+		}
+		return q
+	}
+	segments := func() []string {
+		paths, err := filepath.Glob(filepath.Join(dir, "promTarget", "*"+segmentExt))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return paths
+	}
+
+	// The messages published before a restart are delivered after it.
+	q := open()
+	publishN(t, q, promTargetSubject, 5)
+	if err := q.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(segments()); n != 3 {
+		t.Fatalf("expected 3 segments, got %d", n)
+	}
+
+	q = open()
+	h := newCollectingHandler()
+	if err := q.Subscribe(promTargetSubject, "metrics", h); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"m0", "m1", "m2", "m3", "m4"}, h.wait(t, 5)); diff != "" {
+		t.Fatalf("unexpected messages: %s", diff)
+	}
+	if err := q.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// The recovered segments are removed once all of their records are acked.
+	if n := len(segments()); n != 0 {
+		t.Fatalf("expected no segment, got %d", n)
+	}
+
+	// Acked messages are not delivered again, but the ones handled without being acked are.
+	q = open()
+	h = newCollectingHandler()
+	h.noAck = true
+	if err := q.Subscribe(promTargetSubject, "metrics", h); err != nil {
+		t.Fatal(err)
+	}
+	publishN(t, q, promTargetSubject, 1)
+	if diff := cmp.Diff([]string{"m0"}, h.wait(t, 1)); diff != "" {
+		t.Fatalf("unexpected messages: %s", diff)
+	}
+	if err := q.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A record cut short by a crash is ignored.
+	paths := segments()
+	f, err := os.OpenFile(paths[len(paths)-1], os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write([]byte{0, 0, 0, 9, 1, 2})
+	f.Close()
+
+	q = open()
+	h = newCollectingHandler()
+	if err := q.Subscribe(promTargetSubject, "metrics", h); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"m0"}, h.wait(t, 1)); diff != "" {
+		t.Fatalf("unexpected messages: %s", diff)
+	}
+	select {
+	case <-h.received:
+		t.Fatal("unexpected message")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// Full subjects drop messages.
+	for i := 0; i < 10; i++ {
+		q.Publish(MetricsSubject, bytes.NewBufferString("m"))
+	}
+	if err := q.Publish(MetricsSubject, bytes.NewBufferString("dropped")); err != ErrQueueFull {
+		t.Fatalf("expected a full queue, got %v", err)
+	}
+	if err := q.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
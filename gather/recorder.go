@@ -1,11 +1,15 @@
 package gather
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/influxdata/influxdb/tsdb"
 
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/nats"
 	"github.com/influxdata/influxdb/storage"
 	"go.uber.org/zap"
@@ -44,14 +48,85 @@ type RecorderHandler struct {
 // Process consumes job queue, and use recorder to record.
 func (h *RecorderHandler) Process(s nats.Subscription, m nats.Message) {
 	defer m.Ack()
-	collected := new(MetricsCollection)
-	err := json.Unmarshal(m.Data(), &collected)
+	collected, err := decodeMetrics(m.Data())
 	if err != nil {
 		h.Logger.Error("recorder handler error", zap.Error(err))
 		return
 	}
-	err = h.Recorder.Record(*collected)
+	err = h.Recorder.Record(collected)
 	if err != nil {
 		h.Logger.Error("recorder handler error", zap.Error(err))
 	}
 }
+
+// metricsMessage is a MetricsCollection, as published to the metrics queue.
+type metricsMessage struct {
+	OrgID    influxdb.ID `json:"orgID"`
+	BucketID influxdb.ID `json:"bucketID"`
+	// Points are the metrics as line protocol, which keeps the types of their fields, unlike JSON.
+	Points string `json:"points"`
+	// Types are the types of the metrics, in the order of Points.
+	Types []MetricType `json:"types"`
+}
+
+// encodeMetrics encodes collected as a message of the metrics queue.
+func encodeMetrics(collected MetricsCollection) (*bytes.Buffer, error) {
+	msg := metricsMessage{
+		OrgID:    collected.OrgID,
+		BucketID: collected.BucketID,
+		Types:    make([]MetricType, len(collected.MetricsSlice)),
+	}
+	r, err := collected.MetricsSlice.Reader()
+	if err != nil {
+		return nil, err
+	}
+	points := new(bytes.Buffer)
+	if _, err := points.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	msg.Points = points.String()
+	for i, m := range collected.MetricsSlice {
+		msg.Types[i] = m.Type
+	}
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(msg); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// decodeMetrics decodes a message of the metrics queue.
+func decodeMetrics(data []byte) (MetricsCollection, error) {
+	var msg metricsMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return MetricsCollection{}, err
+	}
+	points, err := models.ParsePointsString(msg.Points)
+	if err != nil {
+		return MetricsCollection{}, err
+	}
+	if len(points) != len(msg.Types) {
+		return MetricsCollection{}, fmt.Errorf("metrics message has %d points but %d types", len(points), len(msg.Types))
+	}
+
+	collected := MetricsCollection{
+		OrgID:        msg.OrgID,
+		BucketID:     msg.BucketID,
+		MetricsSlice: make(MetricsSlice, 0, len(points)),
+	}
+	for i, pt := range points {
+		fields, err := pt.Fields()
+		if err != nil {
+			return MetricsCollection{}, err
+		}
+		collected.MetricsSlice = append(collected.MetricsSlice, Metrics{
+			Name:      string(pt.Name()),
+			Tags:      pt.Tags().Map(),
+			Fields:    fields,
+			Timestamp: pt.Time(),
+			Type:      msg.Types[i],
+		})
+	}
+	return collected, nil
+}
@@ -0,0 +1,131 @@
+package gather
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/influxdata/influxdb"
+)
+
+// relabeler applies the tags and relabel rules of a target to the metrics scraped from it.
+type relabeler struct {
+	tags  map[string]string
+	rules []relabelRule
+}
+
+type relabelRule struct {
+	influxdb.ScraperRelabelRule
+	re *regexp.Regexp
+}
+
+func newRelabeler(target influxdb.ScraperTarget) (*relabeler, error) {
+	r := &relabeler{tags: target.Tags}
+	for _, rule := range target.RelabelRules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		re, err := rule.CompileRegex()
+		if err != nil {
+			return nil, err
+		}
+		r.rules = append(r.rules, relabelRule{ScraperRelabelRule: rule, re: re})
+	}
+	return r, nil
+}
+
+// Relabel applies the tags and relabel rules of target to ms, and returns the metrics left.
+func Relabel(ms MetricsSlice, target influxdb.ScraperTarget) (MetricsSlice, error) {
+	if len(target.Tags) == 0 && len(target.RelabelRules) == 0 {
+		return ms, nil
+	}
+
+	r, err := newRelabeler(target)
+	if err != nil {
+		return nil, err
+	}
+	kept := make(MetricsSlice, 0, len(ms))
+	for _, m := range ms {
+		if m, ok := r.relabel(m); ok {
+			kept = append(kept, m)
+		}
+	}
+	return kept, nil
+}
+
+// relabel applies the tags and rules to m, and reports whether m is kept.
+func (r *relabeler) relabel(m Metrics) (Metrics, bool) {
+	labels := make(map[string]string, len(m.Tags)+len(r.tags)+1)
+	for k, v := range m.Tags {
+		labels[k] = v
+	}
+	for k, v := range r.tags {
+		labels[k] = v
+	}
+	labels[influxdb.ScraperMetricNameLabel] = m.Name
+
+	for _, rule := range r.rules {
+		if !rule.apply(labels) {
+			return m, false
+		}
+	}
+
+	m.Name = labels[influxdb.ScraperMetricNameLabel]
+	if m.Name == "" {
+		return m, false
+	}
+	delete(labels, influxdb.ScraperMetricNameLabel)
+	m.Tags = labels
+	return m, true
+}
+
+// apply applies the rule to labels, and reports whether the metric is kept.
+func (r relabelRule) apply(labels map[string]string) bool {
+	switch r.Action {
+	case influxdb.RelabelKeep:
+		return r.re.MatchString(r.source(labels))
+	case influxdb.RelabelDrop:
+		return !r.re.MatchString(r.source(labels))
+	case influxdb.RelabelLabelDrop, influxdb.RelabelLabelKeep:
+		keep := r.Action == influxdb.RelabelLabelKeep
+		for k := range labels {
+			if k != influxdb.ScraperMetricNameLabel && r.re.MatchString(k) != keep {
+				delete(labels, k)
+			}
+		}
+	default:
+		src := r.source(labels)
+		match := r.re.FindStringSubmatchIndex(src)
+		if match == nil {
+			break
+		}
+		replacement := "$1"
+		if r.Replacement != nil {
+			replacement = *r.Replacement
+		}
+		v := string(r.re.ExpandString(nil, replacement, src, match))
+		if v == "" {
+			delete(labels, r.TargetLabel)
+		} else {
+			labels[r.TargetLabel] = v
+		}
+	}
+	return true
+}
+
+// source returns the values of the source labels of the rule, joined by its separator.
+func (r relabelRule) source(labels map[string]string) string {
+	names := r.SourceLabels
+	if len(names) == 0 {
+		names = []string{influxdb.ScraperMetricNameLabel}
+	}
+	sep := r.Separator
+	if sep == "" {
+		sep = ";"
+	}
+
+	values := make([]string, len(names))
+	for i, n := range names {
+		values[i] = labels[n]
+	}
+	return strings.Join(values, sep)
+}
@@ -0,0 +1,138 @@
+package gather
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestRelabel(t *testing.T) {
+	empty := ""
+	ms := MetricsSlice{
+		{Name: "go_goroutines", Tags: map[string]string{"instance": "a:9100", "job": "node"}},
+		{Name: "go_gc_duration_seconds", Tags: map[string]string{"instance": "b:9100", "quantile": "0.5"}},
+		{Name: "http_requests_total", Tags: map[string]string{"instance": "a:9100", "code": "200"}},
+	}
+
+	cases := []struct {
+		name   string
+		target influxdb.ScraperTarget
+		wants  MetricsSlice
+	}{
+		{
+			name:   "no rules",
+			target: influxdb.ScraperTarget{},
+			wants:  ms,
+		},
+		{
+			name: "keep by name",
+			target: influxdb.ScraperTarget{RelabelRules: []influxdb.ScraperRelabelRule{
+				{Action: influxdb.RelabelKeep, Regex: "go_.*"},
+			}},
+			wants: ms[:2],
+		},
+		{
+			name: "drop by label",
+			target: influxdb.ScraperTarget{RelabelRules: []influxdb.ScraperRelabelRule{
+				{Action: influxdb.RelabelDrop, SourceLabels: []string{"instance"}, Regex: "a:.*"},
+			}},
+			wants: ms[1:2],
+		},
+		{
+			name: "replace",
+			target: influxdb.ScraperTarget{RelabelRules: []influxdb.ScraperRelabelRule{
+				{SourceLabels: []string{"instance"}, Regex: "(.*):.*", TargetLabel: "host"},
+				{SourceLabels: []string{"instance"}, TargetLabel: "instance", Replacement: &empty},
+				{SourceLabels: []string{"__name__"}, Regex: "go_(.*)", TargetLabel: "__name__", Replacement: strPtr("golang_$1")},
+			}},
+			wants: MetricsSlice{
+				{Name: "golang_goroutines", Tags: map[string]string{"host": "a", "job": "node"}},
+				{Name: "golang_gc_duration_seconds", Tags: map[string]string{"host": "b", "quantile": "0.5"}},
+				{Name: "http_requests_total", Tags: map[string]string{"host": "a", "code": "200"}},
+			},
+		},
+		{
+			name: "labeldrop and labelkeep",
+			target: influxdb.ScraperTarget{RelabelRules: []influxdb.ScraperRelabelRule{
+				{Action: influxdb.RelabelLabelDrop, Regex: "instance"},
+				{Action: influxdb.RelabelLabelKeep, Regex: "job|code"},
+			}},
+			wants: MetricsSlice{
+				{Name: "go_goroutines", Tags: map[string]string{"job": "node"}},
+				{Name: "go_gc_duration_seconds", Tags: map[string]string{}},
+				{Name: "http_requests_total", Tags: map[string]string{"code": "200"}},
+			},
+		},
+		{
+			name: "tags are added before the rules",
+			target: influxdb.ScraperTarget{
+				Tags: map[string]string{"job": "scraper", "env": "prod"},
+				RelabelRules: []influxdb.ScraperRelabelRule{
+					{Action: influxdb.RelabelKeep, SourceLabels: []string{"job"}, Regex: "scraper"},
+					{Action: influxdb.RelabelKeep, Regex: "go_goroutines"},
+				},
+			},
+			wants: MetricsSlice{
+				{Name: "go_goroutines", Tags: map[string]string{"instance": "a:9100", "job": "scraper", "env": "prod"}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Relabel(ms, c.target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(c.wants, got); diff != "" {
+				t.Fatalf("unexpected metrics: %s", diff)
+			}
+		})
+	}
+
+	t.Run("invalid rule", func(t *testing.T) {
+		_, err := Relabel(ms, influxdb.ScraperTarget{RelabelRules: []influxdb.ScraperRelabelRule{
+			{Action: influxdb.RelabelKeep, Regex: "("},
+		}})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestPreviewer(t *testing.T) {
+	ts := httptest.NewServer(mockHTTPHandler{responseMap: map[string]string{"/metrics": sampleRespSmall}})
+	defer ts.Close()
+
+	p := NewPreviewer(mock.NewSecretService())
+	target := influxdb.ScraperTarget{
+		Type: influxdb.PrometheusScraperType,
+		URL:  ts.URL + "/metrics",
+		Tags: map[string]string{"env": "prod"},
+	}
+	preview, err := p.PreviewTarget(context.Background(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Scraped != 1 || preview.Kept != 1 || !strings.HasPrefix(preview.Points, "go_goroutines,env=prod ") {
+		t.Fatalf("unexpected preview %+v", preview)
+	}
+
+	target.RelabelRules = []influxdb.ScraperRelabelRule{{Action: influxdb.RelabelDrop, Regex: "go_.*"}}
+	preview, err = p.PreviewTarget(context.Background(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Scraped != 1 || preview.Kept != 0 || preview.Points != "" {
+		t.Fatalf("unexpected preview %+v", preview)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/kit/tracing"
 	"github.com/influxdata/influxdb/nats"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
 
@@ -19,20 +20,35 @@ const (
 	promTargetSubject = "promTarget"
 )
 
+// scheduleResolution is the most time between the checks for targets due to be scraped.
+const scheduleResolution = time.Second
+
 // Scheduler is struct to run scrape jobs.
 type Scheduler struct {
 	Targets influxdb.ScraperTargetStoreService
-	// Interval is between each metrics gathering event.
+	// Interval is between each metrics gathering event, for the targets without an interval of their own.
 	Interval time.Duration
-	// Timeout is the maxisium time duration allowed by each TCP request
+	// Timeout is the maxisium time duration allowed by each TCP request, for the targets without a timeout of their own.
 	Timeout time.Duration
+	// DiscoveryDir is the directory the files of file discovery are read from.
+	// File discovery is disabled if it is empty.
+	DiscoveryDir string
 
 	// Publisher will send the gather requests and gathered metrics to the queue.
 	Publisher nats.Publisher
 
+	// Status records the outcome of every scrape, if set.
+	Status influxdb.ScraperStatusService
+
 	Logger *zap.Logger
 
 	gather chan struct{}
+
+	// lastScraped is when every target was last requested to be scraped.
+	lastScraped map[influxdb.ID]time.Time
+
+	metrics   *scrapeMetrics
+	discovery *discoverer
 }
 
 // NewScheduler creates a new Scheduler and subscriptions for scraper jobs.
@@ -40,6 +56,8 @@ func NewScheduler(
 	numScrapers int,
 	l *zap.Logger,
 	targets influxdb.ScraperTargetStoreService,
+	secrets influxdb.SecretService,
+	status influxdb.ScraperStatusService,
 	p nats.Publisher,
 	s nats.Subscriber,
 	interval time.Duration,
@@ -56,15 +74,22 @@ func NewScheduler(
 		Interval:  interval,
 		Timeout:   timeout,
 		Publisher: p,
+		Status:    status,
 		Logger:    l,
 		gather:    make(chan struct{}, 100),
+
+		lastScraped: make(map[influxdb.ID]time.Time),
+		metrics:     newScrapeMetrics(),
+		discovery:   newDiscoverer(),
 	}
 
 	for i := 0; i < numScrapers; i++ {
 		err := s.Subscribe(promTargetSubject, "metrics", &handler{
-			Scraper:   new(prometheusScraper),
+			Scraper:   newTypeScraper(secrets),
 			Publisher: p,
 			Logger:    l,
+			Status:    status,
+			metrics:   scheduler.metrics,
 		})
 		if err != nil {
 			return nil, err
@@ -75,14 +100,20 @@ func NewScheduler(
 }
 
 // Run will retrieve scraper targets from the target storage,
-// and publish them to nats job queue for gather.
+// and publish the ones due to be scraped to nats job queue for gather.
 func (s *Scheduler) Run(ctx context.Context) error {
+	resolution := scheduleResolution
+	if s.Interval < resolution {
+		resolution = s.Interval
+	}
 	go func(s *Scheduler, ctx context.Context) {
+		ticker := time.NewTicker(resolution)
+		defer ticker.Stop()
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case <-time.After(s.Interval): // TODO: change to ticker because of garbage collection
+			case <-ticker.C:
 				s.gather <- struct{}{}
 			}
 		}
@@ -113,23 +144,103 @@ func (s *Scheduler) doGather(ctx context.Context) {
 		tracing.LogError(span, err)
 		return
 	}
+
+	now := time.Now()
+	lastScraped := make(map[influxdb.ID]time.Time, len(targets))
 	for _, target := range targets {
-		if err := requestScrape(target, s.Publisher); err != nil {
-			s.Logger.Error("json encoding error", zap.Error(err))
+		last, ok := s.lastScraped[target.ID]
+		if ok && now.Sub(last) < s.interval(target) {
+			lastScraped[target.ID] = last
+			continue
+		}
+		lastScraped[target.ID] = now
+
+		if target.Timeout == nil {
+			target.Timeout = &influxdb.Duration{Duration: s.Timeout}
+		}
+
+		if target.Discovery == nil {
+			if err := requestScrape(scrapeRequest{ScraperTarget: target}, s.Publisher); err != nil {
+				s.Logger.Error("json encoding error", zap.Error(err))
+				tracing.LogError(span, err)
+			}
+			continue
+		}
+
+		s.discovery.dir = s.DiscoveryDir
+		targets, err := s.discovery.targets(ctx, target)
+		if err != nil {
+			s.Logger.Error("cannot discover targets", zap.Error(err), zap.Stringer("target_id", target.ID))
 			tracing.LogError(span, err)
+			continue
+		}
+		instances := make([]string, 0, len(targets))
+		for _, t := range targets {
+			req := scrapeRequest{ScraperTarget: t, Instance: t.Tags[InstanceTag]}
+			if err := requestScrape(req, s.Publisher); err != nil {
+				s.Logger.Error("json encoding error", zap.Error(err))
+				tracing.LogError(span, err)
+			}
+			instances = append(instances, req.Instance)
 		}
+		s.retainInstances(ctx, target.ID, instances)
 	}
+	// Forget the targets that were removed.
+	for id := range s.lastScraped {
+		if _, ok := lastScraped[id]; !ok {
+			s.metrics.forget(id)
+		}
+	}
+	s.discovery.forget(lastScraped)
+	s.lastScraped = lastScraped
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (s *Scheduler) PrometheusCollectors() []prometheus.Collector {
+	return s.metrics.PrometheusCollectors()
+}
+
+// interval returns the time between the scrapes of target.
+func (s *Scheduler) interval(target influxdb.ScraperTarget) time.Duration {
+	if target.Interval != nil {
+		return target.Interval.Duration
+	}
+	return s.Interval
+}
+
+// retainInstances drops the metrics and statuses of the targets discovered by the target id that are not in instances.
+func (s *Scheduler) retainInstances(ctx context.Context, id influxdb.ID, instances []string) {
+	keep := make(map[string]bool, len(instances))
+	for _, instance := range instances {
+		keep[instance] = true
+	}
+	s.metrics.retain(id, keep)
+
+	if s.Status == nil {
+		return
+	}
+	if err := s.Status.RetainInstances(ctx, id, instances); err != nil {
+		s.Logger.Error("cannot drop the statuses of undiscovered targets", zap.Error(err), zap.Stringer("target_id", id))
+	}
+}
+
+// scrapeRequest is a request to scrape a target, as published to the queue.
+type scrapeRequest struct {
+	influxdb.ScraperTarget
+	// Instance is the address of the target, if it was discovered by the scraper target.
+	// The scrape is recorded as one of the instance.
+	Instance string `json:"instance,omitempty"`
 }
 
-func requestScrape(t influxdb.ScraperTarget, publisher nats.Publisher) error {
+func requestScrape(req scrapeRequest, publisher nats.Publisher) error {
 	buf := new(bytes.Buffer)
-	err := json.NewEncoder(buf).Encode(t)
+	err := json.NewEncoder(buf).Encode(req)
 	if err != nil {
 		return err
 	}
-	switch t.Type {
-	case influxdb.PrometheusScraperType:
+	// Targets of every type share the subject, which keeps its name for the queues holding requests.
+	if influxdb.ValidScraperType(string(req.Type)) {
 		return publisher.Publish(promTargetSubject, buf)
 	}
-	return fmt.Errorf("unsupported target scrape type: %s", t.Type)
+	return fmt.Errorf("unsupported target scrape type: %s", req.Type)
 }
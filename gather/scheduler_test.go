@@ -2,8 +2,13 @@ package gather
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -12,6 +17,7 @@ import (
 	influxlogger "github.com/influxdata/influxdb/logger"
 	"github.com/influxdata/influxdb/mock"
 	influxdbtesting "github.com/influxdata/influxdb/testing"
+	"go.uber.org/zap"
 )
 
 func TestScheduler(t *testing.T) {
@@ -48,7 +54,7 @@ func TestScheduler(t *testing.T) {
 	})
 
 	scheduler, err := NewScheduler(10, logger,
-		storage, publisher, subscriber, time.Millisecond, time.Microsecond)
+		storage, nil, nil, publisher, subscriber, time.Millisecond, time.Second)
 
 	go func() {
 		err = scheduler.run(ctx)
@@ -97,3 +103,132 @@ const sampleRespSmall = `
 # TYPE go_goroutines gauge
 go_goroutines 36
 `
+
+// countingPublisher counts the scrapes requested of every target, and keeps the instances requested last.
+type countingPublisher struct {
+	requests  map[influxdb.ID]int
+	instances []string
+}
+
+func (p *countingPublisher) Publish(subject string, r io.Reader) error {
+	var req scrapeRequest
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return err
+	}
+	p.requests[req.ID]++
+	if req.Instance != "" {
+		p.instances = append(p.instances, req.Instance)
+	}
+	return nil
+}
+
+func TestScheduler_TargetInterval(t *testing.T) {
+	hourly := influxdbtesting.MustIDBase16("3a0d0a6365646120")
+	frequent := influxdbtesting.MustIDBase16("3a0d0a6365646121")
+	storage := &mockStorage{
+		Targets: []influxdb.ScraperTarget{
+			{
+				ID:       hourly,
+				Type:     influxdb.PrometheusScraperType,
+				Interval: &influxdb.Duration{Duration: time.Hour},
+			},
+			{
+				ID:   frequent,
+				Type: influxdb.PrometheusScraperType,
+			},
+		},
+	}
+	publisher := &countingPublisher{requests: make(map[influxdb.ID]int)}
+	_, subscriber := mock.NewNats()
+
+	scheduler, err := NewScheduler(0, zap.NewNop(), storage, nil, nil, publisher, subscriber, time.Millisecond, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		time.Sleep(2 * time.Millisecond)
+		scheduler.doGather(context.Background())
+	}
+
+	if got := publisher.requests[hourly]; got != 1 {
+		t.Errorf("expected the hourly target to be scraped once, got %d", got)
+	}
+	if got := publisher.requests[frequent]; got != 3 {
+		t.Errorf("expected the target without interval to be scraped 3 times, got %d", got)
+	}
+}
+
+func TestScheduler_Discovery(t *testing.T) {
+	dir, err := ioutil.TempDir("", "discovery")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "targets.json"), []byte(`[{"targets": ["node1:9100", "node2:9100"]}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	id := influxdbtesting.MustIDBase16("3a0d0a6365646120")
+	storage := &mockStorage{
+		Targets: []influxdb.ScraperTarget{
+			{
+				ID:   id,
+				Type: influxdb.PrometheusScraperType,
+				Discovery: &influxdb.ScraperDiscovery{
+					Type:  influxdb.FileScraperDiscovery,
+					Files: []string{"targets.json"},
+				},
+			},
+		},
+	}
+	publisher := &countingPublisher{requests: make(map[influxdb.ID]int)}
+	_, subscriber := mock.NewNats()
+
+	var retained []string
+	status := &mock.ScraperStatusService{
+		RetainInstancesFn: func(ctx context.Context, tid influxdb.ID, instances []string) error {
+			retained = instances
+			return nil
+		},
+	}
+
+	scheduler, err := NewScheduler(0, zap.NewNop(), storage, nil, status, publisher, subscriber, time.Millisecond, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheduler.DiscoveryDir = dir
+	scheduler.doGather(context.Background())
+
+	if got := publisher.requests[id]; got != 2 {
+		t.Errorf("expected both discovered targets to be scraped, got %d scrapes", got)
+	}
+	if !reflect.DeepEqual(publisher.instances, []string{"node1:9100", "node2:9100"}) {
+		t.Errorf("expected the scrapes of node1 and node2, got %v", publisher.instances)
+	}
+	scheduler.metrics.record(id, influxdb.ScrapeResult{Instance: "node1:9100"})
+	scheduler.metrics.record(id, influxdb.ScrapeResult{Instance: "node2:9100", Error: "unavailable"})
+
+	// A target that is no longer discovered loses its metrics and status.
+	if err := ioutil.WriteFile(filepath.Join(dir, "targets.json"), []byte(`[{"targets": ["node1:9100"]}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// Make sure the file looks modified, even on file systems with coarse modification times.
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(filepath.Join(dir, "targets.json"), later, later); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	publisher.instances = nil
+	scheduler.doGather(context.Background())
+
+	if !reflect.DeepEqual(publisher.instances, []string{"node1:9100"}) {
+		t.Errorf("expected the scrape of node1, got %v", publisher.instances)
+	}
+	if !reflect.DeepEqual(retained, []string{"node1:9100"}) {
+		t.Errorf("expected the status of node1 to be retained, got %v", retained)
+	}
+	if !scheduler.metrics.instances[id]["node1:9100"] || scheduler.metrics.instances[id]["node2:9100"] {
+		t.Errorf("expected only the metrics of node1 to be left, got %v", scheduler.metrics.instances[id])
+	}
+}
@@ -0,0 +1,101 @@
+package gather
+
+import (
+	"sync"
+
+	"github.com/influxdata/influxdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// scrapeMetrics are the metrics of the scrapes of the targets, named like the ones Prometheus records.
+// The metrics of the targets discovered by a scraper target are split out by instance.
+type scrapeMetrics struct {
+	up              *prometheus.GaugeVec
+	duration        *prometheus.GaugeVec
+	samplesScraped  *prometheus.GaugeVec
+	scrapesComplete *prometheus.CounterVec
+
+	mu        sync.Mutex
+	instances map[influxdb.ID]map[string]bool // Target ID -> instances with metrics, "" for the target itself.
+}
+
+func newScrapeMetrics() *scrapeMetrics {
+	return &scrapeMetrics{
+		up: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "up",
+			Help: "Whether the last scrape of a scraper target succeeded, by target ID and discovered instance.",
+		}, []string{"target_id", "instance"}),
+		duration: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "scrape_duration_seconds",
+			Help: "Duration of the last scrape of a scraper target, by target ID and discovered instance.",
+		}, []string{"target_id", "instance"}),
+		samplesScraped: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "scrape_samples_scraped",
+			Help: "Number of metrics written by the last scrape of a scraper target, by target ID and discovered instance.",
+		}, []string{"target_id", "instance"}),
+		scrapesComplete: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "scrapes_complete",
+			Help: "Number of scrapes completed, split out by target ID, discovered instance and success or failure.",
+		}, []string{"target_id", "instance", "status"}),
+		instances: make(map[influxdb.ID]map[string]bool),
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (m *scrapeMetrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{m.up, m.duration, m.samplesScraped, m.scrapesComplete}
+}
+
+// record records the result r of a scrape of the target id, or of its discovered target r.Instance if it is set.
+func (m *scrapeMetrics) record(id influxdb.ID, r influxdb.ScrapeResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.instances[id] == nil {
+		m.instances[id] = make(map[string]bool)
+	}
+	m.instances[id][r.Instance] = true
+
+	tid := id.String()
+	up, status := 1.0, "success"
+	if r.Error != "" {
+		up, status = 0, "failed"
+	}
+	m.up.WithLabelValues(tid, r.Instance).Set(up)
+	m.duration.WithLabelValues(tid, r.Instance).Set(r.Duration.Seconds())
+	m.samplesScraped.WithLabelValues(tid, r.Instance).Set(float64(r.Samples))
+	m.scrapesComplete.WithLabelValues(tid, r.Instance, status).Inc()
+}
+
+// retain removes the metrics of the targets discovered by the target id that are not in instances,
+// once they are no longer discovered.
+func (m *scrapeMetrics) retain(id influxdb.ID, instances map[string]bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for instance := range m.instances[id] {
+		if !instances[instance] {
+			m.delete(id, instance)
+		}
+	}
+}
+
+// forget removes the metrics of the target id, once it is removed.
+func (m *scrapeMetrics) forget(id influxdb.ID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for instance := range m.instances[id] {
+		m.delete(id, instance)
+	}
+	delete(m.instances, id)
+}
+
+// delete removes the metrics of the instance of the target id.
+// m.mu must be held.
+func (m *scrapeMetrics) delete(id influxdb.ID, instance string) {
+	tid := id.String()
+	m.up.DeleteLabelValues(tid, instance)
+	m.duration.DeleteLabelValues(tid, instance)
+	m.samplesScraped.DeleteLabelValues(tid, instance)
+	m.scrapesComplete.DeleteLabelValues(tid, instance, "success")
+	m.scrapesComplete.DeleteLabelValues(tid, instance, "failed")
+	delete(m.instances[id], instance)
+}
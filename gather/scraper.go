@@ -2,6 +2,7 @@ package gather
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/influxdata/influxdb"
 )
@@ -10,3 +11,25 @@ import (
 type Scraper interface {
 	Gather(ctx context.Context, target influxdb.ScraperTarget) (collected MetricsCollection, err error)
 }
+
+// typeScraper gathers the metrics of each target with the scraper of its type.
+type typeScraper map[influxdb.ScraperType]Scraper
+
+// newTypeScraper returns a Scraper for every scraper type, loading the credentials of the targets from secrets.
+func newTypeScraper(secrets influxdb.SecretService) typeScraper {
+	return typeScraper{
+		influxdb.PrometheusScraperType:   &prometheusScraper{Secrets: secrets},
+		influxdb.LineProtocolScraperType: &lineProtocolScraper{Secrets: secrets},
+		influxdb.JSONScraperType:         &jsonScraper{Secrets: secrets},
+		influxdb.ExpvarScraperType:       &expvarScraper{Secrets: secrets},
+	}
+}
+
+// Gather gathers the metrics of target with the scraper of its type.
+func (s typeScraper) Gather(ctx context.Context, target influxdb.ScraperTarget) (MetricsCollection, error) {
+	scraper, ok := s[target.Type]
+	if !ok {
+		return MetricsCollection{}, fmt.Errorf("unsupported target scrape type: %s", target.Type)
+	}
+	return scraper.Gather(ctx, target)
+}
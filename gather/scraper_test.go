@@ -2,15 +2,18 @@ package gather
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"sort"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
 )
 
 var (
@@ -306,3 +309,248 @@ var metricsCmpOption = cmp.Options{
 			reflect.DeepEqual(x.Fields, y.Fields)
 	}),
 }
+
+func TestPrometheusScraper_Options(t *testing.T) {
+	secrets := mock.NewSecretService()
+	secrets.LoadSecretFn = func(ctx context.Context, oid influxdb.ID, k string) (string, error) {
+		if oid != *orgID || k != "scraper-token" {
+			return "", fmt.Errorf("secret %q not found", k)
+		}
+		return "s3cr3t", nil
+	}
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cr3t" || r.Header.Get("X-Scope") != "metrics" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mockHTTPHandler{responseMap: map[string]string{"/metrics": sampleRespSmall}}.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	cases := []struct {
+		name   string
+		target influxdb.ScraperTarget
+		hasErr bool
+	}{
+		{
+			name: "authorized",
+			target: influxdb.ScraperTarget{
+				BearerTokenSecret: "scraper-token",
+				Headers:           map[string]string{"X-Scope": "metrics"},
+				TLS:               &influxdb.ScraperTLSConfig{InsecureSkipVerify: true},
+			},
+		},
+		{
+			name: "unauthorized",
+			target: influxdb.ScraperTarget{
+				Headers: map[string]string{"X-Scope": "metrics"},
+				TLS:     &influxdb.ScraperTLSConfig{InsecureSkipVerify: true},
+			},
+			hasErr: true,
+		},
+		{
+			name: "missing secret",
+			target: influxdb.ScraperTarget{
+				BearerTokenSecret: "other-token",
+				TLS:               &influxdb.ScraperTLSConfig{InsecureSkipVerify: true},
+			},
+			hasErr: true,
+		},
+		{
+			name: "unverified certificate",
+			target: influxdb.ScraperTarget{
+				BearerTokenSecret: "scraper-token",
+				Headers:           map[string]string{"X-Scope": "metrics"},
+			},
+			hasErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			scraper := &prometheusScraper{Secrets: secrets}
+			c.target.URL = ts.URL + "/metrics"
+			c.target.OrgID = *orgID
+			c.target.BucketID = *bucketID
+
+			results, err := scraper.Gather(context.Background(), c.target)
+			if (err != nil) != c.hasErr {
+				t.Fatalf("expected error %t, got %v", c.hasErr, err)
+			}
+			if !c.hasErr && len(results.MetricsSlice) != 1 {
+				t.Fatalf("expected 1 metric, got %d", len(results.MetricsSlice))
+			}
+		})
+	}
+}
+
+func TestTypeScraper(t *testing.T) {
+	ts := httptest.NewServer(mockHTTPHandler{
+		responseMap: map[string]string{
+			"/lp": "cpu,host=a usage=0.5,busy=true 1000\nmem,host=a used=42i 2000\n",
+			"/actuator": `{
+				"name": "jvm.memory.used",
+				"measurements": [{"statistic": "VALUE", "value": 1024}],
+				"availableTags": [{"tag": "area", "values": ["heap"]}]
+			}`,
+			"/debug/vars": `{
+				"cmdline": ["influxd"],
+				"memstats": {"Alloc": 100, "PauseNs": [1, 2]},
+				"httpd::8086": {"name": "httpd", "tags": {"bind": ":8086"}, "values": {"req": 3}},
+				"runtime": {"name": "runtime", "values": {}}
+			}`,
+		},
+	})
+	defer ts.Close()
+
+	scraper := newTypeScraper(nil)
+	cases := []struct {
+		name   string
+		target influxdb.ScraperTarget
+		want   []Metrics
+		hasErr bool
+	}{
+		{
+			name: "line protocol",
+			target: influxdb.ScraperTarget{
+				Type:         influxdb.LineProtocolScraperType,
+				URL:          ts.URL + "/lp",
+				LineProtocol: &influxdb.ScraperLineProtocolConfig{Precision: "s"},
+			},
+			want: []Metrics{
+				{
+					Name:   "cpu",
+					Tags:   map[string]string{"host": "a"},
+					Fields: map[string]interface{}{"usage": 0.5, "busy": true},
+					Type:   MetricTypeUntyped,
+				},
+				{
+					Name:   "mem",
+					Tags:   map[string]string{"host": "a"},
+					Fields: map[string]interface{}{"used": int64(42)},
+					Type:   MetricTypeUntyped,
+				},
+			},
+		},
+		{
+			name: "json",
+			target: influxdb.ScraperTarget{
+				Type: influxdb.JSONScraperType,
+				URL:  ts.URL + "/actuator",
+				JSON: &influxdb.ScraperJSONConfig{
+					Measurement: "jvm",
+					Fields: map[string]string{
+						"memory_used": "$.measurements[0].value",
+						"missing":     "$.measurements[1].value",
+					},
+					Tags: map[string]string{
+						"area":   "$.availableTags[0].values[0]",
+						"metric": "$.name",
+					},
+				},
+			},
+			want: []Metrics{
+				{
+					Name:   "jvm",
+					Tags:   map[string]string{"area": "heap", "metric": "jvm.memory.used"},
+					Fields: map[string]interface{}{"memory_used": float64(1024)},
+					Type:   MetricTypeUntyped,
+				},
+			},
+		},
+		{
+			name: "json field of an object",
+			target: influxdb.ScraperTarget{
+				Type: influxdb.JSONScraperType,
+				URL:  ts.URL + "/actuator",
+				JSON: &influxdb.ScraperJSONConfig{
+					Measurement: "jvm",
+					Fields:      map[string]string{"measurement": "$.measurements[0]"},
+				},
+			},
+			hasErr: true,
+		},
+		{
+			name: "json without fields found",
+			target: influxdb.ScraperTarget{
+				Type: influxdb.JSONScraperType,
+				URL:  ts.URL + "/actuator",
+				JSON: &influxdb.ScraperJSONConfig{
+					Measurement: "jvm",
+					Fields:      map[string]string{"missing": "$.missing"},
+				},
+			},
+			hasErr: true,
+		},
+		{
+			name: "expvar",
+			target: influxdb.ScraperTarget{
+				Type: influxdb.ExpvarScraperType,
+				URL:  ts.URL + "/debug/vars",
+			},
+			want: []Metrics{
+				{
+					Name:   "influxdb_httpd",
+					Tags:   map[string]string{"bind": ":8086"},
+					Fields: map[string]interface{}{"req": float64(3)},
+					Type:   MetricTypeUntyped,
+				},
+				{
+					Name:   "influxdb_memstats",
+					Tags:   map[string]string{},
+					Fields: map[string]interface{}{"Alloc": float64(100)},
+					Type:   MetricTypeUntyped,
+				},
+			},
+		},
+		{
+			name: "expvar of a document other than an object",
+			target: influxdb.ScraperTarget{
+				Type: influxdb.ExpvarScraperType,
+				URL:  ts.URL + "/lp",
+			},
+			hasErr: true,
+		},
+		{
+			name: "unknown type",
+			target: influxdb.ScraperTarget{
+				Type: "graphite",
+				URL:  ts.URL + "/lp",
+			},
+			hasErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.target.OrgID = *orgID
+			c.target.BucketID = *bucketID
+
+			results, err := scraper.Gather(context.Background(), c.target)
+			if (err != nil) != c.hasErr {
+				t.Fatalf("expected error %t, got %v", c.hasErr, err)
+			}
+			if c.hasErr {
+				return
+			}
+			ms := results.MetricsSlice
+			sort.Slice(ms, func(i, j int) bool { return ms[i].Name < ms[j].Name })
+			if diff := cmp.Diff(c.want, []Metrics(ms), metricsCmpOption); diff != "" {
+				t.Fatalf("unexpected metrics: %s", diff)
+			}
+		})
+	}
+
+	t.Run("line protocol timestamps", func(t *testing.T) {
+		results, err := scraper.Gather(context.Background(), influxdb.ScraperTarget{
+			Type:         influxdb.LineProtocolScraperType,
+			URL:          ts.URL + "/lp",
+			LineProtocol: &influxdb.ScraperLineProtocolConfig{Precision: "s"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ts := results.MetricsSlice[0].Timestamp; !ts.Equal(time.Unix(1000, 0)) {
+			t.Fatalf("expected the timestamp of the point, got %s", ts)
+		}
+	})
+}
@@ -28,6 +28,7 @@ type APIHandler struct {
 	TaskHandler          *TaskHandler
 	TelegrafHandler      *TelegrafHandler
 	QueryHandler         *FluxHandler
+	PromQLHandler        *PromQLHandler
 	WriteHandler         *WriteHandler
 	DocumentHandler      *DocumentHandler
 	SetupHandler         *SetupHandler
@@ -64,8 +65,13 @@ type APIBackend struct {
 	InfluxQLService                 query.ProxyQueryService
 	FluxService                     query.ProxyQueryService
 	TaskService                     influxdb.TaskService
+	BackfillService                 influxdb.BackfillService
+	TaskTestService                 influxdb.TaskTestService
 	TelegrafService                 influxdb.TelegrafConfigStore
+	TelegrafAgentService            influxdb.TelegrafAgentService
 	ScraperTargetStoreService       influxdb.ScraperTargetStoreService
+	ScraperPreviewService           influxdb.ScraperPreviewService
+	ScraperStatusService            influxdb.ScraperStatusService
 	SecretService                   influxdb.SecretService
 	LookupService                   influxdb.LookupService
 	ChronografService               *server.Service
@@ -112,6 +118,9 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 
 	scraperBackend := NewScraperBackend(b)
 	scraperBackend.ScraperStorageService = authorizer.NewScraperTargetStoreService(b.ScraperTargetStoreService, b.UserResourceMappingService)
+	if b.SecretService != nil {
+		scraperBackend.SecretService = authorizer.NewSecretService(b.SecretService)
+	}
 	h.ScraperHandler = NewScraperHandler(scraperBackend)
 
 	sourceBackend := NewSourceBackend(b)
@@ -136,6 +145,10 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 	fluxBackend := NewFluxBackend(b)
 	h.QueryHandler = NewFluxHandler(fluxBackend)
 
+	promQLBackend := NewPromQLBackend(b)
+	promQLBackend.BucketService = authorizer.NewBucketService(b.BucketService)
+	h.PromQLHandler = NewPromQLHandler(promQLBackend)
+
 	h.ChronografHandler = NewChronografHandler(b.ChronografService)
 	h.SwaggerHandler = newSwaggerLoader(b.Logger.With(zap.String("service", "swagger-loader")))
 	h.LabelHandler = NewLabelHandler(authorizer.NewLabelService(b.LabelService))
@@ -156,6 +169,7 @@ var apiLinks = map[string]interface{}{
 	"variables": "/api/v2/variables",
 	"me":        "/api/v2/me",
 	"orgs":      "/api/v2/orgs",
+	"promql":    "/api/v2/promql",
 	"query": map[string]string{
 		"self":        "/api/v2/query",
 		"ast":         "/api/v2/query/ast",
@@ -221,6 +235,11 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/promql") {
+		h.PromQLHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/buckets") {
 		h.BucketHandler.ServeHTTP(w, r)
 		return
@@ -271,7 +290,7 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if strings.HasPrefix(r.URL.Path, "/api/v2/telegrafs") {
+	if strings.HasPrefix(r.URL.Path, "/api/v2/telegrafs") || strings.HasPrefix(r.URL.Path, "/api/v2/telegraf/plugins") {
 		h.TelegrafHandler.ServeHTTP(w, r)
 		return
 	}
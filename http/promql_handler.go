@@ -0,0 +1,686 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/promql"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const (
+	promQLPath = "/api/v2/promql"
+	// promQLAPIPath is the prefix of the Prometheus HTTP API for a bucket.
+	promQLAPIPath = promQLPath + "/:bucketID/api/v1"
+
+	// defaultPromQLSeriesRange is how far back the series, labels and label values
+	// endpoints look for series when the request does not have a start time.
+	defaultPromQLSeriesRange = time.Hour
+
+	// maxPromQLPoints is the maximum number of steps of a range query, like in Prometheus.
+	maxPromQLPoints = 11000
+)
+
+// PromQLBackend is all services and associated parameters required to construct
+// the PromQLHandler.
+type PromQLBackend struct {
+	Logger *zap.Logger
+
+	BucketService     influxdb.BucketService
+	ProxyQueryService query.ProxyQueryService
+}
+
+// NewPromQLBackend returns a new instance of PromQLBackend.
+func NewPromQLBackend(b *APIBackend) *PromQLBackend {
+	return &PromQLBackend{
+		Logger: b.Logger.With(zap.String("handler", "promql")),
+
+		BucketService:     b.BucketService,
+		ProxyQueryService: b.FluxService,
+	}
+}
+
+// PromQLHandler serves the query endpoints of the Prometheus HTTP API over the
+// metrics the scrapers write to a bucket. The API of each bucket is served at
+// /api/v2/promql/:bucketID so it can be used as the URL of a Prometheus data source.
+type PromQLHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	Now           func() time.Time
+	BucketService influxdb.BucketService
+	QueryService  query.QueryService
+}
+
+// NewPromQLHandler returns a new handler at /api/v2/promql for PromQL queries.
+func NewPromQLHandler(b *PromQLBackend) *PromQLHandler {
+	h := &PromQLHandler{
+		Router: NewRouter(),
+		Logger: b.Logger,
+		Now:    time.Now,
+
+		BucketService: b.BucketService,
+		QueryService:  query.QueryServiceProxyBridge{ProxyQueryService: b.ProxyQueryService},
+	}
+
+	// The Prometheus API accepts both query parameters and form encoded bodies.
+	for _, method := range []string{"GET", "POST"} {
+		h.HandlerFunc(method, promQLAPIPath+"/query", h.handleQuery)
+		h.HandlerFunc(method, promQLAPIPath+"/query_range", h.handleQueryRange)
+		h.HandlerFunc(method, promQLAPIPath+"/series", h.handleSeries)
+		h.HandlerFunc(method, promQLAPIPath+"/labels", h.handleLabels)
+	}
+	h.HandlerFunc("GET", promQLAPIPath+"/label/:name/values", h.handleLabelValues)
+	return h
+}
+
+// promQLResponse is the envelope of every response of the Prometheus HTTP API.
+type promQLResponse struct {
+	Status    string      `json:"status"`
+	Data      interface{} `json:"data,omitempty"`
+	ErrorType string      `json:"errorType,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+type promQLQueryData struct {
+	ResultType promql.ValueType `json:"resultType"`
+	Result     interface{}      `json:"result"`
+}
+
+// promQLSeries is a series of an instant vector or a range vector.
+type promQLSeries struct {
+	Metric map[string]string `json:"metric"`
+	Value  *promQLPoint      `json:"value,omitempty"`
+	Values []promQLPoint     `json:"values,omitempty"`
+}
+
+// promQLPoint is a sample encoded as the pair of its unix time in seconds
+// and its value as a string.
+type promQLPoint struct {
+	T time.Time
+	V float64
+}
+
+func (p promQLPoint) MarshalJSON() ([]byte, error) {
+	t := strconv.FormatFloat(float64(p.T.UnixNano())/1e9, 'f', -1, 64)
+	var v string
+	switch {
+	case math.IsInf(p.V, 1):
+		v = "+Inf"
+	case math.IsInf(p.V, -1):
+		v = "-Inf"
+	case math.IsNaN(p.V):
+		v = "NaN"
+	default:
+		v = strconv.FormatFloat(p.V, 'f', -1, 64)
+	}
+	return []byte(fmt.Sprintf(`[%s,%q]`, t, v)), nil
+}
+
+// promQLError is an error with the error type of the Prometheus HTTP API.
+type promQLError struct {
+	typ  string
+	code int
+	err  error
+}
+
+func (e *promQLError) Error() string {
+	return e.err.Error()
+}
+
+func badData(err error) error {
+	return &promQLError{typ: "bad_data", code: http.StatusBadRequest, err: err}
+}
+
+func (h *PromQLHandler) encodeError(ctx context.Context, w http.ResponseWriter, err error) {
+	perr, ok := err.(*promQLError)
+	if !ok {
+		perr = &promQLError{typ: "execution", code: http.StatusUnprocessableEntity, err: errors.New(influxdb.ErrorMessage(err))}
+		if code := influxdb.ErrorCode(err); code == influxdb.ENotFound {
+			perr.typ, perr.code = "not_found", http.StatusNotFound
+		} else if code == influxdb.EUnauthorized || code == influxdb.EForbidden {
+			perr.typ, perr.code = "unauthorized", http.StatusUnauthorized
+		}
+	}
+	if err := encodeResponse(ctx, w, perr.code, promQLResponse{
+		Status:    "error",
+		ErrorType: perr.typ,
+		Error:     perr.Error(),
+	}); err != nil {
+		h.Logger.Info("Error writing response to client", zap.String("handler", "promql"), zap.Error(err))
+	}
+}
+
+func (h *PromQLHandler) encodeData(ctx context.Context, w http.ResponseWriter, data interface{}) {
+	if err := encodeResponse(ctx, w, http.StatusOK, promQLResponse{
+		Status: "success",
+		Data:   data,
+	}); err != nil {
+		h.Logger.Info("Error writing response to client", zap.String("handler", "promql"), zap.Error(err))
+	}
+}
+
+// handleQuery evaluates an instant query.
+func (h *PromQLHandler) handleQuery(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "PromQLHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	bucket, err := h.decodeBucket(ctx, r)
+	if err != nil {
+		h.encodeError(ctx, w, err)
+		return
+	}
+	ts, err := parsePromQLTime(r.FormValue("time"), h.Now())
+	if err != nil {
+		h.encodeError(ctx, w, badData(fmt.Errorf("invalid parameter 'time': %s", err)))
+		return
+	}
+
+	prog, err := promql.Transpile(r.FormValue("query"), promql.Config{
+		BucketID: bucket.ID,
+		Start:    ts,
+		End:      ts,
+	})
+	if err != nil {
+		h.encodeError(ctx, w, badData(err))
+		return
+	}
+
+	if prog.Type == promql.ScalarValue {
+		h.encodeData(ctx, w, promQLQueryData{
+			ResultType: promql.ScalarValue,
+			Result:     promQLPoint{T: ts, V: prog.Scalar},
+		})
+		return
+	}
+
+	series, err := h.query(ctx, bucket, prog.AST)
+	if err != nil {
+		h.encodeError(ctx, w, err)
+		return
+	}
+	if prog.Type == promql.VectorValue {
+		for _, s := range series {
+			if len(s.Values) > 0 {
+				s.Value = &s.Values[len(s.Values)-1]
+				s.Values = nil
+			}
+		}
+	}
+	h.encodeData(ctx, w, promQLQueryData{
+		ResultType: prog.Type,
+		Result:     series,
+	})
+}
+
+// handleQueryRange evaluates an expression at every step between the start and the end.
+func (h *PromQLHandler) handleQueryRange(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "PromQLHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	bucket, err := h.decodeBucket(ctx, r)
+	if err != nil {
+		h.encodeError(ctx, w, err)
+		return
+	}
+
+	config := promql.Config{BucketID: bucket.ID}
+	for _, p := range []struct {
+		name string
+		t    *time.Time
+	}{
+		{"start", &config.Start},
+		{"end", &config.End},
+	} {
+		v := r.FormValue(p.name)
+		if v == "" {
+			h.encodeError(ctx, w, badData(fmt.Errorf("missing parameter '%s'", p.name)))
+			return
+		}
+		if *p.t, err = parsePromQLTime(v, time.Time{}); err != nil {
+			h.encodeError(ctx, w, badData(fmt.Errorf("invalid parameter '%s': %s", p.name, err)))
+			return
+		}
+	}
+	if config.End.Before(config.Start) {
+		h.encodeError(ctx, w, badData(fmt.Errorf("end timestamp must not be before start time")))
+		return
+	}
+	if config.Step, err = parsePromQLDuration(r.FormValue("step")); err != nil {
+		h.encodeError(ctx, w, badData(fmt.Errorf("invalid parameter 'step': %s", err)))
+		return
+	} else if config.Step <= 0 {
+		h.encodeError(ctx, w, badData(fmt.Errorf("zero or negative query resolution step widths are not accepted")))
+		return
+	}
+	// Both scalars and vectors have a point at every step.
+	if config.End.Sub(config.Start)/config.Step > maxPromQLPoints {
+		h.encodeError(ctx, w, badData(fmt.Errorf("exceeded maximum resolution of %d points per timeseries. Try decreasing the query resolution (?step=XX)", maxPromQLPoints)))
+		return
+	}
+
+	prog, err := promql.Transpile(r.FormValue("query"), config)
+	if err != nil {
+		h.encodeError(ctx, w, badData(err))
+		return
+	}
+
+	var series []*promQLSeries
+	if prog.Type == promql.ScalarValue {
+		// Scalars have the same value at every step.
+		s := &promQLSeries{Metric: map[string]string{}}
+		for ts := config.Start; !ts.After(config.End); ts = ts.Add(config.Step) {
+			s.Values = append(s.Values, promQLPoint{T: ts, V: prog.Scalar})
+		}
+		series = append(series, s)
+	} else if series, err = h.query(ctx, bucket, prog.AST); err != nil {
+		h.encodeError(ctx, w, err)
+		return
+	}
+	h.encodeData(ctx, w, promQLQueryData{
+		ResultType: promql.MatrixValue,
+		Result:     series,
+	})
+}
+
+// handleSeries returns the labels of the series that match the selectors.
+func (h *PromQLHandler) handleSeries(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "PromQLHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	bucket, config, err := h.decodeSeriesRequest(ctx, r)
+	if err != nil {
+		h.encodeError(ctx, w, err)
+		return
+	}
+	if len(r.Form["match[]"]) == 0 {
+		h.encodeError(ctx, w, badData(fmt.Errorf("no match[] parameter provided")))
+		return
+	}
+
+	labels, err := h.seriesLabels(ctx, bucket, r.Form["match[]"], config)
+	if err != nil {
+		h.encodeError(ctx, w, err)
+		return
+	}
+	h.encodeData(ctx, w, labels)
+}
+
+// handleLabels returns the names of the labels of the series.
+func (h *PromQLHandler) handleLabels(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "PromQLHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	bucket, config, err := h.decodeSeriesRequest(ctx, r)
+	if err != nil {
+		h.encodeError(ctx, w, err)
+		return
+	}
+
+	labels, err := h.seriesLabels(ctx, bucket, r.Form["match[]"], config)
+	if err != nil {
+		h.encodeError(ctx, w, err)
+		return
+	}
+	names := make(map[string]bool)
+	for _, l := range labels {
+		for k := range l {
+			names[k] = true
+		}
+	}
+	h.encodeData(ctx, w, sortedKeys(names))
+}
+
+// handleLabelValues returns the values of a label of the series.
+func (h *PromQLHandler) handleLabelValues(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "PromQLHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	bucket, config, err := h.decodeSeriesRequest(ctx, r)
+	if err != nil {
+		h.encodeError(ctx, w, err)
+		return
+	}
+
+	labels, err := h.seriesLabels(ctx, bucket, r.Form["match[]"], config)
+	if err != nil {
+		h.encodeError(ctx, w, err)
+		return
+	}
+	name := httprouter.ParamsFromContext(ctx).ByName("name")
+	values := make(map[string]bool)
+	for _, l := range labels {
+		if v, ok := l[name]; ok {
+			values[v] = true
+		}
+	}
+	h.encodeData(ctx, w, sortedKeys(values))
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// decodeBucket finds the bucket of the request. Finding the bucket verifies
+// the request is authorized to read it.
+func (h *PromQLHandler) decodeBucket(ctx context.Context, r *http.Request) (*influxdb.Bucket, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, badData(err)
+	}
+	var id influxdb.ID
+	if err := id.DecodeFromString(httprouter.ParamsFromContext(ctx).ByName("bucketID")); err != nil {
+		return nil, badData(err)
+	}
+	return h.BucketService.FindBucketByID(ctx, id)
+}
+
+// decodeSeriesRequest decodes the bucket and the optional time range of the
+// requests for the series and their labels.
+func (h *PromQLHandler) decodeSeriesRequest(ctx context.Context, r *http.Request) (*influxdb.Bucket, promql.Config, error) {
+	bucket, err := h.decodeBucket(ctx, r)
+	if err != nil {
+		return nil, promql.Config{}, err
+	}
+	config := promql.Config{BucketID: bucket.ID}
+	if config.End, err = parsePromQLTime(r.FormValue("end"), h.Now()); err != nil {
+		return nil, config, badData(fmt.Errorf("invalid parameter 'end': %s", err))
+	}
+	if config.Start, err = parsePromQLTime(r.FormValue("start"), config.End.Add(-defaultPromQLSeriesRange)); err != nil {
+		return nil, config, badData(fmt.Errorf("invalid parameter 'start': %s", err))
+	}
+	return bucket, config, nil
+}
+
+// seriesLabels returns the labels of the series that match one of the selectors
+// or of every series when there are no selectors.
+func (h *PromQLHandler) seriesLabels(ctx context.Context, bucket *influxdb.Bucket, selectors []string, config promql.Config) ([]map[string]string, error) {
+	if len(selectors) > 0 {
+		pkg, err := promql.Series(selectors, config)
+		if err != nil {
+			return nil, badData(err)
+		}
+		series, err := h.query(ctx, bucket, pkg)
+		if err != nil {
+			return nil, err
+		}
+		labels := make([]map[string]string, 0, len(series))
+		for _, s := range series {
+			labels = append(labels, s.Metric)
+		}
+		return labels, nil
+	}
+
+	// The fields of the measurements are converted to the labels of the series.
+	type field struct {
+		measurement, field string
+		tags               map[string]string
+	}
+	var (
+		fields     []field
+		histograms = make(map[string]bool)
+	)
+	err := h.read(ctx, bucket, promql.AllSeries(config), func(key flux.GroupKey, _ flux.ColReader) error {
+		f := field{tags: make(map[string]string)}
+		for j, c := range key.Cols() {
+			if c.Type != flux.TString || key.IsNull(j) {
+				continue
+			}
+			switch v := key.ValueString(j); c.Label {
+			case "_measurement":
+				f.measurement = v
+			case "_field":
+				f.field = v
+			case execute.DefaultStartColLabel, execute.DefaultStopColLabel:
+			default:
+				f.tags[c.Label] = v
+			}
+		}
+		if f.field == "+Inf" {
+			histograms[f.measurement] = true
+		}
+		fields = append(fields, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]map[string]string, 0, len(fields))
+	for _, f := range fields {
+		labels = append(labels, promql.SeriesLabels(f.measurement, f.field, f.tags, histograms[f.measurement]))
+	}
+	return labels, nil
+}
+
+// query runs the program and returns the series it produced. The labels of
+// the series are the string columns of the group key of the tables.
+func (h *PromQLHandler) query(ctx context.Context, bucket *influxdb.Bucket, pkg *ast.Package) ([]*promQLSeries, error) {
+	var (
+		series []*promQLSeries
+		byKey  = make(map[string]*promQLSeries)
+	)
+	err := h.read(ctx, bucket, pkg, func(key flux.GroupKey, cr flux.ColReader) error {
+		metric := make(map[string]string)
+		for j, c := range key.Cols() {
+			if c.Type == flux.TString && !key.IsNull(j) {
+				metric[c.Label] = key.ValueString(j)
+			}
+		}
+		id := seriesID(metric)
+		s, ok := byKey[id]
+		if !ok {
+			s = &promQLSeries{Metric: metric}
+			byKey[id] = s
+			series = append(series, s)
+		}
+
+		if cr == nil {
+			return nil
+		}
+		timeIdx := execute.ColIdx(execute.DefaultTimeColLabel, cr.Cols())
+		valueIdx := execute.ColIdx(execute.DefaultValueColLabel, cr.Cols())
+		if timeIdx < 0 || valueIdx < 0 {
+			return nil
+		}
+		value := floatValue(cr, valueIdx)
+		if value == nil {
+			return nil
+		}
+		times := cr.Times(timeIdx)
+		for i := 0; i < cr.Len(); i++ {
+			v, ok := value(i)
+			if times.IsNull(i) || !ok {
+				continue
+			}
+			s.Values = append(s.Values, promQLPoint{
+				T: time.Unix(0, times.Value(i)).UTC(),
+				V: v,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Series without samples are not part of the result.
+	nonEmpty := series[:0]
+	for _, s := range series {
+		if len(s.Values) == 0 {
+			continue
+		}
+		sort.SliceStable(s.Values, func(i, j int) bool {
+			return s.Values[i].T.Before(s.Values[j].T)
+		})
+		nonEmpty = append(nonEmpty, s)
+	}
+	series = nonEmpty
+	sort.Slice(series, func(i, j int) bool {
+		return seriesID(series[i].Metric) < seriesID(series[j].Metric)
+	})
+	return series, nil
+}
+
+// floatValue returns the value of the column j of cr at a row as a float, and whether it is not null,
+// or nil if the column is not numeric. Prometheus samples are floats, so integers are converted.
+func floatValue(cr flux.ColReader, j int) func(i int) (float64, bool) {
+	switch cr.Cols()[j].Type {
+	case flux.TFloat:
+		vs := cr.Floats(j)
+		return func(i int) (float64, bool) { return vs.Value(i), vs.IsValid(i) }
+	case flux.TInt:
+		vs := cr.Ints(j)
+		return func(i int) (float64, bool) { return float64(vs.Value(i)), vs.IsValid(i) }
+	case flux.TUInt:
+		vs := cr.UInts(j)
+		return func(i int) (float64, bool) { return float64(vs.Value(i)), vs.IsValid(i) }
+	default:
+		return nil
+	}
+}
+
+func seriesID(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s=%q,", k, labels[k])
+	}
+	return b.String()
+}
+
+// read runs the program with the authorization of the request and calls fn with
+// the group key and every column reader of the tables. Tables without any rows
+// call fn once with a nil column reader.
+func (h *PromQLHandler) read(ctx context.Context, bucket *influxdb.Bucket, pkg *ast.Package, fn func(flux.GroupKey, flux.ColReader) error) error {
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+	var auth *influxdb.Authorization
+	switch a := a.(type) {
+	case *influxdb.Authorization:
+		auth = a
+	case *influxdb.Session:
+		auth = a.EphemeralAuth(bucket.OrganizationID)
+	default:
+		return influxdb.ErrAuthorizerNotSupported
+	}
+
+	results, err := h.QueryService.Query(ctx, &query.Request{
+		Authorization:  auth,
+		OrganizationID: bucket.OrganizationID,
+		Compiler: lang.ASTCompiler{
+			AST: pkg,
+			Now: h.Now(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer results.Release()
+
+	for results.More() {
+		err := results.Next().Tables().Do(func(tbl flux.Table) error {
+			read := false
+			if err := tbl.Do(func(cr flux.ColReader) error {
+				read = true
+				return fn(tbl.Key(), cr)
+			}); err != nil {
+				return err
+			}
+			if !read {
+				return fn(tbl.Key(), nil)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return results.Err()
+}
+
+// parsePromQLTime parses a time of the Prometheus HTTP API which is either a
+// unix timestamp in seconds or an RFC 3339 time. The default is used when the
+// time is empty.
+func parsePromQLTime(s string, def time.Time) (time.Time, error) {
+	if s == "" {
+		return def, nil
+	}
+	if v, err := strconv.ParseFloat(s, 64); err == nil {
+		sec, frac := math.Modf(v)
+		return time.Unix(int64(sec), int64(frac*1e9)).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot parse %q to a valid timestamp", s)
+	}
+	return t, nil
+}
+
+// parsePromQLDuration parses a duration which is either a number of
+// seconds or a duration string.
+func parsePromQLDuration(s string) (time.Duration, error) {
+	if v, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(v * float64(time.Second)), nil
+	}
+	d, err := promqlDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse %q to a valid duration", s)
+	}
+	return d, nil
+}
+
+// promqlDuration parses durations with the units of PromQL.
+func promqlDuration(s string) (time.Duration, error) {
+	var units = map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+		"y": 365 * 24 * time.Hour,
+	}
+	for u, d := range units {
+		if strings.HasSuffix(s, u) {
+			n, err := strconv.ParseInt(strings.TrimSuffix(s, u), 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			return time.Duration(n) * d, nil
+		}
+	}
+	return time.ParseDuration(s)
+}
+
+var _ json.Marshaler = promQLPoint{}
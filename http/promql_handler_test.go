@@ -0,0 +1,289 @@
+package http
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/query"
+	querymock "github.com/influxdata/influxdb/query/mock"
+	"go.uber.org/zap"
+)
+
+func newPromQLTestHandler(results string, queried *bool) *PromQLHandler {
+	b := &PromQLBackend{
+		Logger: zap.NewNop().With(zap.String("handler", "promql")),
+		BucketService: &mock.BucketService{
+			FindBucketByIDFn: func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+				if id != 1 {
+					return nil, &platform.Error{Code: platform.ENotFound, Msg: "bucket not found"}
+				}
+				return &platform.Bucket{ID: 1, OrganizationID: 2, Name: "metrics"}, nil
+			},
+		},
+		ProxyQueryService: &querymock.ProxyQueryService{
+			QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (flux.Statistics, error) {
+				*queried = true
+				_, err := io.WriteString(w, results)
+				return flux.Statistics{}, err
+			},
+		},
+	}
+	h := NewPromQLHandler(b)
+	h.Now = func() time.Time { return time.Date(2019, 1, 1, 0, 1, 0, 0, time.UTC) }
+	return h
+}
+
+func TestPromQLHandler(t *testing.T) {
+	const vectorResults = `#datatype,string,long,dateTime:RFC3339,double,string,string
+#group,false,false,false,false,true,true
+#default,_result,,,,,
+,result,table,_time,_value,__name__,job
+,,0,2019-01-01T00:00:30Z,1.5,reqs,a
+,,1,2019-01-01T00:00:30Z,2,reqs,b
+
+`
+	const rangeResults = `#datatype,string,long,dateTime:RFC3339,double,string
+#group,false,false,false,false,true
+#default,_result,,,,
+,result,table,_time,_value,job
+,,0,2019-01-01T00:00:40Z,4,a
+,,0,2019-01-01T00:00:20Z,+Inf,a
+
+`
+	const intResults = `#datatype,string,long,dateTime:RFC3339,long,string
+#group,false,false,false,false,true
+#default,_result,,,,
+,result,table,_time,_value,job
+,,0,2019-01-01T00:00:20Z,3,a
+,,0,2019-01-01T00:00:40Z,5,a
+
+`
+	const fieldResults = `#datatype,string,long,dateTime:RFC3339,dateTime:RFC3339,dateTime:RFC3339,double,string,string,string
+#group,false,false,true,true,false,false,true,true,true
+#default,_result,,,,,,,,
+,result,table,_start,_stop,_time,_value,_field,_measurement,job
+,,0,2019-01-01T00:00:00Z,2019-01-01T00:01:00Z,2019-01-01T00:00:30Z,1,counter,reqs,a
+,,1,2019-01-01T00:00:00Z,2019-01-01T00:01:00Z,2019-01-01T00:00:30Z,1,+Inf,lat,b
+,,2,2019-01-01T00:00:00Z,2019-01-01T00:01:00Z,2019-01-01T00:00:30Z,1,0.5,lat,b
+,,3,2019-01-01T00:00:00Z,2019-01-01T00:01:00Z,2019-01-01T00:00:30Z,1,count,lat,b
+
+`
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		params      url.Values
+		results     string
+		wantQueried bool
+		status      int
+		want        string
+	}{
+		{
+			name:   "instant query",
+			method: "GET",
+			path:   "/api/v2/promql/0000000000000001/api/v1/query",
+			params: url.Values{
+				"query": []string{"reqs"},
+				"time":  []string{"1546300830"},
+			},
+			results:     vectorResults,
+			wantQueried: true,
+			status:      http.StatusOK,
+			want: `{"status":"success","data":{"resultType":"vector","result":[
+				{"metric":{"__name__":"reqs","job":"a"},"value":[1546300830,"1.5"]},
+				{"metric":{"__name__":"reqs","job":"b"},"value":[1546300830,"2"]}
+			]}}`,
+		},
+		{
+			name:   "range query",
+			method: "POST",
+			path:   "/api/v2/promql/0000000000000001/api/v1/query_range",
+			params: url.Values{
+				"query": []string{"rate(reqs[1m])"},
+				"start": []string{"2019-01-01T00:00:20Z"},
+				"end":   []string{"2019-01-01T00:00:40Z"},
+				"step":  []string{"20s"},
+			},
+			results:     rangeResults,
+			wantQueried: true,
+			status:      http.StatusOK,
+			want: `{"status":"success","data":{"resultType":"matrix","result":[
+				{"metric":{"job":"a"},"values":[[1546300820,"+Inf"],[1546300840,"4"]]}
+			]}}`,
+		},
+		{
+			name:   "range query of integers",
+			method: "GET",
+			path:   "/api/v2/promql/0000000000000001/api/v1/query_range",
+			params: url.Values{
+				"query": []string{"reqs"},
+				"start": []string{"2019-01-01T00:00:20Z"},
+				"end":   []string{"2019-01-01T00:00:40Z"},
+				"step":  []string{"20s"},
+			},
+			results:     intResults,
+			wantQueried: true,
+			status:      http.StatusOK,
+			want: `{"status":"success","data":{"resultType":"matrix","result":[
+				{"metric":{"job":"a"},"values":[[1546300820,"3"],[1546300840,"5"]]}
+			]}}`,
+		},
+		{
+			name:   "range query ending before its start",
+			method: "GET",
+			path:   "/api/v2/promql/0000000000000001/api/v1/query_range",
+			params: url.Values{
+				"query": []string{"reqs"},
+				"start": []string{"1546300860"},
+				"end":   []string{"1546300800"},
+				"step":  []string{"20s"},
+			},
+			status: http.StatusBadRequest,
+			want:   `{"status":"error","errorType":"bad_data","error":"end timestamp must not be before start time"}`,
+		},
+		{
+			name:   "scalar query is not run",
+			method: "GET",
+			path:   "/api/v2/promql/0000000000000001/api/v1/query",
+			params: url.Values{
+				"query": []string{"1 + 2.5"},
+			},
+			status: http.StatusOK,
+			want:   `{"status":"success","data":{"resultType":"scalar","result":[1546300860,"3.5"]}}`,
+		},
+		{
+			name:   "invalid query",
+			method: "GET",
+			path:   "/api/v2/promql/0000000000000001/api/v1/query",
+			params: url.Values{
+				"query": []string{"sum("},
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "range query without step",
+			method: "GET",
+			path:   "/api/v2/promql/0000000000000001/api/v1/query_range",
+			params: url.Values{
+				"query": []string{"reqs"},
+				"start": []string{"1546300800"},
+				"end":   []string{"1546300860"},
+			},
+			status: http.StatusBadRequest,
+			want:   `{"status":"error","errorType":"bad_data","error":"invalid parameter 'step': cannot parse \"\" to a valid duration"}`,
+		},
+		{
+			name:   "range query with too many points",
+			method: "GET",
+			path:   "/api/v2/promql/0000000000000001/api/v1/query_range",
+			params: url.Values{
+				"query": []string{"rate(reqs[1m])"},
+				"start": []string{"1546300800"},
+				"end":   []string{"1546311801"},
+				"step":  []string{"1s"},
+			},
+			status: http.StatusBadRequest,
+			want:   `{"status":"error","errorType":"bad_data","error":"exceeded maximum resolution of 11000 points per timeseries. Try decreasing the query resolution (?step=XX)"}`,
+		},
+		{
+			name:   "scalar range query with too many points",
+			method: "GET",
+			path:   "/api/v2/promql/0000000000000001/api/v1/query_range",
+			params: url.Values{
+				"query": []string{"1"},
+				"start": []string{"0"},
+				"end":   []string{"1546300800"},
+				"step":  []string{"1ms"},
+			},
+			status: http.StatusBadRequest,
+			want:   `{"status":"error","errorType":"bad_data","error":"exceeded maximum resolution of 11000 points per timeseries. Try decreasing the query resolution (?step=XX)"}`,
+		},
+		{
+			name:   "unknown bucket",
+			method: "GET",
+			path:   "/api/v2/promql/0000000000000003/api/v1/query",
+			params: url.Values{
+				"query": []string{"reqs"},
+			},
+			status: http.StatusNotFound,
+			want:   `{"status":"error","errorType":"not_found","error":"bucket not found"}`,
+		},
+		{
+			name:        "labels",
+			method:      "GET",
+			path:        "/api/v2/promql/0000000000000001/api/v1/labels",
+			results:     fieldResults,
+			wantQueried: true,
+			status:      http.StatusOK,
+			want:        `{"status":"success","data":["__name__","job","le"]}`,
+		},
+		{
+			name:        "label values",
+			method:      "GET",
+			path:        "/api/v2/promql/0000000000000001/api/v1/label/__name__/values",
+			results:     fieldResults,
+			wantQueried: true,
+			status:      http.StatusOK,
+			want:        `{"status":"success","data":["lat_bucket","lat_count","reqs"]}`,
+		},
+		{
+			name:   "series",
+			method: "GET",
+			path:   "/api/v2/promql/0000000000000001/api/v1/series",
+			params: url.Values{
+				"match[]": []string{`reqs{job="a"}`},
+			},
+			results:     vectorResults,
+			wantQueried: true,
+			status:      http.StatusOK,
+			want: `{"status":"success","data":[
+				{"__name__":"reqs","job":"a"},
+				{"__name__":"reqs","job":"b"}
+			]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queried bool
+			h := newPromQLTestHandler(tt.results, &queried)
+
+			var r *http.Request
+			if tt.method == "POST" {
+				r = httptest.NewRequest("POST", tt.path, strings.NewReader(tt.params.Encode()))
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			} else {
+				r = httptest.NewRequest("GET", tt.path+"?"+tt.params.Encode(), nil)
+			}
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{ID: 3, OrgID: 2}))
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.status {
+				t.Errorf("%q. PromQLHandler status = %v, want %v: %s", tt.name, res.StatusCode, tt.status, body)
+			}
+			if queried != tt.wantQueried {
+				t.Errorf("%q. PromQLHandler queried = %v, want %v", tt.name, queried, tt.wantQueried)
+			}
+			if tt.want == "" {
+				return
+			}
+			if eq, diff, err := jsonEqual(string(body), tt.want); err != nil || !eq {
+				t.Errorf("%q. PromQLHandler = ***%v***, err = %v", tt.name, diff, err)
+			}
+		})
+	}
+}
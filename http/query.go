@@ -31,6 +31,7 @@ type QueryRequest struct {
 	Query   string       `json:"query"`
 	Type    string       `json:"type"`
 	Dialect QueryDialect `json:"dialect"`
+	Params  QueryParams  `json:"params,omitempty"`
 
 	Org *influxdb.Organization `json:"-"`
 }
@@ -75,10 +76,21 @@ func (r QueryRequest) Validate() error {
 		}
 	}
 
+	if r.Spec != nil && len(r.Params) > 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "request body cannot specify both a spec and params",
+		}
+	}
+
 	if r.Type != "flux" {
 		return fmt.Errorf(`unknown query type: %s`, r.Type)
 	}
 
+	if err := r.Params.Validate(); err != nil {
+		return err
+	}
+
 	if len(r.Dialect.CommentPrefix) > 1 {
 		return fmt.Errorf("invalid dialect comment prefix: must be length 0 or 1")
 	}
@@ -231,8 +243,8 @@ func (r QueryRequest) proxyRequest(now func() time.Time) (*query.ProxyRequest, e
 			AST: pkg,
 			Now: now(),
 		}
-		if r.Extern != nil {
-			c.PrependFile(r.Extern)
+		if err := r.prependFiles(&c); err != nil {
+			return nil, err
 		}
 		compiler = c
 	} else if r.AST != nil {
@@ -240,8 +252,8 @@ func (r QueryRequest) proxyRequest(now func() time.Time) (*query.ProxyRequest, e
 			AST: r.AST,
 			Now: now(),
 		}
-		if r.Extern != nil {
-			c.PrependFile(r.Extern)
+		if err := r.prependFiles(&c); err != nil {
+			return nil, err
 		}
 		compiler = c
 	} else if r.Spec != nil {
@@ -274,6 +286,22 @@ func (r QueryRequest) proxyRequest(now func() time.Time) (*query.ProxyRequest, e
 	}, nil
 }
 
+// prependFiles prepends the params option and the external declarations to
+// the files of the compiler.
+func (r QueryRequest) prependFiles(c *lang.ASTCompiler) error {
+	if len(r.Params) > 0 {
+		file, err := r.Params.fluxFile()
+		if err != nil {
+			return err
+		}
+		c.PrependFile(file)
+	}
+	if r.Extern != nil {
+		c.PrependFile(r.Extern)
+	}
+	return nil
+}
+
 // QueryRequestFromProxyRequest converts a query.ProxyRequest into a QueryRequest.
 // The ProxyRequest must contain supported compilers and dialects otherwise an error occurs.
 func QueryRequestFromProxyRequest(req *query.ProxyRequest) (*QueryRequest, error) {
@@ -22,6 +22,7 @@ import (
 	"github.com/influxdata/influxdb/kit/check"
 	"github.com/influxdata/influxdb/kit/tracing"
 	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/cache"
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -102,6 +103,7 @@ func (h *FluxHandler) handleQuery(w http.ResponseWriter, r *http.Request) {
 
 	// Transform the context into one with the request's authorization.
 	ctx = pcontext.SetAuthorizer(ctx, req.Request.Authorization)
+	ctx = cache.ContextWithOptions(ctx, cache.ParseCacheControl(r.Header.Get("Cache-Control")))
 
 	hd, ok := req.Dialect.(HTTPDialect)
 	if !ok {
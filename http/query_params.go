@@ -0,0 +1,150 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxdb"
+)
+
+// QueryParams are the values of the parameters of a query. Flux queries read
+// them from the params option, e.g. params.host, and InfluxQL queries from
+// $host placeholders. Values are strings, integers, floats, booleans, arrays
+// of values of the same type and objects of values. Times and durations are
+// also accepted when the params are not decoded from JSON.
+type QueryParams map[string]interface{}
+
+// UnmarshalJSON decodes the params keeping numbers without a fraction or
+// exponent as integers.
+func (p *QueryParams) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil {
+		return err
+	}
+	*p = m
+	return nil
+}
+
+var paramNameRE = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Validate returns an error if a param has an invalid name or value.
+func (p QueryParams) Validate() error {
+	_, err := p.fluxFile()
+	return err
+}
+
+// fluxFile returns a file declaring the params option with the params.
+func (p QueryParams) fluxFile() (*ast.File, error) {
+	obj, err := paramsObject(p, "")
+	if err != nil {
+		return nil, err
+	}
+	return &ast.File{
+		Body: []ast.Statement{
+			&ast.OptionStatement{
+				Assignment: &ast.VariableAssignment{
+					ID:   &ast.Identifier{Name: "params"},
+					Init: obj,
+				},
+			},
+		},
+	}, nil
+}
+
+func paramsObject(m map[string]interface{}, prefix string) (*ast.ObjectExpression, error) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	// Params are sorted so the same params always declare the same option.
+	sort.Strings(keys)
+
+	obj := &ast.ObjectExpression{
+		Properties: make([]*ast.Property, 0, len(keys)),
+	}
+	for _, k := range keys {
+		if !paramNameRE.MatchString(k) {
+			return nil, invalidParam(prefix+k, "name must be an identifier")
+		}
+		v, err := paramValue(m[k], prefix+k)
+		if err != nil {
+			return nil, err
+		}
+		obj.Properties = append(obj.Properties, &ast.Property{
+			Key:   &ast.Identifier{Name: k},
+			Value: v,
+		})
+	}
+	return obj, nil
+}
+
+func paramValue(v interface{}, name string) (ast.Expression, error) {
+	switch v := v.(type) {
+	case string:
+		return &ast.StringLiteral{Value: v}, nil
+	case bool:
+		return &ast.BooleanLiteral{Value: v}, nil
+	case int:
+		return &ast.IntegerLiteral{Value: int64(v)}, nil
+	case int64:
+		return &ast.IntegerLiteral{Value: v}, nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, invalidParam(name, "float must be finite")
+		}
+		return &ast.FloatLiteral{Value: v}, nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return &ast.IntegerLiteral{Value: i}, nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, invalidParam(name, err.Error())
+		}
+		return &ast.FloatLiteral{Value: f}, nil
+	case time.Time:
+		return &ast.DateTimeLiteral{Value: v}, nil
+	case time.Duration:
+		return &ast.DurationLiteral{
+			Values: []ast.Duration{{Magnitude: int64(v), Unit: "ns"}},
+		}, nil
+	case []interface{}:
+		arr := &ast.ArrayExpression{
+			Elements: make([]ast.Expression, 0, len(v)),
+		}
+		for i, e := range v {
+			el, err := paramValue(e, fmt.Sprintf("%s[%d]", name, i))
+			if err != nil {
+				return nil, err
+			}
+			if i > 0 && el.Type() != arr.Elements[0].Type() {
+				return nil, invalidParam(name, "array elements must have the same type")
+			}
+			arr.Elements = append(arr.Elements, el)
+		}
+		return arr, nil
+	case map[string]interface{}:
+		return paramsObject(v, name+".")
+	case QueryParams:
+		return paramsObject(v, name+".")
+	case nil:
+		return nil, invalidParam(name, "value must not be null")
+	default:
+		return nil, invalidParam(name, fmt.Sprintf("unsupported type %T", v))
+	}
+}
+
+func invalidParam(name, msg string) error {
+	return &influxdb.Error{
+		Code: influxdb.EInvalid,
+		Msg:  fmt.Sprintf("invalid query param %q: %s", name, msg),
+	}
+}
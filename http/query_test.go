@@ -3,6 +3,7 @@ package http
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -81,6 +82,7 @@ func TestQueryRequest_Validate(t *testing.T) {
 		Query   string
 		Type    string
 		Dialect QueryDialect
+		Params  QueryParams
 		org     *platform.Organization
 	}
 	tests := []struct {
@@ -108,6 +110,58 @@ func TestQueryRequest_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "query cannot have both params and spec",
+			fields: fields{
+				Spec:   &flux.Spec{},
+				Type:   "flux",
+				Params: QueryParams{"x": 1},
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "param names must be identifiers",
+			fields: fields{
+				Query:  "howdy",
+				Type:   "flux",
+				Params: QueryParams{"not-an-identifier": 1},
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "param arrays must have elements of the same type",
+			fields: fields{
+				Query:  "howdy",
+				Type:   "flux",
+				Params: QueryParams{"x": []interface{}{"a", int64(1)}},
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "params cannot be null",
+			fields: fields{
+				Query:  "howdy",
+				Type:   "flux",
+				Params: QueryParams{"x": nil},
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "requires flux type",
 			fields: fields{
@@ -194,6 +248,7 @@ func TestQueryRequest_Validate(t *testing.T) {
 				Query:   tt.fields.Query,
 				Type:    tt.fields.Type,
 				Dialect: tt.fields.Dialect,
+				Params:  tt.fields.Params,
 				Org:     tt.fields.org,
 			}
 			if err := r.Validate(); (err != nil) != tt.wantErr {
@@ -211,6 +266,7 @@ func TestQueryRequest_proxyRequest(t *testing.T) {
 		Query   string
 		Type    string
 		Dialect QueryDialect
+		Params  QueryParams
 		org     *platform.Organization
 	}
 	tests := []struct {
@@ -342,6 +398,77 @@ func TestQueryRequest_proxyRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid query with params",
+			fields: fields{
+				Query: "howdy",
+				Type:  "flux",
+				Params: QueryParams{
+					"host":  "a",
+					"n":     json.Number("3"),
+					"ratio": json.Number("0.5"),
+					"ok":    true,
+					"hosts": []interface{}{"a", "b"},
+				},
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+				},
+				org: &platform.Organization{},
+			},
+			now: func() time.Time { return time.Unix(1, 1) },
+			want: &query.ProxyRequest{
+				Request: query.Request{
+					Compiler: lang.ASTCompiler{
+						AST: &ast.Package{
+							Package: "main",
+							Files: []*ast.File{
+								{
+									Body: []ast.Statement{
+										&ast.OptionStatement{
+											Assignment: &ast.VariableAssignment{
+												ID: &ast.Identifier{Name: "params"},
+												Init: &ast.ObjectExpression{
+													Properties: []*ast.Property{
+														{Key: &ast.Identifier{Name: "host"}, Value: &ast.StringLiteral{Value: "a"}},
+														{
+															Key: &ast.Identifier{Name: "hosts"},
+															Value: &ast.ArrayExpression{
+																Elements: []ast.Expression{
+																	&ast.StringLiteral{Value: "a"},
+																	&ast.StringLiteral{Value: "b"},
+																},
+															},
+														},
+														{Key: &ast.Identifier{Name: "n"}, Value: &ast.IntegerLiteral{Value: 3}},
+														{Key: &ast.Identifier{Name: "ok"}, Value: &ast.BooleanLiteral{Value: true}},
+														{Key: &ast.Identifier{Name: "ratio"}, Value: &ast.FloatLiteral{Value: 0.5}},
+													},
+												},
+											},
+										},
+									},
+								},
+								{
+									Body: []ast.Statement{
+										&ast.ExpressionStatement{
+											Expression: &ast.Identifier{Name: "howdy"},
+										},
+									},
+								},
+							},
+						},
+						Now: time.Unix(1, 1),
+					},
+				},
+				Dialect: &csv.Dialect{
+					ResultEncoderConfig: csv.ResultEncoderConfig{
+						NoHeader:  false,
+						Delimiter: ',',
+					},
+				},
+			},
+		},
 		{
 			name: "valid spec",
 			fields: fields{
@@ -381,6 +508,7 @@ func TestQueryRequest_proxyRequest(t *testing.T) {
 				Query:   tt.fields.Query,
 				Type:    tt.fields.Type,
 				Dialect: tt.fields.Dialect,
+				Params:  tt.fields.Params,
 				Org:     tt.fields.org,
 			}
 			got, err := r.proxyRequest(tt.now)
@@ -461,6 +589,36 @@ func Test_decodeQueryRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid query request with params",
+			args: args{
+				r: httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"query": "from()", "params": {"n": 1, "f": 1.5, "s": "a"}}`)),
+				svc: &mock.OrganizationService{
+					FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+						return &platform.Organization{
+							ID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+						}, nil
+					},
+				},
+			},
+			want: &QueryRequest{
+				Query: "from()",
+				Type:  "flux",
+				Dialect: QueryDialect{
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+					Header:         func(x bool) *bool { return &x }(true),
+				},
+				Params: QueryParams{
+					"n": json.Number("1"),
+					"f": json.Number("1.5"),
+					"s": "a",
+				},
+				Org: &platform.Organization{
+					ID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+				},
+			},
+		},
 		{
 			name: "error decoding json",
 			args: args{
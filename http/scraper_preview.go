@@ -0,0 +1,135 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	"github.com/influxdata/influxdb/kit/tracing"
+)
+
+// scraperPreviewRequest overrides the tags and relabel rules of the previewed target, to try them before saving them.
+type scraperPreviewRequest struct {
+	Tags         map[string]string             `json:"tags,omitempty"`
+	RelabelRules []influxdb.ScraperRelabelRule `json:"relabelRules,omitempty"`
+}
+
+// handlePostScraperTargetPreview is the HTTP handler for the POST /api/v2/scrapers/:id/preview route.
+func (h *ScraperHandler) handlePostScraperTargetPreview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := decodeScraperTargetIDRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	var req scraperPreviewRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			EncodeError(ctx, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "failed to decode request",
+				Err:  err,
+			}, w)
+			return
+		}
+	}
+
+	target, err := h.ScraperStorageService.GetTargetByID(ctx, *id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	// A preview scrapes the target with its secrets and returns what it scraped,
+	// so it requires the permissions to change the target.
+	if err := h.authorizePreview(ctx, target); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	if req.Tags != nil {
+		target.Tags = req.Tags
+	}
+	if req.RelabelRules != nil {
+		target.RelabelRules = req.RelabelRules
+	}
+	if err := target.ValidateOptions(); err != nil {
+		EncodeError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}, w)
+		return
+	}
+
+	preview, err := h.ScraperPreviewService.PreviewTarget(ctx, *target)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, preview); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// authorizePreview returns an error if the caller may not write the target, or reference its secrets.
+func (h *ScraperHandler) authorizePreview(ctx context.Context, target *influxdb.ScraperTarget) error {
+	p, err := influxdb.NewPermissionAtID(target.ID, influxdb.WriteAction, influxdb.ScraperResourceType, target.OrgID)
+	if err != nil {
+		return err
+	}
+	if err := authorizer.VerifyPermissions(ctx, []influxdb.Permission{*p}); err != nil {
+		return err
+	}
+	return h.validateSecrets(ctx, target.OrgID, target)
+}
+
+// PreviewTarget scrapes the target with the ID of target once, with the tags and relabel rules of target
+// instead of its own when target has any, and returns what would have been written.
+func (s *ScraperService) PreviewTarget(ctx context.Context, target influxdb.ScraperTarget) (*influxdb.ScraperPreview, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	url, err := newURL(s.Addr, path.Join(targetIDPath(target.ID), "preview"))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(scraperPreviewRequest{
+		Tags:         target.Tags,
+		RelabelRules: target.RelabelRules,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(url.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var preview influxdb.ScraperPreview
+	if err := json.NewDecoder(resp.Body).Decode(&preview); err != nil {
+		return nil, err
+	}
+	return &preview, nil
+}
@@ -9,6 +9,7 @@ import (
 	"path"
 
 	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
 	pctx "github.com/influxdata/influxdb/context"
 	"github.com/julienschmidt/httprouter"
 	"go.uber.org/zap"
@@ -25,6 +26,9 @@ type ScraperBackend struct {
 	UserService                influxdb.UserService
 	UserResourceMappingService influxdb.UserResourceMappingService
 	LabelService               influxdb.LabelService
+	SecretService              influxdb.SecretService
+	ScraperPreviewService      influxdb.ScraperPreviewService
+	ScraperStatusService       influxdb.ScraperStatusService
 }
 
 // NewScraperBackend returns a new instance of ScraperBackend.
@@ -38,6 +42,9 @@ func NewScraperBackend(b *APIBackend) *ScraperBackend {
 		UserService:                b.UserService,
 		UserResourceMappingService: b.UserResourceMappingService,
 		LabelService:               b.LabelService,
+		SecretService:              b.SecretService,
+		ScraperPreviewService:      b.ScraperPreviewService,
+		ScraperStatusService:       b.ScraperStatusService,
 	}
 }
 
@@ -51,6 +58,9 @@ type ScraperHandler struct {
 	ScraperStorageService      influxdb.ScraperTargetStoreService
 	BucketService              influxdb.BucketService
 	OrganizationService        influxdb.OrganizationService
+	SecretService              influxdb.SecretService
+	ScraperPreviewService      influxdb.ScraperPreviewService
+	ScraperStatusService       influxdb.ScraperStatusService
 }
 
 const (
@@ -61,6 +71,8 @@ const (
 	targetsIDOwnersIDPath  = targetsPath + "/:id/owners/:userID"
 	targetsIDLabelsPath    = targetsPath + "/:id/labels"
 	targetsIDLabelsIDPath  = targetsPath + "/:id/labels/:lid"
+	targetsIDPreviewPath   = targetsPath + "/:id/preview"
+	targetsIDStatusPath    = targetsPath + "/:id/status"
 )
 
 // NewScraperHandler returns a new instance of ScraperHandler.
@@ -74,12 +86,17 @@ func NewScraperHandler(b *ScraperBackend) *ScraperHandler {
 		ScraperStorageService:      b.ScraperStorageService,
 		BucketService:              b.BucketService,
 		OrganizationService:        b.OrganizationService,
+		SecretService:              b.SecretService,
+		ScraperPreviewService:      b.ScraperPreviewService,
+		ScraperStatusService:       b.ScraperStatusService,
 	}
 	h.HandlerFunc("POST", targetsPath, h.handlePostScraperTarget)
 	h.HandlerFunc("GET", targetsPath, h.handleGetScraperTargets)
 	h.HandlerFunc("GET", targetsPath+"/:id", h.handleGetScraperTarget)
 	h.HandlerFunc("PATCH", targetsPath+"/:id", h.handlePatchScraperTarget)
 	h.HandlerFunc("DELETE", targetsPath+"/:id", h.handleDeleteScraperTarget)
+	h.HandlerFunc("POST", targetsIDPreviewPath, h.handlePostScraperTargetPreview)
+	h.HandlerFunc("GET", targetsIDStatusPath, h.handleGetScraperTargetStatus)
 
 	memberBackend := MemberBackend{
 		Logger:                     b.Logger.With(zap.String("handler", "member")),
@@ -130,6 +147,11 @@ func (h *ScraperHandler) handlePostScraperTarget(w http.ResponseWriter, r *http.
 		return
 	}
 
+	if err := h.validateSecrets(ctx, req.OrgID, req); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.ScraperStorageService.AddTarget(ctx, req, auth.GetUserID()); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -179,6 +201,22 @@ func (h *ScraperHandler) handlePatchScraperTarget(w http.ResponseWriter, r *http
 		return
 	}
 
+	if len(update.SecretKeys()) > 0 {
+		orgID := update.OrgID
+		if !orgID.Valid() {
+			target, err := h.ScraperStorageService.GetTargetByID(ctx, update.ID)
+			if err != nil {
+				EncodeError(ctx, err, w)
+				return
+			}
+			orgID = target.OrgID
+		}
+		if err := h.validateSecrets(ctx, orgID, update); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+	}
+
 	target, err := h.ScraperStorageService.UpdateTarget(ctx, update, auth.GetUserID())
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -245,6 +283,46 @@ func (h *ScraperHandler) handleGetScraperTargets(w http.ResponseWriter, r *http.
 	}
 }
 
+// validateSecrets returns an error if the caller may not reference the secrets target is scraped with,
+// or if the organization orgID lacks one of them.
+// Scraping sends the values of the secrets to the URL of the target, which the writers of the target choose,
+// so referencing secrets requires the permission to write the secrets of the organization.
+func (h *ScraperHandler) validateSecrets(ctx context.Context, orgID influxdb.ID, target *influxdb.ScraperTarget) error {
+	ks := target.SecretKeys()
+	if len(ks) == 0 {
+		return nil
+	}
+
+	p, err := influxdb.NewPermission(influxdb.WriteAction, influxdb.SecretsResourceType, orgID)
+	if err != nil {
+		return err
+	}
+	if err := authorizer.VerifyPermissions(ctx, []influxdb.Permission{*p}); err != nil {
+		return err
+	}
+	if h.SecretService == nil {
+		return nil
+	}
+
+	existing, err := h.SecretService.GetSecretKeys(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	found := make(map[string]bool, len(existing))
+	for _, k := range existing {
+		found[k] = true
+	}
+	for _, k := range ks {
+		if !found[k] {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("secret %q of scraper target not found in organization", k),
+			}
+		}
+	}
+	return nil
+}
+
 func decodeScraperTargetUpdateRequest(ctx context.Context, r *http.Request) (*influxdb.ScraperTarget, error) {
 	update := &influxdb.ScraperTarget{}
 	if err := json.NewDecoder(r.Body).Decode(update); err != nil {
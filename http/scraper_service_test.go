@@ -10,8 +10,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
 	platcontext "github.com/influxdata/influxdb/context"
 	httpMock "github.com/influxdata/influxdb/http/mock"
 	"github.com/influxdata/influxdb/inmem"
@@ -482,10 +484,12 @@ func TestService_handlePostScraperTarget(t *testing.T) {
 		OrganizationService       platform.OrganizationService
 		BucketService             platform.BucketService
 		ScraperTargetStoreService platform.ScraperTargetStoreService
+		SecretService             platform.SecretService
 	}
 
 	type args struct {
-		target *platform.ScraperTarget
+		target      *platform.ScraperTarget
+		permissions []platform.Permission
 	}
 
 	type wants struct {
@@ -562,6 +566,150 @@ func TestService_handlePostScraperTarget(t *testing.T) {
 				),
 			},
 		},
+		{
+			name: "create a new scraper target with scrape options",
+			fields: fields{
+				OrganizationService: &mock.OrganizationService{
+					FindOrganizationByIDF: func(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+						return &platform.Organization{
+							ID:   platformtesting.MustIDBase16("0000000000000211"),
+							Name: "org1",
+						}, nil
+					},
+				},
+				BucketService: &mock.BucketService{
+					FindBucketByIDFn: func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+						return &platform.Bucket{
+							ID:   platformtesting.MustIDBase16("0000000000000212"),
+							Name: "bucket1",
+						}, nil
+					},
+				},
+				ScraperTargetStoreService: &mock.ScraperTargetStoreService{
+					AddTargetF: func(ctx context.Context, st *platform.ScraperTarget, userID platform.ID) error {
+						st.ID = targetOneID
+						return nil
+					},
+				},
+				SecretService: &mock.SecretService{
+					GetSecretKeysFn: func(ctx context.Context, orgID platform.ID) ([]string, error) {
+						return []string{"exporter-token"}, nil
+					},
+				},
+			},
+			args: args{
+				target: &platform.ScraperTarget{
+					Name:              "hello",
+					Type:              platform.PrometheusScraperType,
+					BucketID:          platformtesting.MustIDBase16("0000000000000212"),
+					OrgID:             platformtesting.MustIDBase16("0000000000000211"),
+					URL:               "www.some.url",
+					Interval:          &platform.Duration{Duration: time.Minute},
+					BearerTokenSecret: "exporter-token",
+				},
+				permissions: []platform.Permission{secretsPermission(platform.WriteAction, "0000000000000211")},
+			},
+			wants: wants{
+				statusCode:  http.StatusCreated,
+				contentType: "application/json; charset=utf-8",
+				body: fmt.Sprintf(
+					`
+                    {
+                      "id": "%s",
+                      "name": "hello",
+                      "type": "prometheus",
+                      "url": "www.some.url",
+                      "orgID": "0000000000000211",
+                      "organization": "org1",
+                      "bucket": "bucket1",
+                      "bucketID": "0000000000000212",
+                      "interval": "1m0s",
+                      "bearerTokenSecret": "exporter-token",
+                      "links": {
+                        "bucket": "/api/v2/buckets/0000000000000212",
+                        "organization": "/api/v2/orgs/0000000000000211",
+                        "self": "/api/v2/scrapers/%s",
+                        "members": "/api/v2/scrapers/%s/members",
+                        "owners": "/api/v2/scrapers/%s/owners"
+                      }
+                    }
+                    `,
+					targetOneIDString, targetOneIDString, targetOneIDString, targetOneIDString,
+				),
+			},
+		},
+		{
+			name: "create a new scraper target with a missing secret",
+			fields: fields{
+				SecretService: &mock.SecretService{
+					GetSecretKeysFn: func(ctx context.Context, orgID platform.ID) ([]string, error) {
+						return []string{"exporter-token"}, nil
+					},
+				},
+			},
+			args: args{
+				target: &platform.ScraperTarget{
+					Name:              "hello",
+					Type:              platform.PrometheusScraperType,
+					BucketID:          platformtesting.MustIDBase16("0000000000000212"),
+					OrgID:             platformtesting.MustIDBase16("0000000000000211"),
+					URL:               "www.some.url",
+					BearerTokenSecret: "other-token",
+				},
+				permissions: []platform.Permission{secretsPermission(platform.WriteAction, "0000000000000211")},
+			},
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "create a new scraper target with a secret the caller may not read",
+			fields: fields{
+				SecretService: authorizer.NewSecretService(&mock.SecretService{
+					GetSecretKeysFn: func(ctx context.Context, orgID platform.ID) ([]string, error) {
+						return []string{"exporter-token"}, nil
+					},
+				}),
+			},
+			args: args{
+				target: &platform.ScraperTarget{
+					Name:              "hello",
+					Type:              platform.PrometheusScraperType,
+					BucketID:          platformtesting.MustIDBase16("0000000000000212"),
+					OrgID:             platformtesting.MustIDBase16("0000000000000211"),
+					URL:               "www.some.url",
+					BearerTokenSecret: "exporter-token",
+				},
+				permissions: []platform.Permission{secretsPermission(platform.WriteAction, "0000000000000211")},
+			},
+			wants: wants{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "create a new scraper target with a secret the caller may only read",
+			fields: fields{
+				SecretService: &mock.SecretService{
+					GetSecretKeysFn: func(ctx context.Context, orgID platform.ID) ([]string, error) {
+						return []string{"exporter-token"}, nil
+					},
+				},
+			},
+			args: args{
+				target: &platform.ScraperTarget{
+					Name:              "hello",
+					Type:              platform.PrometheusScraperType,
+					BucketID:          platformtesting.MustIDBase16("0000000000000212"),
+					OrgID:             platformtesting.MustIDBase16("0000000000000211"),
+					URL:               "www.some.url",
+					BearerTokenSecret: "exporter-token",
+				},
+				permissions: []platform.Permission{secretsPermission(platform.ReadAction, "0000000000000211")},
+			},
+			wants: wants{
+				statusCode: http.StatusForbidden,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -570,6 +718,7 @@ func TestService_handlePostScraperTarget(t *testing.T) {
 			scraperBackend.ScraperStorageService = tt.fields.ScraperTargetStoreService
 			scraperBackend.OrganizationService = tt.fields.OrganizationService
 			scraperBackend.BucketService = tt.fields.BucketService
+			scraperBackend.SecretService = tt.fields.SecretService
 			h := NewScraperHandler(scraperBackend)
 
 			st, err := json.Marshal(tt.args.target)
@@ -578,7 +727,7 @@ func TestService_handlePostScraperTarget(t *testing.T) {
 			}
 
 			r := httptest.NewRequest("GET", "http://any.tld", bytes.NewReader(st))
-			r = r.WithContext(platcontext.SetAuthorizer(r.Context(), &platform.Authorization{}))
+			r = r.WithContext(platcontext.SetAuthorizer(r.Context(), &platform.Authorization{Status: platform.Active, Permissions: tt.args.permissions}))
 			w := httptest.NewRecorder()
 
 			h.handlePostScraperTarget(w, r)
@@ -600,6 +749,15 @@ func TestService_handlePostScraperTarget(t *testing.T) {
 	}
 }
 
+// secretsPermission returns the permission to act on the secrets of the organization orgID.
+func secretsPermission(a platform.Action, orgID string) platform.Permission {
+	p, err := platform.NewPermission(a, platform.SecretsResourceType, platformtesting.MustIDBase16(orgID))
+	if err != nil {
+		panic(err)
+	}
+	return *p
+}
+
 func TestService_handlePatchScraperTarget(t *testing.T) {
 	type fields struct {
 		BucketService             platform.BucketService
@@ -829,6 +987,9 @@ func initScraperService(f platformtesting.TargetFields, t *testing.T) (platform.
 		&platform.Authorization{
 			UserID: platformtesting.MustIDBase16("020f755c3c082002"),
 			Token:  "tok",
+			Status: platform.Active,
+			// Targets scraped with secrets require the permission to write them.
+			Permissions: platform.OperPermissions(),
 		},
 	))
 	client := struct {
@@ -850,3 +1011,217 @@ func initScraperService(f platformtesting.TargetFields, t *testing.T) (platform.
 func TestScraperService(t *testing.T) {
 	platformtesting.ScraperService(initScraperService, t)
 }
+
+func TestService_handlePostScraperTargetPreview(t *testing.T) {
+	target := &platform.ScraperTarget{
+		ID:    targetOneID,
+		Name:  "target1",
+		Type:  platform.PrometheusScraperType,
+		URL:   "www.some.url",
+		OrgID: platformtesting.MustIDBase16("0000000000000211"),
+		Tags:  map[string]string{"env": "prod"},
+	}
+	scraperPermission := func(a platform.Action) platform.Permission {
+		p, err := platform.NewPermissionAtID(targetOneID, a, platform.ScraperResourceType, target.OrgID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return *p
+	}
+
+	type wants struct {
+		statusCode int
+		body       string
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		secret      string
+		permissions []platform.Permission
+		wants       wants
+	}{
+		{
+			name:        "preview the rules of the target",
+			permissions: []platform.Permission{scraperPermission(platform.WriteAction)},
+			wants: wants{
+				statusCode: http.StatusOK,
+				body:       `{"scraped": 2, "kept": 1, "points": "env=prod"}`,
+			},
+		},
+		{
+			name:        "preview other rules",
+			body:        `{"relabelRules": [{"action": "drop", "regex": "go_.*"}]}`,
+			permissions: []platform.Permission{scraperPermission(platform.WriteAction)},
+			wants: wants{
+				statusCode: http.StatusOK,
+				body:       `{"scraped": 2, "kept": 1, "points": "env=prod,rules=1"}`,
+			},
+		},
+		{
+			name:        "invalid rules",
+			body:        `{"relabelRules": [{"action": "keep", "regex": "("}]}`,
+			permissions: []platform.Permission{scraperPermission(platform.WriteAction)},
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:        "preview a target the caller may only read",
+			permissions: []platform.Permission{scraperPermission(platform.ReadAction)},
+			wants: wants{
+				statusCode: http.StatusForbidden,
+			},
+		},
+		{
+			name:        "preview a target with a secret the caller may not write",
+			secret:      "exporter-token",
+			permissions: []platform.Permission{scraperPermission(platform.WriteAction), secretsPermission(platform.ReadAction, "0000000000000211")},
+			wants: wants{
+				statusCode: http.StatusForbidden,
+			},
+		},
+		{
+			name:        "preview a target with a secret",
+			secret:      "exporter-token",
+			permissions: []platform.Permission{scraperPermission(platform.WriteAction), secretsPermission(platform.WriteAction, "0000000000000211")},
+			wants: wants{
+				statusCode: http.StatusOK,
+				body:       `{"scraped": 2, "kept": 1, "points": "env=prod"}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scraperBackend := NewMockScraperBackend()
+			scraperBackend.ScraperStorageService = &mock.ScraperTargetStoreService{
+				GetTargetByIDF: func(ctx context.Context, id platform.ID) (*platform.ScraperTarget, error) {
+					if id != targetOneID {
+						return nil, &platform.Error{Code: platform.ENotFound, Msg: platform.ErrScraperTargetNotFound}
+					}
+					t := *target
+					t.BearerTokenSecret = tt.secret
+					return &t, nil
+				},
+			}
+			scraperBackend.ScraperPreviewService = &mock.ScraperPreviewService{
+				PreviewTargetFn: func(ctx context.Context, target platform.ScraperTarget) (*platform.ScraperPreview, error) {
+					points := fmt.Sprintf("env=%s", target.Tags["env"])
+					if len(target.RelabelRules) > 0 {
+						points += fmt.Sprintf(",rules=%d", len(target.RelabelRules))
+					}
+					return &platform.ScraperPreview{Scraped: 2, Kept: 1, Points: points}, nil
+				},
+			}
+			h := NewScraperHandler(scraperBackend)
+
+			r := httptest.NewRequest("POST", "http://any.tld/api/v2/scrapers/"+targetOneIDString+"/preview", bytes.NewBufferString(tt.body))
+			r = r.WithContext(platcontext.SetAuthorizer(r.Context(), &platform.Authorization{Status: platform.Active, Permissions: tt.permissions}))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.wants.statusCode {
+				t.Fatalf("handlePostScraperTargetPreview() = %v, want %v: %s", res.StatusCode, tt.wants.statusCode, body)
+			}
+			if eq, diff, _ := jsonEqual(string(body), tt.wants.body); tt.wants.body != "" && !eq {
+				t.Errorf("handlePostScraperTargetPreview() = ***%s***", diff)
+			}
+		})
+	}
+}
+
+func TestService_handleGetScraperTargetStatus(t *testing.T) {
+	scrapedAt := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := platform.ScrapeResult{
+		Time:       scrapedAt,
+		Duration:   platform.Duration{Duration: 150 * time.Millisecond},
+		StatusCode: http.StatusNotFound,
+		Error:      "scraping http://localhost:9100/metric returned status 404 Not Found",
+	}
+
+	type wants struct {
+		statusCode int
+		body       string
+	}
+
+	tests := []struct {
+		name  string
+		id    string
+		wants wants
+	}{
+		{
+			name: "get the status of a target",
+			id:   targetOneIDString,
+			wants: wants{
+				statusCode: http.StatusOK,
+				body: fmt.Sprintf(`{
+  "targetID": "%[1]s",
+  "up": false,
+  "lastScrape": {
+    "time": "2019-01-01T00:00:00Z",
+    "duration": "150ms",
+    "samples": 0,
+    "statusCode": 404,
+    "error": "scraping http://localhost:9100/metric returned status 404 Not Found"
+  },
+  "history": [
+    {
+      "time": "2019-01-01T00:00:00Z",
+      "duration": "150ms",
+      "samples": 0,
+      "statusCode": 404,
+      "error": "scraping http://localhost:9100/metric returned status 404 Not Found"
+    }
+  ]
+}`, targetOneIDString),
+			},
+		},
+		{
+			name: "target not found",
+			id:   targetTwoIDString,
+			wants: wants{
+				statusCode: http.StatusNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scraperBackend := NewMockScraperBackend()
+			scraperBackend.ScraperStorageService = &mock.ScraperTargetStoreService{
+				GetTargetByIDF: func(ctx context.Context, id platform.ID) (*platform.ScraperTarget, error) {
+					if id != targetOneID {
+						return nil, &platform.Error{Code: platform.ENotFound, Msg: platform.ErrScraperTargetNotFound}
+					}
+					return &platform.ScraperTarget{ID: targetOneID}, nil
+				},
+			}
+			scraperBackend.ScraperStatusService = &mock.ScraperStatusService{
+				FindTargetStatusFn: func(ctx context.Context, id platform.ID) (*platform.ScraperTargetStatus, error) {
+					return &platform.ScraperTargetStatus{
+						TargetID:   id,
+						LastScrape: &result,
+						History:    []platform.ScrapeResult{result},
+					}, nil
+				},
+			}
+			h := NewScraperHandler(scraperBackend)
+
+			r := httptest.NewRequest("GET", "http://any.tld/api/v2/scrapers/"+tt.id+"/status", nil)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.wants.statusCode {
+				t.Fatalf("handleGetScraperTargetStatus() = %v, want %v: %s", res.StatusCode, tt.wants.statusCode, body)
+			}
+			if eq, diff, _ := jsonEqual(string(body), tt.wants.body); tt.wants.body != "" && !eq {
+				t.Errorf("handleGetScraperTargetStatus() = ***%s***", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+)
+
+// handleGetScraperTargetStatus is the HTTP handler for the GET /api/v2/scrapers/:id/status route.
+func (h *ScraperHandler) handleGetScraperTargetStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := decodeScraperTargetIDRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	// Only return the status of the targets the authorizer may read.
+	if _, err := h.ScraperStorageService.GetTargetByID(ctx, *id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	status, err := h.ScraperStatusService.FindTargetStatus(ctx, *id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, status); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// FindTargetStatus returns the health of the scraper target id.
+func (s *ScraperService) FindTargetStatus(ctx context.Context, id influxdb.ID) (*influxdb.ScraperTargetStatus, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	url, err := newURL(s.Addr, path.Join(targetIDPath(id), "status"))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(url.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var status influxdb.ScraperTargetStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
@@ -0,0 +1,433 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/julienschmidt/httprouter"
+)
+
+type backfillResponse struct {
+	Links map[string]string `json:"links,omitempty"`
+	platform.Backfill
+}
+
+func newBackfillResponse(b platform.Backfill) backfillResponse {
+	return backfillResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/backfills/%s", b.TaskID, b.ID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", b.TaskID),
+			"runs": fmt.Sprintf("/api/v2/tasks/%s/runs", b.TaskID),
+		},
+		Backfill: b,
+	}
+}
+
+type backfillsResponse struct {
+	Links     map[string]string   `json:"links"`
+	Backfills []*backfillResponse `json:"backfills"`
+}
+
+func newBackfillsResponse(bs []*platform.Backfill, taskID platform.ID) backfillsResponse {
+	r := backfillsResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/backfills", taskID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", taskID),
+		},
+		Backfills: make([]*backfillResponse, len(bs)),
+	}
+
+	for i := range bs {
+		b := newBackfillResponse(*bs[i])
+		r.Backfills[i] = &b
+	}
+	return r
+}
+
+func (h *TaskHandler) handlePostBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePostBackfillRequest(ctx, r)
+	if err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	auth, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EUnauthorized,
+			Msg:  "failed to get authorizer",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if k := auth.Kind(); k != platform.AuthorizationKind {
+		// The runs are queued long after this request, so queue them as the task's authorization
+		// rather than as a session that may expire.
+		authz, err := h.getAuthorizationForTask(ctx, req.TaskID)
+		if err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+		ctx = pcontext.SetAuthorizer(ctx, authz)
+	}
+
+	b, err := h.BackfillService.CreateBackfill(ctx, *req)
+	if err != nil {
+		err = &platform.Error{
+			Err: err,
+			Msg: "failed to create backfill",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newBackfillResponse(*b)); err != nil {
+		logEncodingError(h.logger, r, err)
+		return
+	}
+}
+
+func decodePostBackfillRequest(ctx context.Context, r *http.Request) (*platform.BackfillCreate, error) {
+	taskID, err := decodeBackfillTaskID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var req struct {
+		Start       string `json:"start"`
+		Stop        string `json:"stop"`
+		Concurrency int    `json:"concurrency"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, err
+	}
+
+	start, err := time.Parse(time.RFC3339, req.Start)
+	if err != nil {
+		return nil, err
+	}
+	stop, err := time.Parse(time.RFC3339, req.Stop)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &platform.BackfillCreate{
+		TaskID:      taskID,
+		Start:       start,
+		Stop:        stop,
+		Concurrency: req.Concurrency,
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (h *TaskHandler) handleGetBackfills(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, err := decodeBackfillTaskID(ctx)
+	if err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	bs, err := h.BackfillService.FindBackfills(ctx, taskID)
+	if err != nil {
+		err = &platform.Error{
+			Err: err,
+			Msg: "failed to find backfills",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newBackfillsResponse(bs, taskID)); err != nil {
+		logEncodingError(h.logger, r, err)
+		return
+	}
+}
+
+func (h *TaskHandler) handleGetBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, id, err := decodeBackfillIDs(ctx)
+	if err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	b, err := h.BackfillService.FindBackfillByID(ctx, taskID, id)
+	if err != nil {
+		err = &platform.Error{
+			Err: err,
+			Msg: "failed to find backfill",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newBackfillResponse(*b)); err != nil {
+		logEncodingError(h.logger, r, err)
+		return
+	}
+}
+
+func (h *TaskHandler) handleCancelBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, id, err := decodeBackfillIDs(ctx)
+	if err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.BackfillService.CancelBackfill(ctx, taskID, id); err != nil {
+		err = &platform.Error{
+			Err: err,
+			Msg: "failed to cancel backfill",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeBackfillTaskID(ctx context.Context) (platform.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("id")
+	if tid == "" {
+		return 0, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "you must provide a task ID",
+		}
+	}
+
+	var taskID platform.ID
+	if err := taskID.DecodeFromString(tid); err != nil {
+		return 0, err
+	}
+	return taskID, nil
+}
+
+func decodeBackfillIDs(ctx context.Context) (platform.ID, platform.ID, error) {
+	taskID, err := decodeBackfillTaskID(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	params := httprouter.ParamsFromContext(ctx)
+	bid := params.ByName("bid")
+	if bid == "" {
+		return 0, 0, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "you must provide a backfill ID",
+		}
+	}
+
+	var id platform.ID
+	if err := id.DecodeFromString(bid); err != nil {
+		return 0, 0, err
+	}
+	return taskID, id, nil
+}
+
+// CreateBackfill starts a backfill of a task.
+func (t TaskService) CreateBackfill(ctx context.Context, c platform.BackfillCreate) (*platform.Backfill, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := newURL(t.Addr, taskIDBackfillsPath(c.TaskID))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(struct {
+		Start       string `json:"start"`
+		Stop        string `json:"stop"`
+		Concurrency int    `json:"concurrency,omitempty"`
+	}{
+		Start:       c.Start.UTC().Format(time.RFC3339),
+		Stop:        c.Stop.UTC().Format(time.RFC3339),
+		Concurrency: c.Concurrency,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(t.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var br backfillResponse
+	if err := json.NewDecoder(resp.Body).Decode(&br); err != nil {
+		return nil, err
+	}
+	return &br.Backfill, nil
+}
+
+// FindBackfillByID returns a single backfill of a task.
+func (t TaskService) FindBackfillByID(ctx context.Context, taskID, id platform.ID) (*platform.Backfill, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := newURL(t.Addr, taskIDBackfillIDPath(taskID, id))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(t.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var br backfillResponse
+	if err := json.NewDecoder(resp.Body).Decode(&br); err != nil {
+		return nil, err
+	}
+	return &br.Backfill, nil
+}
+
+// FindBackfills returns the backfills of a task.
+func (t TaskService) FindBackfills(ctx context.Context, taskID platform.ID) ([]*platform.Backfill, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := newURL(t.Addr, taskIDBackfillsPath(taskID))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(t.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var bsr backfillsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&bsr); err != nil {
+		return nil, err
+	}
+
+	bs := make([]*platform.Backfill, len(bsr.Backfills))
+	for i := range bsr.Backfills {
+		bs[i] = &bsr.Backfills[i].Backfill
+	}
+	return bs, nil
+}
+
+// CancelBackfill cancels a running backfill of a task.
+func (t TaskService) CancelBackfill(ctx context.Context, taskID, id platform.ID) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := newURL(t.Addr, taskIDBackfillIDPath(taskID, id))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	SetToken(t.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckErrorStatus(http.StatusNoContent, resp)
+}
+
+func taskIDBackfillsPath(taskID platform.ID) string {
+	return path.Join(tasksPath, taskID.String(), "backfills")
+}
+
+func taskIDBackfillIDPath(taskID, id platform.ID) string {
+	return path.Join(tasksPath, taskID.String(), "backfills", id.String())
+}
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/julienschmidt/httprouter"
+)
+
+func TestTaskHandler_handlePostBackfill(t *testing.T) {
+	type wants struct {
+		statusCode int
+		body       string
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		created *platform.BackfillCreate
+		wants   wants
+	}{
+		{
+			name: "create a backfill",
+			body: `{"start": "2019-01-01T00:00:00Z", "stop": "2019-04-01T00:00:00Z", "concurrency": 4}`,
+			created: &platform.BackfillCreate{
+				TaskID:      1,
+				Start:       time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+				Stop:        time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC),
+				Concurrency: 4,
+			},
+			wants: wants{
+				statusCode: http.StatusCreated,
+				body: `
+{
+  "links": {
+    "self": "/api/v2/tasks/0000000000000001/backfills/0000000000000002",
+    "task": "/api/v2/tasks/0000000000000001",
+    "runs": "/api/v2/tasks/0000000000000001/runs"
+  },
+  "id": "0000000000000002",
+  "taskID": "0000000000000001",
+  "start": "2019-01-01T00:00:00Z",
+  "stop": "2019-04-01T00:00:00Z",
+  "concurrency": 4,
+  "status": "running",
+  "requestedAt": "2019-05-01T00:00:00Z",
+  "completed": 0,
+  "failed": 0,
+  "pending": 2160
+}`,
+			},
+		},
+		{
+			name: "stop before start",
+			body: `{"start": "2019-04-01T00:00:00Z", "stop": "2019-01-01T00:00:00Z"}`,
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "missing stop",
+			body: `{"start": "2019-04-01T00:00:00Z"}`,
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *platform.BackfillCreate
+			bs := &mock.BackfillService{
+				CreateBackfillFn: func(_ context.Context, c platform.BackfillCreate) (*platform.Backfill, error) {
+					created = &c
+					return &platform.Backfill{
+						ID:          2,
+						TaskID:      c.TaskID,
+						Start:       c.Start.Format(time.RFC3339),
+						Stop:        c.Stop.Format(time.RFC3339),
+						Concurrency: c.Concurrency,
+						Status:      platform.BackfillStatusRunning,
+						RequestedAt: "2019-05-01T00:00:00Z",
+						Pending:     2160,
+					}, nil
+				},
+			}
+
+			r := httptest.NewRequest("POST", "http://any.url", strings.NewReader(tt.body))
+			r = r.WithContext(context.WithValue(
+				context.Background(),
+				httprouter.ParamsKey,
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: platform.ID(1).String(),
+					},
+				}))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{Permissions: platform.OperPermissions()}))
+			w := httptest.NewRecorder()
+			taskBackend := NewMockTaskBackend(t)
+			taskBackend.BackfillService = bs
+			h := NewTaskHandler(taskBackend)
+			h.handlePostBackfill(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+
+			if res.StatusCode != tt.wants.statusCode {
+				t.Errorf("%q. handlePostBackfill() = %v, want %v: %s", tt.name, res.StatusCode, tt.wants.statusCode, body)
+			}
+			if eq, diff, _ := jsonEqual(string(body), tt.wants.body); tt.wants.body != "" && !eq {
+				t.Errorf("%q. handlePostBackfill() = ***%s***", tt.name, diff)
+			}
+			if tt.created != nil && (created == nil || *created != *tt.created) {
+				t.Errorf("%q. created backfill %+v, want %+v", tt.name, created, tt.created)
+			}
+		})
+	}
+}
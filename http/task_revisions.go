@@ -0,0 +1,387 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/task/backend"
+	"github.com/julienschmidt/httprouter"
+)
+
+type taskRevisionResponse struct {
+	Links map[string]string `json:"links,omitempty"`
+	platform.TaskRevision
+}
+
+func newTaskRevisionResponse(r platform.TaskRevision) taskRevisionResponse {
+	return taskRevisionResponse{
+		Links: map[string]string{
+			"self":     fmt.Sprintf("/api/v2/tasks/%s/revisions/%d", r.TaskID, r.Revision),
+			"task":     fmt.Sprintf("/api/v2/tasks/%s", r.TaskID),
+			"diff":     fmt.Sprintf("/api/v2/tasks/%s/revisions/%d/diff", r.TaskID, r.Revision),
+			"rollback": fmt.Sprintf("/api/v2/tasks/%s/revisions/%d/rollback", r.TaskID, r.Revision),
+		},
+		TaskRevision: r,
+	}
+}
+
+type taskRevisionsResponse struct {
+	Links     map[string]string       `json:"links"`
+	Revisions []*taskRevisionResponse `json:"revisions"`
+}
+
+func newTaskRevisionsResponse(rs []*platform.TaskRevision, taskID platform.ID) taskRevisionsResponse {
+	r := taskRevisionsResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/revisions", taskID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", taskID),
+		},
+		Revisions: make([]*taskRevisionResponse, len(rs)),
+	}
+
+	for i := range rs {
+		rev := newTaskRevisionResponse(*rs[i])
+		r.Revisions[i] = &rev
+	}
+	return r
+}
+
+func (h *TaskHandler) handleGetTaskRevisions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, err := decodeBackfillTaskID(ctx)
+	if err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	revs, err := h.TaskService.FindTaskRevisions(ctx, taskID)
+	if err != nil {
+		err := &platform.Error{
+			Err: err,
+			Msg: "failed to find task revisions",
+		}
+		if err.Err == backend.ErrTaskNotFound {
+			err.Code = platform.ENotFound
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newTaskRevisionsResponse(revs, taskID)); err != nil {
+		logEncodingError(h.logger, r, err)
+		return
+	}
+}
+
+func (h *TaskHandler) handleGetTaskRevision(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, revision, err := decodeTaskRevisionRequest(ctx)
+	if err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	rev, err := h.TaskService.FindTaskRevision(ctx, taskID, revision)
+	if err != nil {
+		err := &platform.Error{
+			Err: err,
+			Msg: "failed to find task revision",
+		}
+		if err.Err == backend.ErrTaskNotFound || err.Err == backend.ErrTaskRevisionNotFound {
+			err.Code = platform.ENotFound
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newTaskRevisionResponse(*rev)); err != nil {
+		logEncodingError(h.logger, r, err)
+		return
+	}
+}
+
+// handleGetTaskRevisionDiff diffs a revision against the revision given by the against query parameter,
+// or against the previous revision if against is not set.
+func (h *TaskHandler) handleGetTaskRevisionDiff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, revision, err := decodeTaskRevisionRequest(ctx)
+	if err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	against := revision - 1
+	if a := r.URL.Query().Get("against"); a != "" {
+		against, err = strconv.Atoi(a)
+		if err != nil {
+			err = &platform.Error{
+				Err:  err,
+				Code: platform.EInvalid,
+				Msg:  "failed to decode request",
+			}
+			EncodeError(ctx, err, w)
+			return
+		}
+	}
+
+	to, err := h.TaskService.FindTaskRevision(ctx, taskID, revision)
+	if err != nil {
+		err := &platform.Error{
+			Err: err,
+			Msg: "failed to find task revision",
+		}
+		if err.Err == backend.ErrTaskNotFound || err.Err == backend.ErrTaskRevisionNotFound {
+			err.Code = platform.ENotFound
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	// The first revision is diffed against an empty task.
+	from := &platform.TaskRevision{TaskID: taskID}
+	if against > 0 {
+		from, err = h.TaskService.FindTaskRevision(ctx, taskID, against)
+		if err != nil {
+			err := &platform.Error{
+				Err: err,
+				Msg: "failed to find task revision",
+			}
+			if err.Err == backend.ErrTaskRevisionNotFound {
+				err.Code = platform.ENotFound
+			}
+			EncodeError(ctx, err, w)
+			return
+		}
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, platform.DiffTaskRevisions(from, to)); err != nil {
+		logEncodingError(h.logger, r, err)
+		return
+	}
+}
+
+func (h *TaskHandler) handleRollbackTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, revision, err := decodeTaskRevisionRequest(ctx)
+	if err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	task, err := h.TaskService.RollbackTask(ctx, taskID, revision)
+	if err != nil {
+		err := &platform.Error{
+			Err: err,
+			Msg: "failed to roll back task",
+		}
+		if err.Err == backend.ErrTaskNotFound || err.Err == backend.ErrTaskRevisionNotFound {
+			err.Code = platform.ENotFound
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	labels, err := h.LabelService.FindResourceLabels(ctx, platform.LabelMappingFilter{ResourceID: task.ID})
+	if err != nil {
+		err = &platform.Error{
+			Err: err,
+			Msg: "failed to find resource labels",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newTaskResponse(*task, labels)); err != nil {
+		logEncodingError(h.logger, r, err)
+		return
+	}
+}
+
+func decodeTaskRevisionRequest(ctx context.Context) (platform.ID, int, error) {
+	taskID, err := decodeBackfillTaskID(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	params := httprouter.ParamsFromContext(ctx)
+	rev := params.ByName("rev")
+	if rev == "" {
+		return 0, 0, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "you must provide a revision",
+		}
+	}
+
+	revision, err := strconv.Atoi(rev)
+	if err != nil {
+		return 0, 0, err
+	}
+	return taskID, revision, nil
+}
+
+// taskRevisionError returns the backend error for a not found error returned by a revision endpoint,
+// as the backend errors are part of the TaskService contract.
+func taskRevisionError(err error) error {
+	if platform.ErrorCode(err) != platform.ENotFound {
+		return err
+	}
+	if strings.Contains(err.Error(), backend.ErrTaskRevisionNotFound.Error()) {
+		return backend.ErrTaskRevisionNotFound
+	}
+	return backend.ErrTaskNotFound
+}
+
+// FindTaskRevisions returns every revision of a task, oldest first.
+func (t TaskService) FindTaskRevisions(ctx context.Context, taskID platform.ID) ([]*platform.TaskRevision, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := newURL(t.Addr, taskIDRevisionsPath(taskID))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(t.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, taskRevisionError(err)
+	}
+
+	var rsr taskRevisionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rsr); err != nil {
+		return nil, err
+	}
+
+	revs := make([]*platform.TaskRevision, len(rsr.Revisions))
+	for i := range rsr.Revisions {
+		revs[i] = &rsr.Revisions[i].TaskRevision
+	}
+	return revs, nil
+}
+
+// FindTaskRevision returns a single revision of a task.
+func (t TaskService) FindTaskRevision(ctx context.Context, taskID platform.ID, revision int) (*platform.TaskRevision, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := newURL(t.Addr, taskIDRevisionPath(taskID, revision))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(t.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, taskRevisionError(err)
+	}
+
+	var rr taskRevisionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rr); err != nil {
+		return nil, err
+	}
+	return &rr.TaskRevision, nil
+}
+
+// RollbackTask sets the script of a task back to that of a revision.
+func (t TaskService) RollbackTask(ctx context.Context, taskID platform.ID, revision int) (*platform.Task, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := newURL(t.Addr, path.Join(taskIDRevisionPath(taskID, revision), "rollback"))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(t.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, taskRevisionError(err)
+	}
+
+	var tr taskResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return nil, err
+	}
+	return &tr.Task, nil
+}
+
+func taskIDRevisionsPath(taskID platform.ID) string {
+	return path.Join(tasksPath, taskID.String(), "revisions")
+}
+
+func taskIDRevisionPath(taskID platform.ID, revision int) string {
+	return path.Join(tasksPath, taskID.String(), "revisions", strconv.Itoa(revision))
+}
@@ -38,6 +38,8 @@ type TaskBackend struct {
 	LabelService               platform.LabelService
 	UserService                platform.UserService
 	BucketService              platform.BucketService
+	BackfillService            platform.BackfillService
+	TaskTestService            platform.TaskTestService
 }
 
 // NewTaskBackend returns a new instance of TaskBackend.
@@ -51,6 +53,8 @@ func NewTaskBackend(b *APIBackend) *TaskBackend {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		BucketService:              b.BucketService,
+		BackfillService:            b.BackfillService,
+		TaskTestService:            b.TaskTestService,
 	}
 }
 
@@ -66,10 +70,13 @@ type TaskHandler struct {
 	LabelService               platform.LabelService
 	UserService                platform.UserService
 	BucketService              platform.BucketService
+	BackfillService            platform.BackfillService
+	TaskTestService            platform.TaskTestService
 }
 
 const (
 	tasksPath              = "/api/v2/tasks"
+	tasksTestPath          = "/api/v2/tasks/test"
 	tasksIDPath            = "/api/v2/tasks/:id"
 	tasksIDLogsPath        = "/api/v2/tasks/:id/logs"
 	tasksIDMembersPath     = "/api/v2/tasks/:id/members"
@@ -82,6 +89,13 @@ const (
 	tasksIDRunsIDRetryPath = "/api/v2/tasks/:id/runs/:rid/retry"
 	tasksIDLabelsPath      = "/api/v2/tasks/:id/labels"
 	tasksIDLabelsIDPath    = "/api/v2/tasks/:id/labels/:lid"
+	tasksIDBackfillsPath   = "/api/v2/tasks/:id/backfills"
+	tasksIDBackfillsIDPath = "/api/v2/tasks/:id/backfills/:bid"
+
+	tasksIDRevisionsPath           = "/api/v2/tasks/:id/revisions"
+	tasksIDRevisionsIDPath         = "/api/v2/tasks/:id/revisions/:rev"
+	tasksIDRevisionsIDDiffPath     = "/api/v2/tasks/:id/revisions/:rev/diff"
+	tasksIDRevisionsIDRollbackPath = "/api/v2/tasks/:id/revisions/:rev/rollback"
 )
 
 // NewTaskHandler returns a new instance of TaskHandler.
@@ -97,6 +111,8 @@ func NewTaskHandler(b *TaskBackend) *TaskHandler {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		BucketService:              b.BucketService,
+		BackfillService:            b.BackfillService,
+		TaskTestService:            b.TaskTestService,
 	}
 
 	h.HandlerFunc("GET", tasksPath, h.handleGetTasks)
@@ -137,6 +153,16 @@ func NewTaskHandler(b *TaskBackend) *TaskHandler {
 	h.HandlerFunc("POST", tasksIDRunsIDRetryPath, h.handleRetryRun)
 	h.HandlerFunc("DELETE", tasksIDRunsIDPath, h.handleCancelRun)
 
+	h.HandlerFunc("GET", tasksIDBackfillsPath, h.handleGetBackfills)
+	h.HandlerFunc("POST", tasksIDBackfillsPath, h.handlePostBackfill)
+	h.HandlerFunc("GET", tasksIDBackfillsIDPath, h.handleGetBackfill)
+	h.HandlerFunc("DELETE", tasksIDBackfillsIDPath, h.handleCancelBackfill)
+
+	h.HandlerFunc("GET", tasksIDRevisionsPath, h.handleGetTaskRevisions)
+	h.HandlerFunc("GET", tasksIDRevisionsIDPath, h.handleGetTaskRevision)
+	h.HandlerFunc("GET", tasksIDRevisionsIDDiffPath, h.handleGetTaskRevisionDiff)
+	h.HandlerFunc("POST", tasksIDRevisionsIDRollbackPath, h.handleRollbackTask)
+
 	labelBackend := &LabelBackend{
 		Logger:       b.Logger.With(zap.String("handler", "label")),
 		LabelService: b.LabelService,
@@ -158,12 +184,13 @@ type taskResponse struct {
 func newTaskResponse(t platform.Task, labels []*platform.Label) taskResponse {
 	response := taskResponse{
 		Links: map[string]string{
-			"self":    fmt.Sprintf("/api/v2/tasks/%s", t.ID),
-			"members": fmt.Sprintf("/api/v2/tasks/%s/members", t.ID),
-			"owners":  fmt.Sprintf("/api/v2/tasks/%s/owners", t.ID),
-			"labels":  fmt.Sprintf("/api/v2/tasks/%s/labels", t.ID),
-			"runs":    fmt.Sprintf("/api/v2/tasks/%s/runs", t.ID),
-			"logs":    fmt.Sprintf("/api/v2/tasks/%s/logs", t.ID),
+			"self":      fmt.Sprintf("/api/v2/tasks/%s", t.ID),
+			"members":   fmt.Sprintf("/api/v2/tasks/%s/members", t.ID),
+			"owners":    fmt.Sprintf("/api/v2/tasks/%s/owners", t.ID),
+			"labels":    fmt.Sprintf("/api/v2/tasks/%s/labels", t.ID),
+			"runs":      fmt.Sprintf("/api/v2/tasks/%s/runs", t.ID),
+			"logs":      fmt.Sprintf("/api/v2/tasks/%s/logs", t.ID),
+			"revisions": fmt.Sprintf("/api/v2/tasks/%s/revisions", t.ID),
 		},
 		Task:   t,
 		Labels: []platform.Label{},
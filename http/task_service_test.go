@@ -133,7 +133,8 @@ func TestTaskHandler_handleGetTasks(t *testing.T) {
         "members": "/api/v2/tasks/0000000000000001/members",
         "labels": "/api/v2/tasks/0000000000000001/labels",
         "runs": "/api/v2/tasks/0000000000000001/runs",
-        "logs": "/api/v2/tasks/0000000000000001/logs"
+        "logs": "/api/v2/tasks/0000000000000001/logs",
+        "revisions": "/api/v2/tasks/0000000000000001/revisions"
       },
       "id": "0000000000000001",
       "name": "task1",
@@ -159,7 +160,8 @@ func TestTaskHandler_handleGetTasks(t *testing.T) {
         "members": "/api/v2/tasks/0000000000000002/members",
         "labels": "/api/v2/tasks/0000000000000002/labels",
         "runs": "/api/v2/tasks/0000000000000002/runs",
-        "logs": "/api/v2/tasks/0000000000000002/logs"
+        "logs": "/api/v2/tasks/0000000000000002/logs",
+        "revisions": "/api/v2/tasks/0000000000000002/revisions"
       },
       "id": "0000000000000002",
       "name": "task2",
@@ -232,7 +234,8 @@ func TestTaskHandler_handleGetTasks(t *testing.T) {
         "members": "/api/v2/tasks/0000000000000002/members",
         "labels": "/api/v2/tasks/0000000000000002/labels",
         "runs": "/api/v2/tasks/0000000000000002/runs",
-        "logs": "/api/v2/tasks/0000000000000002/logs"
+        "logs": "/api/v2/tasks/0000000000000002/logs",
+        "revisions": "/api/v2/tasks/0000000000000002/revisions"
       },
       "id": "0000000000000002",
       "name": "task2",
@@ -304,7 +307,8 @@ func TestTaskHandler_handleGetTasks(t *testing.T) {
         "members": "/api/v2/tasks/0000000000000002/members",
         "labels": "/api/v2/tasks/0000000000000002/labels",
         "runs": "/api/v2/tasks/0000000000000002/runs",
-        "logs": "/api/v2/tasks/0000000000000002/logs"
+        "logs": "/api/v2/tasks/0000000000000002/logs",
+        "revisions": "/api/v2/tasks/0000000000000002/revisions"
       },
       "id": "0000000000000002",
       "name": "task2",
@@ -464,7 +468,8 @@ func TestTaskHandler_handlePostTasks(t *testing.T) {
     "members": "/api/v2/tasks/0000000000000001/members",
     "labels": "/api/v2/tasks/0000000000000001/labels",
     "runs": "/api/v2/tasks/0000000000000001/runs",
-    "logs": "/api/v2/tasks/0000000000000001/logs"
+    "logs": "/api/v2/tasks/0000000000000001/logs",
+    "revisions": "/api/v2/tasks/0000000000000001/revisions"
   },
   "id": "0000000000000001",
   "name": "task1",
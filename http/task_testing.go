@@ -0,0 +1,131 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+)
+
+// ServeHTTP serves the task test endpoint, which the router cannot route alongside the task ID paths,
+// and otherwise routes the request.
+func (h *TaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" && r.URL.Path == tasksTestPath {
+		h.handlePostTaskTest(w, r)
+		return
+	}
+	h.Router.ServeHTTP(w, r)
+}
+
+type taskTestResponse struct {
+	Runs []*platform.TaskTestRun `json:"runs"`
+}
+
+func (h *TaskHandler) handlePostTaskTest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req platform.TaskTest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if req.TaskID.Valid() {
+		// Only test tasks the authorizer may read.
+		if _, err := h.TaskService.FindTaskByID(ctx, req.TaskID); err != nil {
+			err = &platform.Error{
+				Err: err,
+				Msg: "failed to find task",
+			}
+			EncodeError(ctx, err, w)
+			return
+		}
+	} else if !req.OrganizationID.Valid() && req.Organization != "" {
+		o, err := h.OrganizationService.FindOrganization(ctx, platform.OrganizationFilter{Name: &req.Organization})
+		if err != nil {
+			err = &platform.Error{
+				Err: err,
+				Msg: "could not identify organization",
+			}
+			EncodeError(ctx, err, w)
+			return
+		}
+		req.OrganizationID = o.ID
+	}
+
+	if err := req.Validate(); err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "invalid task test",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	runs, err := h.TaskTestService.TestTask(ctx, req)
+	if err != nil {
+		err = &platform.Error{
+			Err: err,
+			Msg: "failed to test task",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, taskTestResponse{Runs: runs}); err != nil {
+		logEncodingError(h.logger, r, err)
+		return
+	}
+}
+
+// TestTask runs the script of a task without writing the data of its to() calls.
+func (t TaskService) TestTask(ctx context.Context, tt platform.TaskTest) ([]*platform.TaskTestRun, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := newURL(t.Addr, tasksTestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(tt)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(t.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var tr taskTestResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return nil, err
+	}
+	return tr.Runs, nil
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestTaskHandler_handlePostTaskTest(t *testing.T) {
+	type wants struct {
+		statusCode int
+		body       string
+	}
+
+	tests := []struct {
+		name   string
+		body   string
+		tested *platform.TaskTest
+		wants  wants
+	}{
+		{
+			name: "test flux",
+			body: `{"flux": "option task = {name: \"x\", every: 1h} from(bucket: \"a\") |> to(bucket: \"b\")", "orgID": "0000000000000001", "now": "2019-01-01T12:00:00Z"}`,
+			tested: &platform.TaskTest{
+				Flux:           `option task = {name: "x", every: 1h} from(bucket: "a") |> to(bucket: "b")`,
+				OrganizationID: 1,
+				Now:            time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC),
+			},
+			wants: wants{
+				statusCode: http.StatusOK,
+				body: `
+{
+  "runs": [
+    {
+      "scheduledFor": "2019-01-01T12:00:00Z",
+      "results": [{"name": "to2", "csv": "#datatype,string\r\n"}],
+      "logs": [{"time": "2019-01-01T12:00:01Z", "message": "Completed successfully in 1s"}]
+    }
+  ]
+}`,
+			},
+		},
+		{
+			name: "test task",
+			body: `{"taskID": "0000000000000002", "scheduledFor": ["2019-01-01T11:00:00Z"]}`,
+			tested: &platform.TaskTest{
+				TaskID:       2,
+				ScheduledFor: []time.Time{time.Date(2019, 1, 1, 11, 0, 0, 0, time.UTC)},
+			},
+			wants: wants{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name: "missing task",
+			body: `{"taskID": "0000000000000003"}`,
+			wants: wants{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name: "flux and task",
+			body: `{"flux": "from(bucket: \"a\")", "taskID": "0000000000000002"}`,
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tested *platform.TaskTest
+			tts := &mock.TaskTestService{
+				TestTaskFn: func(_ context.Context, test platform.TaskTest) ([]*platform.TaskTestRun, error) {
+					tested = &test
+					return []*platform.TaskTestRun{{
+						ScheduledFor: "2019-01-01T12:00:00Z",
+						Results:      []platform.TaskTestResult{{Name: "to2", CSV: "#datatype,string\r\n"}},
+						Logs:         []platform.Log{{Time: "2019-01-01T12:00:01Z", Message: "Completed successfully in 1s"}},
+					}}, nil
+				},
+			}
+			ts := &mock.TaskService{
+				FindTaskByIDFn: func(_ context.Context, id platform.ID) (*platform.Task, error) {
+					if id != 2 {
+						return nil, &platform.Error{Code: platform.ENotFound, Msg: "task not found"}
+					}
+					return &platform.Task{ID: id, OrganizationID: 1}, nil
+				},
+			}
+
+			r := httptest.NewRequest("POST", "http://any.url/api/v2/tasks/test", strings.NewReader(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{Permissions: platform.OperPermissions()}))
+			w := httptest.NewRecorder()
+			taskBackend := NewMockTaskBackend(t)
+			taskBackend.TaskService = ts
+			taskBackend.TaskTestService = tts
+			h := NewTaskHandler(taskBackend)
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+
+			if res.StatusCode != tt.wants.statusCode {
+				t.Errorf("%q. handlePostTaskTest() = %v, want %v: %s", tt.name, res.StatusCode, tt.wants.statusCode, body)
+			}
+			if eq, diff, _ := jsonEqual(string(body), tt.wants.body); tt.wants.body != "" && !eq {
+				t.Errorf("%q. handlePostTaskTest() = ***%s***", tt.name, diff)
+			}
+			if tt.tested != nil && (tested == nil || tested.Flux != tt.tested.Flux || tested.TaskID != tt.tested.TaskID ||
+				tested.OrganizationID != tt.tested.OrganizationID || !tested.Now.Equal(tt.tested.Now) ||
+				len(tested.ScheduledFor) != len(tt.tested.ScheduledFor)) {
+				t.Errorf("%q. tested %+v, want %+v", tt.name, tested, tt.tested)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/golang/gddo/httputil"
@@ -25,6 +26,7 @@ type TelegrafBackend struct {
 	LabelService               platform.LabelService
 	UserService                platform.UserService
 	OrganizationService        platform.OrganizationService
+	TelegrafAgentService       platform.TelegrafAgentService
 }
 
 // NewTelegrafBackend returns a new instance of TelegrafBackend.
@@ -37,6 +39,7 @@ func NewTelegrafBackend(b *APIBackend) *TelegrafBackend {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		OrganizationService:        b.OrganizationService,
+		TelegrafAgentService:       b.TelegrafAgentService,
 	}
 }
 
@@ -50,6 +53,7 @@ type TelegrafHandler struct {
 	LabelService               platform.LabelService
 	UserService                platform.UserService
 	OrganizationService        platform.OrganizationService
+	TelegrafAgentService       platform.TelegrafAgentService
 }
 
 const (
@@ -74,12 +78,17 @@ func NewTelegrafHandler(b *TelegrafBackend) *TelegrafHandler {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		OrganizationService:        b.OrganizationService,
+		TelegrafAgentService:       b.TelegrafAgentService,
 	}
 	h.HandlerFunc("POST", telegrafsPath, h.handlePostTelegraf)
 	h.HandlerFunc("GET", telegrafsPath, h.handleGetTelegrafs)
 	h.HandlerFunc("GET", telegrafsIDPath, h.handleGetTelegraf)
 	h.HandlerFunc("DELETE", telegrafsIDPath, h.handleDeleteTelegraf)
 	h.HandlerFunc("PUT", telegrafsIDPath, h.handlePutTelegraf)
+	h.HandlerFunc("POST", telegrafsIDAgentsPath, h.handlePostTelegrafAgent)
+	h.HandlerFunc("GET", telegrafsIDAgentsPath, h.handleGetTelegrafAgents)
+	h.HandlerFunc("GET", telegrafsIDRevisionsPath, h.handleGetTelegrafRevisions)
+	h.HandlerFunc("GET", telegrafPluginsPath, h.handleGetTelegrafPlugins)
 
 	memberBackend := MemberBackend{
 		Logger:                     b.Logger.With(zap.String("handler", "member")),
@@ -141,6 +150,7 @@ func (r *telegrafResponse) MarshalJSON() ([]byte, error) {
 		OrganizationID platform.ID                  `json:"organizationID,omitempty"`
 		Name           string                       `json:"name"`
 		Description    string                       `json:"description"`
+		Revision       int                          `json:"revision,omitempty"`
 		Agent          platform.TelegrafAgentConfig `json:"agent"`
 		Plugins        []telegrafPluginEncode       `json:"plugins"`
 		Labels         []platform.Label             `json:"labels"`
@@ -153,6 +163,7 @@ func (r *telegrafResponse) MarshalJSON() ([]byte, error) {
 		OrganizationID: r.OrganizationID,
 		Name:           r.Name,
 		Description:    r.Description,
+		Revision:       r.Revision,
 		Agent:          r.Agent,
 		Plugins:        make([]telegrafPluginEncode, len(r.Plugins)),
 		Labels:         r.Labels,
@@ -264,6 +275,7 @@ func (h *TelegrafHandler) handleGetTelegraf(w http.ResponseWriter, r *http.Reque
 	mimeType := httputil.NegotiateContentType(r, offers, defaultOffer)
 	switch mimeType {
 	case "application/octet-stream":
+		setTelegrafRevisionHeader(w, tc)
 		w.Header().Set("Content-Type", "application/octet-stream")
 		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.toml\"", strings.Replace(strings.TrimSpace(tc.Name), " ", "_", -1)))
 		w.WriteHeader(http.StatusOK)
@@ -280,12 +292,20 @@ func (h *TelegrafHandler) handleGetTelegraf(w http.ResponseWriter, r *http.Reque
 			return
 		}
 	case "application/toml":
+		setTelegrafRevisionHeader(w, tc)
 		w.Header().Set("Content-Type", "application/toml; charset=utf-8")
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(tc.TOML()))
 	}
 }
 
+// setTelegrafRevisionHeader tells the agents fetching the TOML of tc its revision, which they report when they check in.
+func setTelegrafRevisionHeader(w http.ResponseWriter, tc *platform.TelegrafConfig) {
+	if tc.Revision > 0 {
+		w.Header().Set(TelegrafRevisionHeader, strconv.Itoa(tc.Revision))
+	}
+}
+
 func decodeTelegrafConfigFilter(ctx context.Context, r *http.Request) (*platform.TelegrafConfigFilter, error) {
 	f := &platform.TelegrafConfigFilter{}
 	urm, err := decodeUserResourceMappingFilter(ctx, r)
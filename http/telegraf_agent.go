@@ -0,0 +1,255 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"go.uber.org/zap"
+)
+
+const (
+	telegrafsIDAgentsPath    = "/api/v2/telegrafs/:id/agents"
+	telegrafsIDRevisionsPath = "/api/v2/telegrafs/:id/revisions"
+)
+
+// TelegrafRevisionHeader is the header telling the agents fetching the TOML of a telegraf config its revision.
+const TelegrafRevisionHeader = "Influx-Telegraf-Revision"
+
+// checkInTelegrafAgentRequest is what a telegraf agent reports when it checks in.
+type checkInTelegrafAgentRequest struct {
+	Hostname string `json:"hostname"`
+	Version  string `json:"version"`
+	Revision int    `json:"revision"`
+}
+
+type telegrafAgentsResponse struct {
+	Agents []*platform.TelegrafAgent `json:"agents"`
+}
+
+type telegrafConfigRevisionsResponse struct {
+	Revisions []*platform.TelegrafConfigRevision `json:"revisions"`
+}
+
+// handlePostTelegrafAgent is the HTTP handler for the POST /api/v2/telegrafs/:id/agents route.
+// Agents check in with a token that can read the telegraf config.
+func (h *TelegrafHandler) handlePostTelegrafAgent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeGetTelegrafRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	req := new(checkInTelegrafAgentRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "cannot decode telegraf agent check in",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	// Only agents that may read the telegraf config check in.
+	if _, err := h.TelegrafService.FindTelegrafConfigByID(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	agent, err := h.TelegrafAgentService.CheckInTelegrafAgent(ctx, platform.TelegrafAgentCheckIn{
+		TelegrafConfigID: id,
+		Hostname:         req.Hostname,
+		Version:          req.Version,
+		Revision:         req.Revision,
+	})
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, agent); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handleGetTelegrafAgents is the HTTP handler for the GET /api/v2/telegrafs/:id/agents route.
+func (h *TelegrafHandler) handleGetTelegrafAgents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeGetTelegrafRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	// Only return the agents of the telegraf configs the authorizer may read.
+	if _, err := h.TelegrafService.FindTelegrafConfigByID(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	agents, err := h.TelegrafAgentService.FindTelegrafAgents(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, telegrafAgentsResponse{Agents: agents}); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handleGetTelegrafRevisions is the HTTP handler for the GET /api/v2/telegrafs/:id/revisions route.
+func (h *TelegrafHandler) handleGetTelegrafRevisions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeGetTelegrafRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	// Only return the revisions of the telegraf configs the authorizer may read.
+	if _, err := h.TelegrafService.FindTelegrafConfigByID(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	revisions, err := h.TelegrafAgentService.FindTelegrafConfigRevisions(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, telegrafConfigRevisionsResponse{Revisions: revisions}); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+var _ platform.TelegrafAgentService = (*TelegrafService)(nil)
+
+func telegrafIDPath(id platform.ID) string {
+	return path.Join(telegrafsPath, id.String())
+}
+
+// CheckInTelegrafAgent records the check in of an agent, and returns the agent.
+func (s *TelegrafService) CheckInTelegrafAgent(ctx context.Context, c platform.TelegrafAgentCheckIn) (*platform.TelegrafAgent, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	url, err := newURL(s.Addr, path.Join(telegrafIDPath(c.TelegrafConfigID), "agents"))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(checkInTelegrafAgentRequest{
+		Hostname: c.Hostname,
+		Version:  c.Version,
+		Revision: c.Revision,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(url.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	agent := new(platform.TelegrafAgent)
+	if err := json.NewDecoder(resp.Body).Decode(agent); err != nil {
+		return nil, err
+	}
+	return agent, nil
+}
+
+// FindTelegrafAgents returns the agents that checked in for the telegraf config id, by hostname.
+func (s *TelegrafService) FindTelegrafAgents(ctx context.Context, id platform.ID) ([]*platform.TelegrafAgent, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	url, err := newURL(s.Addr, path.Join(telegrafIDPath(id), "agents"))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(url.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var agents telegrafAgentsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&agents); err != nil {
+		return nil, err
+	}
+	return agents.Agents, nil
+}
+
+// FindTelegrafConfigRevisions returns the revisions of the telegraf config id, latest first.
+func (s *TelegrafService) FindTelegrafConfigRevisions(ctx context.Context, id platform.ID) ([]*platform.TelegrafConfigRevision, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	url, err := newURL(s.Addr, path.Join(telegrafIDPath(id), "revisions"))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(url.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var revisions telegrafConfigRevisionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&revisions); err != nil {
+		return nil, err
+	}
+	return revisions.Revisions, nil
+}
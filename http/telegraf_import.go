@@ -0,0 +1,128 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	platform "github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"go.uber.org/zap"
+)
+
+// telegrafsImportPath is the path importing telegraf.conf files.
+const telegrafsImportPath = "/api/v2/telegrafs/import"
+
+// importTelegrafRequest is the telegraf.conf file imported as a telegraf config.
+type importTelegrafRequest struct {
+	OrganizationID platform.ID `json:"organizationID"`
+	Name           string      `json:"name"`
+	Description    string      `json:"description"`
+	// Config is the TOML of the telegraf.conf file.
+	Config string `json:"config"`
+}
+
+// ServeHTTP serves the telegraf import endpoint, which the router cannot route alongside the telegraf config ID paths,
+// and otherwise routes the request.
+func (h *TelegrafHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" && r.URL.Path == telegrafsImportPath {
+		h.handleImportTelegraf(w, r)
+		return
+	}
+	h.Router.ServeHTTP(w, r)
+}
+
+// handleImportTelegraf is the HTTP handler for the POST /api/v2/telegrafs/import route.
+func (h *TelegrafHandler) handleImportTelegraf(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := new(importTelegrafRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		h.Logger.Debug("failed to decode request", zap.Error(err))
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "cannot decode telegraf import request",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	tc, err := platform.ParseTelegrafConfigTOML(req.Config)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	tc.OrganizationID = req.OrganizationID
+	tc.Name = req.Name
+	tc.Description = req.Description
+
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.TelegrafService.CreateTelegrafConfig(ctx, tc, auth.GetUserID()); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newTelegrafResponse(tc, []*platform.Label{})); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// TelegrafService connects to Influx via HTTP using tokens to manage telegraf configs.
+type TelegrafService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+// ImportTelegrafConfig creates the telegraf config of orgID parsed from the TOML of a telegraf.conf file.
+func (s *TelegrafService) ImportTelegrafConfig(ctx context.Context, orgID platform.ID, name, description, config string) (*platform.TelegrafConfig, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	url, err := newURL(s.Addr, telegrafsImportPath)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(importTelegrafRequest{
+		OrganizationID: orgID,
+		Name:           name,
+		Description:    description,
+		Config:         config,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(url.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	tc := new(platform.TelegrafConfig)
+	if err := json.NewDecoder(resp.Body).Decode(tc); err != nil {
+		return nil, err
+	}
+	return tc, nil
+}
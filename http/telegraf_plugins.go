@@ -0,0 +1,56 @@
+package http
+
+import (
+	"net/http"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/telegraf/plugins"
+	"github.com/influxdata/influxdb/telegraf/plugins/catalog"
+)
+
+// telegrafPluginsPath lists the plugins of the catalog. It isn't under /api/v2/telegrafs,
+// whose paths are the ones of the configs.
+const telegrafPluginsPath = "/api/v2/telegraf/plugins"
+
+type telegrafPluginsResponse struct {
+	Plugins []*catalog.Schema `json:"plugins"`
+}
+
+// handleGetTelegrafPlugins is the HTTP handler for the GET /api/v2/telegraf/plugins route,
+// which lists the plugins of the catalog, optionally of a type.
+func (h *TelegrafHandler) handleGetTelegrafPlugins(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	typ := plugins.Type(r.URL.Query().Get("type"))
+	switch typ {
+	case "", plugins.Input, plugins.Output, plugins.Processor, plugins.Aggregator:
+	default:
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "type must be one of input, output, processor or aggregator",
+		}, w)
+		return
+	}
+
+	schemas, err := catalog.Schemas()
+	if err != nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInternal,
+			Msg:  "cannot load the catalog of telegraf plugins",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	res := telegrafPluginsResponse{Plugins: []*catalog.Schema{}}
+	for _, s := range schemas {
+		if typ == "" || s.Type == typ {
+			res.Plugins = append(res.Plugins, s)
+		}
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
@@ -7,12 +7,15 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"go.uber.org/zap"
 
 	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
 	"github.com/influxdata/influxdb/mock"
 	"github.com/influxdata/influxdb/telegraf/plugins/inputs"
 	"github.com/influxdata/influxdb/telegraf/plugins/outputs"
@@ -213,6 +216,7 @@ func TestTelegrafHandler_handleGetTelegraf(t *testing.T) {
 	type wants struct {
 		statusCode  int
 		contentType string
+		revision    string
 		body        string
 	}
 	tests := []struct {
@@ -611,6 +615,7 @@ func TestTelegrafHandler_handleGetTelegraf(t *testing.T) {
 						ID:             platform.ID(1),
 						OrganizationID: platform.ID(2),
 						Name:           "my config",
+						Revision:       3,
 						Agent: platform.TelegrafAgentConfig{
 							Interval: 10000,
 						},
@@ -635,6 +640,7 @@ func TestTelegrafHandler_handleGetTelegraf(t *testing.T) {
 			wants: wants{
 				statusCode:  http.StatusOK,
 				contentType: "application/octet-stream",
+				revision:    "3",
 				body: `# Configuration for telegraf agent
 [agent]
   ## Default data collection interval for all inputs
@@ -740,6 +746,9 @@ func TestTelegrafHandler_handleGetTelegraf(t *testing.T) {
 				t.Errorf("%q. handleGetTelegraf() = %v, want %v", tt.name, content, tt.wants.contentType)
 				return
 			}
+			if revision := res.Header.Get(TelegrafRevisionHeader); revision != tt.wants.revision {
+				t.Errorf("%q. handleGetTelegraf() revision = %v, want %v", tt.name, revision, tt.wants.revision)
+			}
 
 			if strings.Contains(tt.wants.contentType, "application/json") {
 				if eq, diff, _ := jsonEqual(string(body), tt.wants.body); tt.wants.body != "" && !eq {
@@ -931,3 +940,312 @@ func Test_newTelegrafResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestTelegrafHandler_handleImportTelegraf(t *testing.T) {
+	type wants struct {
+		statusCode int
+		name       string
+		plugins    []string
+	}
+	tests := []struct {
+		name  string
+		path  string
+		body  string
+		wants wants
+	}{
+		{
+			name: "import telegraf.conf",
+			path: "http://any.url/api/v2/telegrafs/import",
+			body: `{
+  "organizationID": "0000000000000009",
+  "name": "my config",
+  "config": "[agent]\n  interval = \"15s\"\n\n[[inputs.cpu]]\n\n[[inputs.kafka_consumer]]\n  brokers = [\"localhost:9092\"]\n\n[[outputs.file]]\n  files = [\"stdout\"]\n"
+}`,
+			wants: wants{
+				statusCode: http.StatusCreated,
+				name:       "my config",
+				plugins:    []string{"cpu", "file", "kafka_consumer"},
+			},
+		},
+		{
+			name: "invalid telegraf.conf",
+			path: "http://any.url/api/v2/telegrafs/import",
+			body: `{"organizationID": "0000000000000009", "name": "my config", "config": "[[inputs.cpu"}`,
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "post to a telegraf config",
+			path: "http://any.url/api/v2/telegrafs/0000000000000001",
+			body: `{}`,
+			wants: wants{
+				statusCode: http.StatusMethodNotAllowed,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *platform.TelegrafConfig
+			telegrafBackend := NewMockTelegrafBackend()
+			telegrafBackend.TelegrafService = &mock.TelegrafConfigStore{
+				CreateTelegrafConfigF: func(ctx context.Context, tc *platform.TelegrafConfig, userID platform.ID) error {
+					tc.ID = platform.ID(1)
+					created = tc
+					return nil
+				},
+			}
+			h := NewTelegrafHandler(telegrafBackend)
+
+			r := httptest.NewRequest("POST", tt.path, strings.NewReader(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{UserID: platform.ID(2)}))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			if res.StatusCode != tt.wants.statusCode {
+				body, _ := ioutil.ReadAll(res.Body)
+				t.Fatalf("handleImportTelegraf() = %v, want %v: %s", res.StatusCode, tt.wants.statusCode, body)
+			}
+			if tt.wants.statusCode != http.StatusCreated {
+				return
+			}
+
+			if created.Name != tt.wants.name {
+				t.Errorf("handleImportTelegraf() name = %q, want %q", created.Name, tt.wants.name)
+			}
+			if created.OrganizationID != platform.ID(9) {
+				t.Errorf("handleImportTelegraf() organizationID = %v, want %v", created.OrganizationID, platform.ID(9))
+			}
+			var plugins []string
+			for _, p := range created.Plugins {
+				plugins = append(plugins, p.Config.PluginName())
+			}
+			sort.Strings(plugins)
+			if fmt.Sprint(plugins) != fmt.Sprint(tt.wants.plugins) {
+				t.Errorf("handleImportTelegraf() plugins = %v, want %v", plugins, tt.wants.plugins)
+			}
+		})
+	}
+}
+
+func TestTelegrafHandler_handleTelegrafAgents(t *testing.T) {
+	seenAt := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type wants struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		wants  wants
+	}{
+		{
+			name:   "check in an agent",
+			method: "POST",
+			path:   "http://any.url/api/v2/telegrafs/0000000000000001/agents",
+			body:   `{"hostname": "host1", "version": "1.10.0", "revision": 1}`,
+			wants: wants{
+				statusCode: http.StatusOK,
+				body: `{
+  "telegrafConfigID": "0000000000000001",
+  "hostname": "host1",
+  "version": "1.10.0",
+  "revision": 1,
+  "lastSeen": "2019-01-01T00:00:00Z",
+  "stale": true
+}`,
+			},
+		},
+		{
+			name:   "list the agents of a config",
+			method: "GET",
+			path:   "http://any.url/api/v2/telegrafs/0000000000000001/agents",
+			wants: wants{
+				statusCode: http.StatusOK,
+				body: `{
+  "agents": [
+    {
+      "telegrafConfigID": "0000000000000001",
+      "hostname": "host1",
+      "version": "1.10.0",
+      "revision": 2,
+      "lastSeen": "2019-01-01T00:00:00Z",
+      "stale": false
+    }
+  ]
+}`,
+			},
+		},
+		{
+			name:   "list the revisions of a config",
+			method: "GET",
+			path:   "http://any.url/api/v2/telegrafs/0000000000000001/revisions",
+			wants: wants{
+				statusCode: http.StatusOK,
+				body: `{
+  "revisions": [
+    {
+      "telegrafConfigID": "0000000000000001",
+      "revision": 2,
+      "userID": "0000000000000002",
+      "createdAt": "2019-01-01T00:00:00Z",
+      "toml": "[agent]\n"
+    }
+  ]
+}`,
+			},
+		},
+		{
+			name:   "check in an agent of a missing config",
+			method: "POST",
+			path:   "http://any.url/api/v2/telegrafs/0000000000000003/agents",
+			body:   `{"hostname": "host1", "revision": 1}`,
+			wants: wants{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name:   "list the agents of a missing config",
+			method: "GET",
+			path:   "http://any.url/api/v2/telegrafs/0000000000000003/agents",
+			wants: wants{
+				statusCode: http.StatusNotFound,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			telegrafBackend := NewMockTelegrafBackend()
+			telegrafBackend.TelegrafService = &mock.TelegrafConfigStore{
+				FindTelegrafConfigByIDF: func(ctx context.Context, id platform.ID) (*platform.TelegrafConfig, error) {
+					if id != platform.ID(1) {
+						return nil, &platform.Error{Code: platform.ENotFound, Msg: platform.ErrTelegrafConfigNotFound}
+					}
+					return &platform.TelegrafConfig{ID: id}, nil
+				},
+			}
+			telegrafBackend.TelegrafAgentService = &mock.TelegrafAgentService{
+				CheckInTelegrafAgentFn: func(ctx context.Context, c platform.TelegrafAgentCheckIn) (*platform.TelegrafAgent, error) {
+					return &platform.TelegrafAgent{TelegrafAgentCheckIn: c, LastSeen: seenAt, Stale: true}, nil
+				},
+				FindTelegrafAgentsFn: func(ctx context.Context, id platform.ID) ([]*platform.TelegrafAgent, error) {
+					return []*platform.TelegrafAgent{
+						{
+							TelegrafAgentCheckIn: platform.TelegrafAgentCheckIn{
+								TelegrafConfigID: id,
+								Hostname:         "host1",
+								Version:          "1.10.0",
+								Revision:         2,
+							},
+							LastSeen: seenAt,
+						},
+					}, nil
+				},
+				FindTelegrafConfigRevisionsFn: func(ctx context.Context, id platform.ID) ([]*platform.TelegrafConfigRevision, error) {
+					return []*platform.TelegrafConfigRevision{
+						{
+							TelegrafConfigID: id,
+							Revision:         2,
+							UserID:           platform.ID(2),
+							CreatedAt:        seenAt,
+							TOML:             "[agent]\n",
+						},
+					}, nil
+				},
+			}
+			h := NewTelegrafHandler(telegrafBackend)
+
+			r := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.wants.statusCode {
+				t.Fatalf("%s %s = %v, want %v: %s", tt.method, tt.path, res.StatusCode, tt.wants.statusCode, body)
+			}
+			if eq, diff, _ := jsonEqual(string(body), tt.wants.body); tt.wants.body != "" && !eq {
+				t.Errorf("%s %s = ***%s***", tt.method, tt.path, diff)
+			}
+		})
+	}
+}
+
+func TestTelegrafHandler_handleGetTelegrafPlugins(t *testing.T) {
+	type wants struct {
+		statusCode int
+		plugins    []string
+	}
+	tests := []struct {
+		name  string
+		path  string
+		wants wants
+	}{
+		{
+			name: "list the plugins",
+			path: "http://any.url/api/v2/telegraf/plugins",
+			wants: wants{
+				statusCode: http.StatusOK,
+				plugins:    []string{"input http", "input mysql", "input postgresql", "input snmp"},
+			},
+		},
+		{
+			name: "list the plugins of a type",
+			path: "http://any.url/api/v2/telegraf/plugins?type=output",
+			wants: wants{
+				statusCode: http.StatusOK,
+				plugins:    []string{},
+			},
+		},
+		{
+			name: "unknown type",
+			path: "http://any.url/api/v2/telegraf/plugins?type=serializer",
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTelegrafHandler(NewMockTelegrafBackend())
+
+			r := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.wants.statusCode {
+				t.Fatalf("handleGetTelegrafPlugins() = %v, want %v: %s", res.StatusCode, tt.wants.statusCode, body)
+			}
+			if tt.wants.statusCode != http.StatusOK {
+				return
+			}
+
+			var resp struct {
+				Plugins []struct {
+					Type    string                 `json:"type"`
+					Name    string                 `json:"name"`
+					Options map[string]interface{} `json:"options"`
+				} `json:"plugins"`
+			}
+			if err := json.Unmarshal(body, &resp); err != nil {
+				t.Fatalf("bad response %s: %v", body, err)
+			}
+			plugins := []string{}
+			for _, p := range resp.Plugins {
+				if p.Options["type"] != "object" {
+					t.Errorf("expected the options of %s to be the schema of an object, got %v", p.Name, p.Options)
+				}
+				plugins = append(plugins, p.Type+" "+p.Name)
+			}
+			if fmt.Sprint(plugins) != fmt.Sprint(tt.wants.plugins) {
+				t.Errorf("handleGetTelegrafPlugins() plugins = %v, want %v", plugins, tt.wants.plugins)
+			}
+		})
+	}
+}
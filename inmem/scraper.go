@@ -67,6 +67,13 @@ func (s *Service) AddTarget(ctx context.Context, target *platform.ScraperTarget,
 			Op:   OpPrefix + platform.OpAddTarget,
 		}
 	}
+	if err := target.ValidateOptions(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  err.Error(),
+			Op:   OpPrefix + platform.OpAddTarget,
+		}
+	}
 	if err := s.PutTarget(ctx, target); err != nil {
 		return &platform.Error{
 			Op:  OpPrefix + platform.OpAddTarget,
@@ -119,6 +126,13 @@ func (s *Service) UpdateTarget(ctx context.Context, update *platform.ScraperTarg
 			Msg:  "provided scraper target ID has invalid format",
 		}
 	}
+	if err := update.ValidateOptions(); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   op,
+			Msg:  err.Error(),
+		}
+	}
 	oldTarget, pe := s.loadScraperTarget(update.ID)
 	if pe != nil {
 		return nil, &platform.Error{
@@ -0,0 +1,191 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/influxdata/influxdb"
+)
+
+var (
+	scraperStatusBucket = []byte("scraperstatusv1")
+)
+
+var _ influxdb.ScraperStatusService = (*Service)(nil)
+
+func (s *Service) initializeScraperStatus(ctx context.Context, tx Tx) error {
+	_, err := s.scraperStatusBucket(tx)
+	return err
+}
+
+func (s *Service) scraperStatusBucket(tx Tx) (Bucket, error) {
+	b, err := tx.Bucket(scraperStatusBucket)
+	if err != nil {
+		return nil, UnexpectedScrapersBucketError(err)
+	}
+
+	return b, nil
+}
+
+// FindTargetStatus returns the status of the scraper target id.
+func (s *Service) FindTargetStatus(ctx context.Context, id influxdb.ID) (*influxdb.ScraperTargetStatus, error) {
+	var status *influxdb.ScraperTargetStatus
+	err := s.kv.View(ctx, func(tx Tx) error {
+		if _, err := s.findTargetByID(ctx, tx, id); err != nil {
+			return err
+		}
+
+		var err error
+		status, err = s.findTargetStatus(ctx, tx, id)
+		return err
+	})
+	return status, err
+}
+
+func (s *Service) findTargetStatus(ctx context.Context, tx Tx, id influxdb.ID) (*influxdb.ScraperTargetStatus, error) {
+	encID, err := id.Encode()
+	if err != nil {
+		return nil, ErrInvalidScraperID
+	}
+
+	bucket, err := s.scraperStatusBucket(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &influxdb.ScraperTargetStatus{TargetID: id, History: []influxdb.ScrapeResult{}}
+	v, err := bucket.Get(encID)
+	if IsNotFound(err) {
+		// The target was not scraped yet.
+		return status, nil
+	}
+	if err != nil {
+		return nil, InternalScraperServiceError(err)
+	}
+
+	if err := json.Unmarshal(v, status); err != nil {
+		return nil, CorruptScraperError(err)
+	}
+	return status, nil
+}
+
+// RecordScrape adds r to the history of the scraper target id,
+// keeping the last influxdb.ScraperStatusHistoryLength scrapes,
+// and to the status of the discovered target r.Instance if it is set.
+func (s *Service) RecordScrape(ctx context.Context, id influxdb.ID, r influxdb.ScrapeResult) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		if _, err := s.findTargetByID(ctx, tx, id); err != nil {
+			return err
+		}
+
+		status, err := s.findTargetStatus(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		status.History = append([]influxdb.ScrapeResult{r}, status.History...)
+		if len(status.History) > influxdb.ScraperStatusHistoryLength {
+			status.History = status.History[:influxdb.ScraperStatusHistoryLength]
+		}
+		status.LastScrape = &status.History[0]
+		if r.Instance == "" {
+			status.Up = r.Error == ""
+			return s.putTargetStatus(ctx, tx, status)
+		}
+
+		i := sort.Search(len(status.Instances), func(i int) bool {
+			return status.Instances[i].Instance >= r.Instance
+		})
+		if i == len(status.Instances) || status.Instances[i].Instance != r.Instance {
+			status.Instances = append(status.Instances, influxdb.ScraperInstanceStatus{})
+			copy(status.Instances[i+1:], status.Instances[i:])
+		}
+		status.Instances[i] = influxdb.ScraperInstanceStatus{Instance: r.Instance, Up: r.Error == "", LastScrape: r}
+		status.Up = instancesUp(status.Instances)
+		return s.putTargetStatus(ctx, tx, status)
+	})
+}
+
+// RetainInstances drops the statuses of the targets discovered by the scraper target id that are not in instances.
+func (s *Service) RetainInstances(ctx context.Context, id influxdb.ID, instances []string) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		if _, err := s.findTargetByID(ctx, tx, id); err != nil {
+			return err
+		}
+
+		status, err := s.findTargetStatus(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if len(status.Instances) == 0 {
+			return nil
+		}
+
+		keep := make(map[string]bool, len(instances))
+		for _, instance := range instances {
+			keep[instance] = true
+		}
+		retained := status.Instances[:0]
+		for _, is := range status.Instances {
+			if keep[is.Instance] {
+				retained = append(retained, is)
+			}
+		}
+		if len(retained) == len(status.Instances) {
+			return nil
+		}
+		status.Instances = retained
+		status.Up = instancesUp(status.Instances)
+		return s.putTargetStatus(ctx, tx, status)
+	})
+}
+
+// instancesUp reports whether every discovered target of a scraper target is up.
+func instancesUp(instances []influxdb.ScraperInstanceStatus) bool {
+	for _, is := range instances {
+		if !is.Up {
+			return false
+		}
+	}
+	return len(instances) > 0
+}
+
+func (s *Service) putTargetStatus(ctx context.Context, tx Tx, status *influxdb.ScraperTargetStatus) error {
+	v, err := json.Marshal(status)
+	if err != nil {
+		return ErrUnprocessableScraper(err)
+	}
+
+	encID, err := status.TargetID.Encode()
+	if err != nil {
+		return ErrInvalidScraperID
+	}
+
+	bucket, err := s.scraperStatusBucket(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := bucket.Put(encID, v); err != nil {
+		return UnexpectedScrapersBucketError(err)
+	}
+	return nil
+}
+
+func (s *Service) deleteTargetStatus(ctx context.Context, tx Tx, id influxdb.ID) error {
+	encID, err := id.Encode()
+	if err != nil {
+		return ErrInvalidScraperID
+	}
+
+	bucket, err := s.scraperStatusBucket(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := bucket.Delete(encID); err != nil && !IsNotFound(err) {
+		return InternalScraperServiceError(err)
+	}
+	return nil
+}
@@ -65,6 +65,15 @@ func ErrUnprocessableScraper(err error) *influxdb.Error {
 	}
 }
 
+// InvalidScraperOptionsError is used when the scrape options of a scraper target are invalid.
+func InvalidScraperOptionsError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EInvalid,
+		Msg:  err.Error(),
+		Op:   "kv/scraper",
+	}
+}
+
 // InternalScraperServiceError is used when the error comes from an
 // internal system.
 func InternalScraperServiceError(err error) *influxdb.Error {
@@ -144,6 +153,10 @@ func (s *Service) addTarget(ctx context.Context, tx Tx, target *influxdb.Scraper
 		return ErrInvalidScrapersBucketID
 	}
 
+	if err := target.ValidateOptions(); err != nil {
+		return InvalidScraperOptionsError(err)
+	}
+
 	target.ID = s.IDGenerator.ID()
 	if err := s.putTarget(ctx, tx, target); err != nil {
 		return err
@@ -192,6 +205,10 @@ func (s *Service) removeTarget(ctx context.Context, tx Tx, id influxdb.ID) error
 		return InternalScraperServiceError(err)
 	}
 
+	if err := s.deleteTargetStatus(ctx, tx, id); err != nil {
+		return err
+	}
+
 	return s.deleteUserResourceMappings(ctx, tx, influxdb.UserResourceMappingFilter{
 		ResourceID:   id,
 		ResourceType: influxdb.ScraperResourceType,
@@ -215,6 +232,10 @@ func (s *Service) updateTarget(ctx context.Context, tx Tx, update *influxdb.Scra
 		return nil, ErrInvalidScraperID
 	}
 
+	if err := update.ValidateOptions(); err != nil {
+		return nil, InvalidScraperOptionsError(err)
+	}
+
 	target, err := s.findTargetByID(ctx, tx, update.ID)
 	if err != nil {
 		return nil, err
@@ -3,6 +3,7 @@ package kv_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/kv"
@@ -72,3 +73,107 @@ func initScraperTargetStoreService(s kv.Store, f influxdbtesting.TargetFields, t
 		}
 	}
 }
+
+func TestScraperTargetStatus(t *testing.T) {
+	s, closeFn, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeFn()
+
+	svc := kv.NewService(s)
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing kv service: %v", err)
+	}
+
+	target := &influxdb.ScraperTarget{
+		Name:     "target",
+		Type:     influxdb.PrometheusScraperType,
+		URL:      "http://localhost:9100/metrics",
+		OrgID:    influxdbtesting.MustIDBase16("020f755c3c082000"),
+		BucketID: influxdbtesting.MustIDBase16("020f755c3c082001"),
+	}
+	if err := svc.AddTarget(ctx, target, influxdbtesting.MustIDBase16("020f755c3c082002")); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := svc.FindTargetStatus(ctx, target.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Up || status.LastScrape != nil || len(status.History) != 0 {
+		t.Fatalf("expected the status of a target never scraped, got %+v", status)
+	}
+
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < influxdb.ScraperStatusHistoryLength+2; i++ {
+		r := influxdb.ScrapeResult{Time: start.Add(time.Duration(i) * time.Minute), Samples: i, StatusCode: 200}
+		if i%2 == 1 {
+			r = influxdb.ScrapeResult{Time: r.Time, StatusCode: 404, Error: "not found"}
+		}
+		if err := svc.RecordScrape(ctx, target.ID, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	status, err = svc.FindTargetStatus(ctx, target.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.History) != influxdb.ScraperStatusHistoryLength {
+		t.Fatalf("expected %d scrapes, got %d", influxdb.ScraperStatusHistoryLength, len(status.History))
+	}
+	last := start.Add(time.Duration(influxdb.ScraperStatusHistoryLength+1) * time.Minute)
+	if status.Up || status.LastScrape == nil || status.LastScrape.StatusCode != 404 || !status.History[0].Time.Equal(last) {
+		t.Fatalf("expected the last scrape to have failed at %s, got %+v", last, status)
+	}
+	if status.History[1].Samples != influxdb.ScraperStatusHistoryLength {
+		t.Fatalf("expected the previous scrape to have %d samples, got %+v", influxdb.ScraperStatusHistoryLength, status.History[1])
+	}
+
+	// The discovered targets of a target have statuses of their own.
+	for i, instance := range []string{"node2:9100", "node1:9100", "node2:9100"} {
+		r := influxdb.ScrapeResult{Time: last.Add(time.Duration(i+1) * time.Minute), StatusCode: 200, Instance: instance}
+		if instance == "node1:9100" {
+			r.StatusCode, r.Error = 503, "unavailable"
+		}
+		if err := svc.RecordScrape(ctx, target.ID, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	status, err = svc.FindTargetStatus(ctx, target.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Instances) != 2 || status.Instances[0].Instance != "node1:9100" || status.Instances[1].Instance != "node2:9100" {
+		t.Fatalf("expected the statuses of node1 and node2, got %+v", status.Instances)
+	}
+	if status.Up || status.Instances[0].Up || !status.Instances[1].Up {
+		t.Fatalf("expected only node2 to be up, got %+v", status)
+	}
+	if status.LastScrape.Instance != "node2:9100" {
+		t.Fatalf("expected the last scrape to be of node2, got %+v", status.LastScrape)
+	}
+
+	if err := svc.RetainInstances(ctx, target.ID, []string{"node2:9100"}); err != nil {
+		t.Fatal(err)
+	}
+	status, err = svc.FindTargetStatus(ctx, target.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Instances) != 1 || status.Instances[0].Instance != "node2:9100" || !status.Up {
+		t.Fatalf("expected only node2 to be left and up, got %+v", status)
+	}
+
+	if err := svc.RemoveTarget(ctx, target.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.FindTargetStatus(ctx, target.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if err := svc.RecordScrape(ctx, target.ID, influxdb.ScrapeResult{}); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
@@ -81,6 +81,10 @@ func (s *Service) Initialize(ctx context.Context) error {
 			return err
 		}
 
+		if err := s.initializeTaskLeases(ctx, tx); err != nil {
+			return err
+		}
+
 		if err := s.initializePasswords(ctx, tx); err != nil {
 			return err
 		}
@@ -89,6 +93,10 @@ func (s *Service) Initialize(ctx context.Context) error {
 			return err
 		}
 
+		if err := s.initializeScraperStatus(ctx, tx); err != nil {
+			return err
+		}
+
 		if err := s.initializeSecrets(ctx, tx); err != nil {
 			return err
 		}
@@ -105,6 +113,10 @@ func (s *Service) Initialize(ctx context.Context) error {
 			return err
 		}
 
+		if err := s.initializeTelegrafAgents(ctx, tx); err != nil {
+			return err
+		}
+
 		if err := s.initializeURMs(ctx, tx); err != nil {
 			return err
 		}
@@ -1,10 +1,14 @@
 package kv
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,7 +16,6 @@ import (
 	icontext "github.com/influxdata/influxdb/context"
 	"github.com/influxdata/influxdb/task/backend"
 	"github.com/influxdata/influxdb/task/options"
-	cron "gopkg.in/robfig/cron.v2"
 )
 
 var (
@@ -98,10 +101,17 @@ func ErrTaskOptionParse(err error) *influxdb.Error {
 //   <taskID>: task data storage
 // taskRunBucket:
 //   <taskID>/<runID>: run data storage
+//   <taskID>/<runID>/try: attempt number of a retried run
 //   <taskID>/manualRuns: list of runs to run manually
 //   <taskID>/latestCompleted: run data for the latest completed run of a task
 // taskIndexBucket
 //   <orgID>/<taskID>: index for tasks by org
+// taskRevisionBucket
+//   <taskID>/<revision>: revisions of the script and authorization of a task
+// taskUpstreamBucket
+//   <taskID>/<now>: upstream tasks that succeeded for a run time of a task, by the time as a big-endian uint64
+// taskLeaseBucket (see task_lease.go)
+//   <taskID>: lease of the node scheduling a task
 
 // We may want to add a <taskName>/<taskID> index to allow us to look up tasks by task name.
 
@@ -109,6 +119,9 @@ var (
 	taskBucket      = []byte("tasksv1")
 	taskRunBucket   = []byte("taskRunsv1")
 	taskIndexBucket = []byte("taskIndexsv1")
+
+	taskRevisionBucket = []byte("taskRevisionsv1")
+	taskUpstreamBucket = []byte("taskUpstreamSuccessesv1")
 )
 
 var _ influxdb.TaskService = (*Service)(nil)
@@ -124,6 +137,12 @@ func (s *Service) initializeTasks(ctx context.Context, tx Tx) error {
 	if _, err := tx.Bucket(taskIndexBucket); err != nil {
 		return err
 	}
+	if _, err := tx.Bucket(taskRevisionBucket); err != nil {
+		return err
+	}
+	if _, err := tx.Bucket(taskUpstreamBucket); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -199,6 +218,31 @@ func (s *Service) FindTasks(ctx context.Context, filter influxdb.TaskFilter) ([]
 	return ts, len(ts), nil
 }
 
+// validateDependsOn checks the tasks that task depends on against the other tasks of its organization.
+func (s *Service) validateDependsOn(ctx context.Context, tx Tx, task *influxdb.Task) error {
+	if len(task.DependsOn) == 0 {
+		return nil
+	}
+
+	var tasks []*influxdb.Task
+	filter := influxdb.TaskFilter{
+		OrganizationID: &task.OrganizationID,
+		Limit:          influxdb.TaskMaxPageSize,
+	}
+	for {
+		ts, _, err := s.findTasks(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		tasks = append(tasks, ts...)
+		if len(ts) < filter.Limit {
+			break
+		}
+		filter.After = &ts[len(ts)-1].ID
+	}
+	return backend.ValidateDependsOn(task, tasks)
+}
+
 func (s *Service) findTasks(ctx context.Context, tx Tx, filter influxdb.TaskFilter) ([]*influxdb.Task, int, error) {
 	if filter.User == nil && filter.OrganizationID == nil && filter.Organization == "" {
 		return nil, 0, errors.New("find tasks requires filtering by org or user")
@@ -402,9 +446,27 @@ func (s *Service) createTask(ctx context.Context, tx Tx, tc influxdb.TaskCreate)
 		Every:           opt.Every.String(),
 		Cron:            opt.Cron,
 		Offset:          opt.Offset.String(),
+		DependsOn:       opt.DependsOn,
+		Location:        opt.Location,
+		Revision:        1,
 		CreatedAt:       time.Now().UTC().Format(time.RFC3339),
 	}
 
+	if err := s.validateDependsOn(ctx, tx, task); err != nil {
+		return nil, err
+	}
+
+	if err := s.putTaskRevision(ctx, tx, &influxdb.TaskRevision{
+		TaskID:          task.ID,
+		Revision:        task.Revision,
+		Flux:            task.Flux,
+		AuthorizationID: task.AuthorizationID,
+		AuthorID:        userAuth.GetUserID(),
+		CreatedAt:       task.CreatedAt,
+	}); err != nil {
+		return nil, err
+	}
+
 	taskBucket, err := tx.Bucket(taskBucket)
 	if err != nil {
 		return nil, ErrUnexpectedTaskBucketErr(err)
@@ -477,6 +539,7 @@ func (s *Service) updateTask(ctx context.Context, tx Tx, id influxdb.ID, upd inf
 	if err != nil {
 		return nil, err
 	}
+	oldFlux, oldAuthz := task.Flux, task.AuthorizationID
 
 	// update the flux script
 	if !upd.Options.IsZero() || upd.Flux != nil {
@@ -495,9 +558,14 @@ func (s *Service) updateTask(ctx context.Context, tx Tx, id influxdb.ID, upd inf
 		if options.Offset != nil {
 			task.Offset = options.Offset.String()
 		}
+		task.DependsOn = options.DependsOn
+		task.Location = options.Location
+		if err := s.validateDependsOn(ctx, tx, task); err != nil {
+			return nil, err
+		}
 	}
 
-	// update the Token
+	// update the authorization
 	if upd.Token != "" {
 		auth, err := s.findAuthorizationByToken(ctx, tx, upd.Token)
 		if err != nil {
@@ -511,6 +579,38 @@ func (s *Service) updateTask(ctx context.Context, tx Tx, id influxdb.ID, upd inf
 	}
 
 	task.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+
+	// record a revision if the script or authorization changed
+	if task.Flux != oldFlux || task.AuthorizationID != oldAuthz {
+		if task.Revision == 0 {
+			// The task was stored before revisions were recorded; keep what it was before this update.
+			task.Revision = 1
+			if err := s.putTaskRevision(ctx, tx, &influxdb.TaskRevision{
+				TaskID:          task.ID,
+				Revision:        task.Revision,
+				Flux:            oldFlux,
+				AuthorizationID: oldAuthz,
+			}); err != nil {
+				return nil, err
+			}
+		}
+
+		task.Revision++
+		rev := &influxdb.TaskRevision{
+			TaskID:          task.ID,
+			Revision:        task.Revision,
+			Flux:            task.Flux,
+			AuthorizationID: task.AuthorizationID,
+			CreatedAt:       task.UpdatedAt,
+		}
+		if userAuth, err := icontext.GetAuthorizer(ctx); err == nil {
+			rev.AuthorID = userAuth.GetUserID()
+		}
+		if err := s.putTaskRevision(ctx, tx, rev); err != nil {
+			return nil, err
+		}
+	}
+
 	// save the updated task
 	bucket, err := tx.Bucket(taskBucket)
 	if err != nil {
@@ -612,9 +712,175 @@ func (s *Service) deleteTask(ctx context.Context, tx Tx, id influxdb.ID) error {
 	if err := taskBucket.Delete(key); err != nil {
 		return ErrUnexpectedTaskBucketErr(err)
 	}
+
+	// remove the revisions
+	revisionBucket, err := tx.Bucket(taskRevisionBucket)
+	if err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+	for rev := 1; rev <= task.Revision; rev++ {
+		key, err := taskRevisionKey(task.ID, rev)
+		if err != nil {
+			return err
+		}
+
+		if err := revisionBucket.Delete(key); err != nil {
+			return ErrUnexpectedTaskBucketErr(err)
+		}
+	}
+
+	// remove the upstream successes
+	upstreamBucket, err := tx.Bucket(taskUpstreamBucket)
+	if err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+	keys, err := taskUpstreamKeys(upstreamBucket, task.ID)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := upstreamBucket.Delete(key); err != nil {
+			return ErrUnexpectedTaskBucketErr(err)
+		}
+	}
+
+	// remove the lease
+	return s.deleteTaskLease(ctx, tx, task.ID)
+}
+
+// FindTaskRevisions returns the revisions of a task, oldest first.
+func (s *Service) FindTaskRevisions(ctx context.Context, taskID influxdb.ID) ([]*influxdb.TaskRevision, error) {
+	var revs []*influxdb.TaskRevision
+	err := s.kv.View(ctx, func(tx Tx) error {
+		task, err := s.findTaskByID(ctx, tx, taskID)
+		if err != nil {
+			return err
+		}
+
+		revs = make([]*influxdb.TaskRevision, 0, task.Revision)
+		for rev := 1; rev <= task.Revision; rev++ {
+			r, err := s.findTaskRevision(ctx, tx, taskID, rev)
+			if err != nil {
+				return err
+			}
+			revs = append(revs, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return revs, nil
+}
+
+// FindTaskRevision returns a single revision of a task.
+func (s *Service) FindTaskRevision(ctx context.Context, taskID influxdb.ID, revision int) (*influxdb.TaskRevision, error) {
+	var r *influxdb.TaskRevision
+	err := s.kv.View(ctx, func(tx Tx) error {
+		if _, err := s.findTaskByID(ctx, tx, taskID); err != nil {
+			return err
+		}
+
+		rev, err := s.findTaskRevision(ctx, tx, taskID, revision)
+		if err != nil {
+			return err
+		}
+		r = rev
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (s *Service) findTaskRevision(ctx context.Context, tx Tx, taskID influxdb.ID, revision int) (*influxdb.TaskRevision, error) {
+	b, err := tx.Bucket(taskRevisionBucket)
+	if err != nil {
+		return nil, ErrUnexpectedTaskBucketErr(err)
+	}
+
+	key, err := taskRevisionKey(taskID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := b.Get(key)
+	if IsNotFound(err) {
+		return nil, backend.ErrTaskRevisionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rev := &influxdb.TaskRevision{}
+	if err := json.Unmarshal(v, rev); err != nil {
+		return nil, ErrInternalTaskServiceError(err)
+	}
+	return rev, nil
+}
+
+// putTaskRevision stores a revision of a task, after setting its options from its script.
+func (s *Service) putTaskRevision(ctx context.Context, tx Tx, rev *influxdb.TaskRevision) error {
+	if err := rev.SetOptions(); err != nil {
+		return ErrTaskOptionParse(err)
+	}
+
+	b, err := tx.Bucket(taskRevisionBucket)
+	if err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+
+	key, err := taskRevisionKey(rev.TaskID, rev.Revision)
+	if err != nil {
+		return err
+	}
+
+	revBytes, err := json.Marshal(rev)
+	if err != nil {
+		return ErrInternalTaskServiceError(err)
+	}
+
+	if err := b.Put(key, revBytes); err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
 	return nil
 }
 
+// RollbackTask restores the script of a task from one of its revisions.
+// The task keeps its current authorization.
+func (s *Service) RollbackTask(ctx context.Context, taskID influxdb.ID, revision int) (*influxdb.Task, error) {
+	var t *influxdb.Task
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		task, err := s.rollbackTask(ctx, tx, taskID, revision)
+		if err != nil {
+			return err
+		}
+		t = task
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *Service) rollbackTask(ctx context.Context, tx Tx, taskID influxdb.ID, revision int) (*influxdb.Task, error) {
+	if _, err := s.findTaskByID(ctx, tx, taskID); err != nil {
+		return nil, err
+	}
+
+	rev, err := s.findTaskRevision(ctx, tx, taskID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updateTask(ctx, tx, taskID, influxdb.TaskUpdate{Flux: &rev.Flux})
+}
+
 // FindLogs returns logs for a run.
 func (s *Service) FindLogs(ctx context.Context, filter influxdb.LogFilter) ([]*influxdb.Log, int, error) {
 	var logs []*influxdb.Log
@@ -1024,6 +1290,11 @@ func (s *Service) createNextRun(ctx context.Context, tx Tx, taskID influxdb.ID,
 		return rc, nil
 	}
 
+	if task.EffectiveCron() == "" {
+		// A task depending on other tasks only runs when they queue its runs.
+		return backend.RunCreation{}, backend.RunNotYetDueError{DueAt: math.MaxInt64}
+	}
+
 	// get the latest completed and the latest currently running run's time
 	// the earliest it could have been completed is "created at"
 	latestCompleted, err := time.Parse(time.RFC3339, task.CreatedAt)
@@ -1047,7 +1318,7 @@ func (s *Service) createNextRun(ctx context.Context, tx Tx, taskID influxdb.ID,
 	}
 
 	// create a run if possible
-	sch, err := cron.Parse(task.EffectiveCron())
+	sch, err := options.ParseSchedule(task.EffectiveCron())
 	if err != nil {
 		return backend.RunCreation{}, ErrTaskTimeParse(err)
 	}
@@ -1147,7 +1418,7 @@ func (s *Service) currentlyRunning(ctx context.Context, tx Tx, taskID influxdb.I
 		if k == nil || !strings.HasPrefix(string(k), string(taskKey)) {
 			break
 		}
-		if strings.HasSuffix(string(k), "manualRuns") || strings.HasSuffix(string(k), "latestCompleted") {
+		if strings.HasSuffix(string(k), "manualRuns") || strings.HasSuffix(string(k), "latestCompleted") || strings.HasSuffix(string(k), "/try") {
 			k, v = c.Next()
 			continue
 		}
@@ -1273,10 +1544,213 @@ func (s *Service) finishRun(ctx context.Context, tx Tx, taskID, runID influxdb.I
 	if err := bucket.Delete(key); err != nil {
 		return nil, ErrUnexpectedTaskBucketErr(err)
 	}
+	if err := bucket.Delete(taskRunTryKey(key)); err != nil {
+		return nil, ErrUnexpectedTaskBucketErr(err)
+	}
 
 	return r, nil
 }
 
+// IncrementRunTry records another attempt at a currently running run and returns the attempt number.
+func (s *Service) IncrementRunTry(ctx context.Context, taskID, runID influxdb.ID) (uint32, error) {
+	var try uint32
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		t, err := s.incrementRunTry(ctx, tx, taskID, runID)
+		if err != nil {
+			return err
+		}
+		try = t
+		return nil
+	})
+	return try, err
+}
+
+func (s *Service) incrementRunTry(ctx context.Context, tx Tx, taskID, runID influxdb.ID) (uint32, error) {
+	// the run must be currently running
+	if _, err := s.findRunByID(ctx, tx, taskID, runID); err != nil {
+		return 0, err
+	}
+
+	bucket, err := tx.Bucket(taskRunBucket)
+	if err != nil {
+		return 0, ErrUnexpectedTaskBucketErr(err)
+	}
+	key, err := taskRunKey(taskID, runID)
+	if err != nil {
+		return 0, err
+	}
+	tryKey := taskRunTryKey(key)
+
+	// runs that were never retried have no try stored and are on their first try
+	try := uint32(1)
+	tryBytes, err := bucket.Get(tryKey)
+	if err != nil && err != ErrKeyNotFound {
+		return 0, ErrUnexpectedTaskBucketErr(err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(tryBytes, &try); err != nil {
+			return 0, ErrInternalTaskServiceError(err)
+		}
+	}
+	try++
+
+	tryBytes, err = json.Marshal(try)
+	if err != nil {
+		return 0, ErrInternalTaskServiceError(err)
+	}
+	if err := bucket.Put(tryKey, tryBytes); err != nil {
+		return 0, ErrUnexpectedTaskBucketErr(err)
+	}
+	return try, nil
+}
+
+// AddUpstreamSuccess records that an upstream task succeeded for a run time of a task,
+// and returns the upstream tasks that succeeded for that time.
+func (s *Service) AddUpstreamSuccess(ctx context.Context, taskID, upstreamID influxdb.ID, now int64) ([]influxdb.ID, error) {
+	var ids []influxdb.ID
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		i, err := s.addUpstreamSuccess(ctx, tx, taskID, upstreamID, now)
+		if err != nil {
+			return err
+		}
+		ids = i
+		return nil
+	})
+	return ids, err
+}
+
+func (s *Service) addUpstreamSuccess(ctx context.Context, tx Tx, taskID, upstreamID influxdb.ID, now int64) ([]influxdb.ID, error) {
+	if _, err := s.findTaskByID(ctx, tx, taskID); err != nil {
+		return nil, err
+	}
+
+	bucket, err := tx.Bucket(taskUpstreamBucket)
+	if err != nil {
+		return nil, ErrUnexpectedTaskBucketErr(err)
+	}
+	key, err := taskUpstreamKey(taskID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []influxdb.ID
+	idsBytes, err := bucket.Get(key)
+	if err != nil && !IsNotFound(err) {
+		return nil, ErrUnexpectedTaskBucketErr(err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(idsBytes, &ids); err != nil {
+			return nil, ErrInternalTaskServiceError(err)
+		}
+	}
+	for _, id := range ids {
+		if id == upstreamID {
+			return ids, nil
+		}
+	}
+	ids = append(ids, upstreamID)
+
+	idsBytes, err = json.Marshal(ids)
+	if err != nil {
+		return nil, ErrInternalTaskServiceError(err)
+	}
+	if err := bucket.Put(key, idsBytes); err != nil {
+		return nil, ErrUnexpectedTaskBucketErr(err)
+	}
+
+	// forget the oldest run time, if there are too many
+	keys, err := taskUpstreamKeys(bucket, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) > backend.MaxPendingUpstreamRuns {
+		if err := bucket.Delete(keys[0]); err != nil {
+			return nil, ErrUnexpectedTaskBucketErr(err)
+		}
+	}
+	return ids, nil
+}
+
+// DeleteUpstreamSuccesses forgets the upstream tasks that succeeded for a run time of a task.
+func (s *Service) DeleteUpstreamSuccesses(ctx context.Context, taskID influxdb.ID, now int64) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		bucket, err := tx.Bucket(taskUpstreamBucket)
+		if err != nil {
+			return ErrUnexpectedTaskBucketErr(err)
+		}
+		key, err := taskUpstreamKey(taskID, now)
+		if err != nil {
+			return err
+		}
+		if err := bucket.Delete(key); err != nil {
+			return ErrUnexpectedTaskBucketErr(err)
+		}
+		return nil
+	})
+}
+
+// SkipMissedRuns advances the latest completed run of a task, so that at most maxRuns of the runs due no later than now remain to be created.
+func (s *Service) SkipMissedRuns(ctx context.Context, taskID influxdb.ID, now int64, maxRuns int) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.skipMissedRuns(ctx, tx, taskID, now, maxRuns)
+	})
+}
+
+func (s *Service) skipMissedRuns(ctx context.Context, tx Tx, taskID influxdb.ID, now int64, maxRuns int) error {
+	task, err := s.findTaskByID(ctx, tx, taskID)
+	if err != nil {
+		return err
+	}
+
+	// the earliest the latest run could have been completed is "created at"
+	latestCompleted, err := time.Parse(time.RFC3339, task.CreatedAt)
+	if err != nil {
+		return ErrTaskTimeParse(err)
+	}
+
+	lRun, err := s.findLatestCompleted(ctx, tx, taskID)
+	if err != nil {
+		return err
+	}
+	if lRun != nil {
+		runTime, err := lRun.ScheduledForTime()
+		if err != nil {
+			return err
+		}
+		if runTime.After(latestCompleted) {
+			latestCompleted = runTime
+		}
+	} else {
+		lRun = &influxdb.Run{TaskID: taskID}
+	}
+
+	lc, err := backend.CatchupLatestCompleted(task.EffectiveCron(), task.Offset, latestCompleted.Unix(), now, maxRuns)
+	if err != nil {
+		return ErrTaskTimeParse(err)
+	}
+	if lc <= latestCompleted.Unix() {
+		return nil
+	}
+
+	lRun.ScheduledFor = time.Unix(lc, 0).UTC().Format(time.RFC3339)
+	rb, err := json.Marshal(lRun)
+	if err != nil {
+		return ErrInternalTaskServiceError(err)
+	}
+	bucket, err := tx.Bucket(taskRunBucket)
+	if err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+	lKey, err := taskLatestCompletedKey(taskID)
+	if err != nil {
+		return err
+	}
+	if err := bucket.Put(lKey, rb); err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+	return nil
+}
+
 // NextDueRun returns the Unix timestamp of when the next call to CreateNextRun will be ready.
 // The returned timestamp reflects the task's offset, so it does not necessarily exactly match the schedule time.
 func (s *Service) NextDueRun(ctx context.Context, taskID influxdb.ID) (int64, error) {
@@ -1301,6 +1775,9 @@ func (s *Service) nextDueRun(ctx context.Context, tx Tx, taskID influxdb.ID) (in
 	if err != nil {
 		return 0, err
 	}
+	if task.EffectiveCron() == "" {
+		return math.MaxInt64, nil
+	}
 
 	latestCompleted, err := s.findLatestCompletedTime(ctx, tx, taskID)
 	if err != nil {
@@ -1315,7 +1792,7 @@ func (s *Service) nextDueRun(ctx context.Context, tx Tx, taskID influxdb.ID) (in
 	}
 
 	// create a run if possible
-	sch, err := cron.Parse(task.EffectiveCron())
+	sch, err := options.ParseSchedule(task.EffectiveCron())
 	if err != nil {
 		return 0, ErrTaskTimeParse(err)
 	}
@@ -1348,6 +1825,13 @@ func (s *Service) updateRunState(ctx context.Context, tx Tx, taskID, runID influ
 	switch state {
 	case backend.RunStarted:
 		run.StartedAt = when.UTC().Format(time.RFC3339Nano)
+
+		// record the revision of the task the run executes
+		task, err := s.findTaskByID(ctx, tx, taskID)
+		if err != nil {
+			return err
+		}
+		run.TaskRevision = task.Revision
 	case backend.RunSuccess, backend.RunFail, backend.RunCanceled:
 		run.FinishedAt = when.UTC().Format(time.RFC3339Nano)
 	}
@@ -1418,6 +1902,48 @@ func (s *Service) addRunLog(ctx context.Context, tx Tx, taskID, runID influxdb.I
 	return nil
 }
 
+// UpdateRunStatistics sets the statistics of the execution of the run.
+func (s *Service) UpdateRunStatistics(ctx context.Context, taskID, runID influxdb.ID, when time.Time, stats influxdb.RunStatistics) error {
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		err := s.updateRunStatistics(ctx, tx, taskID, runID, stats)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	return err
+}
+
+func (s *Service) updateRunStatistics(ctx context.Context, tx Tx, taskID, runID influxdb.ID, stats influxdb.RunStatistics) error {
+	// find run
+	run, err := s.findRunByID(ctx, tx, taskID, runID)
+	if err != nil {
+		return err
+	}
+	run.Statistics = &stats
+	// save run
+	b, err := tx.Bucket(taskRunBucket)
+	if err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+
+	runBytes, err := json.Marshal(run)
+	if err != nil {
+		return ErrInternalTaskServiceError(err)
+	}
+
+	runKey, err := taskRunKey(taskID, run.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := b.Put(runKey, runBytes); err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+
+	return nil
+}
+
 func (s *Service) findLatestCompleted(ctx context.Context, tx Tx, id influxdb.ID) (*influxdb.Run, error) {
 	bucket, err := tx.Bucket(taskRunBucket)
 	if err != nil {
@@ -1493,6 +2019,49 @@ func taskOrgKey(orgID, taskID influxdb.ID) ([]byte, error) {
 	return []byte(string(encodedOrgID) + "/" + string(encodedID)), nil
 }
 
+func taskRevisionKey(taskID influxdb.ID, revision int) ([]byte, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return nil, ErrInvalidTaskID
+	}
+	return []byte(string(encodedID) + "/" + strconv.Itoa(revision)), nil
+}
+
+func taskUpstreamKey(taskID influxdb.ID, now int64) ([]byte, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return nil, ErrInvalidTaskID
+	}
+	k := make([]byte, len(encodedID)+1+8)
+	copy(k, encodedID)
+	k[len(encodedID)] = '/'
+	binary.BigEndian.PutUint64(k[len(encodedID)+1:], uint64(now))
+	return k, nil
+}
+
+// taskUpstreamKeys returns the keys of the upstream successes of a task, oldest run time first.
+func taskUpstreamKeys(bucket Bucket, taskID influxdb.ID) ([][]byte, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return nil, ErrInvalidTaskID
+	}
+	prefix := append(encodedID, '/')
+
+	cur, err := bucket.Cursor()
+	if err != nil {
+		return nil, ErrUnexpectedTaskBucketErr(err)
+	}
+	var keys [][]byte
+	for k, _ := cur.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cur.Next() {
+		keys = append(keys, append([]byte(nil), k...))
+	}
+	return keys, nil
+}
+
+func taskRunTryKey(runKey []byte) []byte {
+	return []byte(string(runKey) + "/try")
+}
+
 func taskRunKey(taskID, runID influxdb.ID) ([]byte, error) {
 	encodedID, err := taskID.Encode()
 	if err != nil {
@@ -0,0 +1,152 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/task/backend"
+)
+
+// Task Lease Storage Schema
+// taskLeaseBucket:
+//   <taskID>: JSON encoded backend.TaskLease, absent if the task is not leased
+//
+// The leases do not depend on the tasks stored in this service, so that they can be shared
+// by nodes keeping their tasks in another store.
+
+var (
+	taskLeaseBucket = []byte("taskLeasesv1")
+)
+
+var _ backend.TaskLeaser = (*Service)(nil)
+
+func (s *Service) initializeTaskLeases(ctx context.Context, tx Tx) error {
+	_, err := s.taskLeaseBucket(tx)
+	return err
+}
+
+func (s *Service) taskLeaseBucket(tx Tx) (Bucket, error) {
+	b, err := tx.Bucket(taskLeaseBucket)
+	if err != nil {
+		return nil, ErrUnexpectedTaskBucketErr(err)
+	}
+
+	return b, nil
+}
+
+// ClaimTaskLease grants owner the lease of a task until expiresAt, unless another owner holds a lease that has not expired.
+func (s *Service) ClaimTaskLease(ctx context.Context, taskID influxdb.ID, owner string, now, expiresAt int64) (*backend.TaskLease, error) {
+	l := &backend.TaskLease{Owner: owner, ExpiresAt: expiresAt}
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		cur, err := s.findTaskLease(ctx, tx, taskID)
+		if err != nil {
+			return err
+		}
+		if cur.Owner != "" && cur.Owner != owner && cur.ExpiresAt > now {
+			return backend.ErrTaskLeaseHeld
+		}
+
+		return s.putTaskLease(ctx, tx, taskID, l)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// ReleaseTaskLease ends the lease of owner on a task.
+func (s *Service) ReleaseTaskLease(ctx context.Context, taskID influxdb.ID, owner string) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		cur, err := s.findTaskLease(ctx, tx, taskID)
+		if err != nil {
+			return err
+		}
+		if cur.Owner == "" {
+			return nil
+		}
+		if cur.Owner != owner {
+			return backend.ErrTaskLeaseHeld
+		}
+
+		return s.deleteTaskLease(ctx, tx, taskID)
+	})
+}
+
+// FindTaskLease returns the lease of a task.
+func (s *Service) FindTaskLease(ctx context.Context, taskID influxdb.ID) (*backend.TaskLease, error) {
+	var l *backend.TaskLease
+	err := s.kv.View(ctx, func(tx Tx) error {
+		var err error
+		l, err = s.findTaskLease(ctx, tx, taskID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (s *Service) findTaskLease(ctx context.Context, tx Tx, taskID influxdb.ID) (*backend.TaskLease, error) {
+	key, err := taskKey(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.taskLeaseBucket(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &backend.TaskLease{}
+	v, err := b.Get(key)
+	if IsNotFound(err) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, ErrUnexpectedTaskBucketErr(err)
+	}
+
+	if err := json.Unmarshal(v, l); err != nil {
+		return nil, ErrInternalTaskServiceError(err)
+	}
+	return l, nil
+}
+
+func (s *Service) putTaskLease(ctx context.Context, tx Tx, taskID influxdb.ID, l *backend.TaskLease) error {
+	key, err := taskKey(taskID)
+	if err != nil {
+		return err
+	}
+
+	b, err := s.taskLeaseBucket(tx)
+	if err != nil {
+		return err
+	}
+
+	v, err := json.Marshal(l)
+	if err != nil {
+		return ErrInternalTaskServiceError(err)
+	}
+	if err := b.Put(key, v); err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+	return nil
+}
+
+func (s *Service) deleteTaskLease(ctx context.Context, tx Tx, taskID influxdb.ID) error {
+	key, err := taskKey(taskID)
+	if err != nil {
+		return err
+	}
+
+	b, err := s.taskLeaseBucket(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := b.Delete(key); err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+	return nil
+}
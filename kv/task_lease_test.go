@@ -0,0 +1,87 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/task/backend"
+)
+
+func TestInmemTaskLeases(t *testing.T) {
+	store, close, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	testTaskLeases(t, store)
+}
+
+func TestBoltTaskLeases(t *testing.T) {
+	store, close, err := NewTestBoltStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	testTaskLeases(t, store)
+}
+
+func testTaskLeases(t *testing.T, store kv.Store) {
+	ctx := context.Background()
+	// Each node has its own service on the shared store.
+	a, b := kv.NewService(store), kv.NewService(store)
+	for _, s := range []*kv.Service{a, b} {
+		if err := s.Initialize(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	id := influxdb.ID(1)
+
+	if l, err := a.FindTaskLease(ctx, id); err != nil || l.Owner != "" {
+		t.Fatalf("expected new task not to be leased, got %#v, %v", l, err)
+	}
+
+	l, err := a.ClaimTaskLease(ctx, id, "a", 100, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Owner != "a" || l.ExpiresAt != 200 {
+		t.Fatalf("unexpected lease: %#v", l)
+	}
+
+	if _, err := b.ClaimTaskLease(ctx, id, "b", 150, 250); err != backend.ErrTaskLeaseHeld {
+		t.Fatalf("expected %v claiming a held lease, got %v", backend.ErrTaskLeaseHeld, err)
+	}
+	if err := b.ReleaseTaskLease(ctx, id, "b"); err != backend.ErrTaskLeaseHeld {
+		t.Fatalf("expected %v releasing a lease held by another owner, got %v", backend.ErrTaskLeaseHeld, err)
+	}
+
+	// The owner renews its lease.
+	if l, err := a.ClaimTaskLease(ctx, id, "a", 150, 300); err != nil || l.ExpiresAt != 300 {
+		t.Fatalf("expected lease to be renewed until 300, got %#v, %v", l, err)
+	}
+
+	// Another owner claims the lease once it expired.
+	if _, err := b.ClaimTaskLease(ctx, id, "b", 300, 400); err != nil {
+		t.Fatal(err)
+	}
+	if l, err := a.FindTaskLease(ctx, id); err != nil || l.Owner != "b" || l.ExpiresAt != 400 {
+		t.Fatalf("expected lease held by b until 400, got %#v, %v", l, err)
+	}
+	if _, err := a.ClaimTaskLease(ctx, id, "a", 350, 450); err != backend.ErrTaskLeaseHeld {
+		t.Fatalf("expected %v renewing a lost lease, got %v", backend.ErrTaskLeaseHeld, err)
+	}
+
+	if err := b.ReleaseTaskLease(ctx, id, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ReleaseTaskLease(ctx, id, "b"); err != nil {
+		t.Fatalf("expected releasing a lease that is not held to succeed, got %v", err)
+	}
+	if _, err := a.ClaimTaskLease(ctx, id, "a", 350, 450); err != nil {
+		t.Fatalf("expected released lease to be claimable, got %v", err)
+	}
+}
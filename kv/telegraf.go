@@ -207,10 +207,15 @@ func (s *Service) CreateTelegrafConfig(ctx context.Context, tc *influxdb.Telegra
 
 func (s *Service) createTelegrafConfig(ctx context.Context, tx Tx, tc *influxdb.TelegrafConfig, userID influxdb.ID) error {
 	tc.ID = s.IDGenerator.ID()
+	tc.Revision = 1
 	if err := s.putTelegrafConfig(ctx, tx, tc); err != nil {
 		return err
 	}
 
+	if err := s.putTelegrafConfigRevision(ctx, tx, tc, userID); err != nil {
+		return err
+	}
+
 	urm := &influxdb.UserResourceMapping{
 		ResourceID:   tc.ID,
 		UserID:       userID,
@@ -240,8 +245,22 @@ func (s *Service) updateTelegrafConfig(ctx context.Context, tx Tx, id influxdb.I
 	// ID and OrganizationID can not be updated
 	tc.ID = current.ID
 	tc.OrganizationID = current.OrganizationID
-	err = s.putTelegrafConfig(ctx, tx, tc)
-	return tc, err
+	// Only changes of the config the agents are given record a revision.
+	changed := tc.TOML() != current.TOML()
+	tc.Revision = current.Revision
+	if changed {
+		tc.Revision++
+	}
+	if err := s.putTelegrafConfig(ctx, tx, tc); err != nil {
+		return nil, err
+	}
+
+	if changed {
+		if err := s.putTelegrafConfigRevision(ctx, tx, tc, userID); err != nil {
+			return nil, err
+		}
+	}
+	return tc, nil
 }
 
 // DeleteTelegrafConfig removes a telegraf config by ID.
@@ -274,6 +293,10 @@ func (s *Service) deleteTelegrafConfig(ctx context.Context, tx Tx, id influxdb.I
 		return UnavailableTelegrafServiceError(err)
 	}
 
+	if err := s.deleteTelegrafAgents(ctx, tx, id); err != nil {
+		return err
+	}
+
 	return s.deleteUserResourceMappings(ctx, tx, influxdb.UserResourceMappingFilter{
 		ResourceID:   id,
 		ResourceType: influxdb.TelegrafsResourceType,
@@ -0,0 +1,292 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+)
+
+var (
+	telegrafRevisionsBucket = []byte("telegrafrevisionsv1")
+	telegrafAgentsBucket    = []byte("telegrafagentsv1")
+)
+
+var _ influxdb.TelegrafAgentService = (*Service)(nil)
+
+func (s *Service) initializeTelegrafAgents(ctx context.Context, tx Tx) error {
+	if _, err := s.telegrafRevisionsBucket(tx); err != nil {
+		return err
+	}
+	if _, err := s.telegrafAgentsBucket(tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *Service) telegrafRevisionsBucket(tx Tx) (Bucket, error) {
+	b, err := tx.Bucket(telegrafRevisionsBucket)
+	if err != nil {
+		return nil, UnavailableTelegrafServiceError(err)
+	}
+	return b, nil
+}
+
+func (s *Service) telegrafAgentsBucket(tx Tx) (Bucket, error) {
+	b, err := tx.Bucket(telegrafAgentsBucket)
+	if err != nil {
+		return nil, UnavailableTelegrafServiceError(err)
+	}
+	return b, nil
+}
+
+// telegrafRevisionKey is the ID of the telegraf config followed by the big endian revision,
+// so that the revisions of a config are sorted.
+func telegrafRevisionKey(id influxdb.ID, revision int) ([]byte, error) {
+	encID, err := id.Encode()
+	if err != nil {
+		return nil, ErrInvalidTelegrafID
+	}
+	k := make([]byte, len(encID)+8)
+	copy(k, encID)
+	binary.BigEndian.PutUint64(k[len(encID):], uint64(revision))
+	return k, nil
+}
+
+func telegrafAgentKey(id influxdb.ID, hostname string) ([]byte, error) {
+	encID, err := id.Encode()
+	if err != nil {
+		return nil, ErrInvalidTelegrafID
+	}
+	return append(encID, hostname...), nil
+}
+
+// CheckInTelegrafAgent records the check in of an agent, and returns the agent.
+func (s *Service) CheckInTelegrafAgent(ctx context.Context, c influxdb.TelegrafAgentCheckIn) (*influxdb.TelegrafAgent, error) {
+	if err := c.Valid(); err != nil {
+		return nil, err
+	}
+
+	var agent *influxdb.TelegrafAgent
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		tc, err := s.findTelegrafConfigByID(ctx, tx, c.TelegrafConfigID)
+		if err != nil {
+			return err
+		}
+
+		latest := tc.Revision
+		if c.Revision > latest {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("telegraf config has no revision %d, its latest revision is %d", c.Revision, latest),
+				Op:   influxdb.OpCheckInTelegrafAgent,
+			}
+		}
+
+		agent = &influxdb.TelegrafAgent{
+			TelegrafAgentCheckIn: c,
+			LastSeen:             s.time(),
+		}
+		if err := s.putTelegrafAgent(ctx, tx, agent); err != nil {
+			return err
+		}
+		agent.Stale = c.Revision < latest
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return agent, nil
+}
+
+func (s *Service) putTelegrafAgent(ctx context.Context, tx Tx, agent *influxdb.TelegrafAgent) error {
+	k, err := telegrafAgentKey(agent.TelegrafConfigID, agent.Hostname)
+	if err != nil {
+		return err
+	}
+
+	v, err := json.Marshal(agent)
+	if err != nil {
+		return ErrUnprocessableTelegraf(err)
+	}
+
+	bucket, err := s.telegrafAgentsBucket(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := bucket.Put(k, v); err != nil {
+		return UnavailableTelegrafServiceError(err)
+	}
+	return nil
+}
+
+// FindTelegrafAgents returns the agents that checked in for the telegraf config id, by hostname.
+func (s *Service) FindTelegrafAgents(ctx context.Context, id influxdb.ID) ([]*influxdb.TelegrafAgent, error) {
+	var agents []*influxdb.TelegrafAgent
+	err := s.kv.View(ctx, func(tx Tx) error {
+		tc, err := s.findTelegrafConfigByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		agents, err = s.findTelegrafAgents(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		for _, agent := range agents {
+			agent.Stale = agent.Revision < tc.Revision
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return agents, nil
+}
+
+func (s *Service) findTelegrafAgents(ctx context.Context, tx Tx, id influxdb.ID) ([]*influxdb.TelegrafAgent, error) {
+	prefix, err := id.Encode()
+	if err != nil {
+		return nil, ErrInvalidTelegrafID
+	}
+
+	bucket, err := s.telegrafAgentsBucket(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	cur, err := bucket.Cursor()
+	if err != nil {
+		return nil, InternalTelegrafServiceError(err)
+	}
+
+	agents := []*influxdb.TelegrafAgent{}
+	for k, v := cur.Seek(prefix); bytes.HasPrefix(k, prefix); k, v = cur.Next() {
+		agent := &influxdb.TelegrafAgent{}
+		if err := json.Unmarshal(v, agent); err != nil {
+			return nil, CorruptTelegrafError(err)
+		}
+		agents = append(agents, agent)
+	}
+	return agents, nil
+}
+
+// FindTelegrafConfigRevisions returns the revisions of the telegraf config id, latest first.
+func (s *Service) FindTelegrafConfigRevisions(ctx context.Context, id influxdb.ID) ([]*influxdb.TelegrafConfigRevision, error) {
+	var revisions []*influxdb.TelegrafConfigRevision
+	err := s.kv.View(ctx, func(tx Tx) error {
+		if _, err := s.findTelegrafConfigByID(ctx, tx, id); err != nil {
+			return err
+		}
+
+		var err error
+		revisions, err = s.findTelegrafConfigRevisions(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(revisions)-1; i < j; i, j = i+1, j-1 {
+		revisions[i], revisions[j] = revisions[j], revisions[i]
+	}
+	return revisions, nil
+}
+
+// findTelegrafConfigRevisions returns the revisions of the telegraf config id, earliest first.
+func (s *Service) findTelegrafConfigRevisions(ctx context.Context, tx Tx, id influxdb.ID) ([]*influxdb.TelegrafConfigRevision, error) {
+	prefix, err := id.Encode()
+	if err != nil {
+		return nil, ErrInvalidTelegrafID
+	}
+
+	bucket, err := s.telegrafRevisionsBucket(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	cur, err := bucket.Cursor()
+	if err != nil {
+		return nil, InternalTelegrafServiceError(err)
+	}
+
+	revisions := []*influxdb.TelegrafConfigRevision{}
+	for k, v := cur.Seek(prefix); bytes.HasPrefix(k, prefix); k, v = cur.Next() {
+		r := &influxdb.TelegrafConfigRevision{}
+		if err := json.Unmarshal(v, r); err != nil {
+			return nil, CorruptTelegrafError(err)
+		}
+		revisions = append(revisions, r)
+	}
+	return revisions, nil
+}
+
+// putTelegrafConfigRevision stores tc as the revision tc.Revision of the telegraf config.
+func (s *Service) putTelegrafConfigRevision(ctx context.Context, tx Tx, tc *influxdb.TelegrafConfig, userID influxdb.ID) error {
+	r := &influxdb.TelegrafConfigRevision{
+		TelegrafConfigID: tc.ID,
+		Revision:         tc.Revision,
+		UserID:           userID,
+		CreatedAt:        s.time(),
+		TOML:             tc.TOML(),
+	}
+
+	k, err := telegrafRevisionKey(r.TelegrafConfigID, r.Revision)
+	if err != nil {
+		return err
+	}
+
+	v, err := json.Marshal(r)
+	if err != nil {
+		return ErrUnprocessableTelegraf(err)
+	}
+
+	bucket, err := s.telegrafRevisionsBucket(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := bucket.Put(k, v); err != nil {
+		return UnavailableTelegrafServiceError(err)
+	}
+	return nil
+}
+
+// deleteTelegrafAgents removes the revisions and the agents of the telegraf config id.
+func (s *Service) deleteTelegrafAgents(ctx context.Context, tx Tx, id influxdb.ID) error {
+	prefix, err := id.Encode()
+	if err != nil {
+		return ErrInvalidTelegrafID
+	}
+
+	revisions, err := s.telegrafRevisionsBucket(tx)
+	if err != nil {
+		return err
+	}
+	agents, err := s.telegrafAgentsBucket(tx)
+	if err != nil {
+		return err
+	}
+
+	for _, bucket := range []Bucket{revisions, agents} {
+		cur, err := bucket.Cursor()
+		if err != nil {
+			return InternalTelegrafServiceError(err)
+		}
+
+		var keys [][]byte
+		for k, _ := cur.Seek(prefix); bytes.HasPrefix(k, prefix); k, _ = cur.Next() {
+			keys = append(keys, append([]byte(nil), k...))
+		}
+		for _, k := range keys {
+			if err := bucket.Delete(k); err != nil {
+				return UnavailableTelegrafServiceError(err)
+			}
+		}
+	}
+	return nil
+}
@@ -2,10 +2,13 @@ package kv_test
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/telegraf/plugins/inputs"
 	influxdbtesting "github.com/influxdata/influxdb/testing"
 )
 
@@ -72,3 +75,132 @@ func initTelegrafService(s kv.Store, f influxdbtesting.TelegrafConfigFields, t *
 		}
 	}
 }
+
+func TestTelegrafAgents(t *testing.T) {
+	s, closeFn, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeFn()
+
+	svc := kv.NewService(s)
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	svc.WithTime(func() time.Time { return now })
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing kv service: %v", err)
+	}
+
+	userID := influxdbtesting.MustIDBase16("020f755c3c082002")
+	tc := &influxdb.TelegrafConfig{
+		OrganizationID: influxdbtesting.MustIDBase16("020f755c3c082000"),
+		Name:           "tc1",
+		Agent:          influxdb.TelegrafAgentConfig{Interval: 10000},
+		Plugins:        []influxdb.TelegrafPlugin{{Config: &inputs.CPUStats{}}},
+	}
+	if err := svc.CreateTelegrafConfig(ctx, tc, userID); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, hostname := range []string{"host1", "host2"} {
+		agent, err := svc.CheckInTelegrafAgent(ctx, influxdb.TelegrafAgentCheckIn{
+			TelegrafConfigID: tc.ID,
+			Hostname:         hostname,
+			Version:          "1.10.0",
+			Revision:         1,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if agent.Stale || !agent.LastSeen.Equal(now) {
+			t.Fatalf("expected agent %s seen at %s running the latest revision, got %+v", hostname, now, agent)
+		}
+	}
+
+	tc.Plugins = append(tc.Plugins, influxdb.TelegrafPlugin{Config: &inputs.MemStats{}})
+	if _, err := svc.UpdateTelegrafConfig(ctx, tc.ID, tc, userID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Renaming leaves the TOML the agents are given, and so the revision, as is.
+	tc.Name = "tc2"
+	updated, err := svc.UpdateTelegrafConfig(ctx, tc.ID, tc, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Revision != 2 {
+		t.Fatalf("expected the config to stay at revision 2, got %d", updated.Revision)
+	}
+	found, err := svc.FindTelegrafConfigByID(ctx, tc.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Revision != 2 {
+		t.Fatalf("expected the stored config at revision 2, got %d", found.Revision)
+	}
+
+	revisions, err := svc.FindTelegrafConfigRevisions(ctx, tc.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 2 || revisions[0].Revision != 2 || revisions[1].Revision != 1 {
+		t.Fatalf("expected revisions 2 and 1, got %+v", revisions)
+	}
+	if !strings.Contains(revisions[0].TOML, "[[inputs.mem]]") || strings.Contains(revisions[1].TOML, "[[inputs.mem]]") {
+		t.Fatalf("expected only revision 2 to have the mem input, got %+v", revisions)
+	}
+	if revisions[0].UserID != userID {
+		t.Fatalf("expected revision 2 to be created by %s, got %s", userID, revisions[0].UserID)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := svc.CheckInTelegrafAgent(ctx, influxdb.TelegrafAgentCheckIn{
+		TelegrafConfigID: tc.ID,
+		Hostname:         "host2",
+		Version:          "1.10.0",
+		Revision:         2,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	agents, err := svc.FindTelegrafAgents(ctx, tc.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(agents) != 2 {
+		t.Fatalf("expected 2 agents, got %+v", agents)
+	}
+	if agents[0].Hostname != "host1" || !agents[0].Stale || agents[0].Revision != 1 {
+		t.Fatalf("expected host1 to run the stale revision 1, got %+v", agents[0])
+	}
+	if agents[1].Hostname != "host2" || agents[1].Stale || !agents[1].LastSeen.Equal(now) {
+		t.Fatalf("expected host2 seen at %s running the latest revision, got %+v", now, agents[1])
+	}
+
+	if _, err := svc.CheckInTelegrafAgent(ctx, influxdb.TelegrafAgentCheckIn{
+		TelegrafConfigID: tc.ID,
+		Hostname:         "host1",
+		Revision:         3,
+	}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error for an unknown revision, got %v", err)
+	}
+	if _, err := svc.CheckInTelegrafAgent(ctx, influxdb.TelegrafAgentCheckIn{
+		TelegrafConfigID: tc.ID,
+		Revision:         1,
+	}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error for a missing hostname, got %v", err)
+	}
+
+	if err := svc.DeleteTelegrafConfig(ctx, tc.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.FindTelegrafAgents(ctx, tc.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if _, err := svc.CheckInTelegrafAgent(ctx, influxdb.TelegrafAgentCheckIn{
+		TelegrafConfigID: tc.ID,
+		Hostname:         "host1",
+	}); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
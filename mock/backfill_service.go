@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"context"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+var _ platform.BackfillService = (*BackfillService)(nil)
+
+type BackfillService struct {
+	CreateBackfillFn   func(context.Context, platform.BackfillCreate) (*platform.Backfill, error)
+	FindBackfillByIDFn func(context.Context, platform.ID, platform.ID) (*platform.Backfill, error)
+	FindBackfillsFn    func(context.Context, platform.ID) ([]*platform.Backfill, error)
+	CancelBackfillFn   func(context.Context, platform.ID, platform.ID) error
+}
+
+func (s *BackfillService) CreateBackfill(ctx context.Context, b platform.BackfillCreate) (*platform.Backfill, error) {
+	return s.CreateBackfillFn(ctx, b)
+}
+
+func (s *BackfillService) FindBackfillByID(ctx context.Context, taskID, id platform.ID) (*platform.Backfill, error) {
+	return s.FindBackfillByIDFn(ctx, taskID, id)
+}
+
+func (s *BackfillService) FindBackfills(ctx context.Context, taskID platform.ID) ([]*platform.Backfill, error) {
+	return s.FindBackfillsFn(ctx, taskID)
+}
+
+func (s *BackfillService) CancelBackfill(ctx context.Context, taskID, id platform.ID) error {
+	return s.CancelBackfillFn(ctx, taskID, id)
+}
@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+var _ platform.ScraperPreviewService = (*ScraperPreviewService)(nil)
+
+// ScraperPreviewService is a mock implementation of platform.ScraperPreviewService.
+type ScraperPreviewService struct {
+	PreviewTargetFn func(context.Context, platform.ScraperTarget) (*platform.ScraperPreview, error)
+}
+
+// PreviewTarget calls PreviewTargetFn.
+func (s *ScraperPreviewService) PreviewTarget(ctx context.Context, target platform.ScraperTarget) (*platform.ScraperPreview, error) {
+	return s.PreviewTargetFn(ctx, target)
+}
@@ -0,0 +1,31 @@
+package mock
+
+import (
+	"context"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+var _ platform.ScraperStatusService = (*ScraperStatusService)(nil)
+
+// ScraperStatusService is a mock implementation of platform.ScraperStatusService.
+type ScraperStatusService struct {
+	FindTargetStatusFn func(context.Context, platform.ID) (*platform.ScraperTargetStatus, error)
+	RecordScrapeFn     func(context.Context, platform.ID, platform.ScrapeResult) error
+	RetainInstancesFn  func(context.Context, platform.ID, []string) error
+}
+
+// FindTargetStatus calls FindTargetStatusFn.
+func (s *ScraperStatusService) FindTargetStatus(ctx context.Context, id platform.ID) (*platform.ScraperTargetStatus, error) {
+	return s.FindTargetStatusFn(ctx, id)
+}
+
+// RecordScrape calls RecordScrapeFn.
+func (s *ScraperStatusService) RecordScrape(ctx context.Context, id platform.ID, r platform.ScrapeResult) error {
+	return s.RecordScrapeFn(ctx, id, r)
+}
+
+// RetainInstances calls RetainInstancesFn.
+func (s *ScraperStatusService) RetainInstances(ctx context.Context, id platform.ID, instances []string) error {
+	return s.RetainInstancesFn(ctx, id, instances)
+}
@@ -20,6 +20,10 @@ type TaskService struct {
 	CancelRunFn    func(context.Context, platform.ID, platform.ID) error
 	RetryRunFn     func(context.Context, platform.ID, platform.ID) (*platform.Run, error)
 	ForceRunFn     func(context.Context, platform.ID, int64) (*platform.Run, error)
+
+	FindTaskRevisionsFn func(context.Context, platform.ID) ([]*platform.TaskRevision, error)
+	FindTaskRevisionFn  func(context.Context, platform.ID, int) (*platform.TaskRevision, error)
+	RollbackTaskFn      func(context.Context, platform.ID, int) (*platform.Task, error)
 }
 
 func (s *TaskService) FindTaskByID(ctx context.Context, id platform.ID) (*platform.Task, error) {
@@ -65,3 +69,15 @@ func (s *TaskService) RetryRun(ctx context.Context, taskID, runID platform.ID) (
 func (s *TaskService) ForceRun(ctx context.Context, taskID platform.ID, scheduledFor int64) (*platform.Run, error) {
 	return s.ForceRunFn(ctx, taskID, scheduledFor)
 }
+
+func (s *TaskService) FindTaskRevisions(ctx context.Context, taskID platform.ID) ([]*platform.TaskRevision, error) {
+	return s.FindTaskRevisionsFn(ctx, taskID)
+}
+
+func (s *TaskService) FindTaskRevision(ctx context.Context, taskID platform.ID, revision int) (*platform.TaskRevision, error) {
+	return s.FindTaskRevisionFn(ctx, taskID, revision)
+}
+
+func (s *TaskService) RollbackTask(ctx context.Context, taskID platform.ID, revision int) (*platform.Task, error) {
+	return s.RollbackTaskFn(ctx, taskID, revision)
+}
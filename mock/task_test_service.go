@@ -0,0 +1,17 @@
+package mock
+
+import (
+	"context"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+var _ platform.TaskTestService = (*TaskTestService)(nil)
+
+type TaskTestService struct {
+	TestTaskFn func(context.Context, platform.TaskTest) ([]*platform.TaskTestRun, error)
+}
+
+func (s *TaskTestService) TestTask(ctx context.Context, t platform.TaskTest) ([]*platform.TaskTestRun, error) {
+	return s.TestTaskFn(ctx, t)
+}
@@ -0,0 +1,31 @@
+package mock
+
+import (
+	"context"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+var _ platform.TelegrafAgentService = (*TelegrafAgentService)(nil)
+
+// TelegrafAgentService is a mock implementation of platform.TelegrafAgentService.
+type TelegrafAgentService struct {
+	CheckInTelegrafAgentFn        func(context.Context, platform.TelegrafAgentCheckIn) (*platform.TelegrafAgent, error)
+	FindTelegrafAgentsFn          func(context.Context, platform.ID) ([]*platform.TelegrafAgent, error)
+	FindTelegrafConfigRevisionsFn func(context.Context, platform.ID) ([]*platform.TelegrafConfigRevision, error)
+}
+
+// CheckInTelegrafAgent calls CheckInTelegrafAgentFn.
+func (s *TelegrafAgentService) CheckInTelegrafAgent(ctx context.Context, c platform.TelegrafAgentCheckIn) (*platform.TelegrafAgent, error) {
+	return s.CheckInTelegrafAgentFn(ctx, c)
+}
+
+// FindTelegrafAgents calls FindTelegrafAgentsFn.
+func (s *TelegrafAgentService) FindTelegrafAgents(ctx context.Context, id platform.ID) ([]*platform.TelegrafAgent, error) {
+	return s.FindTelegrafAgentsFn(ctx, id)
+}
+
+// FindTelegrafConfigRevisions calls FindTelegrafConfigRevisionsFn.
+func (s *TelegrafAgentService) FindTelegrafConfigRevisions(ctx context.Context, id platform.ID) ([]*platform.TelegrafConfigRevision, error) {
+	return s.FindTelegrafConfigRevisionsFn(ctx, id)
+}
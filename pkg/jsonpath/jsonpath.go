@@ -0,0 +1,95 @@
+// Package jsonpath evaluates a subset of JSONPath against decoded JSON documents:
+// the root $, the members .name and ['name'], and the array elements [n].
+package jsonpath // import "github.com/influxdata/influxdb/pkg/jsonpath"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// step selects a member of an object, or an element of an array.
+type step struct {
+	key   string
+	index int
+	array bool
+}
+
+// Path is a parsed JSONPath.
+type Path struct {
+	expr  string
+	steps []step
+}
+
+// String returns the expression the path was parsed from.
+func (p Path) String() string {
+	return p.expr
+}
+
+// Parse parses expr, like $.memory['heap.used'].values[0].
+func Parse(expr string) (Path, error) {
+	p := Path{expr: expr}
+	if !strings.HasPrefix(expr, "$") {
+		return p, fmt.Errorf("invalid JSONPath %q: must start with $", expr)
+	}
+
+	s := expr[1:]
+	for s != "" {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			n := strings.IndexAny(s, ".[")
+			if n == -1 {
+				n = len(s)
+			}
+			if n == 0 {
+				return p, fmt.Errorf("invalid JSONPath %q: empty member name", expr)
+			}
+			p.steps = append(p.steps, step{key: s[:n]})
+			s = s[n:]
+		case '[':
+			n := strings.IndexByte(s, ']')
+			if n == -1 {
+				return p, fmt.Errorf("invalid JSONPath %q: unterminated [", expr)
+			}
+			sel := s[1:n]
+			s = s[n+1:]
+			if len(sel) >= 2 && (sel[0] == '\'' || sel[0] == '"') && sel[len(sel)-1] == sel[0] {
+				p.steps = append(p.steps, step{key: sel[1 : len(sel)-1]})
+				continue
+			}
+			i, err := strconv.Atoi(sel)
+			if err != nil || i < 0 {
+				return p, fmt.Errorf("invalid JSONPath %q: [%s] is neither a quoted name nor an index", expr, sel)
+			}
+			p.steps = append(p.steps, step{index: i, array: true})
+		default:
+			return p, fmt.Errorf("invalid JSONPath %q: unexpected %q", expr, s[0])
+		}
+	}
+	return p, nil
+}
+
+// Get returns the value of doc selected by the path, as decoded by encoding/json,
+// and false if doc has no such value.
+func (p Path) Get(doc interface{}) (interface{}, bool) {
+	v := doc
+	for _, st := range p.steps {
+		if st.array {
+			a, ok := v.([]interface{})
+			if !ok || st.index >= len(a) {
+				return nil, false
+			}
+			v = a[st.index]
+			continue
+		}
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = o[st.key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
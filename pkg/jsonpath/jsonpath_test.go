@@ -0,0 +1,55 @@
+package jsonpath_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/influxdata/influxdb/pkg/jsonpath"
+)
+
+func TestPath_Get(t *testing.T) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(`{
+		"name": "jvm.memory.used",
+		"measurements": [{"statistic": "VALUE", "value": 1.5}],
+		"heap.used": {"bytes": 42}
+	}`), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		expr  string
+		value interface{}
+		found bool
+	}{
+		{expr: "$", value: doc, found: true},
+		{expr: "$.name", value: "jvm.memory.used", found: true},
+		{expr: "$.measurements[0].value", value: 1.5, found: true},
+		{expr: "$['heap.used'].bytes", value: float64(42), found: true},
+		{expr: `$["heap.used"]["bytes"]`, value: float64(42), found: true},
+		{expr: "$.measurements[1].value"},
+		{expr: "$.name.first"},
+		{expr: "$.missing"},
+	}
+	for _, tt := range tests {
+		p, err := jsonpath.Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.expr, err)
+		}
+		v, found := p.Get(doc)
+		if found != tt.found {
+			t.Fatalf("%s: expected found %v, got %v", tt.expr, tt.found, found)
+		}
+		if tt.expr != "$" && found && v != tt.value {
+			t.Fatalf("%s: expected %v, got %v", tt.expr, tt.value, v)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "name", "$.", "$..name", "$[0", "$[-1]", "$[name]", "$name"} {
+		if _, err := jsonpath.Parse(expr); err == nil {
+			t.Errorf("%q: expected an error", expr)
+		}
+	}
+}
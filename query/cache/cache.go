@@ -0,0 +1,260 @@
+// Package cache provides a cache of query results for the query services.
+//
+// Results are cached by organization, the permissions of the authorization,
+// the normalized query and the dialect, and the time the query is run at
+// truncated to a resolution. The query is run at that truncated time, and
+// queries repeated within the resolution, like dashboards viewed by many
+// users, are only run once. The results of a query
+// are invalidated when the storage engine signals a write to the range of a
+// bucket the query reads. Queries that write, like the ones that call to(),
+// are never cached.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/influxdata/flux"
+	platform "github.com/influxdata/influxdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DefaultTTL is the time results are cached for when neither the config
+	// nor the query have a TTL.
+	DefaultTTL = time.Minute
+
+	// DefaultNowResolution is the resolution the time queries are run at
+	// is truncated to when the config does not have a resolution.
+	DefaultNowResolution = 10 * time.Second
+)
+
+// Config configures the size of the cache and how long results are cached.
+type Config struct {
+	// MaxSize is the maximum total size in bytes of the cached results.
+	MaxSize int
+	// MaxEntrySize is the maximum size in bytes of the results of a single
+	// query. Results of the size of the cache are cached when it is zero.
+	MaxEntrySize int
+	// TTL is the time results are cached for unless a query has a shorter TTL.
+	TTL time.Duration
+	// NowResolution is the resolution the time queries run at is truncated to.
+	NowResolution time.Duration
+}
+
+// Cache is a size limited cache of query results. The least recently used
+// results are evicted first when it is full. It implements
+// storage.WriteObserver to invalidate the results of modified buckets.
+type Cache struct {
+	config Config
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	size    int
+	// byBucket indexes the entries by the buckets they depend on, and byOrg
+	// the entries that depend on every bucket of an organization.
+	byBucket map[platform.ID]map[*list.Element]struct{}
+	byOrg    map[platform.ID]map[*list.Element]struct{}
+	// pending are the queries that are running, which are not cached when
+	// their dependencies are modified while they run.
+	pending map[*pending]struct{}
+
+	metrics *metrics
+}
+
+// New returns a new cache with the config.
+func New(config Config) *Cache {
+	if config.TTL <= 0 {
+		config.TTL = DefaultTTL
+	}
+	if config.NowResolution <= 0 {
+		config.NowResolution = DefaultNowResolution
+	}
+	if config.MaxEntrySize <= 0 || config.MaxEntrySize > config.MaxSize {
+		config.MaxEntrySize = config.MaxSize
+	}
+	return &Cache{
+		config:   config,
+		now:      time.Now,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		byBucket: make(map[platform.ID]map[*list.Element]struct{}),
+		byOrg:    make(map[platform.ID]map[*list.Element]struct{}),
+		pending:  make(map[*pending]struct{}),
+		metrics:  newMetrics(),
+	}
+}
+
+// entry is the results of a query.
+type entry struct {
+	key     string
+	results []byte
+	stats   flux.Statistics
+	deps    *dependencies
+	expires time.Time
+}
+
+// pending is a query that is running.
+type pending struct {
+	deps     *dependencies
+	modified bool
+}
+
+// get returns the results of the key if they are cached and have not expired.
+func (c *Cache) get(key string) (*entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		c.metrics.misses.Inc()
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	if !c.now().Before(e.expires) {
+		c.remove(el)
+		c.metrics.misses.Inc()
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+	c.metrics.hits.Inc()
+	return e, true
+}
+
+// start registers a query with the dependencies that is about to run.
+func (c *Cache) start(deps *dependencies) *pending {
+	p := &pending{deps: deps}
+	c.mu.Lock()
+	c.pending[p] = struct{}{}
+	c.mu.Unlock()
+	return p
+}
+
+// finish caches the results of a query that was started unless its
+// dependencies were modified while it ran. Results bigger than the maximum
+// entry size are not cached.
+func (c *Cache) finish(p *pending, key string, results []byte, stats flux.Statistics, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.pending, p)
+	if p.modified || len(results) > c.config.MaxEntrySize {
+		return
+	}
+	// Queries can't keep their results cached longer than the TTL of the cache.
+	if ttl <= 0 || ttl > c.config.TTL {
+		ttl = c.config.TTL
+	}
+
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+	e := &entry{
+		key:     key,
+		results: results,
+		stats:   stats,
+		deps:    p.deps,
+		expires: c.now().Add(ttl),
+	}
+	el := c.lru.PushFront(e)
+	c.entries[key] = el
+	c.index(el, e.deps)
+	c.size += len(results)
+	for c.size > c.config.MaxSize {
+		c.remove(c.lru.Back())
+		c.metrics.evictions.Inc()
+	}
+	c.metrics.size.Set(float64(c.size))
+}
+
+// cancel unregisters a query that was started without caching its results.
+func (c *Cache) cancel(p *pending) {
+	c.mu.Lock()
+	delete(c.pending, p)
+	c.mu.Unlock()
+}
+
+// remove removes the element from the cache. It must be called with the lock held.
+func (c *Cache) remove(el *list.Element) {
+	e := c.lru.Remove(el).(*entry)
+	delete(c.entries, e.key)
+	c.unindex(el, e.deps)
+	c.size -= len(e.results)
+	c.metrics.size.Set(float64(c.size))
+}
+
+// index adds the element to the indexes of its dependencies. It must be called with the lock held.
+func (c *Cache) index(el *list.Element, deps *dependencies) {
+	add := func(index map[platform.ID]map[*list.Element]struct{}, id platform.ID) {
+		els, ok := index[id]
+		if !ok {
+			els = make(map[*list.Element]struct{})
+			index[id] = els
+		}
+		els[el] = struct{}{}
+	}
+	if deps.buckets == nil {
+		add(c.byOrg, deps.orgID)
+		return
+	}
+	for _, id := range deps.buckets {
+		add(c.byBucket, id)
+	}
+}
+
+// unindex removes the element from the indexes of its dependencies. It must be called with the lock held.
+func (c *Cache) unindex(el *list.Element, deps *dependencies) {
+	del := func(index map[platform.ID]map[*list.Element]struct{}, id platform.ID) {
+		delete(index[id], el)
+		if len(index[id]) == 0 {
+			delete(index, id)
+		}
+	}
+	if deps.buckets == nil {
+		del(c.byOrg, deps.orgID)
+		return
+	}
+	for _, id := range deps.buckets {
+		del(c.byBucket, id)
+	}
+}
+
+// RangeModified invalidates the results that depend on the range of the bucket.
+func (c *Cache) RangeModified(orgID, bucketID platform.ID, min, max int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Only the entries that depend on the bucket are checked.
+	var modified []*list.Element
+	for _, els := range []map[*list.Element]struct{}{c.byBucket[bucketID], c.byOrg[orgID]} {
+		for el := range els {
+			if el.Value.(*entry).deps.modifiedBy(orgID, bucketID, min, max) {
+				modified = append(modified, el)
+			}
+		}
+	}
+	for _, el := range modified {
+		c.remove(el)
+		c.metrics.invalidations.Inc()
+	}
+	for p := range c.pending {
+		if p.deps.modifiedBy(orgID, bucketID, min, max) {
+			p.modified = true
+		}
+	}
+}
+
+// Size returns the total size in bytes of the cached results.
+func (c *Cache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// PrometheusCollectors returns the metrics of the cache.
+func (c *Cache) PrometheusCollectors() []prometheus.Collector {
+	return c.metrics.PrometheusCollectors()
+}
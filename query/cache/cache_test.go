@@ -0,0 +1,358 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/lang"
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/query"
+	_ "github.com/influxdata/influxdb/query/builtin"
+	querymock "github.com/influxdata/influxdb/query/mock"
+)
+
+const (
+	orgID    = platform.ID(1)
+	bucketID = platform.ID(2)
+)
+
+var (
+	readPermission = platform.Permission{
+		Action: platform.ReadAction,
+		Resource: platform.Resource{
+			Type:  platform.BucketsResourceType,
+			OrgID: &[]platform.ID{orgID}[0],
+		},
+	}
+	base = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// service returns a cached service and the number of queries it ran. The
+// results of every query are unique.
+func service(c *Cache) (*ProxyQueryService, *int) {
+	var n int
+	return &ProxyQueryService{
+		Cache: c,
+		ProxyQueryService: &querymock.ProxyQueryService{
+			QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (flux.Statistics, error) {
+				n++
+				_, err := fmt.Fprintf(w, "results %d", n)
+				return flux.Statistics{}, err
+			},
+		},
+		BucketService: &mock.BucketService{
+			FindBucketFn: func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+				if *filter.Name != "telegraf" {
+					return nil, &platform.Error{Code: platform.ENotFound}
+				}
+				return &platform.Bucket{ID: bucketID, OrganizationID: orgID, Name: "telegraf"}, nil
+			},
+		},
+	}, &n
+}
+
+func newCache(config Config) *Cache {
+	c := New(config)
+	c.now = func() time.Time { return base }
+	return c
+}
+
+func request(q string, permissions ...platform.Permission) *query.ProxyRequest {
+	return &query.ProxyRequest{
+		Request: query.Request{
+			Authorization:  &platform.Authorization{ID: 3, OrgID: orgID, Permissions: permissions},
+			OrganizationID: orgID,
+			Compiler:       lang.FluxCompiler{Query: q},
+		},
+		Dialect: csv.DefaultDialect(),
+	}
+}
+
+func run(t *testing.T, ctx context.Context, s *ProxyQueryService, req *query.ProxyRequest) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if _, err := s.Query(ctx, &buf, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return buf.String()
+}
+
+const rangeQuery = `from(bucket: "telegraf") |> range(start: 2018-12-31T23:00:00Z, stop: 2019-01-01T00:00:00Z)`
+
+func TestProxyQueryService_Query(t *testing.T) {
+	c := newCache(Config{MaxSize: 1024})
+	s, n := service(c)
+	ctx := context.Background()
+
+	first := run(t, ctx, s, request(rangeQuery, readPermission))
+	// The same query with different formatting is answered from the cache.
+	second := run(t, ctx, s, request(`from(bucket:"telegraf")
+		|> range(start:2018-12-31T23:00:00Z, stop:2019-01-01T00:00:00Z)`, readPermission))
+	if first != second || *n != 1 {
+		t.Fatalf("expected cached results, got %q and %q after %d queries", first, second, *n)
+	}
+
+	// Authorizations with other permissions do not share results.
+	run(t, ctx, s, request(rangeQuery))
+	if *n != 2 {
+		t.Fatalf("expected query for other permissions to run, ran %d queries", *n)
+	}
+
+	// Queries run after the now resolution are run again.
+	c.now = func() time.Time { return base.Add(DefaultNowResolution) }
+	run(t, ctx, s, request(rangeQuery, readPermission))
+	if *n != 3 {
+		t.Fatalf("expected query at a later time to run, ran %d queries", *n)
+	}
+}
+
+func TestProxyQueryService_SideEffects(t *testing.T) {
+	c := newCache(Config{MaxSize: 1024})
+	s, n := service(c)
+	ctx := context.Background()
+
+	q := `from(bucket: "telegraf") |> range(start: -1h) |> to(bucket: "copy", org: "influxdata")`
+	run(t, ctx, s, request(q, readPermission))
+	run(t, ctx, s, request(q, readPermission))
+	if *n != 2 {
+		t.Fatalf("expected query that writes to run every time, ran %d queries", *n)
+	}
+	if got := c.Size(); got != 0 {
+		t.Fatalf("expected results of query that writes not to be cached, size is %d", got)
+	}
+}
+
+func TestProxyQueryService_CompilesOnce(t *testing.T) {
+	c := newCache(Config{MaxSize: 1024})
+	s, _ := service(c)
+	inner := s.ProxyQueryService
+	s.ProxyQueryService = &querymock.ProxyQueryService{
+		QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (flux.Statistics, error) {
+			if _, ok := req.Request.Compiler.(lang.SpecCompiler); !ok {
+				t.Errorf("expected the compiled spec to be run, got %T", req.Request.Compiler)
+			}
+			return inner.Query(ctx, w, req)
+		},
+	}
+	run(t, context.Background(), s, request(rangeQuery, readPermission))
+}
+
+func TestProxyQueryService_Invalidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		orgID    platform.ID
+		bucketID platform.ID
+		min, max time.Time
+		want     bool
+	}{
+		{
+			name:     "write to the range",
+			query:    rangeQuery,
+			orgID:    orgID,
+			bucketID: bucketID,
+			min:      base.Add(-time.Minute),
+			max:      base.Add(-time.Minute),
+			want:     true,
+		},
+		{
+			name:     "write after the range",
+			query:    rangeQuery,
+			orgID:    orgID,
+			bucketID: bucketID,
+			min:      base.Add(time.Minute),
+			max:      base.Add(time.Minute),
+		},
+		{
+			name:     "write to another bucket",
+			query:    rangeQuery,
+			orgID:    orgID,
+			bucketID: 4,
+			min:      base.Add(-time.Minute),
+			max:      base.Add(-time.Minute),
+		},
+		{
+			name:     "write to another organization",
+			query:    rangeQuery,
+			orgID:    4,
+			bucketID: bucketID,
+			min:      base.Add(-time.Minute),
+			max:      base.Add(-time.Minute),
+		},
+		{
+			name:     "bucket by id",
+			query:    `from(bucketID: "0000000000000002") |> range(start: 2018-12-31T23:00:00Z, stop: 2019-01-01T00:00:00Z)`,
+			orgID:    orgID,
+			bucketID: bucketID,
+			min:      base.Add(-time.Minute),
+			max:      base.Add(-time.Minute),
+			want:     true,
+		},
+		{
+			name:     "unknown bucket depends on every bucket",
+			query:    `from(bucket: "unknown") |> range(start: 2018-12-31T23:00:00Z, stop: 2019-01-01T00:00:00Z)`,
+			orgID:    orgID,
+			bucketID: 4,
+			min:      base.Add(-time.Minute),
+			max:      base.Add(-time.Minute),
+			want:     true,
+		},
+		{
+			// Relative ranges are relative to the truncated time the query is run at.
+			name:     "relative range",
+			query:    `from(bucket: "telegraf") |> range(start: -1h)`,
+			orgID:    orgID,
+			bucketID: bucketID,
+			min:      base.Add(-30 * time.Minute),
+			max:      base.Add(-30 * time.Minute),
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCache(Config{MaxSize: 1024})
+			s, n := service(c)
+			ctx := context.Background()
+
+			run(t, ctx, s, request(tt.query, readPermission))
+			c.RangeModified(tt.orgID, tt.bucketID, tt.min.UnixNano(), tt.max.UnixNano())
+			run(t, ctx, s, request(tt.query, readPermission))
+
+			if got := *n == 2; got != tt.want {
+				t.Errorf("invalidated = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProxyQueryService_ModifiedWhileRunning(t *testing.T) {
+	c := newCache(Config{MaxSize: 1024})
+	s, n := service(c)
+	inner := s.ProxyQueryService
+	s.ProxyQueryService = &querymock.ProxyQueryService{
+		QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (flux.Statistics, error) {
+			c.RangeModified(orgID, bucketID, base.Add(-time.Minute).UnixNano(), base.UnixNano())
+			return inner.Query(ctx, w, req)
+		},
+	}
+	ctx := context.Background()
+
+	run(t, ctx, s, request(rangeQuery, readPermission))
+	run(t, ctx, s, request(rangeQuery, readPermission))
+	if *n != 2 {
+		t.Errorf("expected results modified while running not to be cached, ran %d queries", *n)
+	}
+}
+
+func TestProxyQueryService_Options(t *testing.T) {
+	c := newCache(Config{MaxSize: 1024})
+	s, n := service(c)
+
+	noStore := ContextWithOptions(context.Background(), Options{NoCache: true, NoStore: true})
+	run(t, noStore, s, request(rangeQuery, readPermission))
+	run(t, context.Background(), s, request(rangeQuery, readPermission))
+	if *n != 2 {
+		t.Fatalf("expected results of no-store query not to be cached, ran %d queries", *n)
+	}
+
+	// No cache runs the query and caches the new results.
+	noCache := ContextWithOptions(context.Background(), Options{NoCache: true})
+	want := run(t, noCache, s, request(rangeQuery, readPermission))
+	if got := run(t, context.Background(), s, request(rangeQuery, readPermission)); got != want || *n != 3 {
+		t.Fatalf("expected results of no-cache query to be cached, got %q after %d queries", got, *n)
+	}
+
+	// A shorter TTL of the query overrides the TTL of the cache.
+	c.config.NowResolution = 24 * time.Hour
+	ttl := ContextWithOptions(context.Background(), Options{NoCache: true, TTL: DefaultTTL / 2})
+	run(t, ttl, s, request(rangeQuery, readPermission))
+	c.now = func() time.Time { return base.Add(DefaultTTL / 2) }
+	run(t, context.Background(), s, request(rangeQuery, readPermission))
+	if *n != 5 {
+		t.Fatalf("expected results to be cached for the TTL of the query, ran %d queries", *n)
+	}
+
+	// A longer TTL of the query is capped by the TTL of the cache.
+	ttl = ContextWithOptions(context.Background(), Options{NoCache: true, TTL: time.Hour})
+	run(t, ttl, s, request(rangeQuery, readPermission))
+	c.now = func() time.Time { return base.Add(DefaultTTL/2 + DefaultTTL) }
+	run(t, context.Background(), s, request(rangeQuery, readPermission))
+	if *n != 7 {
+		t.Fatalf("expected results to be cached for the TTL of the cache, ran %d queries", *n)
+	}
+}
+
+func TestProxyQueryService_TruncatedNow(t *testing.T) {
+	c := newCache(Config{MaxSize: 1024})
+	s, _ := service(c)
+	inner := s.ProxyQueryService
+	var ran time.Time
+	s.ProxyQueryService = &querymock.ProxyQueryService{
+		QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (flux.Statistics, error) {
+			ran = req.Request.Compiler.(lang.SpecCompiler).Spec.Now
+			return inner.Query(ctx, w, req)
+		},
+	}
+
+	// The query is run at the time of its key, that its cached results are for.
+	c.now = func() time.Time { return base.Add(DefaultNowResolution / 2) }
+	run(t, context.Background(), s, request(rangeQuery, readPermission))
+	if !ran.Equal(base) {
+		t.Fatalf("expected the query to run at %s, ran at %s", base, ran)
+	}
+}
+
+func TestCache_Size(t *testing.T) {
+	// The results of the mock are 9 bytes.
+	c := newCache(Config{MaxSize: 20, MaxEntrySize: 10})
+	s, n := service(c)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		run(t, ctx, s, request(fmt.Sprintf(`from(bucket: "telegraf") |> range(start: -%dh)`, i+1), readPermission))
+	}
+	if got := c.Size(); got != 18 {
+		t.Fatalf("expected the least recently used results to be evicted, size is %d", got)
+	}
+	run(t, ctx, s, request(`from(bucket: "telegraf") |> range(start: -1h)`, readPermission))
+	if *n != 4 {
+		t.Fatalf("expected evicted results to run again, ran %d queries", *n)
+	}
+
+	c = newCache(Config{MaxSize: 20, MaxEntrySize: 5})
+	s, _ = service(c)
+	run(t, ctx, s, request(rangeQuery, readPermission))
+	if got := c.Size(); got != 0 {
+		t.Fatalf("expected results bigger than the maximum entry size not to be cached, size is %d", got)
+	}
+}
+
+func TestParseCacheControl(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Options
+	}{
+		{header: "", want: Options{}},
+		{header: "no-cache", want: Options{NoCache: true}},
+		{header: "No-Store", want: Options{NoCache: true, NoStore: true}},
+		{header: "max-age=30", want: Options{TTL: 30 * time.Second}},
+		{header: "max-age=0", want: Options{NoCache: true}},
+		{header: "no-cache, max-age=120", want: Options{NoCache: true, TTL: 2 * time.Minute}},
+		{header: "max-age=abc", want: Options{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := ParseCacheControl(tt.header); !cmp.Equal(got, tt.want) {
+				t.Errorf("ParseCacheControl(%q) = %+v, want %+v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"context"
+	"math"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/stdlib/http"
+	"github.com/influxdata/flux/stdlib/kafka"
+	"github.com/influxdata/flux/stdlib/universe"
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb"
+)
+
+// dependencies are the buckets and the time range the results of a query
+// depend on.
+type dependencies struct {
+	orgID platform.ID
+	// buckets are the buckets the query reads. The query depends on every
+	// bucket of the organization when it is nil.
+	buckets []platform.ID
+	// min and max are the bounds of the time range the query reads.
+	min, max int64
+}
+
+// modifiedBy reports whether a modification of the range of the bucket
+// changes the results.
+func (d *dependencies) modifiedBy(orgID, bucketID platform.ID, min, max int64) bool {
+	if d.orgID != orgID || min > d.max || max < d.min {
+		return false
+	}
+	if d.buckets == nil {
+		return true
+	}
+	for _, id := range d.buckets {
+		if id == bucketID {
+			return true
+		}
+	}
+	return false
+}
+
+// sideEffects are the operations that write their tables somewhere else.
+var sideEffects = map[flux.OperationKind]bool{
+	influxdb.ToKind:   true,
+	http.ToHTTPKind:   true,
+	kafka.ToKafkaKind: true,
+}
+
+// hasSideEffects reports whether running the spec does more than reading data,
+// in which case its results are not cached.
+func hasSideEffects(spec *flux.Spec) bool {
+	for _, op := range spec.Operations {
+		if sideEffects[op.Spec.Kind()] {
+			return true
+		}
+	}
+	return false
+}
+
+// findDependencies returns the dependencies of the spec of a query run by the
+// organization. The query is assumed to read every bucket of the organization
+// when the ID of a bucket cannot be found, and to read all time when it has no
+// range.
+func findDependencies(ctx context.Context, buckets platform.BucketService, orgID platform.ID, spec *flux.Spec) *dependencies {
+	deps := &dependencies{
+		orgID:   orgID,
+		buckets: []platform.ID{},
+		min:     math.MaxInt64,
+		max:     math.MinInt64,
+	}
+
+	for _, op := range spec.Operations {
+		switch s := op.Spec.(type) {
+		case *influxdb.FromOpSpec:
+			if deps.buckets == nil {
+				continue
+			}
+			id, ok := findBucketID(ctx, buckets, orgID, s)
+			if !ok {
+				deps.buckets = nil
+				continue
+			}
+			deps.buckets = append(deps.buckets, id)
+		case *universe.RangeOpSpec:
+			if start := s.Start.Time(spec.Now).UnixNano(); start < deps.min {
+				deps.min = start
+			}
+			if stop := s.Stop.Time(spec.Now).UnixNano(); stop > deps.max {
+				deps.max = stop
+			}
+		}
+	}
+	if deps.min > deps.max {
+		deps.min, deps.max = math.MinInt64, math.MaxInt64
+	}
+	return deps
+}
+
+func findBucketID(ctx context.Context, buckets platform.BucketService, orgID platform.ID, s *influxdb.FromOpSpec) (platform.ID, bool) {
+	if s.BucketID != "" {
+		var id platform.ID
+		if err := id.DecodeFromString(s.BucketID); err != nil {
+			return 0, false
+		}
+		return id, true
+	}
+	if buckets == nil {
+		return 0, false
+	}
+	name := s.Bucket
+	b, err := buckets.FindBucket(ctx, platform.BucketFilter{
+		OrganizationID: &orgID,
+		Name:           &name,
+	})
+	if err != nil {
+		return 0, false
+	}
+	return b.ID, true
+}
@@ -0,0 +1,61 @@
+package cache
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// metrics is a collection of metrics relating to the query result cache.
+type metrics struct {
+	hits          prometheus.Counter
+	misses        prometheus.Counter
+	evictions     prometheus.Counter
+	invalidations prometheus.Counter
+	size          prometheus.Gauge
+}
+
+func newMetrics() *metrics {
+	const namespace = "query"
+	const subsystem = "cache"
+
+	return &metrics{
+		hits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "hits_total",
+			Help:      "Total number of queries answered from the cache.",
+		}),
+		misses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "misses_total",
+			Help:      "Total number of cacheable queries that were not in the cache.",
+		}),
+		evictions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "evictions_total",
+			Help:      "Total number of results evicted to keep the cache within its size.",
+		}),
+		invalidations: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "invalidations_total",
+			Help:      "Total number of results invalidated by writes to the buckets they read.",
+		}),
+		size: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "size_bytes",
+			Help:      "Total size of the cached results in bytes.",
+		}),
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (m *metrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.hits,
+		m.misses,
+		m.evictions,
+		m.invalidations,
+		m.size,
+	}
+}
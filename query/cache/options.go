@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type contextKey string
+
+const optionsContextKey contextKey = "cache-options"
+
+// Options are the cache options of a single query.
+type Options struct {
+	// NoCache makes the query run even if its results are cached.
+	NoCache bool
+	// NoStore prevents the results of the query from being cached.
+	NoStore bool
+	// TTL is the time the results of the query are cached for.
+	// The TTL of the cache is used when it is zero or longer.
+	TTL time.Duration
+}
+
+// ContextWithOptions returns a context with the cache options of the query.
+func ContextWithOptions(ctx context.Context, opts Options) context.Context {
+	return context.WithValue(ctx, optionsContextKey, opts)
+}
+
+// OptionsFromContext returns the cache options of the query in the context.
+func OptionsFromContext(ctx context.Context) Options {
+	opts, _ := ctx.Value(optionsContextKey).(Options)
+	return opts
+}
+
+// ParseCacheControl returns the options for the directives of a Cache-Control
+// header. The no-cache directive bypasses the cache, no-store also prevents
+// the results from being cached and max-age sets the TTL of the results,
+// up to the TTL of the cache.
+func ParseCacheControl(header string) Options {
+	var opts Options
+	for _, directive := range strings.Split(header, ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+		switch {
+		case directive == "no-cache":
+			opts.NoCache = true
+		case directive == "no-store":
+			opts.NoCache, opts.NoStore = true, true
+		case strings.HasPrefix(directive, "max-age="):
+			secs, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+			if err != nil {
+				continue
+			}
+			if secs <= 0 {
+				opts.NoCache = true
+			} else {
+				opts.TTL = time.Duration(secs) * time.Second
+			}
+		}
+	}
+	return opts
+}
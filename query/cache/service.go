@@ -0,0 +1,205 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/parser"
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/check"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/influxql"
+)
+
+// ProxyQueryService wraps a ProxyQueryService and caches the results of the
+// queries. Services that need a QueryService can wrap it with a
+// query.QueryServiceProxyBridge.
+type ProxyQueryService struct {
+	Cache             *Cache
+	ProxyQueryService query.ProxyQueryService
+	// BucketService finds the IDs of the buckets queries read by name.
+	BucketService platform.BucketService
+}
+
+// Query writes the cached results of the query or runs the query and caches
+// its results.
+func (s *ProxyQueryService) Query(ctx context.Context, w io.Writer, req *query.ProxyRequest) (flux.Statistics, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	opts := OptionsFromContext(ctx)
+	key, now, ok := s.Cache.key(req)
+	if !ok {
+		return s.ProxyQueryService.Query(ctx, w, req)
+	}
+	if !opts.NoCache {
+		if e, ok := s.Cache.get(key); ok {
+			_, err := w.Write(e.results)
+			return e.stats, err
+		}
+	}
+	if opts.NoStore {
+		return s.ProxyQueryService.Query(ctx, w, req)
+	}
+
+	// The query is compiled at the time of its key, which its cached results are for,
+	// and to find the buckets and the range it reads.
+	spec, err := compileAt(ctx, req.Request.Compiler, now)
+	if err != nil {
+		return s.ProxyQueryService.Query(ctx, w, req)
+	}
+	// The compiled spec is run so the query is not compiled again.
+	compiled := *req
+	compiled.Request.Compiler = lang.SpecCompiler{Spec: spec}
+	// Queries that write are run every time.
+	if hasSideEffects(spec) {
+		return s.ProxyQueryService.Query(ctx, w, &compiled)
+	}
+	p := s.Cache.start(findDependencies(ctx, s.BucketService, req.Request.OrganizationID, spec))
+
+	buf := &limitedBuffer{max: s.Cache.config.MaxEntrySize}
+	stats, err := s.ProxyQueryService.Query(ctx, io.MultiWriter(w, buf), &compiled)
+	if err != nil {
+		s.Cache.cancel(p)
+		return stats, tracing.LogError(span, err)
+	} else if buf.exceeded {
+		s.Cache.cancel(p)
+		return stats, nil
+	}
+	s.Cache.finish(p, key, buf.Bytes(), stats, opts.TTL)
+	return stats, nil
+}
+
+// Check returns the health of the wrapped service.
+func (s *ProxyQueryService) Check(ctx context.Context) check.Response {
+	return s.ProxyQueryService.Check(ctx)
+}
+
+// cacheKey is everything the results of a query depend on besides the data.
+type cacheKey struct {
+	OrganizationID platform.ID `json:"organizationID"`
+	Permissions    []string    `json:"permissions"`
+	CompilerType   string      `json:"compilerType"`
+	Query          string      `json:"query"`
+	DialectType    string      `json:"dialectType"`
+	Dialect        interface{} `json:"dialect"`
+	Now            time.Time   `json:"now"`
+}
+
+// key returns the key of the results of the request, and the time the query
+// must run at for its results to be cached under the key. Requests without an
+// authorization, and requests whose compiler can't be given the time to run
+// at, are not cached.
+func (c *Cache) key(req *query.ProxyRequest) (string, time.Time, bool) {
+	auth := req.Request.Authorization
+	if auth == nil || req.Request.Compiler == nil {
+		return "", time.Time{}, false
+	}
+
+	// Requests are only answered with the results of requests with the same
+	// permissions so they cannot read more than they are allowed to.
+	k := cacheKey{
+		OrganizationID: req.Request.OrganizationID,
+		CompilerType:   string(req.Request.Compiler.CompilerType()),
+		DialectType:    fmt.Sprintf("%T", req.Dialect),
+		Dialect:        req.Dialect,
+		Now:            c.now().Truncate(c.config.NowResolution),
+	}
+	for _, p := range auth.Permissions {
+		k.Permissions = append(k.Permissions, p.String())
+	}
+	sort.Strings(k.Permissions)
+
+	switch compiler := req.Request.Compiler.(type) {
+	case lang.FluxCompiler:
+		k.Query = normalizeFlux(compiler.Query)
+	case lang.ASTCompiler:
+		k.Query = ast.Format(compiler.AST)
+		if !compiler.Now.IsZero() {
+			k.Now = compiler.Now.Truncate(c.config.NowResolution)
+		}
+	case *influxql.Compiler:
+		q, err := json.Marshal(compiler)
+		if err != nil {
+			return "", time.Time{}, false
+		}
+		k.Query = string(q)
+		if compiler.Now != nil {
+			k.Now = compiler.Now.Truncate(c.config.NowResolution)
+		}
+	case lang.SpecCompiler:
+		// The spec was compiled at its own time.
+		q, err := json.Marshal(compiler)
+		if err != nil {
+			return "", time.Time{}, false
+		}
+		k.Query = string(q)
+		k.Now = compiler.Spec.Now
+	default:
+		return "", time.Time{}, false
+	}
+
+	b, err := json.Marshal(k)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), k.Now, true
+}
+
+// compileAt compiles the query of the compiler as if it ran at now.
+func compileAt(ctx context.Context, compiler flux.Compiler, now time.Time) (*flux.Spec, error) {
+	switch compiler := compiler.(type) {
+	case lang.FluxCompiler:
+		return flux.Compile(ctx, compiler.Query, now)
+	case lang.ASTCompiler:
+		compiler.Now = now
+		return compiler.Compile(ctx)
+	case *influxql.Compiler:
+		at := *compiler
+		at.Now = &now
+		return at.Compile(ctx)
+	default:
+		return compiler.Compile(ctx)
+	}
+}
+
+// normalizeFlux formats the query so queries that only differ in their
+// formatting have the same key.
+func normalizeFlux(q string) string {
+	pkg := parser.ParseSource(q)
+	if ast.Check(pkg) > 0 {
+		return q
+	}
+	return ast.Format(pkg)
+}
+
+// limitedBuffer buffers the writes until they exceed the maximum size.
+type limitedBuffer struct {
+	bytes.Buffer
+	max      int
+	exceeded bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.exceeded {
+		return len(p), nil
+	}
+	if b.Len()+len(p) > b.max {
+		b.exceeded = true
+		b.Reset()
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
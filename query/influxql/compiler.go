@@ -24,6 +24,8 @@ type Compiler struct {
 	RP      string     `json:"rp,omitempty"`
 	Query   string     `json:"query"`
 	Now     *time.Time `json:"now,omitempty"`
+	// Params are the values of the $name placeholders of the query.
+	Params map[string]interface{} `json:"params,omitempty"`
 
 	dbrpMappingSvc platform.DBRPMappingService
 }
@@ -49,6 +51,7 @@ func (c *Compiler) Compile(ctx context.Context) (*flux.Spec, error) {
 			DefaultDatabase:        c.DB,
 			DefaultRetentionPolicy: c.RP,
 			Now:                    now,
+			Params:                 c.Params,
 		},
 	)
 	astPkg, err := transpiler.Transpile(ctx, c.Query)
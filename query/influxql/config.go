@@ -10,4 +10,6 @@ type Config struct {
 	DefaultRetentionPolicy string
 	Now                    time.Time
 	Cluster                string
+	// Params are the values of the $name placeholders of the query.
+	Params map[string]interface{}
 }
@@ -2,6 +2,7 @@ package influxql
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/execute"
@@ -30,20 +31,52 @@ type varRefCursor struct {
 	ref  *influxql.VarRef
 }
 
-// createVarRefCursor creates a new cursor from a variable reference using the sources
-// in the transpilerState.
-func createVarRefCursor(t *transpilerState, ref *influxql.VarRef) (cursor, error) {
+// createCursor creates a new cursor for the variable references using the sources
+// in the transpilerState. A single field from a measurement produces a varRefCursor and
+// multiple fields are pivoted into the same table so they can be evaluated together.
+func createCursor(t *transpilerState, refs []*influxql.VarRef) (cursor, error) {
 	if len(t.stmt.Sources) != 1 {
 		// TODO(jsternberg): Support multiple sources.
 		return nil, errors.New("unimplemented: only one source is allowed")
 	}
 
-	// Only support a direct measurement. Subqueries are not supported yet.
-	mm, ok := t.stmt.Sources[0].(*influxql.Measurement)
-	if !ok {
-		return nil, errors.New("unimplemented: source must be a measurement")
+	switch src := t.stmt.Sources[0].(type) {
+	case *influxql.Measurement:
+		if len(refs) == 1 {
+			return createVarRefCursor(t, src, refs[0])
+		}
+		return createPivotCursor(t, src, refs)
+	case *influxql.SubQuery:
+		return createSubQueryCursor(t, src, refs)
+	default:
+		return nil, fmt.Errorf("unimplemented: source must be a measurement or subquery, got %T", src)
+	}
+}
+
+// createVarRefCursor creates a new cursor from a variable reference using the measurement.
+func createVarRefCursor(t *transpilerState, mm *influxql.Measurement, ref *influxql.VarRef) (cursor, error) {
+	expr, err := t.measurementCursor(mm, &ast.BinaryExpression{
+		Operator: ast.EqualOperator,
+		Left: &ast.MemberExpression{
+			Object:   &ast.Identifier{Name: "r"},
+			Property: &ast.Identifier{Name: "_field"},
+		},
+		Right: &ast.StringLiteral{
+			Value: ref.Val,
+		},
+	})
+	if err != nil {
+		return nil, err
 	}
+	return &varRefCursor{
+		expr: expr,
+		ref:  ref,
+	}, nil
+}
 
+// measurementCursor creates the from, range, and filter calls to read the fields matching
+// the field expression from the measurement.
+func (t *transpilerState) measurementCursor(mm *influxql.Measurement, fieldExpr ast.Expression) (ast.Expression, error) {
 	// Create the from spec and add it to the list of operations.
 	from, err := t.from(mm)
 	if err != nil {
@@ -95,7 +128,7 @@ func createVarRefCursor(t *transpilerState, ref *influxql.VarRef) (cursor, error
 		},
 	}
 
-	expr := &ast.PipeExpression{
+	return &ast.PipeExpression{
 		Argument: range_,
 		Call: &ast.CallExpression{
 			Callee: &ast.Identifier{
@@ -126,16 +159,7 @@ func createVarRefCursor(t *transpilerState, ref *influxql.VarRef) (cursor, error
 											Value: mm.Name,
 										},
 									},
-									Right: &ast.BinaryExpression{
-										Operator: ast.EqualOperator,
-										Left: &ast.MemberExpression{
-											Object:   &ast.Identifier{Name: "r"},
-											Property: &ast.Identifier{Name: "_field"},
-										},
-										Right: &ast.StringLiteral{
-											Value: ref.Val,
-										},
-									},
+									Right: fieldExpr,
 								},
 							},
 						},
@@ -143,10 +167,6 @@ func createVarRefCursor(t *transpilerState, ref *influxql.VarRef) (cursor, error
 				},
 			},
 		},
-	}
-	return &varRefCursor{
-		expr: expr,
-		ref:  ref,
 	}, nil
 }
 
@@ -179,3 +199,106 @@ type pipeCursor struct {
 }
 
 func (c *pipeCursor) Expr() ast.Expression { return c.expr }
+
+// pivotCursor contains a cursor for multiple fields from the same measurement. The fields
+// are pivoted into their own columns so they can be accessed from the same row.
+type pivotCursor struct {
+	expr ast.Expression
+	refs []*influxql.VarRef
+}
+
+// createPivotCursor creates a new cursor that reads each of the variable references
+// from the measurement and pivots the fields into columns.
+func createPivotCursor(t *transpilerState, mm *influxql.Measurement, refs []*influxql.VarRef) (cursor, error) {
+	var fieldExpr ast.Expression
+	for i := len(refs) - 1; i >= 0; i-- {
+		expr := &ast.BinaryExpression{
+			Operator: ast.EqualOperator,
+			Left: &ast.MemberExpression{
+				Object:   &ast.Identifier{Name: "r"},
+				Property: &ast.Identifier{Name: "_field"},
+			},
+			Right: &ast.StringLiteral{
+				Value: refs[i].Val,
+			},
+		}
+		if fieldExpr == nil {
+			fieldExpr = expr
+			continue
+		}
+		fieldExpr = &ast.LogicalExpression{
+			Operator: ast.OrOperator,
+			Left:     expr,
+			Right:    fieldExpr,
+		}
+	}
+
+	expr, err := t.measurementCursor(mm, fieldExpr)
+	if err != nil {
+		return nil, err
+	}
+	return &pivotCursor{
+		expr: &ast.PipeExpression{
+			Argument: expr,
+			Call: &ast.CallExpression{
+				Callee: &ast.Identifier{
+					Name: "pivot",
+				},
+				Arguments: []ast.Expression{
+					&ast.ObjectExpression{
+						Properties: []*ast.Property{
+							{
+								Key: &ast.Identifier{Name: "rowKey"},
+								Value: &ast.ArrayExpression{
+									Elements: []ast.Expression{
+										&ast.StringLiteral{Value: execute.DefaultTimeColLabel},
+									},
+								},
+							},
+							{
+								Key: &ast.Identifier{Name: "columnKey"},
+								Value: &ast.ArrayExpression{
+									Elements: []ast.Expression{
+										&ast.StringLiteral{Value: "_field"},
+									},
+								},
+							},
+							{
+								Key:   &ast.Identifier{Name: "valueColumn"},
+								Value: &ast.StringLiteral{Value: execute.DefaultValueColLabel},
+							},
+						},
+					},
+				},
+			},
+		},
+		refs: refs,
+	}, nil
+}
+
+func (c *pivotCursor) Expr() ast.Expression {
+	return c.expr
+}
+
+func (c *pivotCursor) Keys() []influxql.Expr {
+	keys := make([]influxql.Expr, 0, len(c.refs))
+	for _, ref := range c.refs {
+		keys = append(keys, ref)
+	}
+	return keys
+}
+
+func (c *pivotCursor) Value(expr influxql.Expr) (string, bool) {
+	ref, ok := expr.(*influxql.VarRef)
+	if !ok {
+		return "", false
+	}
+
+	// The pivot creates a column with the same name as the field.
+	for _, r := range c.refs {
+		if ref == r || ref.Val == r.Val {
+			return ref.Val, true
+		}
+	}
+	return "", false
+}
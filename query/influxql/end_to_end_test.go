@@ -4,12 +4,16 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/execute/executetest"
@@ -78,7 +82,6 @@ var skipTests = map[string]string{
 	"explicit_type_0":          "Transpiler should remove _start column (https://github.com/influxdata/platform/issues/1360)",
 	"explicit_type_1":          "Transpiler should remove _start column (https://github.com/influxdata/platform/issues/1360)",
 	"fills_0":                  "need fill/Interpolate function (https://github.com/influxdata/platform/issues/272)",
-	"random_math_0":            "transpiler does not implement joining fields within a cursor (https://github.com/influxdata/platform/issues/1340)",
 	"selector_0":               "Transpiler: unimplemented functions: top and bottom (https://github.com/influxdata/platform/issues/1601)",
 	"selector_1":               "Transpiler: unimplemented functions: top and bottom (https://github.com/influxdata/platform/issues/1601)",
 	"selector_2":               "Transpiler: first function uses different series than influxQL (https://github.com/influxdata/platform/issues/1605)",
@@ -94,21 +97,14 @@ var skipTests = map[string]string{
 	"series_agg_7":             "Transpiler should remove _start column (https://github.com/influxdata/platform/issues/1360)",
 	"series_agg_8":             "Transpiler should remove _start column (https://github.com/influxdata/platform/issues/1360)",
 	"series_agg_9":             "Transpiler should remove _start column (https://github.com/influxdata/platform/issues/1360)",
-	"Subquery_0":               "Implement subqueries in the transpiler (https://github.com/influxdata/platform/issues/194)",
-	"Subquery_1":               "Implement subqueries in the transpiler (https://github.com/influxdata/platform/issues/194)",
-	"Subquery_2":               "Implement subqueries in the transpiler (https://github.com/influxdata/platform/issues/194)",
-	"Subquery_3":               "Implement subqueries in the transpiler (https://github.com/influxdata/platform/issues/194)",
-	"Subquery_4":               "Implement subqueries in the transpiler (https://github.com/influxdata/platform/issues/194)",
-	"Subquery_5":               "Implement subqueries in the transpiler (https://github.com/influxdata/platform/issues/194)",
-	"NestedSubquery_0":         "Implement subqueries in the transpiler (https://github.com/influxdata/platform/issues/194)",
-	"NestedSubquery_1":         "Implement subqueries in the transpiler (https://github.com/influxdata/platform/issues/194)",
-	"NestedSubquery_2":         "Implement subqueries in the transpiler (https://github.com/influxdata/platform/issues/194)",
-	"NestedSubquery_3":         "Implement subqueries in the transpiler (https://github.com/influxdata/platform/issues/194)",
-	"SimulatedHTTP_0":          "Implement subqueries in the transpiler (https://github.com/influxdata/platform/issues/194)",
-	"SimulatedHTTP_1":          "Implement subqueries in the transpiler (https://github.com/influxdata/platform/issues/194)",
-	"SimulatedHTTP_2":          "Implement subqueries in the transpiler (https://github.com/influxdata/platform/issues/194)",
-	"SimulatedHTTP_3":          "Implement subqueries in the transpiler (https://github.com/influxdata/platform/issues/194)",
-	"SimulatedHTTP_4":          "Implement subqueries in the transpiler (https://github.com/influxdata/platform/issues/194)",
+	"Subquery_0":               "Transpiler: unimplemented: field wildcard",
+	"NestedSubquery_0":         "Transpiler: unimplemented functions: top and bottom (https://github.com/influxdata/platform/issues/1601)",
+	"NestedSubquery_1":         "Transpiler: unimplemented functions: top and bottom (https://github.com/influxdata/platform/issues/1601)",
+	"SimulatedHTTP_0":          "Transpiler: multiple sources are not implemented",
+	"SimulatedHTTP_1":          "Transpiler: unimplemented functions: top and bottom (https://github.com/influxdata/platform/issues/1601)",
+	"SimulatedHTTP_2":          "Transpiler: Implement spread (https://github.com/influxdata/platform/issues/1611)",
+	"SimulatedHTTP_3":          "Transpiler: multiple sources are not implemented",
+	"SimulatedHTTP_4":          "Transpiler: multiple sources are not implemented",
 	"SelectorMath_0":           "Transpiler: unimplemented functions: top and bottom (https://github.com/influxdata/platform/issues/1601)",
 	"SelectorMath_1":           "Transpiler: unimplemented functions: top and bottom (https://github.com/influxdata/platform/issues/1601)",
 	"SelectorMath_2":           "Transpiler: unimplemented functions: top and bottom (https://github.com/influxdata/platform/issues/1601)",
@@ -143,6 +139,15 @@ var skipTests = map[string]string{
 	"SelectorMath_31":          "Transpiler: unimplemented functions: top and bottom (https://github.com/influxdata/platform/issues/1601)",
 }
 
+// approxTests compare the floats of the results with a relative tolerance. The rows are in
+// the same order as in 1.x, but mean adds them up with the SIMD sum of arrow, which keeps
+// several partial sums unless built with the noasm tag. The results then differ from the
+// sequential sum of 1.x in the last bits, whatever the order of the rows.
+var approxTests = map[string]string{
+	"Subquery_1": "mean uses the SIMD sum of arrow",
+	"Subquery_3": "mean uses the SIMD sum of arrow",
+}
+
 var querier = fluxquerytest.NewQuerier()
 
 func withEachInfluxQLFile(t testing.TB, fn func(prefix, caseName string)) {
@@ -178,6 +183,33 @@ func Test_GeneratedInfluxQLQueries(t *testing.T) {
 	})
 }
 
+// The schema statements read the last hour when they have no time range,
+// so they run against testdata/schema.in.json shortly after its points.
+func TestSchemaStatements(t *testing.T) {
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	inFile := filepath.Join(dir, generatedInfluxQLDataDir, "schema.in.json")
+	now := time.Unix(0, 0).Add(30 * time.Minute).UTC()
+
+	for _, tt := range []struct {
+		name  string
+		query string
+	}{
+		{name: "show_series", query: `SHOW SERIES`},
+		{name: "show_series_from", query: `SHOW SERIES FROM cpu WHERE host = 'a'`},
+		{name: "show_series_cardinality", query: `SHOW SERIES CARDINALITY`},
+		{name: "show_field_keys", query: `SHOW FIELD KEYS`},
+		{name: "show_field_keys_from", query: `SHOW FIELD KEYS FROM cpu`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			outFile := filepath.Join(dir, generatedInfluxQLDataDir, "schema_"+tt.name+".out.json")
+			testInfluxQLResults(t, influxQLCompilerAt(tt.query, inFile, &now), outFile, false)
+		})
+	}
+}
+
 func testGeneratedInfluxQL(t testing.TB, prefix, queryExt string) {
 	q, err := ioutil.ReadFile(prefix + queryExt)
 	if err != nil {
@@ -189,7 +221,13 @@ func testGeneratedInfluxQL(t testing.TB, prefix, queryExt string) {
 
 	inFile := prefix + ".in.json"
 	outFile := prefix + ".out.json"
+	_, approx := approxTests[filepath.Base(prefix)]
+	testInfluxQLResults(t, influxQLCompiler(string(q), inFile), outFile, approx)
+}
 
+// testInfluxQLResults compares the results of the compiler with the ones in outFile.
+// The floats only have to be approximately equal if approx is set.
+func testInfluxQLResults(t testing.TB, compiler flux.Compiler, outFile string, approx bool) {
 	out, err := jsonToResultIterator(outFile)
 	if err != nil {
 		t.Fatalf("failed to read expected JSON results: %v", err)
@@ -201,7 +239,7 @@ func testGeneratedInfluxQL(t testing.TB, prefix, queryExt string) {
 		exp = append(exp, out.Next())
 	}
 
-	res, err := resultsFromQuerier(querier, influxQLCompiler(string(q), inFile))
+	res, err := resultsFromQuerier(querier, compiler)
 	if err != nil {
 		t.Fatalf("failed to run query: %v", err)
 	}
@@ -212,7 +250,11 @@ func testGeneratedInfluxQL(t testing.TB, prefix, queryExt string) {
 		got = append(got, res.Next())
 	}
 
-	if ok, err := executetest.EqualResults(exp, got); !ok {
+	equal := executetest.EqualResults
+	if approx {
+		equal = approxEqualResults
+	}
+	if ok, err := equal(exp, got); !ok {
 		t.Errorf("result not as expected: %v", err)
 
 		expBuffer := new(bytes.Buffer)
@@ -234,6 +276,38 @@ func testGeneratedInfluxQL(t testing.TB, prefix, queryExt string) {
 	}
 }
 
+// approxEqualResults compares the results like executetest.EqualResults,
+// with a relative tolerance for the floats.
+func approxEqualResults(want, got []flux.Result) (bool, error) {
+	if len(want) != len(got) {
+		return false, fmt.Errorf("unexpected number of results - want %d results, got %d results", len(want), len(got))
+	}
+	for i := range want {
+		var wt, gt []*executetest.Table
+		for _, r := range []struct {
+			res    flux.Result
+			tables *[]*executetest.Table
+		}{{want[i], &wt}, {got[i], &gt}} {
+			tables := r.tables
+			if err := r.res.Tables().Do(func(tbl flux.Table) error {
+				t, err := executetest.ConvertTable(tbl)
+				if err != nil {
+					return err
+				}
+				*tables = append(*tables, t)
+				return nil
+			}); err != nil {
+				return false, err
+			}
+			executetest.NormalizeTables(*tables)
+		}
+		if opt := cmpopts.EquateApprox(1e-12, 0); !cmp.Equal(wt, gt, opt) {
+			return false, fmt.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(wt, gt, opt))
+		}
+	}
+	return true, nil
+}
+
 func resultsFromQuerier(querier *fluxquerytest.Querier, compiler flux.Compiler) (flux.ResultIterator, error) {
 	req := &query.ProxyRequest{
 		Request: query.Request{
@@ -250,10 +324,16 @@ func resultsFromQuerier(querier *fluxquerytest.Querier, compiler flux.Compiler)
 }
 
 func influxQLCompiler(query, filename string) *fluxquerytest.ReplaceSpecCompiler {
+	return influxQLCompilerAt(query, filename, nil)
+}
+
+// influxQLCompilerAt returns a compiler that runs the query at now, if set.
+func influxQLCompilerAt(query, filename string, now *time.Time) *fluxquerytest.ReplaceSpecCompiler {
 	compiler := influxql.NewCompiler(dbrpMappingSvcE2E)
 	compiler.Cluster = "cluster"
 	compiler.DB = "db0"
 	compiler.Query = query
+	compiler.Now = now
 	return querytest.FromInfluxJSONCompiler(compiler, filename)
 }
 
@@ -100,8 +100,9 @@ func parseFunction(expr *influxql.Call) (*function, error) {
 }
 
 // createFunctionCursor creates a new cursor that calls a function on one of the columns
-// and returns the result.
-func createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, normalize bool) (cursor, error) {
+// and returns the result. The time is normalized to the start of the window when
+// normalize is set, unless the result is reported at the epoch.
+func createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, normalize, epoch bool) (cursor, error) {
 	cur := &functionCursor{
 		call:   call,
 		parent: in,
@@ -112,13 +113,39 @@ func createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, no
 		if !ok {
 			return nil, fmt.Errorf("undefined variable: %s", call.Args[0])
 		}
+		callExpr := &ast.CallExpression{
+			Callee: &ast.Identifier{
+				Name: call.Name,
+			},
+		}
+		if value != execute.DefaultValueColLabel {
+			// The value is not in the default column so specify the column to use.
+			// Selectors take a single column while aggregates take a list of columns.
+			var arg *ast.Property
+			if influxql.IsSelector(call) {
+				arg = &ast.Property{
+					Key:   &ast.Identifier{Name: "column"},
+					Value: &ast.StringLiteral{Value: value},
+				}
+			} else {
+				arg = &ast.Property{
+					Key: &ast.Identifier{Name: "columns"},
+					Value: &ast.ArrayExpression{
+						Elements: []ast.Expression{
+							&ast.StringLiteral{Value: value},
+						},
+					},
+				}
+			}
+			callExpr.Arguments = []ast.Expression{
+				&ast.ObjectExpression{
+					Properties: []*ast.Property{arg},
+				},
+			}
+		}
 		cur.expr = &ast.PipeExpression{
 			Argument: in.Expr(),
-			Call: &ast.CallExpression{
-				Callee: &ast.Identifier{
-					Name: call.Name,
-				},
-			},
+			Call:     callExpr,
 		}
 		cur.value = value
 		cur.exclude = map[influxql.Expr]struct{}{call.Args[0]: {}}
@@ -258,35 +285,38 @@ func createFunctionCursor(t *transpilerState, call *influxql.Call, in cursor, no
 				},
 			}
 		}
-		cur.expr = &ast.PipeExpression{
-			Argument: cur.expr,
-			Call: &ast.CallExpression{
-				Callee: &ast.Identifier{
-					Name: "duplicate",
-				},
-				Arguments: []ast.Expression{
-					&ast.ObjectExpression{
-						Properties: []*ast.Property{
-							{
-								Key: &ast.Identifier{
-									Name: "column",
-								},
-								Value: &ast.StringLiteral{
-									Value: execute.DefaultStartColLabel,
-								},
-							},
-							{
-								Key: &ast.Identifier{
-									Name: "as",
+		// The time of the aggregates at the epoch is set when the fields are mapped.
+		if !epoch {
+			cur.expr = &ast.PipeExpression{
+				Argument: cur.expr,
+				Call: &ast.CallExpression{
+					Callee: &ast.Identifier{
+						Name: "duplicate",
+					},
+					Arguments: []ast.Expression{
+						&ast.ObjectExpression{
+							Properties: []*ast.Property{
+								{
+									Key: &ast.Identifier{
+										Name: "column",
+									},
+									Value: &ast.StringLiteral{
+										Value: execute.DefaultStartColLabel,
+									},
 								},
-								Value: &ast.StringLiteral{
-									Value: execute.DefaultTimeColLabel,
+								{
+									Key: &ast.Identifier{
+										Name: "as",
+									},
+									Value: &ast.StringLiteral{
+										Value: execute.DefaultTimeColLabel,
+									},
 								},
 							},
 						},
 					},
 				},
-			},
+			}
 		}
 	}
 	return cur, nil
@@ -105,33 +105,26 @@ func identifyGroups(stmt *influxql.SelectStatement) ([]*groupInfo, error) {
 	return groups, nil
 }
 
-func (gr *groupInfo) createCursor(t *transpilerState) (cursor, error) {
-	// Create all of the cursors for every variable reference.
-	// TODO(jsternberg): Determine which of these cursors are from fields and which are tags.
-	var cursors []cursor
+// createCursor creates the cursor of the group. The time of the function calls is
+// left to mapFields when the aggregates are reported at the epoch.
+func (gr *groupInfo) createCursor(t *transpilerState, epoch bool) (cursor, error) {
+	// Identify all of the variable references that need to be read.
+	// TODO(jsternberg): Determine which of these references are from fields and which are tags.
+	var refs []*influxql.VarRef
 	if gr.call != nil {
 		ref, ok := gr.call.Args[0].(*influxql.VarRef)
 		if !ok {
 			// TODO(jsternberg): This should be validated and figured out somewhere else.
 			return nil, fmt.Errorf("first argument to %q must be a variable", gr.call.Name)
 		}
-		cur, err := createVarRefCursor(t, ref)
-		if err != nil {
-			return nil, err
-		}
-		cursors = append(cursors, cur)
+		refs = appendVarRef(refs, ref)
 	}
-
 	for _, ref := range gr.refs {
-		cur, err := createVarRefCursor(t, ref)
-		if err != nil {
-			return nil, err
-		}
-		cursors = append(cursors, cur)
+		refs = appendVarRef(refs, ref)
 	}
 
 	// TODO(jsternberg): Establish which variables in the condition are tags and which are fields.
-	// We need to create the references to fields here so they can be joined.
+	// We need to create the references to fields here so they can be read within the same cursor.
 	var (
 		tags map[influxql.VarRef]struct{}
 		cond influxql.Expr
@@ -146,51 +139,37 @@ func (gr *groupInfo) createCursor(t *transpilerState) (cursor, error) {
 
 			// Walk through the condition for every variable reference. There will be no function
 			// calls here.
-			var condErr error
 			influxql.WalkFunc(cond, func(node influxql.Node) {
-				if condErr != nil {
-					return
-				}
 				ref, ok := node.(*influxql.VarRef)
 				if !ok {
 					return
 				}
 
-				// If the variable reference is in any of the cursors, it is definitely
+				// If the variable reference is already being read, it is definitely
 				// a field and we do not have to inspect it further.
-				for _, cur := range cursors {
-					if _, ok := cur.Value(ref); ok {
+				for _, r := range refs {
+					if r.Val == ref.Val {
 						return
 					}
 				}
 
-				// This may be a field or a tag. If it is a field, we need to create the cursor
-				// and add it to the listing of cursors so it can be joined before we evaluate the condition.
+				// This may be a field or a tag. If it is a field, we need to read it
+				// within the same cursor before we evaluate the condition.
 				switch typ := t.mapType(ref); typ {
 				case influxql.Tag:
 					// Add this variable name to the listing of tags.
 					tags[*ref] = struct{}{}
 				default:
-					cur, err := createVarRefCursor(t, ref)
-					if err != nil {
-						condErr = err
-						return
-					}
-					cursors = append(cursors, cur)
+					refs = appendVarRef(refs, ref)
 				}
 			})
 		}
 	}
 
-	// Join the cursors using an inner join.
-	// TODO(jsternberg): We need to differentiate between various join types and this needs to be
-	// except: ["_field"] rather than joining on the _measurement. This also needs to specify what the time
-	// column should be.
-	if len(cursors) > 1 {
-		return nil, errors.New("unimplemented: joining fields within a cursor")
+	cur, err := createCursor(t, refs)
+	if err != nil {
+		return nil, err
 	}
-
-	cur := Join(t, cursors, []string{"_measurement"})
 	if len(tags) > 0 {
 		cur = &tagsCursor{cursor: cur, tags: tags}
 	}
@@ -242,7 +221,7 @@ func (gr *groupInfo) createCursor(t *transpilerState) (cursor, error) {
 
 	// If a function call is present, evaluate the function call.
 	if gr.call != nil {
-		c, err := createFunctionCursor(t, gr.call, cur, !gr.selector || interval > 0)
+		c, err := createFunctionCursor(t, gr.call, cur, !gr.selector || interval > 0, epoch)
 		if err != nil {
 			return nil, err
 		}
@@ -413,6 +392,37 @@ func (gr *groupInfo) group(t *transpilerState, in cursor) (cursor, error) {
 		cursor: in,
 	}
 
+	// The series in a group are read one after the other. Sort the rows by time so they are
+	// in the same order as 1.x, which merges the series by time. Only the raw fields and
+	// the selectors depend on the order of the rows.
+	if (gr.call == nil || influxql.IsSelector(gr.call)) && t.mergesSeries() {
+		in = &pipeCursor{
+			expr: &ast.PipeExpression{
+				Argument: in.Expr(),
+				Call: &ast.CallExpression{
+					Callee: &ast.Identifier{
+						Name: "sort",
+					},
+					Arguments: []ast.Expression{
+						&ast.ObjectExpression{
+							Properties: []*ast.Property{{
+								Key: &ast.Identifier{
+									Name: "columns",
+								},
+								Value: &ast.ArrayExpression{
+									Elements: []ast.Expression{
+										&ast.StringLiteral{Value: "_time"},
+									},
+								},
+							}},
+						},
+					},
+				},
+			},
+			cursor: in,
+		}
+	}
+
 	if windowEvery > 0 {
 		args := []*ast.Property{{
 			Key: &ast.Identifier{
@@ -452,6 +462,31 @@ func (gr *groupInfo) group(t *transpilerState, in cursor) (cursor, error) {
 	return in, nil
 }
 
+// mergesSeries returns true if grouping may put several series in the same table.
+// The series of a subquery are only merged if the query is not grouped by all of
+// the tags the subquery is grouped by.
+func (t *transpilerState) mergesSeries() bool {
+	src, ok := t.stmt.Sources[0].(*influxql.SubQuery)
+	if !ok {
+		return true
+	}
+
+	dims := make(map[string]struct{})
+	for _, d := range t.stmt.Dimensions {
+		if ref, ok := d.Expr.(*influxql.VarRef); ok {
+			dims[ref.Val] = struct{}{}
+		}
+	}
+	for _, d := range src.Statement.Dimensions {
+		if ref, ok := d.Expr.(*influxql.VarRef); ok {
+			if _, ok := dims[ref.Val]; !ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // tagsCursor is a pseudo-cursor that can be used to access tags within the cursor.
 type tagsCursor struct {
 	cursor
@@ -471,6 +506,17 @@ func (c *tagsCursor) Value(expr influxql.Expr) (string, bool) {
 	return "", false
 }
 
+// appendVarRef appends the variable reference to the list if a reference with
+// the same name is not already present.
+func appendVarRef(refs []*influxql.VarRef, ref *influxql.VarRef) []*influxql.VarRef {
+	for _, r := range refs {
+		if r.Val == ref.Val {
+			return refs
+		}
+	}
+	return append(refs, ref)
+}
+
 func durationLiteral(d time.Duration) (dur []ast.Duration) {
 	for d != 0 {
 		switch {
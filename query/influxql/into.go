@@ -0,0 +1,83 @@
+package influxql
+
+import (
+	"time"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/influxql"
+)
+
+// writtenColumn is the name of the column that reports the number of points
+// written by a SELECT INTO statement.
+const writtenColumn = "written"
+
+// writeTarget writes the results of the cursor to the target of a SELECT INTO statement.
+// The returned cursor reports the number of points that were written in the same
+// shape as the result returned by InfluxDB 1.x.
+func (t *transpilerState) writeTarget(in cursor) (cursor, error) {
+	target := t.stmt.Target.Measurement
+	mapping, err := t.findMapping(target.Database, target.RetentionPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the tags in the dimensions are written as tags. The target
+	// name is left empty when the source measurement should be used.
+	expr := in.Expr()
+	if target.Name != "" {
+		expr = pipe(expr, call("set",
+			property("key", &ast.StringLiteral{Value: "_measurement"}),
+			property("value", &ast.StringLiteral{Value: target.Name}),
+		))
+	}
+
+	var tags []string
+	for _, d := range t.stmt.Dimensions {
+		if ref, ok := d.Expr.(*influxql.VarRef); ok {
+			tags = append(tags, ref.Val)
+		}
+	}
+
+	// Every selected column is written as a field with the column name.
+	columns := t.stmt.ColumnNames()
+	fields := make([]*ast.Property, 0, len(columns))
+	for i, f := range t.stmt.Fields {
+		if ref, ok := f.Expr.(*influxql.VarRef); ok && ref.Val == "time" {
+			continue
+		}
+		// Column names are any strings, so they are always written as string literals.
+		fields = append(fields, &ast.Property{
+			Key: &ast.StringLiteral{Value: columns[i]},
+			Value: &ast.MemberExpression{
+				Object:   &ast.Identifier{Name: "r"},
+				Property: &ast.StringLiteral{Value: columns[i]},
+			},
+		})
+	}
+
+	expr = pipe(expr,
+		call("to",
+			property("bucketID", &ast.StringLiteral{Value: mapping.BucketID.String()}),
+			property("orgID", &ast.StringLiteral{Value: mapping.OrganizationID.String()}),
+			property("tagColumns", stringArray(tags...)),
+			property("fieldFn", predicate(&ast.ObjectExpression{Properties: fields})),
+		),
+		// Count the number of points that were written. The measurement column
+		// is always present so it is used for counting the rows.
+		call("group"),
+		call("count", property("columns", stringArray("_measurement"))),
+		call("map", property("fn", predicate(&ast.ObjectExpression{
+			Properties: []*ast.Property{
+				property(execute.DefaultTimeColLabel, &ast.DateTimeLiteral{Value: time.Unix(0, 0).UTC()}),
+				property(writtenColumn, member("_measurement")),
+			},
+		}))),
+		call("set",
+			property("key", &ast.StringLiteral{Value: "_measurement"}),
+			property("value", &ast.StringLiteral{Value: "result"}),
+		),
+		call("group", property("columns", stringArray("_measurement"))),
+	)
+	return &pipeCursor{expr: expr, cursor: in}, nil
+}
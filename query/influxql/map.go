@@ -31,8 +31,8 @@ func (c *mapCursor) Value(expr influxql.Expr) (string, bool) {
 }
 
 // mapFields will take the list of symbols and maps each of the operations
-// using the column names.
-func (t *transpilerState) mapFields(in cursor) (cursor, error) {
+// using the column names. The time is set to the epoch when epoch is true.
+func (t *transpilerState) mapFields(in cursor, epoch bool) (cursor, error) {
 	columns := t.stmt.ColumnNames()
 	if len(columns) != len(t.stmt.Fields) {
 		// TODO(jsternberg): This scenario should not be possible. Replace the use of ColumnNames with a more
@@ -41,19 +41,24 @@ func (t *transpilerState) mapFields(in cursor) (cursor, error) {
 		panic("number of columns does not match the number of fields")
 	}
 
+	var timeValue ast.Expression = &ast.MemberExpression{
+		Object: &ast.Identifier{
+			Name: "r",
+		},
+		Property: &ast.Identifier{
+			Name: execute.DefaultTimeColLabel,
+		},
+	}
+	if epoch {
+		timeValue = &ast.DateTimeLiteral{Value: time.Unix(0, 0).UTC()}
+	}
+
 	properties := make([]*ast.Property, 0, len(t.stmt.Fields)+1)
 	properties = append(properties, &ast.Property{
 		Key: &ast.Identifier{
 			Name: execute.DefaultTimeColLabel,
 		},
-		Value: &ast.MemberExpression{
-			Object: &ast.Identifier{
-				Name: "r",
-			},
-			Property: &ast.Identifier{
-				Name: execute.DefaultTimeColLabel,
-			},
-		},
+		Value: timeValue,
 	})
 	for i, f := range t.stmt.Fields {
 		if ref, ok := f.Expr.(*influxql.VarRef); ok && ref.Val == "time" {
@@ -170,6 +175,10 @@ func (t *transpilerState) evalBinaryExpr(expr *influxql.BinaryExpr, in cursor) (
 			return b.eval(ast.AdditionOperator)
 		case influxql.SUB:
 			return b.eval(ast.SubtractionOperator)
+		case influxql.MUL:
+			return b.eval(ast.MultiplicationOperator)
+		case influxql.DIV:
+			return b.eval(ast.DivisionOperator)
 		case influxql.AND:
 			return b.logical(ast.AndOperator)
 		case influxql.OR:
@@ -0,0 +1,41 @@
+package influxql
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// bindParams converts the values of the params to the types the parser
+// binds to placeholders. Integers are bound as integers and floats as
+// numbers, JSON numbers without a fraction or exponent are integers, and
+// times are bound as RFC3339 strings so they can be compared to the time.
+func bindParams(params map[string]interface{}) (map[string]interface{}, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+	bound := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		switch v := v.(type) {
+		case string, bool, int64, float64:
+			bound[k] = v
+		case int:
+			bound[k] = int64(v)
+		case json.Number:
+			if i, err := v.Int64(); err == nil {
+				bound[k] = i
+				continue
+			}
+			f, err := v.Float64()
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for parameter %s: %s", k, err)
+			}
+			bound[k] = f
+		case time.Time:
+			bound[k] = v.UTC().Format(time.RFC3339Nano)
+		default:
+			return nil, fmt.Errorf("unable to bind parameter %s with type %T", k, v)
+		}
+	}
+	return bound, nil
+}
@@ -1,5 +1,12 @@
 package influxql
 
+import (
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/influxdb/models"
+)
+
 // all of this code is copied more or less verbatim from the influxdb repo.
 // we copy instead of sharing because we want to prevent inadvertent breaking
 // changes introduced by the transpiler vs the actual InfluxQL engine.
@@ -32,6 +39,9 @@ type Result struct {
 	Messages    []*Message `json:"messages,omitempty"`
 	Partial     bool       `json:"partial,omitempty"`
 	Err         string     `json:"error,omitempty"`
+
+	// seriesIndex is used to find an existing series with the same identity when appending a row.
+	seriesIndex map[string]*Row
 }
 
 // Row represents a single row returned from the execution of a statement.
@@ -42,3 +52,70 @@ type Row struct {
 	Values  [][]interface{}   `json:"values,omitempty"`
 	Partial bool              `json:"partial,omitempty"`
 }
+
+const (
+	// fieldTypeColumn is the column label used by the transpiler for a column whose
+	// type, rather than its value, is reported as the fieldType for SHOW FIELD KEYS.
+	fieldTypeColumn = "_fieldType"
+
+	// seriesKeyColumn is the column label used by the transpiler to mark a table as a
+	// single series for SHOW SERIES. The group key of the table is encoded as the series key.
+	seriesKeyColumn = "_seriesKey"
+)
+
+// fieldTypeName returns the 1.x name of the field type for the column type.
+func fieldTypeName(typ flux.ColType) string {
+	switch typ {
+	case flux.TFloat:
+		return "float"
+	case flux.TInt:
+		return "integer"
+	case flux.TUInt:
+		return "unsigned"
+	case flux.TString:
+		return "string"
+	case flux.TBool:
+		return "boolean"
+	default:
+		return "unknown"
+	}
+}
+
+// seriesKey formats the group key of a table as a 1.x series key.
+func seriesKey(key flux.GroupKey) string {
+	var name string
+	tags := make(map[string]string)
+	for j, c := range key.Cols() {
+		if c.Type != flux.TString {
+			continue
+		}
+		if c.Label == "_measurement" {
+			name = key.ValueString(j)
+		} else if c.Label != "_field" {
+			tags[c.Label] = key.ValueString(j)
+		}
+	}
+	return string(models.MakeKey([]byte(name), models.NewTags(tags)))
+}
+
+// appendRow appends the row to the series in the result. If an existing series has the same
+// name, tags, and columns, the values are appended to that series instead. This mirrors 1.x
+// where the values for a measurement or a series key are returned in a single series.
+func (r *Result) appendRow(row *Row) {
+	id := row.seriesID()
+	if s, ok := r.seriesIndex[id]; ok {
+		s.Values = append(s.Values, row.Values...)
+		return
+	}
+	if r.seriesIndex == nil {
+		r.seriesIndex = make(map[string]*Row)
+	}
+	r.seriesIndex[id] = row
+	r.Series = append(r.Series, row)
+}
+
+// seriesID returns a string that uniquely identifies the name, tags, and columns of the row.
+func (r *Row) seriesID() string {
+	tags := models.NewTags(r.Tags)
+	return string(models.MakeKey([]byte(r.Name), tags)) + "\x00" + strings.Join(r.Columns, "\x00")
+}
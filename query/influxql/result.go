@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 	"time"
 
@@ -27,6 +28,10 @@ type MultiResultEncoder struct{}
 //  4.  All other columns are fields and will be output in the order they are found.
 //      TODO(jsternberg): This function currently requires the first column to be a time field, but this isn't
 //      a strict requirement and will be lifted when we begin to work on transpiling meta queries.
+//  5.  Tables with the same name, tags, and columns are combined into a single series. The series are
+//      sorted by name, like in 1.x.
+//  6.  The _fieldType and _seriesKey columns are used by the schema statements. The type of the _fieldType
+//      column is encoded instead of its value and a table with the _seriesKey column is encoded as a series key.
 func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
 	resp := Response{}
 	wc := &iocounter.Writer{Writer: w}
@@ -47,6 +52,23 @@ func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (i
 		if err := tables.Do(func(tbl flux.Table) error {
 			var row Row
 
+			// A table marked with the series key column is a single series from
+			// SHOW SERIES. Only the series key is encoded and not the individual tags.
+			if execute.ColIdx(seriesKeyColumn, tbl.Cols()) >= 0 {
+				key := seriesKey(tbl.Key())
+				row.Columns = []string{"key"}
+				if err := tbl.Do(func(cr flux.ColReader) error {
+					for i := 0; i < cr.Len(); i++ {
+						row.Values = append(row.Values, []interface{}{key})
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				result.appendRow(&row)
+				return nil
+			}
+
 			for j, c := range tbl.Key().Cols() {
 				if c.Type != flux.TString {
 					// Skip any columns that aren't strings. They are extra ones that
@@ -93,6 +115,8 @@ func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (i
 			for k, v := range resultColMap {
 				if k == execute.DefaultTimeColLabel {
 					k = "time"
+				} else if k == fieldTypeColumn {
+					k = "fieldType"
 				}
 				row.Columns[v] = k
 			}
@@ -113,6 +137,14 @@ func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (i
 					}
 
 					j = resultColMap[c.Label]
+					if c.Label == fieldTypeColumn {
+						// The field type column reports the type of the column instead of its values.
+						for i := range values {
+							values[i][j] = fieldTypeName(c.Type)
+						}
+						continue
+					}
+
 					// Fill in the values for each column.
 					switch c.Type {
 					case flux.TFloat:
@@ -168,13 +200,16 @@ func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (i
 				return err
 			}
 
-			result.Series = append(result.Series, &row)
+			result.appendRow(&row)
 			return nil
 		}); err != nil {
 			resp.error(err)
 			results.Release()
 			break
 		}
+		sort.SliceStable(result.Series, func(i, j int) bool {
+			return result.Series[i].Name < result.Series[j].Name
+		})
 		resp.Results = append(resp.Results, result)
 	}
 
@@ -78,6 +78,75 @@ func TestMultiResultEncoder_Encode(t *testing.T) {
 			),
 			out: `{"results":[{"statement_id":0,"series":[{"columns":["name"],"values":[["telegraf"]]}]}]}`,
 		},
+		{
+			name: "Field Keys",
+			in: flux.NewSliceResultIterator(
+				[]flux.Result{&executetest.Result{
+					Nm: "0",
+					Tbls: []*executetest.Table{
+						{
+							KeyCols: []string{"_measurement", "_field"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "_field", Type: flux.TString},
+								{Label: "fieldKey", Type: flux.TString},
+								{Label: "_fieldType", Type: flux.TInt},
+							},
+							Data: [][]interface{}{
+								{"cpu", "count", "count", int64(2)},
+							},
+						},
+						{
+							KeyCols: []string{"_measurement", "_field"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "_field", Type: flux.TString},
+								{Label: "fieldKey", Type: flux.TString},
+								{Label: "_fieldType", Type: flux.TFloat},
+							},
+							Data: [][]interface{}{
+								{"cpu", "usage", "usage", float64(2)},
+							},
+						},
+					},
+				}},
+			),
+			out: `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["fieldKey","fieldType"],"values":[["count","integer"],["usage","float"]]}]}]}`,
+		},
+		{
+			name: "Series Keys",
+			in: flux.NewSliceResultIterator(
+				[]flux.Result{&executetest.Result{
+					Nm: "0",
+					Tbls: []*executetest.Table{
+						{
+							KeyCols: []string{"_measurement", "host"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "host", Type: flux.TString},
+								{Label: "_seriesKey", Type: flux.TString},
+							},
+							Data: [][]interface{}{
+								{"cpu", "server01", ""},
+							},
+						},
+						{
+							KeyCols: []string{"_measurement", "host", "region"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "host", Type: flux.TString},
+								{Label: "region", Type: flux.TString},
+								{Label: "_seriesKey", Type: flux.TString},
+							},
+							Data: [][]interface{}{
+								{"cpu", "server02", "us west", ""},
+							},
+						},
+					},
+				}},
+			),
+			out: `{"results":[{"statement_id":0,"series":[{"columns":["key"],"values":[["cpu,host=server01"],["cpu,host=server02,region=us\\ west"]]}]}]}`,
+		},
 		{
 			name: "Error",
 			in:   &resultErrorIterator{Error: "expected"},
@@ -0,0 +1,443 @@
+package influxql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxql"
+)
+
+// schemaKeyColumns contains the columns within the group key of a series that are
+// not tags and need to be filtered from the output of the keys function.
+var schemaKeyColumns = []string{"_measurement", "_field", "_start", "_stop"}
+
+// transpileShowMeasurements transpiles SHOW MEASUREMENTS into a pipeline that finds
+// the distinct measurement names. The results are grouped under the "measurements" name
+// so they are encoded in the same shape as 1.x.
+func (t *transpilerState) transpileShowMeasurements(ctx context.Context, stmt *influxql.ShowMeasurementsStatement) (ast.Expression, error) {
+	var sources influxql.Sources
+	if stmt.Source != nil {
+		sources = influxql.Sources{stmt.Source}
+	}
+	expr, err := t.schemaSource(stmt.Database, sources, stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	expr = pipe(expr,
+		call("keep", property("columns", stringArray("_measurement"))),
+		call("group"),
+		call("distinct", property("column", &ast.StringLiteral{Value: "_measurement"})),
+		call("sort"),
+	)
+	expr = pipeLimit(expr, stmt.Limit, stmt.Offset)
+	return pipe(expr,
+		call("set",
+			property("key", &ast.StringLiteral{Value: "_measurement"}),
+			property("value", &ast.StringLiteral{Value: "measurements"}),
+		),
+		call("group",
+			property("columns", stringArray("_measurement")),
+			property("mode", &ast.StringLiteral{Value: "by"}),
+		),
+		call("rename", property("columns", renameColumns("_value", "name"))),
+	), nil
+}
+
+// transpileShowMeasurementCardinality transpiles SHOW MEASUREMENT CARDINALITY by counting
+// the distinct measurement names.
+func (t *transpilerState) transpileShowMeasurementCardinality(ctx context.Context, stmt *influxql.ShowMeasurementCardinalityStatement) (ast.Expression, error) {
+	if len(stmt.Dimensions) > 0 {
+		return nil, errors.New("unimplemented: GROUP BY in SHOW MEASUREMENT CARDINALITY")
+	}
+
+	expr, err := t.schemaSource(stmt.Database, stmt.Sources, stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+	return pipe(expr,
+		call("keep", property("columns", stringArray("_measurement"))),
+		call("group"),
+		call("distinct", property("column", &ast.StringLiteral{Value: "_measurement"})),
+		call("count"),
+		call("rename", property("columns", renameColumns("_value", "count"))),
+	), nil
+}
+
+// transpileShowTagKeys transpiles SHOW TAG KEYS into a pipeline that finds the distinct
+// tag keys within each measurement.
+func (t *transpilerState) transpileShowTagKeys(ctx context.Context, stmt *influxql.ShowTagKeysStatement) (ast.Expression, error) {
+	if stmt.SLimit > 0 || stmt.SOffset > 0 {
+		return nil, errors.New("unimplemented: SLIMIT and SOFFSET in SHOW TAG KEYS")
+	}
+
+	expr, err := t.schemaSource(stmt.Database, stmt.Sources, stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+	expr = pipe(t.tagKeys(expr), call("sort"))
+	expr = pipeLimit(expr, stmt.Limit, stmt.Offset)
+	return pipe(expr,
+		call("rename", property("columns", renameColumns("_value", "tagKey"))),
+	), nil
+}
+
+// transpileShowTagKeyCardinality transpiles SHOW TAG KEY CARDINALITY by counting the
+// distinct tag keys within each measurement.
+func (t *transpilerState) transpileShowTagKeyCardinality(ctx context.Context, stmt *influxql.ShowTagKeyCardinalityStatement) (ast.Expression, error) {
+	if len(stmt.Dimensions) > 0 {
+		return nil, errors.New("unimplemented: GROUP BY in SHOW TAG KEY CARDINALITY")
+	}
+
+	expr, err := t.schemaSource(stmt.Database, stmt.Sources, stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+	return pipe(t.tagKeys(expr),
+		call("count"),
+		call("rename", property("columns", renameColumns("_value", "count"))),
+	), nil
+}
+
+// tagKeys produces a table for each measurement with the distinct tag keys
+// in the _value column.
+func (t *transpilerState) tagKeys(expr ast.Expression) ast.Expression {
+	var filterExpr ast.Expression
+	for i := len(schemaKeyColumns) - 1; i >= 0; i-- {
+		cmp := &ast.BinaryExpression{
+			Operator: ast.NotEqualOperator,
+			Left:     member("_value"),
+			Right:    &ast.StringLiteral{Value: schemaKeyColumns[i]},
+		}
+		if filterExpr == nil {
+			filterExpr = cmp
+			continue
+		}
+		filterExpr = &ast.LogicalExpression{
+			Operator: ast.AndOperator,
+			Left:     cmp,
+			Right:    filterExpr,
+		}
+	}
+
+	return pipe(expr,
+		call("keys"),
+		call("keep", property("columns", stringArray("_measurement", "_value"))),
+		call("group",
+			property("columns", stringArray("_measurement")),
+			property("mode", &ast.StringLiteral{Value: "by"}),
+		),
+		call("distinct"),
+		call("filter", property("fn", predicate(filterExpr))),
+	)
+}
+
+// transpileShowFieldKeys transpiles SHOW FIELD KEYS. The last value of each field is retained
+// in the fieldTypeColumn so the encoder can report the type of the field.
+func (t *transpilerState) transpileShowFieldKeys(ctx context.Context, stmt *influxql.ShowFieldKeysStatement) (ast.Expression, error) {
+	if stmt.Limit > 0 || stmt.Offset > 0 {
+		return nil, errors.New("unimplemented: LIMIT and OFFSET in SHOW FIELD KEYS")
+	}
+
+	expr, err := t.schemaSource(stmt.Database, stmt.Sources, nil)
+	if err != nil {
+		return nil, err
+	}
+	return pipe(expr,
+		call("keep", property("columns", stringArray("_measurement", "_field", "_value"))),
+		call("group",
+			property("columns", stringArray("_measurement", "_field")),
+			property("mode", &ast.StringLiteral{Value: "by"}),
+		),
+		call("last"),
+		call("duplicate",
+			property("column", &ast.StringLiteral{Value: "_field"}),
+			property("as", &ast.StringLiteral{Value: "fieldKey"}),
+		),
+		call("rename", property("columns", renameColumns("_value", fieldTypeColumn))),
+	), nil
+}
+
+// transpileShowFieldKeyCardinality transpiles SHOW FIELD KEY CARDINALITY by counting the
+// distinct fields within each measurement.
+func (t *transpilerState) transpileShowFieldKeyCardinality(ctx context.Context, stmt *influxql.ShowFieldKeyCardinalityStatement) (ast.Expression, error) {
+	if len(stmt.Dimensions) > 0 {
+		return nil, errors.New("unimplemented: GROUP BY in SHOW FIELD KEY CARDINALITY")
+	}
+
+	expr, err := t.schemaSource(stmt.Database, stmt.Sources, stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+	return pipe(expr,
+		call("keep", property("columns", stringArray("_measurement", "_field"))),
+		call("group",
+			property("columns", stringArray("_measurement")),
+			property("mode", &ast.StringLiteral{Value: "by"}),
+		),
+		call("distinct", property("column", &ast.StringLiteral{Value: "_field"})),
+		call("count"),
+		call("rename", property("columns", renameColumns("_value", "count"))),
+	), nil
+}
+
+// transpileShowSeries transpiles SHOW SERIES. Each series is reduced to a single row
+// and marked with the seriesKeyColumn so the encoder can format the series key.
+func (t *transpilerState) transpileShowSeries(ctx context.Context, stmt *influxql.ShowSeriesStatement) (ast.Expression, error) {
+	if stmt.Limit > 0 || stmt.Offset > 0 {
+		return nil, errors.New("unimplemented: LIMIT and OFFSET in SHOW SERIES")
+	}
+
+	expr, err := t.schemaSource(stmt.Database, stmt.Sources, stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+	return pipe(t.series(expr),
+		call("set",
+			property("key", &ast.StringLiteral{Value: seriesKeyColumn}),
+			property("value", &ast.StringLiteral{Value: ""}),
+		),
+	), nil
+}
+
+// transpileShowSeriesCardinality transpiles SHOW SERIES CARDINALITY by counting the
+// number of distinct series.
+func (t *transpilerState) transpileShowSeriesCardinality(ctx context.Context, stmt *influxql.ShowSeriesCardinalityStatement) (ast.Expression, error) {
+	if len(stmt.Dimensions) > 0 {
+		return nil, errors.New("unimplemented: GROUP BY in SHOW SERIES CARDINALITY")
+	}
+
+	expr, err := t.schemaSource(stmt.Database, stmt.Sources, stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+	return pipe(t.series(expr),
+		call("keep", property("columns", stringArray("_measurement"))),
+		call("group"),
+		call("count", property("columns", stringArray("_measurement"))),
+		call("rename", property("columns", renameColumns("_measurement", "count"))),
+	), nil
+}
+
+// series produces a table with a single row for each series. The tables of the fields
+// of a series are grouped together by the remaining columns, which are the measurement and the tags.
+func (t *transpilerState) series(expr ast.Expression) ast.Expression {
+	return pipe(expr,
+		call("drop", property("columns", stringArray("_field", "_value", "_time", "_start", "_stop"))),
+		call("group",
+			property("columns", stringArray()),
+			property("mode", &ast.StringLiteral{Value: "except"}),
+		),
+		call("limit", property("n", &ast.IntegerLiteral{Value: 1})),
+	)
+}
+
+// schemaSource creates the from, range, and filter calls that are common to all of the
+// schema statements. The sources are used to filter the measurement names and the time
+// range is taken from the condition when it is present.
+func (t *transpilerState) schemaSource(database string, sources influxql.Sources, condition influxql.Expr) (ast.Expression, error) {
+	// Schema statements do not factor in retention policies so we always use the default
+	// retention policy when evaluating which bucket we are querying.
+	if database == "" {
+		if t.config.DefaultDatabase == "" {
+			return nil, errDatabaseNameRequired
+		}
+		database = t.config.DefaultDatabase
+	}
+
+	expr, err := t.from(&influxql.Measurement{Database: database})
+	if err != nil {
+		return nil, err
+	}
+
+	valuer := influxql.NowValuer{Now: t.config.Now}
+	cond, tr, err := influxql.ConditionExpr(condition, &valuer)
+	if err != nil {
+		return nil, err
+	}
+
+	// Default to the same range as SHOW TAG VALUES when the condition does not
+	// specify a time range.
+	var start, stop ast.Expression
+	if tr.Min.IsZero() {
+		start = &ast.DurationLiteral{
+			Values: []ast.Duration{{Magnitude: -1, Unit: "h"}},
+		}
+	} else {
+		start = &ast.DateTimeLiteral{Value: tr.MinTime().UTC()}
+	}
+	rangeArgs := []*ast.Property{property("start", start)}
+	if !tr.Max.IsZero() {
+		stop = &ast.DateTimeLiteral{Value: tr.MaxTime().UTC()}
+		rangeArgs = append(rangeArgs, property("stop", stop))
+	}
+	expr = pipe(expr, call("range", rangeArgs...))
+
+	filterExpr, err := t.measurementFilter(sources)
+	if err != nil {
+		return nil, err
+	}
+	if cond != nil {
+		condExpr, err := t.mapField(cond, schemaCursor{})
+		if err != nil {
+			return nil, err
+		}
+		if filterExpr == nil {
+			filterExpr = condExpr
+		} else {
+			filterExpr = &ast.LogicalExpression{
+				Operator: ast.AndOperator,
+				Left:     filterExpr,
+				Right:    condExpr,
+			}
+		}
+	}
+
+	if filterExpr != nil {
+		expr = pipe(expr, call("filter", property("fn", predicate(filterExpr))))
+	}
+	return expr, nil
+}
+
+// measurementFilter constructs the expression to filter the measurement names by
+// the names or regular expressions in the sources.
+func (t *transpilerState) measurementFilter(sources influxql.Sources) (ast.Expression, error) {
+	var filterExpr ast.Expression
+	for i := len(sources) - 1; i >= 0; i-- {
+		mm, ok := sources[i].(*influxql.Measurement)
+		if !ok {
+			return nil, errors.New("unimplemented: source must be a measurement")
+		}
+
+		var cmp ast.Expression
+		if mm.Regex != nil {
+			cmp = &ast.BinaryExpression{
+				Operator: ast.RegexpMatchOperator,
+				Left:     member("_measurement"),
+				Right:    &ast.RegexpLiteral{Value: mm.Regex.Val},
+			}
+		} else {
+			cmp = &ast.BinaryExpression{
+				Operator: ast.EqualOperator,
+				Left:     member("_measurement"),
+				Right:    &ast.StringLiteral{Value: mm.Name},
+			}
+		}
+
+		if filterExpr == nil {
+			filterExpr = cmp
+			continue
+		}
+		filterExpr = &ast.LogicalExpression{
+			Operator: ast.OrOperator,
+			Left:     cmp,
+			Right:    filterExpr,
+		}
+	}
+	return filterExpr, nil
+}
+
+// schemaCursor is a pseudo-cursor used to evaluate the condition of a schema statement.
+// Every variable reference is a tag and the special _name variable refers to the measurement.
+type schemaCursor struct{}
+
+func (schemaCursor) Expr() ast.Expression { return nil }
+
+func (schemaCursor) Keys() []influxql.Expr { return nil }
+
+func (schemaCursor) Value(expr influxql.Expr) (string, bool) {
+	ref, ok := expr.(*influxql.VarRef)
+	if !ok {
+		return "", false
+	}
+	if ref.Val == "_name" {
+		return "_measurement", true
+	}
+	return ref.Val, true
+}
+
+// pipeLimit pipes the expression into a limit call if a limit or offset is set.
+func pipeLimit(expr ast.Expression, limit, offset int) ast.Expression {
+	if limit <= 0 && offset <= 0 {
+		return expr
+	}
+
+	// The limit call requires n so use the largest possible value
+	// if only the offset is set.
+	n := int64(limit)
+	if n <= 0 {
+		n = 1<<63 - 1
+	}
+	args := []*ast.Property{property("n", &ast.IntegerLiteral{Value: n})}
+	if offset > 0 {
+		args = append(args, property("offset", &ast.IntegerLiteral{Value: int64(offset)}))
+	}
+	return pipe(expr, call("limit", args...))
+}
+
+// pipe chains each of the calls onto the argument with a pipe expression.
+func pipe(arg ast.Expression, calls ...*ast.CallExpression) ast.Expression {
+	for _, c := range calls {
+		arg = &ast.PipeExpression{
+			Argument: arg,
+			Call:     c,
+		}
+	}
+	return arg
+}
+
+// call creates a call expression to the named function with the given properties
+// as its arguments.
+func call(name string, props ...*ast.Property) *ast.CallExpression {
+	expr := &ast.CallExpression{
+		Callee: &ast.Identifier{Name: name},
+	}
+	if len(props) > 0 {
+		expr.Arguments = []ast.Expression{
+			&ast.ObjectExpression{Properties: props},
+		}
+	}
+	return expr
+}
+
+func property(key string, value ast.Expression) *ast.Property {
+	return &ast.Property{
+		Key:   &ast.Identifier{Name: key},
+		Value: value,
+	}
+}
+
+func stringArray(values ...string) *ast.ArrayExpression {
+	elements := make([]ast.Expression, 0, len(values))
+	for _, v := range values {
+		elements = append(elements, &ast.StringLiteral{Value: v})
+	}
+	return &ast.ArrayExpression{Elements: elements}
+}
+
+func renameColumns(from, to string) *ast.ObjectExpression {
+	return &ast.ObjectExpression{
+		Properties: []*ast.Property{
+			property(from, &ast.StringLiteral{Value: to}),
+		},
+	}
+}
+
+// member creates a member expression accessing the named property of r.
+func member(name string) *ast.MemberExpression {
+	return &ast.MemberExpression{
+		Object:   &ast.Identifier{Name: "r"},
+		Property: &ast.Identifier{Name: name},
+	}
+}
+
+// predicate creates a function expression with a single r parameter.
+func predicate(body ast.Node) *ast.FunctionExpression {
+	return &ast.FunctionExpression{
+		Params: []*ast.Property{{
+			Key: &ast.Identifier{Name: "r"},
+		}},
+		Body: body,
+	}
+}
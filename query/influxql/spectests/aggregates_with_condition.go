@@ -14,8 +14,7 @@ func init() {
 	|> filter(fn: (r) => r["host"] == "server01")
 	|> group(columns: ["_measurement", "_start"], mode: "by")
 	|> ` + name + `()
-	|> duplicate(column: "_start", as: "_time")
-	|> map(fn: (r) => ({_time: r._time, ` + name + `: r._value}))
+	|> map(fn: (r) => ({_time: 1970-01-01T00:00:00Z, ` + name + `: r._value}))
 	|> yield(name: "0")
 `
 		}),
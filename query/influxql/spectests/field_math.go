@@ -0,0 +1,41 @@
+package spectests
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SELECT value / total FROM db0..cpu`,
+			`package main
+
+from(bucketID: "")
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and (r._field == "value" or r._field == "total"))
+	|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+	|> group(columns: ["_measurement", "_start"], mode: "by")
+	|> sort(columns: ["_time"])
+	|> map(fn: (r) => ({_time: r._time, value_total: r["value"] / r["total"]}))
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT mean(value) * 100 / max(total) FROM db0..cpu GROUP BY host`,
+			`package main
+
+t0 = from(bucketID: "")
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "host"], mode: "by")
+	|> mean()
+t1 = from(bucketID: "")
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "total")
+	|> group(columns: ["_measurement", "_start", "host"], mode: "by")
+	|> sort(columns: ["_time"])
+	|> max()
+	|> drop(columns: ["_time"])
+join(tables: {t0: t0, t1: t1}, on: ["_measurement", "host"])
+	|> map(fn: (r) => ({_time: 1970-01-01T00:00:00Z, mean_max: r["t0__value"] * 100 / r["t1__value"]}))
+	|> yield(name: "0")
+`,
+		),
+	)
+}
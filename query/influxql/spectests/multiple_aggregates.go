@@ -11,16 +11,15 @@ t0 = from(bucketID: "")
 	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
 	|> group(columns: ["_measurement", "_start"], mode: "by")
 	|> mean()
-	|> duplicate(column: "_start", as: "_time")
 t1 = from(bucketID: "")
 	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
 	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
 	|> group(columns: ["_measurement", "_start"], mode: "by")
+	|> sort(columns: ["_time"])
 	|> max()
 	|> drop(columns: ["_time"])
-	|> duplicate(column: "_start", as: "_time")
-join(tables: {t0: t0, t1: t1}, on: ["_time", "_measurement"])
-	|> map(fn: (r) => ({_time: r._time, mean: r["t0__value"], max: r["t1__value"]}))
+join(tables: {t0: t0, t1: t1}, on: ["_measurement"])
+	|> map(fn: (r) => ({_time: 1970-01-01T00:00:00Z, mean: r["t0__value"], max: r["t1__value"]}))
 	|> yield(name: "0")
 `,
 		),
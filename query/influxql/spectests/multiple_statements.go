@@ -11,13 +11,13 @@ from(bucketID: "")
 	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
 	|> group(columns: ["_measurement", "_start"], mode: "by")
 	|> mean()
-	|> duplicate(column: "_start", as: "_time")
-	|> map(fn: (r) => ({_time: r._time, mean: r._value}))
+	|> map(fn: (r) => ({_time: 1970-01-01T00:00:00Z, mean: r._value}))
 	|> yield(name: "0")
 from(bucketID: "")
 	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
 	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
 	|> group(columns: ["_measurement", "_start"], mode: "by")
+	|> sort(columns: ["_time"])
 	|> max()
 	|> map(fn: (r) => ({_time: r._time, max: r._value}))
 	|> yield(name: "1")
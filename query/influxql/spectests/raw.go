@@ -10,8 +10,24 @@ from(bucketID: "")
 	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
 	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
 	|> group(columns: ["_measurement", "_start"], mode: "by")
+	|> sort(columns: ["_time"])
 	|> map(fn: (r) => ({_time: r._time, value: r._value}))
 	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT value FROM db0..cpu ORDER BY time DESC LIMIT 2 OFFSET 1`,
+			`package main
+
+from(bucketID: "")
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start"], mode: "by")
+	|> sort(columns: ["_time"])
+	|> map(fn: (r) => ({_time: r._time, value: r._value}))
+	|> sort(columns: ["_time"], desc: true)
+	|> limit(n: 2, offset: 1)
+	|> yield(name: "0")
 `,
 		),
 	)
@@ -11,6 +11,7 @@ from(bucketID: "")
 	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
 	|> filter(fn: (r) => r["host"] == "server01")
 	|> group(columns: ["_measurement", "_start"], mode: "by")
+	|> sort(columns: ["_time"])
 	|> map(fn: (r) => ({_time: r._time, value: r._value}))
 	|> yield(name: "0")
 `,
@@ -0,0 +1,26 @@
+package spectests
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SELECT mean(value) INTO db0..cpu_mean FROM db0..cpu GROUP BY host`,
+			`package main
+
+from(bucketID: "")
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "host"], mode: "by")
+	|> mean()
+	|> map(fn: (r) => ({_time: 1970-01-01T00:00:00Z, mean: r._value}))
+	|> set(key: "_measurement", value: "cpu_mean")
+	|> to(bucketID: "", orgID: "", tagColumns: ["host"], fieldFn: (r) => ({"mean": r["mean"]}))
+	|> group()
+	|> count(columns: ["_measurement"])
+	|> map(fn: (r) => ({_time: 1970-01-01T00:00:00Z, written: r._measurement}))
+	|> set(key: "_measurement", value: "result")
+	|> group(columns: ["_measurement"])
+	|> yield(name: "0")
+`,
+		),
+	)
+}
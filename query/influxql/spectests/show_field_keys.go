@@ -0,0 +1,35 @@
+package spectests
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SHOW FIELD KEYS FROM "cpu"`,
+			`package main
+
+from(bucketID: "")
+	|> range(start: -1h)
+	|> filter(fn: (r) => r._measurement == "cpu")
+	|> keep(columns: ["_measurement", "_field", "_value"])
+	|> group(columns: ["_measurement", "_field"], mode: "by")
+	|> last()
+	|> duplicate(column: "_field", as: "fieldKey")
+	|> rename(columns: {_value: "_fieldType"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SHOW FIELD KEY CARDINALITY`,
+			`package main
+
+from(bucketID: "")
+	|> range(start: -1h)
+	|> keep(columns: ["_measurement", "_field"])
+	|> group(columns: ["_measurement"], mode: "by")
+	|> distinct(column: "_field")
+	|> count()
+	|> rename(columns: {_value: "count"})
+	|> yield(name: "0")
+`,
+		),
+	)
+}
@@ -0,0 +1,70 @@
+package spectests
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SHOW MEASUREMENTS`,
+			`package main
+
+from(bucketID: "")
+	|> range(start: -1h)
+	|> keep(columns: ["_measurement"])
+	|> group()
+	|> distinct(column: "_measurement")
+	|> sort()
+	|> set(key: "_measurement", value: "measurements")
+	|> group(columns: ["_measurement"], mode: "by")
+	|> rename(columns: {_value: "name"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SHOW MEASUREMENTS ON "db0" WITH MEASUREMENT =~ /cpu.*/ WHERE host = 'server01' LIMIT 10 OFFSET 5`,
+			`package main
+
+from(bucketID: "")
+	|> range(start: -1h)
+	|> filter(fn: (r) => r._measurement =~ /cpu.*/ and r["host"] == "server01")
+	|> keep(columns: ["_measurement"])
+	|> group()
+	|> distinct(column: "_measurement")
+	|> sort()
+	|> limit(n: 10, offset: 5)
+	|> set(key: "_measurement", value: "measurements")
+	|> group(columns: ["_measurement"], mode: "by")
+	|> rename(columns: {_value: "name"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SHOW MEASUREMENTS WHERE time >= '2010-09-15T08:00:00Z' AND time < '2010-09-15T09:00:00Z'`,
+			`package main
+
+from(bucketID: "")
+	|> range(start: 2010-09-15T08:00:00Z, stop: 2010-09-15T08:59:59.999999999Z)
+	|> keep(columns: ["_measurement"])
+	|> group()
+	|> distinct(column: "_measurement")
+	|> sort()
+	|> set(key: "_measurement", value: "measurements")
+	|> group(columns: ["_measurement"], mode: "by")
+	|> rename(columns: {_value: "name"})
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SHOW MEASUREMENT CARDINALITY`,
+			`package main
+
+from(bucketID: "")
+	|> range(start: -1h)
+	|> keep(columns: ["_measurement"])
+	|> group()
+	|> distinct(column: "_measurement")
+	|> count()
+	|> rename(columns: {_value: "count"})
+	|> yield(name: "0")
+`,
+		),
+	)
+}
@@ -0,0 +1,36 @@
+package spectests
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SHOW SERIES FROM "cpu" WHERE "host" = 'server01'`,
+			`package main
+
+from(bucketID: "")
+	|> range(start: -1h)
+	|> filter(fn: (r) => r._measurement == "cpu" and r["host"] == "server01")
+	|> drop(columns: ["_field", "_value", "_time", "_start", "_stop"])
+	|> group(columns: [], mode: "except")
+	|> limit(n: 1)
+	|> set(key: "_seriesKey", value: "")
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SHOW SERIES CARDINALITY`,
+			`package main
+
+from(bucketID: "")
+	|> range(start: -1h)
+	|> drop(columns: ["_field", "_value", "_time", "_start", "_stop"])
+	|> group(columns: [], mode: "except")
+	|> limit(n: 1)
+	|> keep(columns: ["_measurement"])
+	|> group()
+	|> count(columns: ["_measurement"])
+	|> rename(columns: {_measurement: "count"})
+	|> yield(name: "0")
+`,
+		),
+	)
+}
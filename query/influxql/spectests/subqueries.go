@@ -0,0 +1,43 @@
+package spectests
+
+func init() {
+	RegisterFixture(
+		NewFixture(
+			`SELECT mean(value) FROM (SELECT value FROM db0..cpu WHERE value > 0) WHERE time >= now() - 1h`,
+			`package main
+
+t0 = from(bucketID: "")
+	|> range(start: 2010-09-15T08:00:00Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> filter(fn: (r) => r._value > 0)
+	|> group(columns: ["_measurement", "_start"], mode: "by")
+	|> sort(columns: ["_time"])
+	|> map(fn: (r) => ({_time: r._time, value: r._value}))
+t0
+	|> group(columns: ["_measurement", "_start"], mode: "by")
+	|> mean(columns: ["value"])
+	|> duplicate(column: "_start", as: "_time")
+	|> map(fn: (r) => ({_time: r._time, mean: r["value"]}))
+	|> yield(name: "0")
+`,
+		),
+		NewFixture(
+			`SELECT max(mean) FROM (SELECT mean(value) FROM db0..cpu GROUP BY host)`,
+			`package main
+
+t0 = from(bucketID: "")
+	|> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775806Z)
+	|> filter(fn: (r) => r._measurement == "cpu" and r._field == "value")
+	|> group(columns: ["_measurement", "_start", "host"], mode: "by")
+	|> mean()
+	|> map(fn: (r) => ({_time: 1970-01-01T00:00:00Z, mean: r._value}))
+t0
+	|> group(columns: ["_measurement", "_start"], mode: "by")
+	|> sort(columns: ["_time"])
+	|> max(column: "mean")
+	|> map(fn: (r) => ({_time: r._time, max: r["mean"]}))
+	|> yield(name: "0")
+`,
+		),
+	)
+}
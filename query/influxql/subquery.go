@@ -0,0 +1,127 @@
+package influxql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxql"
+)
+
+// subQueryCursor reads the columns produced by a subquery. Each of the columns
+// selected by the subquery is available using the same name in the outer query.
+type subQueryCursor struct {
+	expr ast.Expression
+	refs []*influxql.VarRef
+}
+
+// createSubQueryCursor transpiles the subquery and creates a cursor that reads
+// the variable references from its results. The subquery is only transpiled once
+// and it is assigned to a variable so every cursor reading from it shares the result.
+func createSubQueryCursor(t *transpilerState, src *influxql.SubQuery, refs []*influxql.VarRef) (cursor, error) {
+	ident, ok := t.subqueries[src]
+	if !ok {
+		stmt, err := t.subQueryStatement(src)
+		if err != nil {
+			return nil, err
+		}
+
+		// Transpile the inner statement and restore the outer statement afterwards
+		// since the transpiler state only tracks the statement being transpiled.
+		outer := t.stmt
+		cur, err := t.transpileSelect(context.TODO(), stmt)
+		t.stmt = outer
+		if err != nil {
+			return nil, err
+		}
+		ident = t.assignment(cur.Expr())
+		t.subqueries[src] = ident
+	}
+	return &subQueryCursor{
+		expr: &ast.Identifier{Name: ident.Name},
+		refs: refs,
+	}, nil
+}
+
+// subQueryStatement returns the statement for the subquery with the time range
+// of the outer query applied to it. The outer time range is only used when the
+// subquery does not restrict the time range itself.
+func (t *transpilerState) subQueryStatement(src *influxql.SubQuery) (*influxql.SelectStatement, error) {
+	stmt := src.Statement.Clone()
+	if stmt.Target != nil {
+		return nil, errors.New("subqueries cannot write to a target")
+	} else if len(stmt.SortFields) > 0 && stmt.TimeAscending() != t.stmt.TimeAscending() {
+		return nil, errors.New("subqueries must be ordered in the same direction as the query itself")
+	}
+
+	valuer := influxql.NowValuer{Now: t.config.Now}
+	_, outer, err := influxql.ConditionExpr(t.stmt.Condition, &valuer)
+	if err != nil {
+		return nil, err
+	}
+	_, inner, err := influxql.ConditionExpr(stmt.Condition, &valuer)
+	if err != nil {
+		return nil, err
+	}
+
+	var cond influxql.Expr
+	if inner.Min.IsZero() && !outer.Min.IsZero() {
+		cond = &influxql.BinaryExpr{
+			Op:  influxql.GTE,
+			LHS: &influxql.VarRef{Val: "time"},
+			RHS: &influxql.TimeLiteral{Val: outer.Min},
+		}
+	}
+	if inner.Max.IsZero() && !outer.Max.IsZero() {
+		expr := &influxql.BinaryExpr{
+			Op:  influxql.LTE,
+			LHS: &influxql.VarRef{Val: "time"},
+			RHS: &influxql.TimeLiteral{Val: outer.Max},
+		}
+		if cond == nil {
+			cond = expr
+		} else {
+			cond = &influxql.BinaryExpr{Op: influxql.AND, LHS: cond, RHS: expr}
+		}
+	}
+
+	if cond != nil {
+		if stmt.Condition == nil {
+			stmt.Condition = cond
+		} else {
+			stmt.Condition = &influxql.BinaryExpr{
+				Op:  influxql.AND,
+				LHS: &influxql.ParenExpr{Expr: stmt.Condition},
+				RHS: cond,
+			}
+		}
+	}
+	return stmt, nil
+}
+
+func (c *subQueryCursor) Expr() ast.Expression {
+	return c.expr
+}
+
+func (c *subQueryCursor) Keys() []influxql.Expr {
+	keys := make([]influxql.Expr, 0, len(c.refs))
+	for _, ref := range c.refs {
+		keys = append(keys, ref)
+	}
+	return keys
+}
+
+func (c *subQueryCursor) Value(expr influxql.Expr) (string, bool) {
+	ref, ok := expr.(*influxql.VarRef)
+	if !ok {
+		return "", false
+	}
+
+	// The subquery maps each of its fields to a column with the column name.
+	for _, r := range c.refs {
+		if ref == r || ref.Val == r.Val {
+			return ref.Val, true
+		}
+	}
+	return "", false
+}
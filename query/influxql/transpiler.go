@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/influxdata/flux/ast"
@@ -31,8 +32,14 @@ func NewTranspilerWithConfig(dbrpMappingSvc platform.DBRPMappingService, cfg Con
 }
 
 func (t *Transpiler) Transpile(ctx context.Context, txt string) (*ast.Package, error) {
-	// Parse the text of the query.
-	q, err := influxql.ParseQuery(txt)
+	// Parse the text of the query binding the params to the placeholders.
+	params, err := bindParams(t.Config.Params)
+	if err != nil {
+		return nil, err
+	}
+	p := influxql.NewParser(strings.NewReader(txt))
+	p.SetParams(params)
+	q, err := p.ParseQuery()
 	if err != nil {
 		return nil, err
 	}
@@ -56,6 +63,7 @@ type transpilerState struct {
 	config         Config
 	file           *ast.File
 	assignments    map[string]ast.Expression
+	subqueries     map[*influxql.SubQuery]*ast.Identifier
 	dbrpMappingSvc platform.DBRPMappingService
 }
 
@@ -69,6 +77,7 @@ func newTranspilerState(dbrpMappingSvc platform.DBRPMappingService, config *Conf
 			},
 		},
 		assignments:    make(map[string]ast.Expression),
+		subqueries:     make(map[*influxql.SubQuery]*ast.Identifier),
 		dbrpMappingSvc: dbrpMappingSvc,
 	}
 	if config != nil {
@@ -123,6 +132,22 @@ func (t *transpilerState) transpile(ctx context.Context, s influxql.Statement) (
 		return t.transpileShowDatabases(ctx, stmt)
 	case *influxql.ShowRetentionPoliciesStatement:
 		return t.transpileShowRetentionPolicies(ctx, stmt)
+	case *influxql.ShowMeasurementsStatement:
+		return t.transpileShowMeasurements(ctx, stmt)
+	case *influxql.ShowMeasurementCardinalityStatement:
+		return t.transpileShowMeasurementCardinality(ctx, stmt)
+	case *influxql.ShowTagKeysStatement:
+		return t.transpileShowTagKeys(ctx, stmt)
+	case *influxql.ShowTagKeyCardinalityStatement:
+		return t.transpileShowTagKeyCardinality(ctx, stmt)
+	case *influxql.ShowFieldKeysStatement:
+		return t.transpileShowFieldKeys(ctx, stmt)
+	case *influxql.ShowFieldKeyCardinalityStatement:
+		return t.transpileShowFieldKeyCardinality(ctx, stmt)
+	case *influxql.ShowSeriesStatement:
+		return t.transpileShowSeries(ctx, stmt)
+	case *influxql.ShowSeriesCardinalityStatement:
+		return t.transpileShowSeriesCardinality(ctx, stmt)
 	default:
 		return nil, fmt.Errorf("unknown statement type %T", s)
 	}
@@ -615,34 +640,127 @@ func (t *transpilerState) transpileSelect(ctx context.Context, stmt *influxql.Se
 		return nil, errors.New("at least 1 non-time field must be queried")
 	}
 
+	epoch, err := t.aggregatesAtEpoch(groups)
+	if err != nil {
+		return nil, err
+	}
+
 	cursors := make([]cursor, 0, len(groups))
 	for _, gr := range groups {
-		cur, err := gr.createCursor(t)
+		cur, err := gr.createCursor(t, epoch)
 		if err != nil {
 			return nil, err
 		}
 		cursors = append(cursors, cur)
 	}
 
-	// Join the cursors together on the measurement name.
-	// TODO(jsternberg): This needs to join on all remaining group keys.
-	cur := Join(t, cursors, []string{"_time", "_measurement"})
+	// Join the cursors together on the measurement name and the tags in the dimensions.
+	// The aggregates at the epoch have no time to join on, there is a single row for each group.
+	on := []string{"_time", "_measurement"}
+	if epoch {
+		on = on[1:]
+	}
+	for _, d := range t.stmt.Dimensions {
+		if ref, ok := d.Expr.(*influxql.VarRef); ok {
+			on = append(on, ref.Val)
+		}
+	}
+	cur := Join(t, cursors, on)
 
 	// Map each of the fields into another cursor. This evaluates any lingering expressions.
-	cur, err = t.mapFields(cur)
+	cur, err = t.mapFields(cur, epoch)
 	if err != nil {
 		return nil, err
 	}
+
+	// The rows are sorted by time in ascending order when they are grouped.
+	// Order them and limit the number of rows of each series like 1.x.
+	expr := cur.Expr()
+	if !t.stmt.TimeAscending() {
+		expr = pipe(expr, call("sort",
+			property("columns", stringArray("_time")),
+			property("desc", &ast.BooleanLiteral{Value: true}),
+		))
+	}
+	expr = pipeLimit(expr, t.stmt.Limit, t.stmt.Offset)
+	if expr != cur.Expr() {
+		cur = &pipeCursor{expr: expr, cursor: cur}
+	}
+
+	// Write the results to the target if this is a SELECT INTO statement.
+	if t.stmt.Target != nil {
+		return t.writeTarget(cur)
+	}
 	return cur, nil
 }
 
+// aggregatesAtEpoch returns true if the time of the rows is the epoch. The aggregates that are not
+// grouped by time are at the start of the time range, which is the epoch in 1.x when the
+// query has no lower time bound. A single selector keeps the time of the point it selects.
+func (t *transpilerState) aggregatesAtEpoch(groups []*groupInfo) (bool, error) {
+	if groups[0].call == nil || (len(groups) == 1 && groups[0].selector) {
+		return false, nil
+	}
+	if interval, err := t.stmt.GroupByInterval(); err != nil {
+		return false, err
+	} else if interval > 0 {
+		return false, nil
+	}
+
+	valuer := influxql.NowValuer{Now: t.config.Now}
+	_, tr, err := influxql.ConditionExpr(t.stmt.Condition, &valuer)
+	if err != nil {
+		return false, err
+	}
+	return tr.Min.IsZero(), nil
+}
+
 func (t *transpilerState) mapType(ref *influxql.VarRef) influxql.DataType {
+	// The columns selected by a subquery are always fields in the outer query.
+	if len(t.stmt.Sources) == 1 {
+		if src, ok := t.stmt.Sources[0].(*influxql.SubQuery); ok {
+			for _, name := range src.Statement.ColumnNames() {
+				if name == ref.Val {
+					return influxql.Unknown
+				}
+			}
+		}
+	}
+
 	// TODO(jsternberg): Actually evaluate the type against the schema.
 	return influxql.Tag
 }
 
 func (t *transpilerState) from(m *influxql.Measurement) (ast.Expression, error) {
-	db, rp := m.Database, m.RetentionPolicy
+	mapping, err := t.findMapping(m.Database, m.RetentionPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.CallExpression{
+		Callee: &ast.Identifier{
+			Name: "from",
+		},
+		Arguments: []ast.Expression{
+			&ast.ObjectExpression{
+				Properties: []*ast.Property{
+					{
+						Key: &ast.Identifier{
+							Name: "bucketID",
+						},
+						Value: &ast.StringLiteral{
+							Value: mapping.BucketID.String(),
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// findMapping finds the bucket mapping for the database and retention policy.
+// The configured defaults are used when either of them is not specified.
+func (t *transpilerState) findMapping(db, rp string) (*platform.DBRPMapping, error) {
 	if db == "" {
 		if t.config.DefaultDatabase == "" {
 			return nil, errors.New("database is required")
@@ -669,26 +787,7 @@ func (t *transpilerState) from(m *influxql.Measurement) (ast.Expression, error)
 	if err != nil {
 		return nil, err
 	}
-
-	return &ast.CallExpression{
-		Callee: &ast.Identifier{
-			Name: "from",
-		},
-		Arguments: []ast.Expression{
-			&ast.ObjectExpression{
-				Properties: []*ast.Property{
-					{
-						Key: &ast.Identifier{
-							Name: "bucketID",
-						},
-						Value: &ast.StringLiteral{
-							Value: mapping.BucketID.String(),
-						},
-					},
-				},
-			},
-		},
-	}, nil
+	return mapping, nil
 }
 
 func (t *transpilerState) assignment(expr ast.Expression) *ast.Identifier {
@@ -2,9 +2,13 @@ package influxql_test
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/ast"
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/mock"
 	"github.com/influxdata/influxdb/query/influxql"
@@ -406,3 +410,72 @@ func TestTranspiler_Compile(t *testing.T) {
 		})
 	}
 }
+
+func TestTranspiler_Params(t *testing.T) {
+	for _, tt := range []struct {
+		s      string
+		params map[string]interface{}
+		want   string // the query the params are bound to, if empty an error is expected
+	}{
+		{
+			s:      `SELECT value FROM cpu WHERE host = $host AND value > $min`,
+			params: map[string]interface{}{"host": "server01", "min": json.Number("10")},
+			want:   `SELECT value FROM cpu WHERE host = 'server01' AND value > 10`,
+		},
+		{
+			s:      `SELECT value FROM cpu WHERE value > $min AND up = $up`,
+			params: map[string]interface{}{"min": 1.5, "up": true},
+			want:   `SELECT value FROM cpu WHERE value > 1.5 AND up = true`,
+		},
+		{
+			s: `SELECT mean(value) FROM cpu WHERE time >= $start AND time < $stop GROUP BY time(1m)`,
+			params: map[string]interface{}{
+				"start": time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+				"stop":  "2019-01-01T01:00:00Z",
+			},
+			want: `SELECT mean(value) FROM cpu WHERE time >= '2019-01-01T00:00:00Z' AND time < '2019-01-01T01:00:00Z' GROUP BY time(1m)`,
+		},
+		{
+			s: `SELECT value FROM cpu WHERE host = $host`,
+		},
+		{
+			s:      `SELECT value FROM cpu WHERE host = $host`,
+			params: map[string]interface{}{"host": []string{"a"}},
+		},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			transpile := func(s string, params map[string]interface{}) (string, error) {
+				transpiler := influxql.NewTranspilerWithConfig(
+					dbrpMappingSvc,
+					influxql.Config{
+						DefaultDatabase: "db0",
+						Now:             time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+						Params:          params,
+					},
+				)
+				pkg, err := transpiler.Transpile(context.Background(), s)
+				if err != nil {
+					return "", err
+				}
+				return ast.Format(pkg), nil
+			}
+
+			got, err := transpile(tt.s, tt.params)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			want, err := transpile(tt.want, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != want {
+				t.Errorf("unexpected query -want/+got:\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
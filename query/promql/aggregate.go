@@ -0,0 +1,88 @@
+package promql
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux/ast"
+)
+
+// aggregate aggregates the series of a vector at each evaluation time.
+func (t *transpiler) aggregate(agg *AggregateExpr) (*value, error) {
+	var arg Arg = agg.Selector
+	if agg.Selector == nil {
+		arg = agg.Expr
+	}
+	expr, err := t.instantVector(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	var labels []string
+	if agg.Aggregate != nil {
+		for _, l := range agg.Aggregate.Labels {
+			labels = append(labels, l.Name)
+		}
+	}
+	without := agg.Aggregate != nil && agg.Aggregate.Without
+
+	// The series are grouped by time so each evaluation time is aggregated separately.
+	if without {
+		expr = pipe(expr, groupExcept(append([]string{"_value", nameLabel}, labels...)...))
+	} else {
+		expr = pipe(expr, call("group", property("columns", stringArray(append([]string{"_time"}, labels...)...))))
+	}
+
+	var calls []*ast.CallExpression
+	switch agg.Op.Kind {
+	case SumKind:
+		calls = append(calls, call("sum"))
+	case AvgKind:
+		calls = append(calls, call("mean"))
+	case CountKind:
+		calls = append(calls, call("count"), call("toFloat"))
+	case StdevKind:
+		calls = append(calls, call("stddev"))
+	case MinKind, MaxKind:
+		name := "min"
+		if agg.Op.Kind == MaxKind {
+			name = "max"
+		}
+		// The selectors keep all columns of the selected row so only the
+		// labels that are aggregated by are retained.
+		calls = append(calls, call(name))
+		if without {
+			calls = append(calls, dropColumns(append([]string{nameLabel}, labels...)...))
+		} else {
+			calls = append(calls, keepColumns(append([]string{"_time", "_value"}, labels...)...))
+		}
+	case TopKind, BottomKind:
+		// The selected series keep all of their labels.
+		n, ok := agg.Op.Arg.(*Number)
+		if !ok {
+			return nil, fmt.Errorf("expected number as the parameter of topk and bottomk")
+		}
+		name := "top"
+		if agg.Op.Kind == BottomKind {
+			name = "bottom"
+		}
+		calls = append(calls, call(name, property("n", &ast.IntegerLiteral{Value: int64(n.Val)})))
+	case QuantileKind:
+		q, ok := agg.Op.Arg.(*Number)
+		if !ok {
+			return nil, fmt.Errorf("expected number as the parameter of quantile")
+		}
+		calls = append(calls, call("quantile",
+			property("q", &ast.FloatLiteral{Value: q.Val}),
+			property("method", &ast.StringLiteral{Value: "exact_mean"}),
+		))
+	case CountValuesKind:
+		return nil, fmt.Errorf("count_values is not supported")
+	case StdVarKind:
+		return nil, fmt.Errorf("stdvar is not supported")
+	default:
+		return nil, fmt.Errorf("unknown aggregation operator %d", agg.Op.Kind)
+	}
+
+	expr = pipe(expr, calls...)
+	return t.vector(pipe(expr, groupExcept("_time", "_value"))), nil
+}
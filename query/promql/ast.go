@@ -0,0 +1,159 @@
+package promql
+
+import (
+	"time"
+
+	"github.com/influxdata/flux/ast"
+)
+
+// pipe pipes the argument through each of the calls.
+func pipe(arg ast.Expression, calls ...*ast.CallExpression) ast.Expression {
+	for _, c := range calls {
+		arg = &ast.PipeExpression{
+			Argument: arg,
+			Call:     c,
+		}
+	}
+	return arg
+}
+
+// call creates a call expression to the named function with the given properties
+// as its arguments.
+func call(name string, props ...*ast.Property) *ast.CallExpression {
+	expr := &ast.CallExpression{
+		Callee: &ast.Identifier{Name: name},
+	}
+	if len(props) > 0 {
+		expr.Arguments = []ast.Expression{
+			&ast.ObjectExpression{Properties: props},
+		}
+	}
+	return expr
+}
+
+func property(key string, value ast.Expression) *ast.Property {
+	return &ast.Property{
+		Key:   &ast.Identifier{Name: key},
+		Value: value,
+	}
+}
+
+func stringArray(values ...string) *ast.ArrayExpression {
+	elements := make([]ast.Expression, 0, len(values))
+	for _, v := range values {
+		elements = append(elements, &ast.StringLiteral{Value: v})
+	}
+	return &ast.ArrayExpression{Elements: elements}
+}
+
+// member references the column of the row.
+func member(name string) *ast.MemberExpression {
+	return &ast.MemberExpression{
+		Object:   &ast.Identifier{Name: "r"},
+		Property: &ast.Identifier{Name: name},
+	}
+}
+
+// predicate creates a function expression with a single r parameter.
+func predicate(body ast.Node) *ast.FunctionExpression {
+	return &ast.FunctionExpression{
+		Params: []*ast.Property{{
+			Key: &ast.Identifier{Name: "r"},
+		}},
+		Body: body,
+	}
+}
+
+func compare(op ast.OperatorKind, lhs, rhs ast.Expression) ast.Expression {
+	return &ast.BinaryExpression{
+		Operator: op,
+		Left:     lhs,
+		Right:    rhs,
+	}
+}
+
+func logical(op ast.LogicalOperatorKind, exprs ...ast.Expression) ast.Expression {
+	if len(exprs) == 0 {
+		return &ast.BooleanLiteral{Value: op == ast.AndOperator}
+	}
+	expr := exprs[0]
+	for _, e := range exprs[1:] {
+		expr = &ast.LogicalExpression{
+			Operator: op,
+			Left:     expr,
+			Right:    e,
+		}
+	}
+	return expr
+}
+
+func and(exprs ...ast.Expression) ast.Expression {
+	return logical(ast.AndOperator, exprs...)
+}
+
+func or(exprs ...ast.Expression) ast.Expression {
+	return logical(ast.OrOperator, exprs...)
+}
+
+// duration creates a duration literal using the largest unit that
+// represents the duration exactly.
+func duration(d time.Duration) *ast.DurationLiteral {
+	units := []struct {
+		unit string
+		dur  time.Duration
+	}{
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+		{"ms", time.Millisecond},
+		{"us", time.Microsecond},
+	}
+	for _, u := range units {
+		if d%u.dur == 0 {
+			return &ast.DurationLiteral{Values: []ast.Duration{{Magnitude: int64(d / u.dur), Unit: u.unit}}}
+		}
+	}
+	return &ast.DurationLiteral{Values: []ast.Duration{{Magnitude: int64(d), Unit: "ns"}}}
+}
+
+func dropColumns(columns ...string) *ast.CallExpression {
+	return call("drop", property("columns", stringArray(columns...)))
+}
+
+// keepColumns keeps the columns that exist in the table. The columns are
+// compared in a predicate since keeping a missing column is an error.
+func keepColumns(columns ...string) *ast.CallExpression {
+	var preds []ast.Expression
+	for _, c := range columns {
+		preds = append(preds, compare(ast.EqualOperator, &ast.Identifier{Name: "column"}, &ast.StringLiteral{Value: c}))
+	}
+	return call("keep", property("fn", &ast.FunctionExpression{
+		Params: []*ast.Property{{
+			Key: &ast.Identifier{Name: "column"},
+		}},
+		Body: or(preds...),
+	}))
+}
+
+// groupExcept groups the tables by every column except the given ones.
+func groupExcept(columns ...string) *ast.CallExpression {
+	return call("group",
+		property("columns", stringArray(columns...)),
+		property("mode", &ast.StringLiteral{Value: "except"}),
+	)
+}
+
+// mapValue replaces the value of each row. The group key is retained so
+// the series keep their labels.
+func mapValue(expr ast.Expression) *ast.CallExpression {
+	return call("map", property("fn", predicate(&ast.ObjectExpression{
+		Properties: []*ast.Property{
+			property("_time", member("_time")),
+			property("_value", expr),
+		},
+	})))
+}
+
+func filter(expr ast.Expression) *ast.CallExpression {
+	return call("filter", property("fn", predicate(expr)))
+}
@@ -0,0 +1,233 @@
+package promql
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/influxdata/flux/ast"
+)
+
+// arithmeticOperators are the arithmetic operators that Flux supports.
+var arithmeticOperators = map[BinaryOpKind]ast.OperatorKind{
+	AddOp: ast.AdditionOperator,
+	SubOp: ast.SubtractionOperator,
+	MulOp: ast.MultiplicationOperator,
+	DivOp: ast.DivisionOperator,
+}
+
+var comparisonOperators = map[BinaryOpKind]ast.OperatorKind{
+	EqlOp: ast.EqualOperator,
+	NeqOp: ast.NotEqualOperator,
+	GtrOp: ast.GreaterThanOperator,
+	LssOp: ast.LessThanOperator,
+	GteOp: ast.GreaterThanEqualOperator,
+	LteOp: ast.LessThanEqualOperator,
+}
+
+func (t *transpiler) binary(b *BinaryExpr) (*value, error) {
+	if b.Op.IsSetOperator() {
+		return nil, errors.New("the and, or and unless operators are not supported")
+	}
+	lhs, err := t.transpile(b.LHS)
+	if err != nil {
+		return nil, err
+	}
+	rhs, err := t.transpile(b.RHS)
+	if err != nil {
+		return nil, err
+	}
+	if lhs.typ == MatrixValue || rhs.typ == MatrixValue {
+		return nil, errors.New("binary expressions must contain only scalar and instant vector types")
+	}
+
+	if lhs.typ == ScalarValue && rhs.typ == ScalarValue {
+		return scalarBinary(b, lhs.scalar, rhs.scalar)
+	}
+	if _, ok := arithmeticOperators[b.Op]; !ok && !b.Op.IsComparison() {
+		return nil, fmt.Errorf("operator %d is not supported between vectors", b.Op)
+	}
+	if lhs.typ == VectorValue && rhs.typ == VectorValue {
+		if b.Matching != nil && (b.Matching.GroupLeft || b.Matching.GroupRight) {
+			return t.groupBinary(b, lhs.expr, rhs.expr)
+		}
+		return t.vectorBinary(b, lhs.expr, rhs.expr)
+	}
+
+	// One side is a scalar so the operator is applied to every sample of the vector.
+	var (
+		expr        ast.Expression
+		left, right ast.Expression
+	)
+	if lhs.typ == VectorValue {
+		expr, left, right = lhs.expr, member("_value"), &ast.FloatLiteral{Value: rhs.scalar}
+	} else {
+		expr, left, right = rhs.expr, &ast.FloatLiteral{Value: lhs.scalar}, member("_value")
+	}
+	return t.vector(pipe(expr, operate(b, left, right, nil)...)), nil
+}
+
+// operate applies the operator to the left and right values of each row.
+// Comparisons without the bool modifier filter the rows and use the sample
+// value as the value of the result. Everything else drops the metric name.
+func operate(b *BinaryExpr, left, right, sample ast.Expression) []*ast.CallExpression {
+	if op, ok := arithmeticOperators[b.Op]; ok {
+		return []*ast.CallExpression{
+			dropColumns(nameLabel),
+			mapValue(compare(op, left, right)),
+		}
+	}
+	cmp := compare(comparisonOperators[b.Op], left, right)
+	if b.ReturnBool {
+		return []*ast.CallExpression{
+			dropColumns(nameLabel),
+			mapValue(&ast.CallExpression{
+				Callee: &ast.Identifier{Name: "float"},
+				Arguments: []ast.Expression{&ast.ObjectExpression{
+					Properties: []*ast.Property{property("v", cmp)},
+				}},
+			}),
+		}
+	}
+	calls := []*ast.CallExpression{filter(cmp)}
+	if sample != nil {
+		calls = append(calls, mapValue(sample))
+	}
+	return calls
+}
+
+// scalarBinary evaluates the operator between two scalars.
+func scalarBinary(b *BinaryExpr, lhs, rhs float64) (*value, error) {
+	var v float64
+	switch b.Op {
+	case AddOp:
+		v = lhs + rhs
+	case SubOp:
+		v = lhs - rhs
+	case MulOp:
+		v = lhs * rhs
+	case DivOp:
+		v = lhs / rhs
+	case ModOp:
+		v = math.Mod(lhs, rhs)
+	case PowOp:
+		v = math.Pow(lhs, rhs)
+	default:
+		if !b.ReturnBool {
+			return nil, errors.New("comparisons between scalars must use the bool modifier")
+		}
+		var r bool
+		switch b.Op {
+		case EqlOp:
+			r = lhs == rhs
+		case NeqOp:
+			r = lhs != rhs
+		case GtrOp:
+			r = lhs > rhs
+		case LssOp:
+			r = lhs < rhs
+		case GteOp:
+			r = lhs >= rhs
+		case LteOp:
+			r = lhs <= rhs
+		}
+		if r {
+			v = 1
+		}
+	}
+	return &value{typ: ScalarValue, scalar: v}, nil
+}
+
+// matchingLabels removes the labels of the series that are not used for matching.
+func matchingLabels(m *VectorMatching) *ast.CallExpression {
+	var labels []string
+	if m != nil {
+		for _, l := range m.Labels {
+			labels = append(labels, l.Name)
+		}
+	}
+	if m != nil && m.On {
+		return keepColumns(append([]string{"_time", "_value"}, labels...)...)
+	}
+	return dropColumns(append([]string{nameLabel}, labels...)...)
+}
+
+// vectorBinary applies the operator to the samples of series with the same
+// matching labels. The samples of both vectors are stored as separate columns
+// and they are summed up by their matching labels and time. The counts of the
+// samples on either side tell if a sample had exactly one match.
+func (t *transpiler) vectorBinary(b *BinaryExpr, lhs, rhs ast.Expression) (*value, error) {
+	side := func(expr ast.Expression, left bool) ast.Expression {
+		zero, sample, one := &ast.FloatLiteral{Value: 0}, member("_value"), &ast.FloatLiteral{Value: 1}
+		props := []*ast.Property{property("_time", member("_time"))}
+		if left {
+			props = append(props,
+				property("_lhs", sample), property("_rhs", zero),
+				property("_lhsCount", one), property("_rhsCount", zero),
+			)
+		} else {
+			props = append(props,
+				property("_lhs", zero), property("_rhs", sample),
+				property("_lhsCount", zero), property("_rhsCount", one),
+			)
+		}
+		return pipe(expr,
+			matchingLabels(b.Matching),
+			call("map", property("fn", predicate(&ast.ObjectExpression{Properties: props}))),
+		)
+	}
+
+	columns := []string{"_lhs", "_rhs", "_lhsCount", "_rhsCount"}
+	expr := pipe(
+		call("union", property("tables", &ast.ArrayExpression{
+			Elements: []ast.Expression{side(lhs, true), side(rhs, false)},
+		})),
+		groupExcept(columns...),
+		call("sum", property("columns", stringArray(columns...))),
+		filter(and(
+			compare(ast.EqualOperator, member("_lhsCount"), &ast.FloatLiteral{Value: 1}),
+			compare(ast.EqualOperator, member("_rhsCount"), &ast.FloatLiteral{Value: 1}),
+		)),
+	)
+	expr = pipe(expr, operate(b, member("_lhs"), member("_rhs"), member("_lhs"))...)
+	return t.vector(pipe(expr, groupExcept("_time", "_value"))), nil
+}
+
+// groupBinary applies the operator between the series of the "many" side and
+// the series of the "one" side with the same values for the labels they are
+// matched on. The result has the labels of the "many" side and the included
+// labels of the "one" side.
+func (t *transpiler) groupBinary(b *BinaryExpr, lhs, rhs ast.Expression) (*value, error) {
+	m := b.Matching
+	if !m.On {
+		return nil, errors.New("group_left and group_right are only supported together with on")
+	}
+	on := []string{"_time"}
+	for _, l := range m.Labels {
+		on = append(on, l.Name)
+	}
+	var include []string
+	for _, l := range m.Include {
+		include = append(include, l.Name)
+	}
+
+	many, one := &lhs, &rhs
+	if m.GroupRight {
+		many, one = &rhs, &lhs
+	}
+	*many = t.assignment(pipe(*many, dropColumns(append([]string{nameLabel}, include...)...)))
+	*one = t.assignment(pipe(*one, keepColumns(append(append(on, "_value"), include...)...)))
+
+	expr := pipe(
+		call("join",
+			property("tables", &ast.ObjectExpression{Properties: []*ast.Property{
+				property("lhs", lhs),
+				property("rhs", rhs),
+			}}),
+			property("on", stringArray(on...)),
+		),
+		groupExcept("_time", "_value_lhs", "_value_rhs"),
+	)
+	expr = pipe(expr, operate(b, member("_value_lhs"), member("_value_rhs"), member("_value_lhs"))...)
+	return t.vector(pipe(expr, groupExcept("_time", "_value"))), nil
+}
@@ -0,0 +1,153 @@
+package promql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/influxdata/flux/ast"
+)
+
+// rangeFunction evaluates a function of a range vector. The prepare calls are
+// applied to each series and the calls reduce the samples within the range
+// of each evaluation time to a single value.
+type rangeFunction func(rng time.Duration) (prepare, calls []*ast.CallExpression)
+
+// The functions of counters compute the differences between consecutive samples
+// before the samples are split by evaluation time so the increase from the last
+// sample before the range is part of the range. This approximates the
+// extrapolation Prometheus does to the boundaries of the range. A counter reset
+// does not add to the increase.
+var rangeFunctions = map[string]rangeFunction{
+	"rate": func(rng time.Duration) ([]*ast.CallExpression, []*ast.CallExpression) {
+		return counterDifference(), []*ast.CallExpression{
+			call("sum"),
+			mapValueOnly(&ast.BinaryExpression{
+				Operator: ast.DivisionOperator,
+				Left:     member("_value"),
+				Right:    &ast.FloatLiteral{Value: rng.Seconds()},
+			}),
+		}
+	},
+	"increase": func(time.Duration) ([]*ast.CallExpression, []*ast.CallExpression) {
+		return counterDifference(), []*ast.CallExpression{call("sum")}
+	},
+	"irate": func(time.Duration) ([]*ast.CallExpression, []*ast.CallExpression) {
+		return []*ast.CallExpression{
+			call("derivative",
+				property("unit", duration(time.Second)),
+				property("nonNegative", &ast.BooleanLiteral{Value: true}),
+			),
+			fillZero(),
+		}, []*ast.CallExpression{call("last")}
+	},
+	"delta": func(time.Duration) ([]*ast.CallExpression, []*ast.CallExpression) {
+		return []*ast.CallExpression{call("difference")}, []*ast.CallExpression{call("sum")}
+	},
+	"idelta": func(time.Duration) ([]*ast.CallExpression, []*ast.CallExpression) {
+		return []*ast.CallExpression{call("difference")}, []*ast.CallExpression{call("last")}
+	},
+	"avg_over_time":    overTime(call("mean")),
+	"min_over_time":    overTime(call("min")),
+	"max_over_time":    overTime(call("max")),
+	"sum_over_time":    overTime(call("sum")),
+	"count_over_time":  overTime(call("count"), call("toFloat")),
+	"stddev_over_time": overTime(call("stddev")),
+}
+
+func counterDifference() []*ast.CallExpression {
+	return []*ast.CallExpression{
+		call("difference", property("nonNegative", &ast.BooleanLiteral{Value: true})),
+		fillZero(),
+	}
+}
+
+// fillZero replaces the null values a non negative difference returns for counter resets.
+func fillZero() *ast.CallExpression {
+	return call("fill",
+		property("column", &ast.StringLiteral{Value: "_value"}),
+		property("value", &ast.FloatLiteral{Value: 0}),
+	)
+}
+
+func overTime(calls ...*ast.CallExpression) rangeFunction {
+	return func(time.Duration) ([]*ast.CallExpression, []*ast.CallExpression) {
+		return nil, calls
+	}
+}
+
+// mapValueOnly replaces the value of rows that have no time column.
+func mapValueOnly(expr ast.Expression) *ast.CallExpression {
+	return call("map", property("fn", predicate(&ast.ObjectExpression{
+		Properties: []*ast.Property{property("_value", expr)},
+	})))
+}
+
+func (t *transpiler) function(fn *FunctionCall) (*value, error) {
+	if f, ok := rangeFunctions[fn.Name]; ok {
+		if len(fn.Args) != 1 {
+			return nil, fmt.Errorf("expected 1 argument in call to %q, got %d", fn.Name, len(fn.Args))
+		}
+		v, err := t.transpile(fn.Args[0])
+		if err != nil {
+			return nil, err
+		} else if v.typ != MatrixValue {
+			return nil, fmt.Errorf("expected range vector in call to %q, got %s", fn.Name, v.typ)
+		}
+
+		prepare, calls := f(v.sel.Range)
+		// Functions of range vectors drop the metric name.
+		calls = append([]*ast.CallExpression{dropColumns(nameLabel)}, calls...)
+		expr, err := t.evaluate(v.sel, v.sel.Range, prepare, calls...)
+		if err != nil {
+			return nil, err
+		}
+		return t.vector(expr), nil
+	}
+
+	switch fn.Name {
+	case "histogram_quantile":
+		return t.histogramQuantile(fn)
+	default:
+		return nil, fmt.Errorf("unsupported function %q", fn.Name)
+	}
+}
+
+// histogramQuantile computes the quantile from the buckets of the histograms
+// that have the same labels except for the upper bound.
+func (t *transpiler) histogramQuantile(fn *FunctionCall) (*value, error) {
+	if len(fn.Args) != 2 {
+		return nil, fmt.Errorf("expected 2 arguments in call to %q, got %d", fn.Name, len(fn.Args))
+	}
+	q, err := t.transpile(fn.Args[0])
+	if err != nil {
+		return nil, err
+	} else if q.typ != ScalarValue {
+		return nil, fmt.Errorf("expected scalar as the first argument in call to %q, got %s", fn.Name, q.typ)
+	}
+	expr, err := t.instantVector(fn.Args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return t.vector(pipe(expr,
+		dropColumns(nameLabel),
+		groupExcept("_value", bucketLabel),
+		// The upper bounds are labels so they are strings.
+		call("map", property("fn", predicate(&ast.ObjectExpression{
+			Properties: []*ast.Property{
+				property("_value", member("_value")),
+				property(bucketLabel, &ast.CallExpression{
+					Callee: &ast.Identifier{Name: "float"},
+					Arguments: []ast.Expression{&ast.ObjectExpression{
+						Properties: []*ast.Property{property("v", member(bucketLabel))},
+					}},
+				}),
+			},
+		}))),
+		call("histogramQuantile",
+			property("quantile", &ast.FloatLiteral{Value: q.scalar}),
+			property("upperBoundColumn", &ast.StringLiteral{Value: bucketLabel}),
+		),
+		groupExcept("_time", "_value"),
+	)), nil
+}
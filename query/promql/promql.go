@@ -48,17 +48,17 @@ var g = &grammar{
 									},
 									&ruleRefExpr{
 										pos:  position{line: 11, col: 32, offset: 265},
-										name: "AggregateExpression",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 11, col: 54, offset: 287},
-										name: "VectorSelector",
+										name: "Expression",
 									},
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 11, col: 71, offset: 304},
+							pos:  position{line: 11, col: 45, offset: 278},
+							name: "__",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 11, col: 48, offset: 281},
 							name: "EOF",
 						},
 					},
@@ -67,39 +67,39 @@ var g = &grammar{
 		},
 		{
 			name: "SourceChar",
-			pos:  position{line: 15, col: 1, offset: 337},
+			pos:  position{line: 15, col: 1, offset: 314},
 			expr: &anyMatcher{
-				line: 15, col: 14, offset: 350,
+				line: 15, col: 14, offset: 327,
 			},
 		},
 		{
 			name: "Comment",
-			pos:  position{line: 17, col: 1, offset: 353},
+			pos:  position{line: 17, col: 1, offset: 330},
 			expr: &actionExpr{
-				pos: position{line: 17, col: 11, offset: 363},
+				pos: position{line: 17, col: 11, offset: 340},
 				run: (*parser).callonComment1,
 				expr: &seqExpr{
-					pos: position{line: 17, col: 11, offset: 363},
+					pos: position{line: 17, col: 11, offset: 340},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 17, col: 11, offset: 363},
+							pos:        position{line: 17, col: 11, offset: 340},
 							val:        "#",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 17, col: 15, offset: 367},
+							pos: position{line: 17, col: 15, offset: 344},
 							expr: &seqExpr{
-								pos: position{line: 17, col: 17, offset: 369},
+								pos: position{line: 17, col: 17, offset: 346},
 								exprs: []interface{}{
 									&notExpr{
-										pos: position{line: 17, col: 17, offset: 369},
+										pos: position{line: 17, col: 17, offset: 346},
 										expr: &ruleRefExpr{
-											pos:  position{line: 17, col: 18, offset: 370},
+											pos:  position{line: 17, col: 18, offset: 347},
 											name: "EOL",
 										},
 									},
 									&ruleRefExpr{
-										pos:  position{line: 17, col: 22, offset: 374},
+										pos:  position{line: 17, col: 22, offset: 351},
 										name: "SourceChar",
 									},
 								},
@@ -111,15 +111,15 @@ var g = &grammar{
 		},
 		{
 			name: "Identifier",
-			pos:  position{line: 21, col: 1, offset: 434},
+			pos:  position{line: 21, col: 1, offset: 411},
 			expr: &actionExpr{
-				pos: position{line: 21, col: 14, offset: 447},
+				pos: position{line: 21, col: 14, offset: 424},
 				run: (*parser).callonIdentifier1,
 				expr: &labeledExpr{
-					pos:   position{line: 21, col: 14, offset: 447},
+					pos:   position{line: 21, col: 14, offset: 424},
 					label: "ident",
 					expr: &ruleRefExpr{
-						pos:  position{line: 21, col: 20, offset: 453},
+						pos:  position{line: 21, col: 20, offset: 430},
 						name: "IdentifierName",
 					},
 				},
@@ -127,21 +127,21 @@ var g = &grammar{
 		},
 		{
 			name: "IdentifierName",
-			pos:  position{line: 29, col: 1, offset: 637},
+			pos:  position{line: 29, col: 1, offset: 614},
 			expr: &actionExpr{
-				pos: position{line: 29, col: 18, offset: 654},
+				pos: position{line: 29, col: 18, offset: 631},
 				run: (*parser).callonIdentifierName1,
 				expr: &seqExpr{
-					pos: position{line: 29, col: 18, offset: 654},
+					pos: position{line: 29, col: 18, offset: 631},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 29, col: 18, offset: 654},
+							pos:  position{line: 29, col: 18, offset: 631},
 							name: "IdentifierStart",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 29, col: 34, offset: 670},
+							pos: position{line: 29, col: 34, offset: 647},
 							expr: &ruleRefExpr{
-								pos:  position{line: 29, col: 34, offset: 670},
+								pos:  position{line: 29, col: 34, offset: 647},
 								name: "IdentifierPart",
 							},
 						},
@@ -151,9 +151,9 @@ var g = &grammar{
 		},
 		{
 			name: "IdentifierStart",
-			pos:  position{line: 32, col: 1, offset: 721},
+			pos:  position{line: 32, col: 1, offset: 698},
 			expr: &charClassMatcher{
-				pos:        position{line: 32, col: 19, offset: 739},
+				pos:        position{line: 32, col: 19, offset: 716},
 				val:        "[\\pL_]",
 				chars:      []rune{'_'},
 				classes:    []*unicode.RangeTable{rangeTable("L")},
@@ -163,16 +163,16 @@ var g = &grammar{
 		},
 		{
 			name: "IdentifierPart",
-			pos:  position{line: 33, col: 1, offset: 746},
+			pos:  position{line: 33, col: 1, offset: 723},
 			expr: &choiceExpr{
-				pos: position{line: 33, col: 18, offset: 763},
+				pos: position{line: 33, col: 18, offset: 740},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 33, col: 18, offset: 763},
+						pos:  position{line: 33, col: 18, offset: 740},
 						name: "IdentifierStart",
 					},
 					&charClassMatcher{
-						pos:        position{line: 33, col: 36, offset: 781},
+						pos:        position{line: 33, col: 36, offset: 758},
 						val:        "[\\p{Nd}]",
 						classes:    []*unicode.RangeTable{rangeTable("Nd")},
 						ignoreCase: false,
@@ -183,74 +183,74 @@ var g = &grammar{
 		},
 		{
 			name: "StringLiteral",
-			pos:  position{line: 35, col: 1, offset: 791},
+			pos:  position{line: 35, col: 1, offset: 768},
 			expr: &choiceExpr{
-				pos: position{line: 35, col: 17, offset: 807},
+				pos: position{line: 35, col: 17, offset: 784},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 35, col: 17, offset: 807},
+						pos: position{line: 35, col: 17, offset: 784},
 						run: (*parser).callonStringLiteral2,
 						expr: &choiceExpr{
-							pos: position{line: 35, col: 19, offset: 809},
+							pos: position{line: 35, col: 19, offset: 786},
 							alternatives: []interface{}{
 								&seqExpr{
-									pos: position{line: 35, col: 19, offset: 809},
+									pos: position{line: 35, col: 19, offset: 786},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 35, col: 19, offset: 809},
+											pos:        position{line: 35, col: 19, offset: 786},
 											val:        "\"",
 											ignoreCase: false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 35, col: 23, offset: 813},
+											pos: position{line: 35, col: 23, offset: 790},
 											expr: &ruleRefExpr{
-												pos:  position{line: 35, col: 23, offset: 813},
+												pos:  position{line: 35, col: 23, offset: 790},
 												name: "DoubleStringChar",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 35, col: 41, offset: 831},
+											pos:        position{line: 35, col: 41, offset: 808},
 											val:        "\"",
 											ignoreCase: false,
 										},
 									},
 								},
 								&seqExpr{
-									pos: position{line: 35, col: 47, offset: 837},
+									pos: position{line: 35, col: 47, offset: 814},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 35, col: 47, offset: 837},
+											pos:        position{line: 35, col: 47, offset: 814},
 											val:        "'",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 35, col: 51, offset: 841},
+											pos:  position{line: 35, col: 51, offset: 818},
 											name: "SingleStringChar",
 										},
 										&litMatcher{
-											pos:        position{line: 35, col: 68, offset: 858},
+											pos:        position{line: 35, col: 68, offset: 835},
 											val:        "'",
 											ignoreCase: false,
 										},
 									},
 								},
 								&seqExpr{
-									pos: position{line: 35, col: 74, offset: 864},
+									pos: position{line: 35, col: 74, offset: 841},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 35, col: 74, offset: 864},
+											pos:        position{line: 35, col: 74, offset: 841},
 											val:        "`",
 											ignoreCase: false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 35, col: 78, offset: 868},
+											pos: position{line: 35, col: 78, offset: 845},
 											expr: &ruleRefExpr{
-												pos:  position{line: 35, col: 78, offset: 868},
+												pos:  position{line: 35, col: 78, offset: 845},
 												name: "RawStringChar",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 35, col: 93, offset: 883},
+											pos:        position{line: 35, col: 93, offset: 860},
 											val:        "`",
 											ignoreCase: false,
 										},
@@ -260,35 +260,35 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 41, col: 5, offset: 1029},
+						pos: position{line: 41, col: 5, offset: 1006},
 						run: (*parser).callonStringLiteral18,
 						expr: &choiceExpr{
-							pos: position{line: 41, col: 7, offset: 1031},
+							pos: position{line: 41, col: 7, offset: 1008},
 							alternatives: []interface{}{
 								&seqExpr{
-									pos: position{line: 41, col: 9, offset: 1033},
+									pos: position{line: 41, col: 9, offset: 1010},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 41, col: 9, offset: 1033},
+											pos:        position{line: 41, col: 9, offset: 1010},
 											val:        "\"",
 											ignoreCase: false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 41, col: 13, offset: 1037},
+											pos: position{line: 41, col: 13, offset: 1014},
 											expr: &ruleRefExpr{
-												pos:  position{line: 41, col: 13, offset: 1037},
+												pos:  position{line: 41, col: 13, offset: 1014},
 												name: "DoubleStringChar",
 											},
 										},
 										&choiceExpr{
-											pos: position{line: 41, col: 33, offset: 1057},
+											pos: position{line: 41, col: 33, offset: 1034},
 											alternatives: []interface{}{
 												&ruleRefExpr{
-													pos:  position{line: 41, col: 33, offset: 1057},
+													pos:  position{line: 41, col: 33, offset: 1034},
 													name: "EOL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 41, col: 39, offset: 1063},
+													pos:  position{line: 41, col: 39, offset: 1040},
 													name: "EOF",
 												},
 											},
@@ -296,29 +296,29 @@ var g = &grammar{
 									},
 								},
 								&seqExpr{
-									pos: position{line: 41, col: 51, offset: 1075},
+									pos: position{line: 41, col: 51, offset: 1052},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 41, col: 51, offset: 1075},
+											pos:        position{line: 41, col: 51, offset: 1052},
 											val:        "'",
 											ignoreCase: false,
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 41, col: 55, offset: 1079},
+											pos: position{line: 41, col: 55, offset: 1056},
 											expr: &ruleRefExpr{
-												pos:  position{line: 41, col: 55, offset: 1079},
+												pos:  position{line: 41, col: 55, offset: 1056},
 												name: "SingleStringChar",
 											},
 										},
 										&choiceExpr{
-											pos: position{line: 41, col: 75, offset: 1099},
+											pos: position{line: 41, col: 75, offset: 1076},
 											alternatives: []interface{}{
 												&ruleRefExpr{
-													pos:  position{line: 41, col: 75, offset: 1099},
+													pos:  position{line: 41, col: 75, offset: 1076},
 													name: "EOL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 41, col: 81, offset: 1105},
+													pos:  position{line: 41, col: 81, offset: 1082},
 													name: "EOF",
 												},
 											},
@@ -326,22 +326,22 @@ var g = &grammar{
 									},
 								},
 								&seqExpr{
-									pos: position{line: 41, col: 91, offset: 1115},
+									pos: position{line: 41, col: 91, offset: 1092},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 41, col: 91, offset: 1115},
+											pos:        position{line: 41, col: 91, offset: 1092},
 											val:        "`",
 											ignoreCase: false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 41, col: 95, offset: 1119},
+											pos: position{line: 41, col: 95, offset: 1096},
 											expr: &ruleRefExpr{
-												pos:  position{line: 41, col: 95, offset: 1119},
+												pos:  position{line: 41, col: 95, offset: 1096},
 												name: "RawStringChar",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 41, col: 110, offset: 1134},
+											pos:  position{line: 41, col: 110, offset: 1111},
 											name: "EOF",
 										},
 									},
@@ -354,51 +354,51 @@ var g = &grammar{
 		},
 		{
 			name: "DoubleStringChar",
-			pos:  position{line: 45, col: 1, offset: 1205},
+			pos:  position{line: 45, col: 1, offset: 1182},
 			expr: &choiceExpr{
-				pos: position{line: 45, col: 20, offset: 1224},
+				pos: position{line: 45, col: 20, offset: 1201},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 45, col: 20, offset: 1224},
+						pos: position{line: 45, col: 20, offset: 1201},
 						exprs: []interface{}{
 							&notExpr{
-								pos: position{line: 45, col: 20, offset: 1224},
+								pos: position{line: 45, col: 20, offset: 1201},
 								expr: &choiceExpr{
-									pos: position{line: 45, col: 23, offset: 1227},
+									pos: position{line: 45, col: 23, offset: 1204},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 45, col: 23, offset: 1227},
+											pos:        position{line: 45, col: 23, offset: 1204},
 											val:        "\"",
 											ignoreCase: false,
 										},
 										&litMatcher{
-											pos:        position{line: 45, col: 29, offset: 1233},
+											pos:        position{line: 45, col: 29, offset: 1210},
 											val:        "\\",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 45, col: 36, offset: 1240},
+											pos:  position{line: 45, col: 36, offset: 1217},
 											name: "EOL",
 										},
 									},
 								},
 							},
 							&ruleRefExpr{
-								pos:  position{line: 45, col: 42, offset: 1246},
+								pos:  position{line: 45, col: 42, offset: 1223},
 								name: "SourceChar",
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 45, col: 55, offset: 1259},
+						pos: position{line: 45, col: 55, offset: 1236},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 45, col: 55, offset: 1259},
+								pos:        position{line: 45, col: 55, offset: 1236},
 								val:        "\\",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 45, col: 60, offset: 1264},
+								pos:  position{line: 45, col: 60, offset: 1241},
 								name: "DoubleStringEscape",
 							},
 						},
@@ -408,51 +408,51 @@ var g = &grammar{
 		},
 		{
 			name: "SingleStringChar",
-			pos:  position{line: 46, col: 1, offset: 1283},
+			pos:  position{line: 46, col: 1, offset: 1260},
 			expr: &choiceExpr{
-				pos: position{line: 46, col: 20, offset: 1302},
+				pos: position{line: 46, col: 20, offset: 1279},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 46, col: 20, offset: 1302},
+						pos: position{line: 46, col: 20, offset: 1279},
 						exprs: []interface{}{
 							&notExpr{
-								pos: position{line: 46, col: 20, offset: 1302},
+								pos: position{line: 46, col: 20, offset: 1279},
 								expr: &choiceExpr{
-									pos: position{line: 46, col: 23, offset: 1305},
+									pos: position{line: 46, col: 23, offset: 1282},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 46, col: 23, offset: 1305},
+											pos:        position{line: 46, col: 23, offset: 1282},
 											val:        "'",
 											ignoreCase: false,
 										},
 										&litMatcher{
-											pos:        position{line: 46, col: 29, offset: 1311},
+											pos:        position{line: 46, col: 29, offset: 1288},
 											val:        "\\",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 46, col: 36, offset: 1318},
+											pos:  position{line: 46, col: 36, offset: 1295},
 											name: "EOL",
 										},
 									},
 								},
 							},
 							&ruleRefExpr{
-								pos:  position{line: 46, col: 42, offset: 1324},
+								pos:  position{line: 46, col: 42, offset: 1301},
 								name: "SourceChar",
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 46, col: 55, offset: 1337},
+						pos: position{line: 46, col: 55, offset: 1314},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 46, col: 55, offset: 1337},
+								pos:        position{line: 46, col: 55, offset: 1314},
 								val:        "\\",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 46, col: 60, offset: 1342},
+								pos:  position{line: 46, col: 60, offset: 1319},
 								name: "SingleStringEscape",
 							},
 						},
@@ -462,20 +462,20 @@ var g = &grammar{
 		},
 		{
 			name: "RawStringChar",
-			pos:  position{line: 47, col: 1, offset: 1361},
+			pos:  position{line: 47, col: 1, offset: 1338},
 			expr: &seqExpr{
-				pos: position{line: 47, col: 17, offset: 1377},
+				pos: position{line: 47, col: 17, offset: 1354},
 				exprs: []interface{}{
 					&notExpr{
-						pos: position{line: 47, col: 17, offset: 1377},
+						pos: position{line: 47, col: 17, offset: 1354},
 						expr: &litMatcher{
-							pos:        position{line: 47, col: 18, offset: 1378},
+							pos:        position{line: 47, col: 18, offset: 1355},
 							val:        "`",
 							ignoreCase: false,
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 47, col: 22, offset: 1382},
+						pos:  position{line: 47, col: 22, offset: 1359},
 						name: "SourceChar",
 					},
 				},
@@ -483,40 +483,40 @@ var g = &grammar{
 		},
 		{
 			name: "DoubleStringEscape",
-			pos:  position{line: 49, col: 1, offset: 1394},
+			pos:  position{line: 49, col: 1, offset: 1371},
 			expr: &choiceExpr{
-				pos: position{line: 49, col: 22, offset: 1415},
+				pos: position{line: 49, col: 22, offset: 1392},
 				alternatives: []interface{}{
 					&choiceExpr{
-						pos: position{line: 49, col: 24, offset: 1417},
+						pos: position{line: 49, col: 24, offset: 1394},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 49, col: 24, offset: 1417},
+								pos:        position{line: 49, col: 24, offset: 1394},
 								val:        "\"",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 49, col: 30, offset: 1423},
+								pos:  position{line: 49, col: 30, offset: 1400},
 								name: "CommonEscapeSequence",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 50, col: 7, offset: 1452},
+						pos: position{line: 50, col: 7, offset: 1429},
 						run: (*parser).callonDoubleStringEscape5,
 						expr: &choiceExpr{
-							pos: position{line: 50, col: 9, offset: 1454},
+							pos: position{line: 50, col: 9, offset: 1431},
 							alternatives: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 50, col: 9, offset: 1454},
+									pos:  position{line: 50, col: 9, offset: 1431},
 									name: "SourceChar",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 50, col: 22, offset: 1467},
+									pos:  position{line: 50, col: 22, offset: 1444},
 									name: "EOL",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 50, col: 28, offset: 1473},
+									pos:  position{line: 50, col: 28, offset: 1450},
 									name: "EOF",
 								},
 							},
@@ -527,40 +527,40 @@ var g = &grammar{
 		},
 		{
 			name: "SingleStringEscape",
-			pos:  position{line: 53, col: 1, offset: 1538},
+			pos:  position{line: 53, col: 1, offset: 1515},
 			expr: &choiceExpr{
-				pos: position{line: 53, col: 22, offset: 1559},
+				pos: position{line: 53, col: 22, offset: 1536},
 				alternatives: []interface{}{
 					&choiceExpr{
-						pos: position{line: 53, col: 24, offset: 1561},
+						pos: position{line: 53, col: 24, offset: 1538},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 53, col: 24, offset: 1561},
+								pos:        position{line: 53, col: 24, offset: 1538},
 								val:        "'",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 53, col: 30, offset: 1567},
+								pos:  position{line: 53, col: 30, offset: 1544},
 								name: "CommonEscapeSequence",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 54, col: 7, offset: 1596},
+						pos: position{line: 54, col: 7, offset: 1573},
 						run: (*parser).callonSingleStringEscape5,
 						expr: &choiceExpr{
-							pos: position{line: 54, col: 9, offset: 1598},
+							pos: position{line: 54, col: 9, offset: 1575},
 							alternatives: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 54, col: 9, offset: 1598},
+									pos:  position{line: 54, col: 9, offset: 1575},
 									name: "SourceChar",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 54, col: 22, offset: 1611},
+									pos:  position{line: 54, col: 22, offset: 1588},
 									name: "EOL",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 54, col: 28, offset: 1617},
+									pos:  position{line: 54, col: 28, offset: 1594},
 									name: "EOF",
 								},
 							},
@@ -571,28 +571,28 @@ var g = &grammar{
 		},
 		{
 			name: "CommonEscapeSequence",
-			pos:  position{line: 58, col: 1, offset: 1683},
+			pos:  position{line: 58, col: 1, offset: 1660},
 			expr: &choiceExpr{
-				pos: position{line: 58, col: 24, offset: 1706},
+				pos: position{line: 58, col: 24, offset: 1683},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 58, col: 24, offset: 1706},
+						pos:  position{line: 58, col: 24, offset: 1683},
 						name: "SingleCharEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 58, col: 43, offset: 1725},
+						pos:  position{line: 58, col: 43, offset: 1702},
 						name: "OctalEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 58, col: 57, offset: 1739},
+						pos:  position{line: 58, col: 57, offset: 1716},
 						name: "HexEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 58, col: 69, offset: 1751},
+						pos:  position{line: 58, col: 69, offset: 1728},
 						name: "LongUnicodeEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 58, col: 89, offset: 1771},
+						pos:  position{line: 58, col: 89, offset: 1748},
 						name: "ShortUnicodeEscape",
 					},
 				},
@@ -600,47 +600,47 @@ var g = &grammar{
 		},
 		{
 			name: "SingleCharEscape",
-			pos:  position{line: 59, col: 1, offset: 1790},
+			pos:  position{line: 59, col: 1, offset: 1767},
 			expr: &choiceExpr{
-				pos: position{line: 59, col: 20, offset: 1809},
+				pos: position{line: 59, col: 20, offset: 1786},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 59, col: 20, offset: 1809},
+						pos:        position{line: 59, col: 20, offset: 1786},
 						val:        "a",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 59, col: 26, offset: 1815},
+						pos:        position{line: 59, col: 26, offset: 1792},
 						val:        "b",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 59, col: 32, offset: 1821},
+						pos:        position{line: 59, col: 32, offset: 1798},
 						val:        "n",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 59, col: 38, offset: 1827},
+						pos:        position{line: 59, col: 38, offset: 1804},
 						val:        "f",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 59, col: 44, offset: 1833},
+						pos:        position{line: 59, col: 44, offset: 1810},
 						val:        "r",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 59, col: 50, offset: 1839},
+						pos:        position{line: 59, col: 50, offset: 1816},
 						val:        "t",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 59, col: 56, offset: 1845},
+						pos:        position{line: 59, col: 56, offset: 1822},
 						val:        "v",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 59, col: 62, offset: 1851},
+						pos:        position{line: 59, col: 62, offset: 1828},
 						val:        "\\",
 						ignoreCase: false,
 					},
@@ -649,50 +649,50 @@ var g = &grammar{
 		},
 		{
 			name: "OctalEscape",
-			pos:  position{line: 60, col: 1, offset: 1856},
+			pos:  position{line: 60, col: 1, offset: 1833},
 			expr: &choiceExpr{
-				pos: position{line: 60, col: 15, offset: 1870},
+				pos: position{line: 60, col: 15, offset: 1847},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 60, col: 15, offset: 1870},
+						pos: position{line: 60, col: 15, offset: 1847},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 60, col: 15, offset: 1870},
+								pos:  position{line: 60, col: 15, offset: 1847},
 								name: "OctalDigit",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 60, col: 26, offset: 1881},
+								pos:  position{line: 60, col: 26, offset: 1858},
 								name: "OctalDigit",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 60, col: 37, offset: 1892},
+								pos:  position{line: 60, col: 37, offset: 1869},
 								name: "OctalDigit",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 61, col: 7, offset: 1909},
+						pos: position{line: 61, col: 7, offset: 1886},
 						run: (*parser).callonOctalEscape6,
 						expr: &seqExpr{
-							pos: position{line: 61, col: 7, offset: 1909},
+							pos: position{line: 61, col: 7, offset: 1886},
 							exprs: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 61, col: 7, offset: 1909},
+									pos:  position{line: 61, col: 7, offset: 1886},
 									name: "OctalDigit",
 								},
 								&choiceExpr{
-									pos: position{line: 61, col: 20, offset: 1922},
+									pos: position{line: 61, col: 20, offset: 1899},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 61, col: 20, offset: 1922},
+											pos:  position{line: 61, col: 20, offset: 1899},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 61, col: 33, offset: 1935},
+											pos:  position{line: 61, col: 33, offset: 1912},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 61, col: 39, offset: 1941},
+											pos:  position{line: 61, col: 39, offset: 1918},
 											name: "EOF",
 										},
 									},
@@ -705,52 +705,52 @@ var g = &grammar{
 		},
 		{
 			name: "HexEscape",
-			pos:  position{line: 64, col: 1, offset: 2002},
+			pos:  position{line: 64, col: 1, offset: 1979},
 			expr: &choiceExpr{
-				pos: position{line: 64, col: 13, offset: 2014},
+				pos: position{line: 64, col: 13, offset: 1991},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 64, col: 13, offset: 2014},
+						pos: position{line: 64, col: 13, offset: 1991},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 64, col: 13, offset: 2014},
+								pos:        position{line: 64, col: 13, offset: 1991},
 								val:        "x",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 64, col: 17, offset: 2018},
+								pos:  position{line: 64, col: 17, offset: 1995},
 								name: "HexDigit",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 64, col: 26, offset: 2027},
+								pos:  position{line: 64, col: 26, offset: 2004},
 								name: "HexDigit",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 65, col: 7, offset: 2042},
+						pos: position{line: 65, col: 7, offset: 2019},
 						run: (*parser).callonHexEscape6,
 						expr: &seqExpr{
-							pos: position{line: 65, col: 7, offset: 2042},
+							pos: position{line: 65, col: 7, offset: 2019},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 65, col: 7, offset: 2042},
+									pos:        position{line: 65, col: 7, offset: 2019},
 									val:        "x",
 									ignoreCase: false,
 								},
 								&choiceExpr{
-									pos: position{line: 65, col: 13, offset: 2048},
+									pos: position{line: 65, col: 13, offset: 2025},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 13, offset: 2048},
+											pos:  position{line: 65, col: 13, offset: 2025},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 26, offset: 2061},
+											pos:  position{line: 65, col: 26, offset: 2038},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 32, offset: 2067},
+											pos:  position{line: 65, col: 32, offset: 2044},
 											name: "EOF",
 										},
 									},
@@ -763,80 +763,80 @@ var g = &grammar{
 		},
 		{
 			name: "LongUnicodeEscape",
-			pos:  position{line: 68, col: 1, offset: 2134},
+			pos:  position{line: 68, col: 1, offset: 2111},
 			expr: &choiceExpr{
-				pos: position{line: 69, col: 5, offset: 2159},
+				pos: position{line: 69, col: 5, offset: 2136},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 69, col: 5, offset: 2159},
+						pos: position{line: 69, col: 5, offset: 2136},
 						run: (*parser).callonLongUnicodeEscape2,
 						expr: &seqExpr{
-							pos: position{line: 69, col: 5, offset: 2159},
+							pos: position{line: 69, col: 5, offset: 2136},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 69, col: 5, offset: 2159},
+									pos:        position{line: 69, col: 5, offset: 2136},
 									val:        "U",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 69, col: 9, offset: 2163},
+									pos:  position{line: 69, col: 9, offset: 2140},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 69, col: 18, offset: 2172},
+									pos:  position{line: 69, col: 18, offset: 2149},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 69, col: 27, offset: 2181},
+									pos:  position{line: 69, col: 27, offset: 2158},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 69, col: 36, offset: 2190},
+									pos:  position{line: 69, col: 36, offset: 2167},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 69, col: 45, offset: 2199},
+									pos:  position{line: 69, col: 45, offset: 2176},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 69, col: 54, offset: 2208},
+									pos:  position{line: 69, col: 54, offset: 2185},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 69, col: 63, offset: 2217},
+									pos:  position{line: 69, col: 63, offset: 2194},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 69, col: 72, offset: 2226},
+									pos:  position{line: 69, col: 72, offset: 2203},
 									name: "HexDigit",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 72, col: 7, offset: 2328},
+						pos: position{line: 72, col: 7, offset: 2305},
 						run: (*parser).callonLongUnicodeEscape13,
 						expr: &seqExpr{
-							pos: position{line: 72, col: 7, offset: 2328},
+							pos: position{line: 72, col: 7, offset: 2305},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 72, col: 7, offset: 2328},
+									pos:        position{line: 72, col: 7, offset: 2305},
 									val:        "U",
 									ignoreCase: false,
 								},
 								&choiceExpr{
-									pos: position{line: 72, col: 13, offset: 2334},
+									pos: position{line: 72, col: 13, offset: 2311},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 72, col: 13, offset: 2334},
+											pos:  position{line: 72, col: 13, offset: 2311},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 72, col: 26, offset: 2347},
+											pos:  position{line: 72, col: 26, offset: 2324},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 72, col: 32, offset: 2353},
+											pos:  position{line: 72, col: 32, offset: 2330},
 											name: "EOF",
 										},
 									},
@@ -849,64 +849,64 @@ var g = &grammar{
 		},
 		{
 			name: "ShortUnicodeEscape",
-			pos:  position{line: 75, col: 1, offset: 2416},
+			pos:  position{line: 75, col: 1, offset: 2393},
 			expr: &choiceExpr{
-				pos: position{line: 76, col: 5, offset: 2442},
+				pos: position{line: 76, col: 5, offset: 2419},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 76, col: 5, offset: 2442},
+						pos: position{line: 76, col: 5, offset: 2419},
 						run: (*parser).callonShortUnicodeEscape2,
 						expr: &seqExpr{
-							pos: position{line: 76, col: 5, offset: 2442},
+							pos: position{line: 76, col: 5, offset: 2419},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 76, col: 5, offset: 2442},
+									pos:        position{line: 76, col: 5, offset: 2419},
 									val:        "u",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 76, col: 9, offset: 2446},
+									pos:  position{line: 76, col: 9, offset: 2423},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 76, col: 18, offset: 2455},
+									pos:  position{line: 76, col: 18, offset: 2432},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 76, col: 27, offset: 2464},
+									pos:  position{line: 76, col: 27, offset: 2441},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 76, col: 36, offset: 2473},
+									pos:  position{line: 76, col: 36, offset: 2450},
 									name: "HexDigit",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 79, col: 7, offset: 2575},
+						pos: position{line: 79, col: 7, offset: 2552},
 						run: (*parser).callonShortUnicodeEscape9,
 						expr: &seqExpr{
-							pos: position{line: 79, col: 7, offset: 2575},
+							pos: position{line: 79, col: 7, offset: 2552},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 79, col: 7, offset: 2575},
+									pos:        position{line: 79, col: 7, offset: 2552},
 									val:        "u",
 									ignoreCase: false,
 								},
 								&choiceExpr{
-									pos: position{line: 79, col: 13, offset: 2581},
+									pos: position{line: 79, col: 13, offset: 2558},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 79, col: 13, offset: 2581},
+											pos:  position{line: 79, col: 13, offset: 2558},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 79, col: 26, offset: 2594},
+											pos:  position{line: 79, col: 26, offset: 2571},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 79, col: 32, offset: 2600},
+											pos:  position{line: 79, col: 32, offset: 2577},
 											name: "EOF",
 										},
 									},
@@ -919,9 +919,9 @@ var g = &grammar{
 		},
 		{
 			name: "OctalDigit",
-			pos:  position{line: 83, col: 1, offset: 2664},
+			pos:  position{line: 83, col: 1, offset: 2641},
 			expr: &charClassMatcher{
-				pos:        position{line: 83, col: 14, offset: 2677},
+				pos:        position{line: 83, col: 14, offset: 2654},
 				val:        "[0-7]",
 				ranges:     []rune{'0', '7'},
 				ignoreCase: false,
@@ -930,9 +930,9 @@ var g = &grammar{
 		},
 		{
 			name: "DecimalDigit",
-			pos:  position{line: 84, col: 1, offset: 2683},
+			pos:  position{line: 84, col: 1, offset: 2660},
 			expr: &charClassMatcher{
-				pos:        position{line: 84, col: 16, offset: 2698},
+				pos:        position{line: 84, col: 16, offset: 2675},
 				val:        "[0-9]",
 				ranges:     []rune{'0', '9'},
 				ignoreCase: false,
@@ -941,9 +941,9 @@ var g = &grammar{
 		},
 		{
 			name: "HexDigit",
-			pos:  position{line: 85, col: 1, offset: 2704},
+			pos:  position{line: 85, col: 1, offset: 2681},
 			expr: &charClassMatcher{
-				pos:        position{line: 85, col: 12, offset: 2715},
+				pos:        position{line: 85, col: 12, offset: 2692},
 				val:        "[0-9a-f]i",
 				ranges:     []rune{'0', '9', 'a', 'f'},
 				ignoreCase: true,
@@ -952,44 +952,44 @@ var g = &grammar{
 		},
 		{
 			name: "CharClassMatcher",
-			pos:  position{line: 87, col: 1, offset: 2726},
+			pos:  position{line: 87, col: 1, offset: 2703},
 			expr: &choiceExpr{
-				pos: position{line: 87, col: 20, offset: 2745},
+				pos: position{line: 87, col: 20, offset: 2722},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 87, col: 20, offset: 2745},
+						pos: position{line: 87, col: 20, offset: 2722},
 						run: (*parser).callonCharClassMatcher2,
 						expr: &seqExpr{
-							pos: position{line: 87, col: 20, offset: 2745},
+							pos: position{line: 87, col: 20, offset: 2722},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 87, col: 20, offset: 2745},
+									pos:        position{line: 87, col: 20, offset: 2722},
 									val:        "[",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 87, col: 24, offset: 2749},
+									pos: position{line: 87, col: 24, offset: 2726},
 									expr: &choiceExpr{
-										pos: position{line: 87, col: 26, offset: 2751},
+										pos: position{line: 87, col: 26, offset: 2728},
 										alternatives: []interface{}{
 											&ruleRefExpr{
-												pos:  position{line: 87, col: 26, offset: 2751},
+												pos:  position{line: 87, col: 26, offset: 2728},
 												name: "ClassCharRange",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 87, col: 43, offset: 2768},
+												pos:  position{line: 87, col: 43, offset: 2745},
 												name: "ClassChar",
 											},
 											&seqExpr{
-												pos: position{line: 87, col: 55, offset: 2780},
+												pos: position{line: 87, col: 55, offset: 2757},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 87, col: 55, offset: 2780},
+														pos:        position{line: 87, col: 55, offset: 2757},
 														val:        "\\",
 														ignoreCase: false,
 													},
 													&ruleRefExpr{
-														pos:  position{line: 87, col: 60, offset: 2785},
+														pos:  position{line: 87, col: 60, offset: 2762},
 														name: "UnicodeClassEscape",
 													},
 												},
@@ -998,14 +998,14 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 87, col: 82, offset: 2807},
+									pos:        position{line: 87, col: 82, offset: 2784},
 									val:        "]",
 									ignoreCase: false,
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 87, col: 86, offset: 2811},
+									pos: position{line: 87, col: 86, offset: 2788},
 									expr: &litMatcher{
-										pos:        position{line: 87, col: 86, offset: 2811},
+										pos:        position{line: 87, col: 86, offset: 2788},
 										val:        "i",
 										ignoreCase: false,
 									},
@@ -1014,44 +1014,44 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 89, col: 5, offset: 2853},
+						pos: position{line: 89, col: 5, offset: 2830},
 						run: (*parser).callonCharClassMatcher15,
 						expr: &seqExpr{
-							pos: position{line: 89, col: 5, offset: 2853},
+							pos: position{line: 89, col: 5, offset: 2830},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 89, col: 5, offset: 2853},
+									pos:        position{line: 89, col: 5, offset: 2830},
 									val:        "[",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 89, col: 9, offset: 2857},
+									pos: position{line: 89, col: 9, offset: 2834},
 									expr: &seqExpr{
-										pos: position{line: 89, col: 11, offset: 2859},
+										pos: position{line: 89, col: 11, offset: 2836},
 										exprs: []interface{}{
 											&notExpr{
-												pos: position{line: 89, col: 11, offset: 2859},
+												pos: position{line: 89, col: 11, offset: 2836},
 												expr: &ruleRefExpr{
-													pos:  position{line: 89, col: 14, offset: 2862},
+													pos:  position{line: 89, col: 14, offset: 2839},
 													name: "EOL",
 												},
 											},
 											&ruleRefExpr{
-												pos:  position{line: 89, col: 20, offset: 2868},
+												pos:  position{line: 89, col: 20, offset: 2845},
 												name: "SourceChar",
 											},
 										},
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 89, col: 36, offset: 2884},
+									pos: position{line: 89, col: 36, offset: 2861},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 89, col: 36, offset: 2884},
+											pos:  position{line: 89, col: 36, offset: 2861},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 89, col: 42, offset: 2890},
+											pos:  position{line: 89, col: 42, offset: 2867},
 											name: "EOF",
 										},
 									},
@@ -1064,21 +1064,21 @@ var g = &grammar{
 		},
 		{
 			name: "ClassCharRange",
-			pos:  position{line: 93, col: 1, offset: 2962},
+			pos:  position{line: 93, col: 1, offset: 2939},
 			expr: &seqExpr{
-				pos: position{line: 93, col: 18, offset: 2979},
+				pos: position{line: 93, col: 18, offset: 2956},
 				exprs: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 93, col: 18, offset: 2979},
+						pos:  position{line: 93, col: 18, offset: 2956},
 						name: "ClassChar",
 					},
 					&litMatcher{
-						pos:        position{line: 93, col: 28, offset: 2989},
+						pos:        position{line: 93, col: 28, offset: 2966},
 						val:        "-",
 						ignoreCase: false,
 					},
 					&ruleRefExpr{
-						pos:  position{line: 93, col: 32, offset: 2993},
+						pos:  position{line: 93, col: 32, offset: 2970},
 						name: "ClassChar",
 					},
 				},
@@ -1086,51 +1086,51 @@ var g = &grammar{
 		},
 		{
 			name: "ClassChar",
-			pos:  position{line: 94, col: 1, offset: 3003},
+			pos:  position{line: 94, col: 1, offset: 2980},
 			expr: &choiceExpr{
-				pos: position{line: 94, col: 13, offset: 3015},
+				pos: position{line: 94, col: 13, offset: 2992},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 94, col: 13, offset: 3015},
+						pos: position{line: 94, col: 13, offset: 2992},
 						exprs: []interface{}{
 							&notExpr{
-								pos: position{line: 94, col: 13, offset: 3015},
+								pos: position{line: 94, col: 13, offset: 2992},
 								expr: &choiceExpr{
-									pos: position{line: 94, col: 16, offset: 3018},
+									pos: position{line: 94, col: 16, offset: 2995},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 94, col: 16, offset: 3018},
+											pos:        position{line: 94, col: 16, offset: 2995},
 											val:        "]",
 											ignoreCase: false,
 										},
 										&litMatcher{
-											pos:        position{line: 94, col: 22, offset: 3024},
+											pos:        position{line: 94, col: 22, offset: 3001},
 											val:        "\\",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 94, col: 29, offset: 3031},
+											pos:  position{line: 94, col: 29, offset: 3008},
 											name: "EOL",
 										},
 									},
 								},
 							},
 							&ruleRefExpr{
-								pos:  position{line: 94, col: 35, offset: 3037},
+								pos:  position{line: 94, col: 35, offset: 3014},
 								name: "SourceChar",
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 94, col: 48, offset: 3050},
+						pos: position{line: 94, col: 48, offset: 3027},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 94, col: 48, offset: 3050},
+								pos:        position{line: 94, col: 48, offset: 3027},
 								val:        "\\",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 94, col: 53, offset: 3055},
+								pos:  position{line: 94, col: 53, offset: 3032},
 								name: "CharClassEscape",
 							},
 						},
@@ -1140,51 +1140,51 @@ var g = &grammar{
 		},
 		{
 			name: "CharClassEscape",
-			pos:  position{line: 95, col: 1, offset: 3071},
+			pos:  position{line: 95, col: 1, offset: 3048},
 			expr: &choiceExpr{
-				pos: position{line: 95, col: 19, offset: 3089},
+				pos: position{line: 95, col: 19, offset: 3066},
 				alternatives: []interface{}{
 					&choiceExpr{
-						pos: position{line: 95, col: 21, offset: 3091},
+						pos: position{line: 95, col: 21, offset: 3068},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 95, col: 21, offset: 3091},
+								pos:        position{line: 95, col: 21, offset: 3068},
 								val:        "]",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 95, col: 27, offset: 3097},
+								pos:  position{line: 95, col: 27, offset: 3074},
 								name: "CommonEscapeSequence",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 96, col: 7, offset: 3126},
+						pos: position{line: 96, col: 7, offset: 3103},
 						run: (*parser).callonCharClassEscape5,
 						expr: &seqExpr{
-							pos: position{line: 96, col: 7, offset: 3126},
+							pos: position{line: 96, col: 7, offset: 3103},
 							exprs: []interface{}{
 								&notExpr{
-									pos: position{line: 96, col: 7, offset: 3126},
+									pos: position{line: 96, col: 7, offset: 3103},
 									expr: &litMatcher{
-										pos:        position{line: 96, col: 8, offset: 3127},
+										pos:        position{line: 96, col: 8, offset: 3104},
 										val:        "p",
 										ignoreCase: false,
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 96, col: 14, offset: 3133},
+									pos: position{line: 96, col: 14, offset: 3110},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 96, col: 14, offset: 3133},
+											pos:  position{line: 96, col: 14, offset: 3110},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 96, col: 27, offset: 3146},
+											pos:  position{line: 96, col: 27, offset: 3123},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 96, col: 33, offset: 3152},
+											pos:  position{line: 96, col: 33, offset: 3129},
 											name: "EOF",
 										},
 									},
@@ -1197,49 +1197,49 @@ var g = &grammar{
 		},
 		{
 			name: "UnicodeClassEscape",
-			pos:  position{line: 100, col: 1, offset: 3218},
+			pos:  position{line: 100, col: 1, offset: 3195},
 			expr: &seqExpr{
-				pos: position{line: 100, col: 22, offset: 3239},
+				pos: position{line: 100, col: 22, offset: 3216},
 				exprs: []interface{}{
 					&litMatcher{
-						pos:        position{line: 100, col: 22, offset: 3239},
+						pos:        position{line: 100, col: 22, offset: 3216},
 						val:        "p",
 						ignoreCase: false,
 					},
 					&choiceExpr{
-						pos: position{line: 101, col: 7, offset: 3252},
+						pos: position{line: 101, col: 7, offset: 3229},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 101, col: 7, offset: 3252},
+								pos:  position{line: 101, col: 7, offset: 3229},
 								name: "SingleCharUnicodeClass",
 							},
 							&actionExpr{
-								pos: position{line: 102, col: 7, offset: 3281},
+								pos: position{line: 102, col: 7, offset: 3258},
 								run: (*parser).callonUnicodeClassEscape5,
 								expr: &seqExpr{
-									pos: position{line: 102, col: 7, offset: 3281},
+									pos: position{line: 102, col: 7, offset: 3258},
 									exprs: []interface{}{
 										&notExpr{
-											pos: position{line: 102, col: 7, offset: 3281},
+											pos: position{line: 102, col: 7, offset: 3258},
 											expr: &litMatcher{
-												pos:        position{line: 102, col: 8, offset: 3282},
+												pos:        position{line: 102, col: 8, offset: 3259},
 												val:        "{",
 												ignoreCase: false,
 											},
 										},
 										&choiceExpr{
-											pos: position{line: 102, col: 14, offset: 3288},
+											pos: position{line: 102, col: 14, offset: 3265},
 											alternatives: []interface{}{
 												&ruleRefExpr{
-													pos:  position{line: 102, col: 14, offset: 3288},
+													pos:  position{line: 102, col: 14, offset: 3265},
 													name: "SourceChar",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 102, col: 27, offset: 3301},
+													pos:  position{line: 102, col: 27, offset: 3278},
 													name: "EOL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 102, col: 33, offset: 3307},
+													pos:  position{line: 102, col: 33, offset: 3284},
 													name: "EOF",
 												},
 											},
@@ -1248,26 +1248,26 @@ var g = &grammar{
 								},
 							},
 							&actionExpr{
-								pos: position{line: 103, col: 7, offset: 3378},
+								pos: position{line: 103, col: 7, offset: 3355},
 								run: (*parser).callonUnicodeClassEscape13,
 								expr: &seqExpr{
-									pos: position{line: 103, col: 7, offset: 3378},
+									pos: position{line: 103, col: 7, offset: 3355},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 103, col: 7, offset: 3378},
+											pos:        position{line: 103, col: 7, offset: 3355},
 											val:        "{",
 											ignoreCase: false,
 										},
 										&labeledExpr{
-											pos:   position{line: 103, col: 11, offset: 3382},
+											pos:   position{line: 103, col: 11, offset: 3359},
 											label: "ident",
 											expr: &ruleRefExpr{
-												pos:  position{line: 103, col: 17, offset: 3388},
+												pos:  position{line: 103, col: 17, offset: 3365},
 												name: "IdentifierName",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 103, col: 32, offset: 3403},
+											pos:        position{line: 103, col: 32, offset: 3380},
 											val:        "}",
 											ignoreCase: false,
 										},
@@ -1275,34 +1275,34 @@ var g = &grammar{
 								},
 							},
 							&actionExpr{
-								pos: position{line: 109, col: 7, offset: 3567},
+								pos: position{line: 109, col: 7, offset: 3544},
 								run: (*parser).callonUnicodeClassEscape19,
 								expr: &seqExpr{
-									pos: position{line: 109, col: 7, offset: 3567},
+									pos: position{line: 109, col: 7, offset: 3544},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 109, col: 7, offset: 3567},
+											pos:        position{line: 109, col: 7, offset: 3544},
 											val:        "{",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 109, col: 11, offset: 3571},
+											pos:  position{line: 109, col: 11, offset: 3548},
 											name: "IdentifierName",
 										},
 										&choiceExpr{
-											pos: position{line: 109, col: 28, offset: 3588},
+											pos: position{line: 109, col: 28, offset: 3565},
 											alternatives: []interface{}{
 												&litMatcher{
-													pos:        position{line: 109, col: 28, offset: 3588},
+													pos:        position{line: 109, col: 28, offset: 3565},
 													val:        "]",
 													ignoreCase: false,
 												},
 												&ruleRefExpr{
-													pos:  position{line: 109, col: 34, offset: 3594},
+													pos:  position{line: 109, col: 34, offset: 3571},
 													name: "EOL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 109, col: 40, offset: 3600},
+													pos:  position{line: 109, col: 40, offset: 3577},
 													name: "EOF",
 												},
 											},
@@ -1317,9 +1317,9 @@ var g = &grammar{
 		},
 		{
 			name: "SingleCharUnicodeClass",
-			pos:  position{line: 114, col: 1, offset: 3680},
+			pos:  position{line: 114, col: 1, offset: 3657},
 			expr: &charClassMatcher{
-				pos:        position{line: 114, col: 26, offset: 3705},
+				pos:        position{line: 114, col: 26, offset: 3682},
 				val:        "[LMNCPZS]",
 				chars:      []rune{'L', 'M', 'N', 'C', 'P', 'Z', 'S'},
 				ignoreCase: false,
@@ -1328,39 +1328,39 @@ var g = &grammar{
 		},
 		{
 			name: "Number",
-			pos:  position{line: 117, col: 1, offset: 3717},
+			pos:  position{line: 117, col: 1, offset: 3694},
 			expr: &actionExpr{
-				pos: position{line: 117, col: 10, offset: 3726},
+				pos: position{line: 117, col: 10, offset: 3703},
 				run: (*parser).callonNumber1,
 				expr: &seqExpr{
-					pos: position{line: 117, col: 10, offset: 3726},
+					pos: position{line: 117, col: 10, offset: 3703},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 117, col: 10, offset: 3726},
+							pos: position{line: 117, col: 10, offset: 3703},
 							expr: &litMatcher{
-								pos:        position{line: 117, col: 10, offset: 3726},
+								pos:        position{line: 117, col: 10, offset: 3703},
 								val:        "-",
 								ignoreCase: false,
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 117, col: 15, offset: 3731},
+							pos:  position{line: 117, col: 15, offset: 3708},
 							name: "Integer",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 117, col: 23, offset: 3739},
+							pos: position{line: 117, col: 23, offset: 3716},
 							expr: &seqExpr{
-								pos: position{line: 117, col: 25, offset: 3741},
+								pos: position{line: 117, col: 25, offset: 3718},
 								exprs: []interface{}{
 									&litMatcher{
-										pos:        position{line: 117, col: 25, offset: 3741},
+										pos:        position{line: 117, col: 25, offset: 3718},
 										val:        ".",
 										ignoreCase: false,
 									},
 									&oneOrMoreExpr{
-										pos: position{line: 117, col: 29, offset: 3745},
+										pos: position{line: 117, col: 29, offset: 3722},
 										expr: &ruleRefExpr{
-											pos:  position{line: 117, col: 29, offset: 3745},
+											pos:  position{line: 117, col: 29, offset: 3722},
 											name: "Digit",
 										},
 									},
@@ -1373,29 +1373,29 @@ var g = &grammar{
 		},
 		{
 			name: "Integer",
-			pos:  position{line: 121, col: 1, offset: 3797},
+			pos:  position{line: 121, col: 1, offset: 3774},
 			expr: &choiceExpr{
-				pos: position{line: 121, col: 11, offset: 3807},
+				pos: position{line: 121, col: 11, offset: 3784},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 121, col: 11, offset: 3807},
+						pos:        position{line: 121, col: 11, offset: 3784},
 						val:        "0",
 						ignoreCase: false,
 					},
 					&actionExpr{
-						pos: position{line: 121, col: 17, offset: 3813},
+						pos: position{line: 121, col: 17, offset: 3790},
 						run: (*parser).callonInteger3,
 						expr: &seqExpr{
-							pos: position{line: 121, col: 17, offset: 3813},
+							pos: position{line: 121, col: 17, offset: 3790},
 							exprs: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 121, col: 17, offset: 3813},
+									pos:  position{line: 121, col: 17, offset: 3790},
 									name: "NonZeroDigit",
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 121, col: 30, offset: 3826},
+									pos: position{line: 121, col: 30, offset: 3803},
 									expr: &ruleRefExpr{
-										pos:  position{line: 121, col: 30, offset: 3826},
+										pos:  position{line: 121, col: 30, offset: 3803},
 										name: "Digit",
 									},
 								},
@@ -1407,9 +1407,9 @@ var g = &grammar{
 		},
 		{
 			name: "NonZeroDigit",
-			pos:  position{line: 125, col: 1, offset: 3890},
+			pos:  position{line: 125, col: 1, offset: 3867},
 			expr: &charClassMatcher{
-				pos:        position{line: 125, col: 16, offset: 3905},
+				pos:        position{line: 125, col: 16, offset: 3882},
 				val:        "[1-9]",
 				ranges:     []rune{'1', '9'},
 				ignoreCase: false,
@@ -1418,9 +1418,9 @@ var g = &grammar{
 		},
 		{
 			name: "Digit",
-			pos:  position{line: 126, col: 1, offset: 3911},
+			pos:  position{line: 126, col: 1, offset: 3888},
 			expr: &charClassMatcher{
-				pos:        position{line: 126, col: 9, offset: 3919},
+				pos:        position{line: 126, col: 9, offset: 3896},
 				val:        "[0-9]",
 				ranges:     []rune{'0', '9'},
 				ignoreCase: false,
@@ -1429,31 +1429,31 @@ var g = &grammar{
 		},
 		{
 			name: "LabelBlock",
-			pos:  position{line: 128, col: 1, offset: 3926},
+			pos:  position{line: 128, col: 1, offset: 3903},
 			expr: &choiceExpr{
-				pos: position{line: 128, col: 14, offset: 3939},
+				pos: position{line: 128, col: 14, offset: 3916},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 128, col: 14, offset: 3939},
+						pos: position{line: 128, col: 14, offset: 3916},
 						run: (*parser).callonLabelBlock2,
 						expr: &seqExpr{
-							pos: position{line: 128, col: 14, offset: 3939},
+							pos: position{line: 128, col: 14, offset: 3916},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 128, col: 14, offset: 3939},
+									pos:        position{line: 128, col: 14, offset: 3916},
 									val:        "{",
 									ignoreCase: false,
 								},
 								&labeledExpr{
-									pos:   position{line: 128, col: 18, offset: 3943},
+									pos:   position{line: 128, col: 18, offset: 3920},
 									label: "block",
 									expr: &ruleRefExpr{
-										pos:  position{line: 128, col: 24, offset: 3949},
+										pos:  position{line: 128, col: 24, offset: 3926},
 										name: "LabelMatches",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 128, col: 37, offset: 3962},
+									pos:        position{line: 128, col: 37, offset: 3939},
 									val:        "}",
 									ignoreCase: false,
 								},
@@ -1461,22 +1461,22 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 130, col: 5, offset: 3994},
+						pos: position{line: 130, col: 5, offset: 3971},
 						run: (*parser).callonLabelBlock8,
 						expr: &seqExpr{
-							pos: position{line: 130, col: 5, offset: 3994},
+							pos: position{line: 130, col: 5, offset: 3971},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 130, col: 5, offset: 3994},
+									pos:        position{line: 130, col: 5, offset: 3971},
 									val:        "{",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 130, col: 9, offset: 3998},
+									pos:  position{line: 130, col: 9, offset: 3975},
 									name: "LabelMatches",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 130, col: 22, offset: 4011},
+									pos:  position{line: 130, col: 22, offset: 3988},
 									name: "EOF",
 								},
 							},
@@ -1487,12 +1487,12 @@ var g = &grammar{
 		},
 		{
 			name: "NanoSecondUnits",
-			pos:  position{line: 134, col: 1, offset: 4076},
+			pos:  position{line: 134, col: 1, offset: 4053},
 			expr: &actionExpr{
-				pos: position{line: 134, col: 19, offset: 4094},
+				pos: position{line: 134, col: 19, offset: 4071},
 				run: (*parser).callonNanoSecondUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 134, col: 19, offset: 4094},
+					pos:        position{line: 134, col: 19, offset: 4071},
 					val:        "ns",
 					ignoreCase: false,
 				},
@@ -1500,25 +1500,25 @@ var g = &grammar{
 		},
 		{
 			name: "MicroSecondUnits",
-			pos:  position{line: 139, col: 1, offset: 4199},
+			pos:  position{line: 139, col: 1, offset: 4176},
 			expr: &actionExpr{
-				pos: position{line: 139, col: 20, offset: 4218},
+				pos: position{line: 139, col: 20, offset: 4195},
 				run: (*parser).callonMicroSecondUnits1,
 				expr: &choiceExpr{
-					pos: position{line: 139, col: 21, offset: 4219},
+					pos: position{line: 139, col: 21, offset: 4196},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 139, col: 21, offset: 4219},
+							pos:        position{line: 139, col: 21, offset: 4196},
 							val:        "us",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 139, col: 28, offset: 4226},
+							pos:        position{line: 139, col: 28, offset: 4203},
 							val:        "µs",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 139, col: 35, offset: 4234},
+							pos:        position{line: 139, col: 35, offset: 4211},
 							val:        "μs",
 							ignoreCase: false,
 						},
@@ -1528,12 +1528,12 @@ var g = &grammar{
 		},
 		{
 			name: "MilliSecondUnits",
-			pos:  position{line: 144, col: 1, offset: 4343},
+			pos:  position{line: 144, col: 1, offset: 4320},
 			expr: &actionExpr{
-				pos: position{line: 144, col: 20, offset: 4362},
+				pos: position{line: 144, col: 20, offset: 4339},
 				run: (*parser).callonMilliSecondUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 144, col: 20, offset: 4362},
+					pos:        position{line: 144, col: 20, offset: 4339},
 					val:        "ms",
 					ignoreCase: false,
 				},
@@ -1541,12 +1541,12 @@ var g = &grammar{
 		},
 		{
 			name: "SecondUnits",
-			pos:  position{line: 149, col: 1, offset: 4469},
+			pos:  position{line: 149, col: 1, offset: 4446},
 			expr: &actionExpr{
-				pos: position{line: 149, col: 15, offset: 4483},
+				pos: position{line: 149, col: 15, offset: 4460},
 				run: (*parser).callonSecondUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 149, col: 15, offset: 4483},
+					pos:        position{line: 149, col: 15, offset: 4460},
 					val:        "s",
 					ignoreCase: false,
 				},
@@ -1554,12 +1554,12 @@ var g = &grammar{
 		},
 		{
 			name: "MinuteUnits",
-			pos:  position{line: 153, col: 1, offset: 4520},
+			pos:  position{line: 153, col: 1, offset: 4497},
 			expr: &actionExpr{
-				pos: position{line: 153, col: 15, offset: 4534},
+				pos: position{line: 153, col: 15, offset: 4511},
 				run: (*parser).callonMinuteUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 153, col: 15, offset: 4534},
+					pos:        position{line: 153, col: 15, offset: 4511},
 					val:        "m",
 					ignoreCase: false,
 				},
@@ -1567,12 +1567,12 @@ var g = &grammar{
 		},
 		{
 			name: "HourUnits",
-			pos:  position{line: 157, col: 1, offset: 4571},
+			pos:  position{line: 157, col: 1, offset: 4548},
 			expr: &actionExpr{
-				pos: position{line: 157, col: 13, offset: 4583},
+				pos: position{line: 157, col: 13, offset: 4560},
 				run: (*parser).callonHourUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 157, col: 13, offset: 4583},
+					pos:        position{line: 157, col: 13, offset: 4560},
 					val:        "h",
 					ignoreCase: false,
 				},
@@ -1580,12 +1580,12 @@ var g = &grammar{
 		},
 		{
 			name: "DayUnits",
-			pos:  position{line: 161, col: 1, offset: 4618},
+			pos:  position{line: 161, col: 1, offset: 4595},
 			expr: &actionExpr{
-				pos: position{line: 161, col: 12, offset: 4629},
+				pos: position{line: 161, col: 12, offset: 4606},
 				run: (*parser).callonDayUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 161, col: 12, offset: 4629},
+					pos:        position{line: 161, col: 12, offset: 4606},
 					val:        "d",
 					ignoreCase: false,
 				},
@@ -1593,12 +1593,12 @@ var g = &grammar{
 		},
 		{
 			name: "WeekUnits",
-			pos:  position{line: 167, col: 1, offset: 4837},
+			pos:  position{line: 167, col: 1, offset: 4814},
 			expr: &actionExpr{
-				pos: position{line: 167, col: 13, offset: 4849},
+				pos: position{line: 167, col: 13, offset: 4826},
 				run: (*parser).callonWeekUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 167, col: 13, offset: 4849},
+					pos:        position{line: 167, col: 13, offset: 4826},
 					val:        "w",
 					ignoreCase: false,
 				},
@@ -1606,12 +1606,12 @@ var g = &grammar{
 		},
 		{
 			name: "YearUnits",
-			pos:  position{line: 173, col: 1, offset: 5060},
+			pos:  position{line: 173, col: 1, offset: 5037},
 			expr: &actionExpr{
-				pos: position{line: 173, col: 13, offset: 5072},
+				pos: position{line: 173, col: 13, offset: 5049},
 				run: (*parser).callonYearUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 173, col: 13, offset: 5072},
+					pos:        position{line: 173, col: 13, offset: 5049},
 					val:        "y",
 					ignoreCase: false,
 				},
@@ -1619,44 +1619,44 @@ var g = &grammar{
 		},
 		{
 			name: "DurationUnits",
-			pos:  position{line: 179, col: 1, offset: 5269},
+			pos:  position{line: 179, col: 1, offset: 5246},
 			expr: &choiceExpr{
-				pos: position{line: 179, col: 18, offset: 5286},
+				pos: position{line: 179, col: 18, offset: 5263},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 18, offset: 5286},
+						pos:  position{line: 179, col: 18, offset: 5263},
 						name: "NanoSecondUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 36, offset: 5304},
+						pos:  position{line: 179, col: 36, offset: 5281},
 						name: "MicroSecondUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 55, offset: 5323},
+						pos:  position{line: 179, col: 55, offset: 5300},
 						name: "MilliSecondUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 74, offset: 5342},
+						pos:  position{line: 179, col: 74, offset: 5319},
 						name: "SecondUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 88, offset: 5356},
+						pos:  position{line: 179, col: 88, offset: 5333},
 						name: "MinuteUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 102, offset: 5370},
+						pos:  position{line: 179, col: 102, offset: 5347},
 						name: "HourUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 114, offset: 5382},
+						pos:  position{line: 179, col: 114, offset: 5359},
 						name: "DayUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 125, offset: 5393},
+						pos:  position{line: 179, col: 125, offset: 5370},
 						name: "WeekUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 179, col: 137, offset: 5405},
+						pos:  position{line: 179, col: 137, offset: 5382},
 						name: "YearUnits",
 					},
 				},
@@ -1664,26 +1664,26 @@ var g = &grammar{
 		},
 		{
 			name: "Duration",
-			pos:  position{line: 181, col: 1, offset: 5417},
+			pos:  position{line: 181, col: 1, offset: 5394},
 			expr: &actionExpr{
-				pos: position{line: 181, col: 12, offset: 5428},
+				pos: position{line: 181, col: 12, offset: 5405},
 				run: (*parser).callonDuration1,
 				expr: &seqExpr{
-					pos: position{line: 181, col: 12, offset: 5428},
+					pos: position{line: 181, col: 12, offset: 5405},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 181, col: 12, offset: 5428},
+							pos:   position{line: 181, col: 12, offset: 5405},
 							label: "dur",
 							expr: &ruleRefExpr{
-								pos:  position{line: 181, col: 16, offset: 5432},
+								pos:  position{line: 181, col: 16, offset: 5409},
 								name: "Integer",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 181, col: 24, offset: 5440},
+							pos:   position{line: 181, col: 24, offset: 5417},
 							label: "units",
 							expr: &ruleRefExpr{
-								pos:  position{line: 181, col: 30, offset: 5446},
+								pos:  position{line: 181, col: 30, offset: 5423},
 								name: "DurationUnits",
 							},
 						},
@@ -1693,82 +1693,82 @@ var g = &grammar{
 		},
 		{
 			name: "Operators",
-			pos:  position{line: 187, col: 1, offset: 5595},
+			pos:  position{line: 187, col: 1, offset: 5572},
 			expr: &choiceExpr{
-				pos: position{line: 187, col: 13, offset: 5607},
+				pos: position{line: 187, col: 13, offset: 5584},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 187, col: 13, offset: 5607},
+						pos:        position{line: 187, col: 13, offset: 5584},
 						val:        "-",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 19, offset: 5613},
+						pos:        position{line: 187, col: 19, offset: 5590},
 						val:        "+",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 25, offset: 5619},
+						pos:        position{line: 187, col: 25, offset: 5596},
 						val:        "*",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 31, offset: 5625},
+						pos:        position{line: 187, col: 31, offset: 5602},
 						val:        "%",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 37, offset: 5631},
+						pos:        position{line: 187, col: 37, offset: 5608},
 						val:        "/",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 43, offset: 5637},
+						pos:        position{line: 187, col: 43, offset: 5614},
 						val:        "==",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 50, offset: 5644},
+						pos:        position{line: 187, col: 50, offset: 5621},
 						val:        "!=",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 57, offset: 5651},
+						pos:        position{line: 187, col: 57, offset: 5628},
 						val:        "<=",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 64, offset: 5658},
+						pos:        position{line: 187, col: 64, offset: 5635},
 						val:        "<",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 70, offset: 5664},
+						pos:        position{line: 187, col: 70, offset: 5641},
 						val:        ">=",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 77, offset: 5671},
+						pos:        position{line: 187, col: 77, offset: 5648},
 						val:        ">",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 83, offset: 5677},
+						pos:        position{line: 187, col: 83, offset: 5654},
 						val:        "=~",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 90, offset: 5684},
+						pos:        position{line: 187, col: 90, offset: 5661},
 						val:        "!~",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 97, offset: 5691},
+						pos:        position{line: 187, col: 97, offset: 5668},
 						val:        "^",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 187, col: 103, offset: 5697},
+						pos:        position{line: 187, col: 103, offset: 5674},
 						val:        "=",
 						ignoreCase: false,
 					},
@@ -1777,42 +1777,42 @@ var g = &grammar{
 		},
 		{
 			name: "LabelOperators",
-			pos:  position{line: 189, col: 1, offset: 5702},
+			pos:  position{line: 189, col: 1, offset: 5679},
 			expr: &choiceExpr{
-				pos: position{line: 189, col: 19, offset: 5720},
+				pos: position{line: 189, col: 19, offset: 5697},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 189, col: 19, offset: 5720},
+						pos: position{line: 189, col: 19, offset: 5697},
 						run: (*parser).callonLabelOperators2,
 						expr: &litMatcher{
-							pos:        position{line: 189, col: 19, offset: 5720},
+							pos:        position{line: 189, col: 19, offset: 5697},
 							val:        "!=",
 							ignoreCase: false,
 						},
 					},
 					&actionExpr{
-						pos: position{line: 191, col: 5, offset: 5756},
+						pos: position{line: 191, col: 5, offset: 5733},
 						run: (*parser).callonLabelOperators4,
 						expr: &litMatcher{
-							pos:        position{line: 191, col: 5, offset: 5756},
+							pos:        position{line: 191, col: 5, offset: 5733},
 							val:        "=~",
 							ignoreCase: false,
 						},
 					},
 					&actionExpr{
-						pos: position{line: 193, col: 5, offset: 5794},
+						pos: position{line: 193, col: 5, offset: 5771},
 						run: (*parser).callonLabelOperators6,
 						expr: &litMatcher{
-							pos:        position{line: 193, col: 5, offset: 5794},
+							pos:        position{line: 193, col: 5, offset: 5771},
 							val:        "!~",
 							ignoreCase: false,
 						},
 					},
 					&actionExpr{
-						pos: position{line: 195, col: 5, offset: 5834},
+						pos: position{line: 195, col: 5, offset: 5811},
 						run: (*parser).callonLabelOperators8,
 						expr: &litMatcher{
-							pos:        position{line: 195, col: 5, offset: 5834},
+							pos:        position{line: 195, col: 5, offset: 5811},
 							val:        "=",
 							ignoreCase: false,
 						},
@@ -1822,57 +1822,57 @@ var g = &grammar{
 		},
 		{
 			name: "Label",
-			pos:  position{line: 199, col: 1, offset: 5865},
+			pos:  position{line: 199, col: 1, offset: 5842},
 			expr: &ruleRefExpr{
-				pos:  position{line: 199, col: 9, offset: 5873},
+				pos:  position{line: 199, col: 9, offset: 5850},
 				name: "Identifier",
 			},
 		},
 		{
 			name: "LabelMatch",
-			pos:  position{line: 200, col: 1, offset: 5884},
+			pos:  position{line: 200, col: 1, offset: 5861},
 			expr: &actionExpr{
-				pos: position{line: 200, col: 14, offset: 5897},
+				pos: position{line: 200, col: 14, offset: 5874},
 				run: (*parser).callonLabelMatch1,
 				expr: &seqExpr{
-					pos: position{line: 200, col: 14, offset: 5897},
+					pos: position{line: 200, col: 14, offset: 5874},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 200, col: 14, offset: 5897},
+							pos:   position{line: 200, col: 14, offset: 5874},
 							label: "label",
 							expr: &ruleRefExpr{
-								pos:  position{line: 200, col: 20, offset: 5903},
+								pos:  position{line: 200, col: 20, offset: 5880},
 								name: "Label",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 200, col: 26, offset: 5909},
+							pos:  position{line: 200, col: 26, offset: 5886},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 200, col: 29, offset: 5912},
+							pos:   position{line: 200, col: 29, offset: 5889},
 							label: "op",
 							expr: &ruleRefExpr{
-								pos:  position{line: 200, col: 32, offset: 5915},
+								pos:  position{line: 200, col: 32, offset: 5892},
 								name: "LabelOperators",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 200, col: 47, offset: 5930},
+							pos:  position{line: 200, col: 47, offset: 5907},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 200, col: 50, offset: 5933},
+							pos:   position{line: 200, col: 50, offset: 5910},
 							label: "match",
 							expr: &choiceExpr{
-								pos: position{line: 200, col: 58, offset: 5941},
+								pos: position{line: 200, col: 58, offset: 5918},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 200, col: 58, offset: 5941},
+										pos:  position{line: 200, col: 58, offset: 5918},
 										name: "StringLiteral",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 200, col: 74, offset: 5957},
+										pos:  position{line: 200, col: 74, offset: 5934},
 										name: "Number",
 									},
 								},
@@ -1884,32 +1884,32 @@ var g = &grammar{
 		},
 		{
 			name: "LabelMatches",
-			pos:  position{line: 203, col: 1, offset: 6047},
+			pos:  position{line: 203, col: 1, offset: 6024},
 			expr: &actionExpr{
-				pos: position{line: 203, col: 16, offset: 6062},
+				pos: position{line: 203, col: 16, offset: 6039},
 				run: (*parser).callonLabelMatches1,
 				expr: &seqExpr{
-					pos: position{line: 203, col: 16, offset: 6062},
+					pos: position{line: 203, col: 16, offset: 6039},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 203, col: 16, offset: 6062},
+							pos:   position{line: 203, col: 16, offset: 6039},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 203, col: 22, offset: 6068},
+								pos:  position{line: 203, col: 22, offset: 6045},
 								name: "LabelMatch",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 203, col: 33, offset: 6079},
+							pos:  position{line: 203, col: 33, offset: 6056},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 203, col: 36, offset: 6082},
+							pos:   position{line: 203, col: 36, offset: 6059},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 203, col: 41, offset: 6087},
+								pos: position{line: 203, col: 41, offset: 6064},
 								expr: &ruleRefExpr{
-									pos:  position{line: 203, col: 41, offset: 6087},
+									pos:  position{line: 203, col: 41, offset: 6064},
 									name: "LabelMatchesRest",
 								},
 							},
@@ -1920,27 +1920,27 @@ var g = &grammar{
 		},
 		{
 			name: "LabelMatchesRest",
-			pos:  position{line: 207, col: 1, offset: 6166},
+			pos:  position{line: 207, col: 1, offset: 6143},
 			expr: &actionExpr{
-				pos: position{line: 207, col: 21, offset: 6186},
+				pos: position{line: 207, col: 21, offset: 6163},
 				run: (*parser).callonLabelMatchesRest1,
 				expr: &seqExpr{
-					pos: position{line: 207, col: 21, offset: 6186},
+					pos: position{line: 207, col: 21, offset: 6163},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 207, col: 21, offset: 6186},
+							pos:        position{line: 207, col: 21, offset: 6163},
 							val:        ",",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 207, col: 25, offset: 6190},
+							pos:  position{line: 207, col: 25, offset: 6167},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 207, col: 28, offset: 6193},
+							pos:   position{line: 207, col: 28, offset: 6170},
 							label: "match",
 							expr: &ruleRefExpr{
-								pos:  position{line: 207, col: 34, offset: 6199},
+								pos:  position{line: 207, col: 34, offset: 6176},
 								name: "LabelMatch",
 							},
 						},
@@ -1950,27 +1950,27 @@ var g = &grammar{
 		},
 		{
 			name: "LabelList",
-			pos:  position{line: 211, col: 1, offset: 6237},
+			pos:  position{line: 211, col: 1, offset: 6214},
 			expr: &choiceExpr{
-				pos: position{line: 211, col: 13, offset: 6249},
+				pos: position{line: 211, col: 13, offset: 6226},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 211, col: 13, offset: 6249},
+						pos: position{line: 211, col: 13, offset: 6226},
 						run: (*parser).callonLabelList2,
 						expr: &seqExpr{
-							pos: position{line: 211, col: 14, offset: 6250},
+							pos: position{line: 211, col: 14, offset: 6227},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 211, col: 14, offset: 6250},
+									pos:        position{line: 211, col: 14, offset: 6227},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 211, col: 18, offset: 6254},
+									pos:  position{line: 211, col: 18, offset: 6231},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 211, col: 21, offset: 6257},
+									pos:        position{line: 211, col: 21, offset: 6234},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -1978,49 +1978,49 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 213, col: 6, offset: 6289},
+						pos: position{line: 213, col: 6, offset: 6266},
 						run: (*parser).callonLabelList7,
 						expr: &seqExpr{
-							pos: position{line: 213, col: 6, offset: 6289},
+							pos: position{line: 213, col: 6, offset: 6266},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 213, col: 6, offset: 6289},
+									pos:        position{line: 213, col: 6, offset: 6266},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 213, col: 10, offset: 6293},
+									pos:  position{line: 213, col: 10, offset: 6270},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 213, col: 13, offset: 6296},
+									pos:   position{line: 213, col: 13, offset: 6273},
 									label: "label",
 									expr: &ruleRefExpr{
-										pos:  position{line: 213, col: 19, offset: 6302},
+										pos:  position{line: 213, col: 19, offset: 6279},
 										name: "Label",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 213, col: 25, offset: 6308},
+									pos:  position{line: 213, col: 25, offset: 6285},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 213, col: 28, offset: 6311},
+									pos:   position{line: 213, col: 28, offset: 6288},
 									label: "rest",
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 213, col: 33, offset: 6316},
+										pos: position{line: 213, col: 33, offset: 6293},
 										expr: &ruleRefExpr{
-											pos:  position{line: 213, col: 33, offset: 6316},
+											pos:  position{line: 213, col: 33, offset: 6293},
 											name: "LabelListRest",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 213, col: 48, offset: 6331},
+									pos:  position{line: 213, col: 48, offset: 6308},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 213, col: 51, offset: 6334},
+									pos:        position{line: 213, col: 51, offset: 6311},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -2032,27 +2032,27 @@ var g = &grammar{
 		},
 		{
 			name: "LabelListRest",
-			pos:  position{line: 217, col: 1, offset: 6400},
+			pos:  position{line: 217, col: 1, offset: 6377},
 			expr: &actionExpr{
-				pos: position{line: 217, col: 18, offset: 6417},
+				pos: position{line: 217, col: 18, offset: 6394},
 				run: (*parser).callonLabelListRest1,
 				expr: &seqExpr{
-					pos: position{line: 217, col: 18, offset: 6417},
+					pos: position{line: 217, col: 18, offset: 6394},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 217, col: 18, offset: 6417},
+							pos:        position{line: 217, col: 18, offset: 6394},
 							val:        ",",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 217, col: 22, offset: 6421},
+							pos:  position{line: 217, col: 22, offset: 6398},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 217, col: 25, offset: 6424},
+							pos:   position{line: 217, col: 25, offset: 6401},
 							label: "label",
 							expr: &ruleRefExpr{
-								pos:  position{line: 217, col: 31, offset: 6430},
+								pos:  position{line: 217, col: 31, offset: 6407},
 								name: "Label",
 							},
 						},
@@ -2062,62 +2062,62 @@ var g = &grammar{
 		},
 		{
 			name: "VectorSelector",
-			pos:  position{line: 221, col: 1, offset: 6463},
+			pos:  position{line: 221, col: 1, offset: 6440},
 			expr: &actionExpr{
-				pos: position{line: 221, col: 18, offset: 6480},
+				pos: position{line: 221, col: 18, offset: 6457},
 				run: (*parser).callonVectorSelector1,
 				expr: &seqExpr{
-					pos: position{line: 221, col: 18, offset: 6480},
+					pos: position{line: 221, col: 18, offset: 6457},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 221, col: 18, offset: 6480},
+							pos:   position{line: 221, col: 18, offset: 6457},
 							label: "metric",
 							expr: &ruleRefExpr{
-								pos:  position{line: 221, col: 25, offset: 6487},
+								pos:  position{line: 221, col: 25, offset: 6464},
 								name: "Identifier",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 221, col: 36, offset: 6498},
+							pos:  position{line: 221, col: 36, offset: 6475},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 221, col: 40, offset: 6502},
+							pos:   position{line: 221, col: 40, offset: 6479},
 							label: "block",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 221, col: 46, offset: 6508},
+								pos: position{line: 221, col: 46, offset: 6485},
 								expr: &ruleRefExpr{
-									pos:  position{line: 221, col: 46, offset: 6508},
+									pos:  position{line: 221, col: 46, offset: 6485},
 									name: "LabelBlock",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 221, col: 58, offset: 6520},
+							pos:  position{line: 221, col: 58, offset: 6497},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 221, col: 61, offset: 6523},
+							pos:   position{line: 221, col: 61, offset: 6500},
 							label: "rng",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 221, col: 65, offset: 6527},
+								pos: position{line: 221, col: 65, offset: 6504},
 								expr: &ruleRefExpr{
-									pos:  position{line: 221, col: 65, offset: 6527},
+									pos:  position{line: 221, col: 65, offset: 6504},
 									name: "Range",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 221, col: 72, offset: 6534},
+							pos:  position{line: 221, col: 72, offset: 6511},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 221, col: 75, offset: 6537},
+							pos:   position{line: 221, col: 75, offset: 6514},
 							label: "offset",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 221, col: 82, offset: 6544},
+								pos: position{line: 221, col: 82, offset: 6521},
 								expr: &ruleRefExpr{
-									pos:  position{line: 221, col: 82, offset: 6544},
+									pos:  position{line: 221, col: 82, offset: 6521},
 									name: "Offset",
 								},
 							},
@@ -2128,36 +2128,36 @@ var g = &grammar{
 		},
 		{
 			name: "Range",
-			pos:  position{line: 225, col: 1, offset: 6622},
+			pos:  position{line: 225, col: 1, offset: 6599},
 			expr: &actionExpr{
-				pos: position{line: 225, col: 9, offset: 6630},
+				pos: position{line: 225, col: 9, offset: 6607},
 				run: (*parser).callonRange1,
 				expr: &seqExpr{
-					pos: position{line: 225, col: 9, offset: 6630},
+					pos: position{line: 225, col: 9, offset: 6607},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 225, col: 9, offset: 6630},
+							pos:        position{line: 225, col: 9, offset: 6607},
 							val:        "[",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 225, col: 13, offset: 6634},
+							pos:  position{line: 225, col: 13, offset: 6611},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 225, col: 16, offset: 6637},
+							pos:   position{line: 225, col: 16, offset: 6614},
 							label: "dur",
 							expr: &ruleRefExpr{
-								pos:  position{line: 225, col: 20, offset: 6641},
+								pos:  position{line: 225, col: 20, offset: 6618},
 								name: "Duration",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 225, col: 29, offset: 6650},
+							pos:  position{line: 225, col: 29, offset: 6627},
 							name: "__",
 						},
 						&litMatcher{
-							pos:        position{line: 225, col: 32, offset: 6653},
+							pos:        position{line: 225, col: 32, offset: 6630},
 							val:        "]",
 							ignoreCase: false,
 						},
@@ -2167,27 +2167,27 @@ var g = &grammar{
 		},
 		{
 			name: "Offset",
-			pos:  position{line: 229, col: 1, offset: 6682},
+			pos:  position{line: 229, col: 1, offset: 6659},
 			expr: &actionExpr{
-				pos: position{line: 229, col: 10, offset: 6691},
+				pos: position{line: 229, col: 10, offset: 6668},
 				run: (*parser).callonOffset1,
 				expr: &seqExpr{
-					pos: position{line: 229, col: 10, offset: 6691},
+					pos: position{line: 229, col: 10, offset: 6668},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 229, col: 10, offset: 6691},
+							pos:        position{line: 229, col: 10, offset: 6668},
 							val:        "offset",
 							ignoreCase: true,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 229, col: 20, offset: 6701},
+							pos:  position{line: 229, col: 20, offset: 6678},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 229, col: 23, offset: 6704},
+							pos:   position{line: 229, col: 23, offset: 6681},
 							label: "dur",
 							expr: &ruleRefExpr{
-								pos:  position{line: 229, col: 27, offset: 6708},
+								pos:  position{line: 229, col: 27, offset: 6685},
 								name: "Duration",
 							},
 						},
@@ -2197,12 +2197,12 @@ var g = &grammar{
 		},
 		{
 			name: "CountValueOperator",
-			pos:  position{line: 233, col: 1, offset: 6742},
+			pos:  position{line: 233, col: 1, offset: 6719},
 			expr: &actionExpr{
-				pos: position{line: 233, col: 22, offset: 6763},
+				pos: position{line: 233, col: 22, offset: 6740},
 				run: (*parser).callonCountValueOperator1,
 				expr: &litMatcher{
-					pos:        position{line: 233, col: 22, offset: 6763},
+					pos:        position{line: 233, col: 22, offset: 6740},
 					val:        "count_values",
 					ignoreCase: true,
 				},
@@ -2210,28 +2210,28 @@ var g = &grammar{
 		},
 		{
 			name: "BinaryAggregateOperators",
-			pos:  position{line: 239, col: 1, offset: 6848},
+			pos:  position{line: 239, col: 1, offset: 6825},
 			expr: &actionExpr{
-				pos: position{line: 239, col: 29, offset: 6876},
+				pos: position{line: 239, col: 29, offset: 6853},
 				run: (*parser).callonBinaryAggregateOperators1,
 				expr: &labeledExpr{
-					pos:   position{line: 239, col: 29, offset: 6876},
+					pos:   position{line: 239, col: 29, offset: 6853},
 					label: "op",
 					expr: &choiceExpr{
-						pos: position{line: 239, col: 33, offset: 6880},
+						pos: position{line: 239, col: 33, offset: 6857},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 239, col: 33, offset: 6880},
+								pos:        position{line: 239, col: 33, offset: 6857},
 								val:        "topk",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 239, col: 43, offset: 6890},
+								pos:        position{line: 239, col: 43, offset: 6867},
 								val:        "bottomk",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 239, col: 56, offset: 6903},
+								pos:        position{line: 239, col: 56, offset: 6880},
 								val:        "quantile",
 								ignoreCase: true,
 							},
@@ -2242,48 +2242,48 @@ var g = &grammar{
 		},
 		{
 			name: "UnaryAggregateOperators",
-			pos:  position{line: 245, col: 1, offset: 7005},
+			pos:  position{line: 245, col: 1, offset: 6982},
 			expr: &actionExpr{
-				pos: position{line: 245, col: 27, offset: 7031},
+				pos: position{line: 245, col: 27, offset: 7008},
 				run: (*parser).callonUnaryAggregateOperators1,
 				expr: &labeledExpr{
-					pos:   position{line: 245, col: 27, offset: 7031},
+					pos:   position{line: 245, col: 27, offset: 7008},
 					label: "op",
 					expr: &choiceExpr{
-						pos: position{line: 245, col: 31, offset: 7035},
+						pos: position{line: 245, col: 31, offset: 7012},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 245, col: 31, offset: 7035},
+								pos:        position{line: 245, col: 31, offset: 7012},
 								val:        "sum",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 245, col: 40, offset: 7044},
+								pos:        position{line: 245, col: 40, offset: 7021},
 								val:        "min",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 245, col: 49, offset: 7053},
+								pos:        position{line: 245, col: 49, offset: 7030},
 								val:        "max",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 245, col: 58, offset: 7062},
+								pos:        position{line: 245, col: 58, offset: 7039},
 								val:        "avg",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 245, col: 67, offset: 7071},
+								pos:        position{line: 245, col: 67, offset: 7048},
 								val:        "stddev",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 245, col: 79, offset: 7083},
+								pos:        position{line: 245, col: 79, offset: 7060},
 								val:        "stdvar",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 245, col: 91, offset: 7095},
+								pos:        position{line: 245, col: 91, offset: 7072},
 								val:        "count",
 								ignoreCase: true,
 							},
@@ -2294,20 +2294,20 @@ var g = &grammar{
 		},
 		{
 			name: "AggregateOperators",
-			pos:  position{line: 251, col: 1, offset: 7194},
+			pos:  position{line: 251, col: 1, offset: 7171},
 			expr: &choiceExpr{
-				pos: position{line: 251, col: 22, offset: 7215},
+				pos: position{line: 251, col: 22, offset: 7192},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 251, col: 22, offset: 7215},
+						pos:  position{line: 251, col: 22, offset: 7192},
 						name: "CountValueOperator",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 251, col: 43, offset: 7236},
+						pos:  position{line: 251, col: 43, offset: 7213},
 						name: "BinaryAggregateOperators",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 251, col: 70, offset: 7263},
+						pos:  position{line: 251, col: 70, offset: 7240},
 						name: "UnaryAggregateOperators",
 					},
 				},
@@ -2315,41 +2315,41 @@ var g = &grammar{
 		},
 		{
 			name: "AggregateBy",
-			pos:  position{line: 253, col: 1, offset: 7288},
+			pos:  position{line: 253, col: 1, offset: 7265},
 			expr: &actionExpr{
-				pos: position{line: 253, col: 15, offset: 7302},
+				pos: position{line: 253, col: 15, offset: 7279},
 				run: (*parser).callonAggregateBy1,
 				expr: &seqExpr{
-					pos: position{line: 253, col: 15, offset: 7302},
+					pos: position{line: 253, col: 15, offset: 7279},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 253, col: 15, offset: 7302},
+							pos:        position{line: 253, col: 15, offset: 7279},
 							val:        "by",
 							ignoreCase: true,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 253, col: 21, offset: 7308},
+							pos:  position{line: 253, col: 21, offset: 7285},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 253, col: 24, offset: 7311},
+							pos:   position{line: 253, col: 24, offset: 7288},
 							label: "labels",
 							expr: &ruleRefExpr{
-								pos:  position{line: 253, col: 31, offset: 7318},
+								pos:  position{line: 253, col: 31, offset: 7295},
 								name: "LabelList",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 253, col: 41, offset: 7328},
+							pos:  position{line: 253, col: 41, offset: 7305},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 253, col: 44, offset: 7331},
+							pos:   position{line: 253, col: 44, offset: 7308},
 							label: "keep",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 253, col: 49, offset: 7336},
+								pos: position{line: 253, col: 49, offset: 7313},
 								expr: &litMatcher{
-									pos:        position{line: 253, col: 49, offset: 7336},
+									pos:        position{line: 253, col: 49, offset: 7313},
 									val:        "keep_common",
 									ignoreCase: true,
 								},
@@ -2361,27 +2361,27 @@ var g = &grammar{
 		},
 		{
 			name: "AggregateWithout",
-			pos:  position{line: 260, col: 1, offset: 7449},
+			pos:  position{line: 260, col: 1, offset: 7426},
 			expr: &actionExpr{
-				pos: position{line: 260, col: 20, offset: 7468},
+				pos: position{line: 260, col: 20, offset: 7445},
 				run: (*parser).callonAggregateWithout1,
 				expr: &seqExpr{
-					pos: position{line: 260, col: 20, offset: 7468},
+					pos: position{line: 260, col: 20, offset: 7445},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 260, col: 20, offset: 7468},
+							pos:        position{line: 260, col: 20, offset: 7445},
 							val:        "without",
 							ignoreCase: true,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 260, col: 31, offset: 7479},
+							pos:  position{line: 260, col: 31, offset: 7456},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 260, col: 34, offset: 7482},
+							pos:   position{line: 260, col: 34, offset: 7459},
 							label: "labels",
 							expr: &ruleRefExpr{
-								pos:  position{line: 260, col: 41, offset: 7489},
+								pos:  position{line: 260, col: 41, offset: 7466},
 								name: "LabelList",
 							},
 						},
@@ -2391,16 +2391,16 @@ var g = &grammar{
 		},
 		{
 			name: "AggregateGroup",
-			pos:  position{line: 267, col: 1, offset: 7601},
+			pos:  position{line: 267, col: 1, offset: 7578},
 			expr: &choiceExpr{
-				pos: position{line: 267, col: 18, offset: 7618},
+				pos: position{line: 267, col: 18, offset: 7595},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 267, col: 18, offset: 7618},
+						pos:  position{line: 267, col: 18, offset: 7595},
 						name: "AggregateBy",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 267, col: 32, offset: 7632},
+						pos:  position{line: 267, col: 32, offset: 7609},
 						name: "AggregateWithout",
 					},
 				},
@@ -2408,86 +2408,86 @@ var g = &grammar{
 		},
 		{
 			name: "AggregateExpression",
-			pos:  position{line: 269, col: 1, offset: 7650},
+			pos:  position{line: 269, col: 1, offset: 7627},
 			expr: &choiceExpr{
-				pos: position{line: 270, col: 1, offset: 7672},
+				pos: position{line: 270, col: 1, offset: 7649},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 270, col: 1, offset: 7672},
+						pos: position{line: 270, col: 1, offset: 7649},
 						run: (*parser).callonAggregateExpression2,
 						expr: &seqExpr{
-							pos: position{line: 270, col: 1, offset: 7672},
+							pos: position{line: 270, col: 1, offset: 7649},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 270, col: 1, offset: 7672},
+									pos:   position{line: 270, col: 1, offset: 7649},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 270, col: 4, offset: 7675},
+										pos:  position{line: 270, col: 4, offset: 7652},
 										name: "CountValueOperator",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 270, col: 24, offset: 7695},
+									pos:  position{line: 270, col: 24, offset: 7672},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 270, col: 27, offset: 7698},
+									pos:        position{line: 270, col: 27, offset: 7675},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 270, col: 31, offset: 7702},
+									pos:  position{line: 270, col: 31, offset: 7679},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 270, col: 34, offset: 7705},
+									pos:   position{line: 270, col: 34, offset: 7682},
 									label: "param",
 									expr: &ruleRefExpr{
-										pos:  position{line: 270, col: 40, offset: 7711},
+										pos:  position{line: 270, col: 40, offset: 7688},
 										name: "StringLiteral",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 270, col: 54, offset: 7725},
+									pos:  position{line: 270, col: 54, offset: 7702},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 270, col: 57, offset: 7728},
+									pos:        position{line: 270, col: 57, offset: 7705},
 									val:        ",",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 270, col: 61, offset: 7732},
+									pos:  position{line: 270, col: 61, offset: 7709},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 270, col: 64, offset: 7735},
+									pos:   position{line: 270, col: 64, offset: 7712},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 270, col: 71, offset: 7742},
-										name: "VectorSelector",
+										pos:  position{line: 270, col: 71, offset: 7719},
+										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 270, col: 86, offset: 7757},
+									pos:  position{line: 270, col: 82, offset: 7730},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 270, col: 89, offset: 7760},
+									pos:        position{line: 270, col: 85, offset: 7733},
 									val:        ")",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 270, col: 93, offset: 7764},
+									pos:  position{line: 270, col: 89, offset: 7737},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 270, col: 96, offset: 7767},
+									pos:   position{line: 270, col: 92, offset: 7740},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 270, col: 102, offset: 7773},
+										pos: position{line: 270, col: 98, offset: 7746},
 										expr: &ruleRefExpr{
-											pos:  position{line: 270, col: 102, offset: 7773},
+											pos:  position{line: 270, col: 98, offset: 7746},
 											name: "AggregateGroup",
 										},
 									},
@@ -2496,82 +2496,82 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 276, col: 1, offset: 7921},
+						pos: position{line: 276, col: 1, offset: 7888},
 						run: (*parser).callonAggregateExpression22,
 						expr: &seqExpr{
-							pos: position{line: 276, col: 1, offset: 7921},
+							pos: position{line: 276, col: 1, offset: 7888},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 276, col: 1, offset: 7921},
+									pos:   position{line: 276, col: 1, offset: 7888},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 276, col: 4, offset: 7924},
+										pos:  position{line: 276, col: 4, offset: 7891},
 										name: "CountValueOperator",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 276, col: 24, offset: 7944},
+									pos:  position{line: 276, col: 24, offset: 7911},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 276, col: 27, offset: 7947},
+									pos:   position{line: 276, col: 27, offset: 7914},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 276, col: 33, offset: 7953},
+										pos: position{line: 276, col: 33, offset: 7920},
 										expr: &ruleRefExpr{
-											pos:  position{line: 276, col: 33, offset: 7953},
+											pos:  position{line: 276, col: 33, offset: 7920},
 											name: "AggregateGroup",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 276, col: 49, offset: 7969},
+									pos:  position{line: 276, col: 49, offset: 7936},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 276, col: 52, offset: 7972},
+									pos:        position{line: 276, col: 52, offset: 7939},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 276, col: 56, offset: 7976},
+									pos:  position{line: 276, col: 56, offset: 7943},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 276, col: 59, offset: 7979},
+									pos:   position{line: 276, col: 59, offset: 7946},
 									label: "param",
 									expr: &ruleRefExpr{
-										pos:  position{line: 276, col: 65, offset: 7985},
+										pos:  position{line: 276, col: 65, offset: 7952},
 										name: "StringLiteral",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 276, col: 79, offset: 7999},
+									pos:  position{line: 276, col: 79, offset: 7966},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 276, col: 82, offset: 8002},
+									pos:        position{line: 276, col: 82, offset: 7969},
 									val:        ",",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 276, col: 86, offset: 8006},
+									pos:  position{line: 276, col: 86, offset: 7973},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 276, col: 89, offset: 8009},
+									pos:   position{line: 276, col: 89, offset: 7976},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 276, col: 96, offset: 8016},
-										name: "VectorSelector",
+										pos:  position{line: 276, col: 96, offset: 7983},
+										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 276, col: 111, offset: 8031},
+									pos:  position{line: 276, col: 107, offset: 7994},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 276, col: 114, offset: 8034},
+									pos:        position{line: 276, col: 110, offset: 7997},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -2579,81 +2579,81 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 282, col: 1, offset: 8170},
+						pos: position{line: 282, col: 1, offset: 8127},
 						run: (*parser).callonAggregateExpression42,
 						expr: &seqExpr{
-							pos: position{line: 282, col: 1, offset: 8170},
+							pos: position{line: 282, col: 1, offset: 8127},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 282, col: 1, offset: 8170},
+									pos:   position{line: 282, col: 1, offset: 8127},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 282, col: 4, offset: 8173},
+										pos:  position{line: 282, col: 4, offset: 8130},
 										name: "BinaryAggregateOperators",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 30, offset: 8199},
+									pos:  position{line: 282, col: 30, offset: 8156},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 282, col: 33, offset: 8202},
+									pos:        position{line: 282, col: 33, offset: 8159},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 37, offset: 8206},
+									pos:  position{line: 282, col: 37, offset: 8163},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 282, col: 41, offset: 8210},
+									pos:   position{line: 282, col: 41, offset: 8167},
 									label: "param",
 									expr: &ruleRefExpr{
-										pos:  position{line: 282, col: 47, offset: 8216},
+										pos:  position{line: 282, col: 47, offset: 8173},
 										name: "Number",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 54, offset: 8223},
+									pos:  position{line: 282, col: 54, offset: 8180},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 282, col: 57, offset: 8226},
+									pos:        position{line: 282, col: 57, offset: 8183},
 									val:        ",",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 61, offset: 8230},
+									pos:  position{line: 282, col: 61, offset: 8187},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 282, col: 64, offset: 8233},
+									pos:   position{line: 282, col: 64, offset: 8190},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 282, col: 71, offset: 8240},
-										name: "VectorSelector",
+										pos:  position{line: 282, col: 71, offset: 8197},
+										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 86, offset: 8255},
+									pos:  position{line: 282, col: 82, offset: 8208},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 282, col: 89, offset: 8258},
+									pos:        position{line: 282, col: 85, offset: 8211},
 									val:        ")",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 93, offset: 8262},
+									pos:  position{line: 282, col: 89, offset: 8215},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 282, col: 96, offset: 8265},
+									pos:   position{line: 282, col: 92, offset: 8218},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 282, col: 102, offset: 8271},
+										pos: position{line: 282, col: 98, offset: 8224},
 										expr: &ruleRefExpr{
-											pos:  position{line: 282, col: 102, offset: 8271},
+											pos:  position{line: 282, col: 98, offset: 8224},
 											name: "AggregateGroup",
 										},
 									},
@@ -2662,82 +2662,82 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 288, col: 1, offset: 8412},
+						pos: position{line: 288, col: 1, offset: 8359},
 						run: (*parser).callonAggregateExpression62,
 						expr: &seqExpr{
-							pos: position{line: 288, col: 1, offset: 8412},
+							pos: position{line: 288, col: 1, offset: 8359},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 288, col: 1, offset: 8412},
+									pos:   position{line: 288, col: 1, offset: 8359},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 288, col: 4, offset: 8415},
+										pos:  position{line: 288, col: 4, offset: 8362},
 										name: "BinaryAggregateOperators",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 288, col: 30, offset: 8441},
+									pos:  position{line: 288, col: 30, offset: 8388},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 288, col: 33, offset: 8444},
+									pos:   position{line: 288, col: 33, offset: 8391},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 288, col: 39, offset: 8450},
+										pos: position{line: 288, col: 39, offset: 8397},
 										expr: &ruleRefExpr{
-											pos:  position{line: 288, col: 39, offset: 8450},
+											pos:  position{line: 288, col: 39, offset: 8397},
 											name: "AggregateGroup",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 288, col: 55, offset: 8466},
+									pos:  position{line: 288, col: 55, offset: 8413},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 288, col: 58, offset: 8469},
+									pos:        position{line: 288, col: 58, offset: 8416},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 288, col: 62, offset: 8473},
+									pos:  position{line: 288, col: 62, offset: 8420},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 288, col: 66, offset: 8477},
+									pos:   position{line: 288, col: 66, offset: 8424},
 									label: "param",
 									expr: &ruleRefExpr{
-										pos:  position{line: 288, col: 72, offset: 8483},
+										pos:  position{line: 288, col: 72, offset: 8430},
 										name: "Number",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 288, col: 79, offset: 8490},
+									pos:  position{line: 288, col: 79, offset: 8437},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 288, col: 82, offset: 8493},
+									pos:        position{line: 288, col: 82, offset: 8440},
 									val:        ",",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 288, col: 86, offset: 8497},
+									pos:  position{line: 288, col: 86, offset: 8444},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 288, col: 89, offset: 8500},
+									pos:   position{line: 288, col: 89, offset: 8447},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 288, col: 96, offset: 8507},
-										name: "VectorSelector",
+										pos:  position{line: 288, col: 96, offset: 8454},
+										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 288, col: 111, offset: 8522},
+									pos:  position{line: 288, col: 107, offset: 8465},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 288, col: 114, offset: 8525},
+									pos:        position{line: 288, col: 110, offset: 8468},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -2745,60 +2745,60 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 294, col: 1, offset: 8654},
+						pos: position{line: 294, col: 1, offset: 8591},
 						run: (*parser).callonAggregateExpression82,
 						expr: &seqExpr{
-							pos: position{line: 294, col: 1, offset: 8654},
+							pos: position{line: 294, col: 1, offset: 8591},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 294, col: 1, offset: 8654},
+									pos:   position{line: 294, col: 1, offset: 8591},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 294, col: 4, offset: 8657},
+										pos:  position{line: 294, col: 4, offset: 8594},
 										name: "UnaryAggregateOperators",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 294, col: 29, offset: 8682},
+									pos:  position{line: 294, col: 29, offset: 8619},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 294, col: 32, offset: 8685},
+									pos:        position{line: 294, col: 32, offset: 8622},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 294, col: 36, offset: 8689},
+									pos:  position{line: 294, col: 36, offset: 8626},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 294, col: 39, offset: 8692},
+									pos:   position{line: 294, col: 39, offset: 8629},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 294, col: 46, offset: 8699},
-										name: "VectorSelector",
+										pos:  position{line: 294, col: 46, offset: 8636},
+										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 294, col: 61, offset: 8714},
+									pos:  position{line: 294, col: 57, offset: 8647},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 294, col: 64, offset: 8717},
+									pos:        position{line: 294, col: 60, offset: 8650},
 									val:        ")",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 294, col: 68, offset: 8721},
+									pos:  position{line: 294, col: 64, offset: 8654},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 294, col: 71, offset: 8724},
+									pos:   position{line: 294, col: 67, offset: 8657},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 294, col: 77, offset: 8730},
+										pos: position{line: 294, col: 73, offset: 8663},
 										expr: &ruleRefExpr{
-											pos:  position{line: 294, col: 77, offset: 8730},
+											pos:  position{line: 294, col: 73, offset: 8663},
 											name: "AggregateGroup",
 										},
 									},
@@ -2807,61 +2807,61 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 298, col: 1, offset: 8823},
+						pos: position{line: 298, col: 1, offset: 8750},
 						run: (*parser).callonAggregateExpression97,
 						expr: &seqExpr{
-							pos: position{line: 298, col: 1, offset: 8823},
+							pos: position{line: 298, col: 1, offset: 8750},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 298, col: 1, offset: 8823},
+									pos:   position{line: 298, col: 1, offset: 8750},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 298, col: 4, offset: 8826},
+										pos:  position{line: 298, col: 4, offset: 8753},
 										name: "UnaryAggregateOperators",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 298, col: 29, offset: 8851},
+									pos:  position{line: 298, col: 29, offset: 8778},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 298, col: 32, offset: 8854},
+									pos:   position{line: 298, col: 32, offset: 8781},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 298, col: 38, offset: 8860},
+										pos: position{line: 298, col: 38, offset: 8787},
 										expr: &ruleRefExpr{
-											pos:  position{line: 298, col: 38, offset: 8860},
+											pos:  position{line: 298, col: 38, offset: 8787},
 											name: "AggregateGroup",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 298, col: 54, offset: 8876},
+									pos:  position{line: 298, col: 54, offset: 8803},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 298, col: 57, offset: 8879},
+									pos:        position{line: 298, col: 57, offset: 8806},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 298, col: 61, offset: 8883},
+									pos:  position{line: 298, col: 61, offset: 8810},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 298, col: 64, offset: 8886},
+									pos:   position{line: 298, col: 64, offset: 8813},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 298, col: 71, offset: 8893},
-										name: "VectorSelector",
+										pos:  position{line: 298, col: 71, offset: 8820},
+										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 298, col: 86, offset: 8908},
+									pos:  position{line: 298, col: 82, offset: 8831},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 298, col: 89, offset: 8911},
+									pos:        position{line: 298, col: 85, offset: 8834},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -2872,110 +2872,292 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "__",
-			pos:  position{line: 302, col: 1, offset: 8991},
-			expr: &zeroOrMoreExpr{
-				pos: position{line: 302, col: 6, offset: 8996},
-				expr: &choiceExpr{
-					pos: position{line: 302, col: 8, offset: 8998},
-					alternatives: []interface{}{
-						&ruleRefExpr{
-							pos:  position{line: 302, col: 8, offset: 8998},
-							name: "Whitespace",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 302, col: 21, offset: 9011},
-							name: "EOL",
+			name: "Expression",
+			pos:  position{line: 302, col: 1, offset: 8908},
+			expr: &ruleRefExpr{
+				pos:  position{line: 302, col: 14, offset: 8921},
+				name: "OrExpression",
+			},
+		},
+		{
+			name: "OrExpression",
+			pos:  position{line: 304, col: 1, offset: 8935},
+			expr: &actionExpr{
+				pos: position{line: 304, col: 16, offset: 8950},
+				run: (*parser).callonOrExpression1,
+				expr: &seqExpr{
+					pos: position{line: 304, col: 16, offset: 8950},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 304, col: 16, offset: 8950},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 304, col: 22, offset: 8956},
+								name: "AndUnlessExpression",
+							},
 						},
-						&ruleRefExpr{
-							pos:  position{line: 302, col: 27, offset: 9017},
-							name: "Comment",
+						&labeledExpr{
+							pos:   position{line: 304, col: 42, offset: 8976},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 304, col: 47, offset: 8981},
+								expr: &seqExpr{
+									pos: position{line: 304, col: 49, offset: 8983},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 304, col: 49, offset: 8983},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 304, col: 52, offset: 8986},
+											name: "OrOperator",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 304, col: 63, offset: 8997},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 304, col: 66, offset: 9000},
+											name: "BinaryModifiers",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 304, col: 82, offset: 9016},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 304, col: 85, offset: 9019},
+											name: "AndUnlessExpression",
+										},
+									},
+								},
+							},
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "_",
-			pos:  position{line: 303, col: 1, offset: 9028},
-			expr: &zeroOrMoreExpr{
-				pos: position{line: 303, col: 5, offset: 9032},
-				expr: &ruleRefExpr{
-					pos:  position{line: 303, col: 5, offset: 9032},
-					name: "Whitespace",
+			name: "AndUnlessExpression",
+			pos:  position{line: 308, col: 1, offset: 9091},
+			expr: &actionExpr{
+				pos: position{line: 308, col: 23, offset: 9113},
+				run: (*parser).callonAndUnlessExpression1,
+				expr: &seqExpr{
+					pos: position{line: 308, col: 23, offset: 9113},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 308, col: 23, offset: 9113},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 308, col: 29, offset: 9119},
+								name: "ComparisonExpression",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 308, col: 50, offset: 9140},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 308, col: 55, offset: 9145},
+								expr: &seqExpr{
+									pos: position{line: 308, col: 57, offset: 9147},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 308, col: 57, offset: 9147},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 308, col: 60, offset: 9150},
+											name: "AndUnlessOperator",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 308, col: 78, offset: 9168},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 308, col: 81, offset: 9171},
+											name: "BinaryModifiers",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 308, col: 97, offset: 9187},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 308, col: 100, offset: 9190},
+											name: "ComparisonExpression",
+										},
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		{
-			name: "Whitespace",
-			pos:  position{line: 305, col: 1, offset: 9045},
-			expr: &charClassMatcher{
-				pos:        position{line: 305, col: 14, offset: 9058},
-				val:        "[ \\t\\r]",
-				chars:      []rune{' ', '\t', '\r'},
-				ignoreCase: false,
-				inverted:   false,
-			},
-		},
-		{
-			name: "EOL",
-			pos:  position{line: 306, col: 1, offset: 9066},
-			expr: &litMatcher{
-				pos:        position{line: 306, col: 7, offset: 9072},
-				val:        "\n",
-				ignoreCase: false,
-			},
-		},
-		{
-			name: "EOS",
-			pos:  position{line: 307, col: 1, offset: 9077},
-			expr: &choiceExpr{
-				pos: position{line: 307, col: 7, offset: 9083},
-				alternatives: []interface{}{
-					&seqExpr{
-						pos: position{line: 307, col: 7, offset: 9083},
-						exprs: []interface{}{
-							&ruleRefExpr{
-								pos:  position{line: 307, col: 7, offset: 9083},
-								name: "__",
+			name: "ComparisonExpression",
+			pos:  position{line: 312, col: 1, offset: 9263},
+			expr: &actionExpr{
+				pos: position{line: 312, col: 24, offset: 9286},
+				run: (*parser).callonComparisonExpression1,
+				expr: &seqExpr{
+					pos: position{line: 312, col: 24, offset: 9286},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 312, col: 24, offset: 9286},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 312, col: 30, offset: 9292},
+								name: "AdditiveExpression",
 							},
-							&litMatcher{
-								pos:        position{line: 307, col: 10, offset: 9086},
-								val:        ";",
-								ignoreCase: false,
+						},
+						&labeledExpr{
+							pos:   position{line: 312, col: 49, offset: 9311},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 312, col: 54, offset: 9316},
+								expr: &seqExpr{
+									pos: position{line: 312, col: 56, offset: 9318},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 312, col: 56, offset: 9318},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 312, col: 59, offset: 9321},
+											name: "ComparisonOperator",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 312, col: 78, offset: 9340},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 312, col: 81, offset: 9343},
+											name: "BinaryModifiers",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 312, col: 97, offset: 9359},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 312, col: 100, offset: 9362},
+											name: "AdditiveExpression",
+										},
+									},
+								},
 							},
 						},
 					},
-					&seqExpr{
-						pos: position{line: 307, col: 16, offset: 9092},
-						exprs: []interface{}{
-							&ruleRefExpr{
-								pos:  position{line: 307, col: 16, offset: 9092},
-								name: "_",
+				},
+			},
+		},
+		{
+			name: "AdditiveExpression",
+			pos:  position{line: 316, col: 1, offset: 9433},
+			expr: &actionExpr{
+				pos: position{line: 316, col: 22, offset: 9454},
+				run: (*parser).callonAdditiveExpression1,
+				expr: &seqExpr{
+					pos: position{line: 316, col: 22, offset: 9454},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 316, col: 22, offset: 9454},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 316, col: 28, offset: 9460},
+								name: "MultiplicativeExpression",
 							},
-							&zeroOrOneExpr{
-								pos: position{line: 307, col: 18, offset: 9094},
-								expr: &ruleRefExpr{
-									pos:  position{line: 307, col: 18, offset: 9094},
-									name: "SingleLineComment",
+						},
+						&labeledExpr{
+							pos:   position{line: 316, col: 53, offset: 9485},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 316, col: 58, offset: 9490},
+								expr: &seqExpr{
+									pos: position{line: 316, col: 60, offset: 9492},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 316, col: 60, offset: 9492},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 316, col: 63, offset: 9495},
+											name: "AdditiveOperator",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 316, col: 80, offset: 9512},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 316, col: 83, offset: 9515},
+											name: "BinaryModifiers",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 316, col: 99, offset: 9531},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 316, col: 102, offset: 9534},
+											name: "MultiplicativeExpression",
+										},
+									},
 								},
 							},
-							&ruleRefExpr{
-								pos:  position{line: 307, col: 37, offset: 9113},
-								name: "EOL",
-							},
 						},
 					},
-					&seqExpr{
-						pos: position{line: 307, col: 43, offset: 9119},
-						exprs: []interface{}{
-							&ruleRefExpr{
-								pos:  position{line: 307, col: 43, offset: 9119},
-								name: "__",
+				},
+			},
+		},
+		{
+			name: "MultiplicativeExpression",
+			pos:  position{line: 320, col: 1, offset: 9611},
+			expr: &actionExpr{
+				pos: position{line: 320, col: 28, offset: 9638},
+				run: (*parser).callonMultiplicativeExpression1,
+				expr: &seqExpr{
+					pos: position{line: 320, col: 28, offset: 9638},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 320, col: 28, offset: 9638},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 320, col: 34, offset: 9644},
+								name: "UnaryExpression",
 							},
-							&ruleRefExpr{
-								pos:  position{line: 307, col: 46, offset: 9122},
-								name: "EOF",
+						},
+						&labeledExpr{
+							pos:   position{line: 320, col: 50, offset: 9660},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 320, col: 55, offset: 9665},
+								expr: &seqExpr{
+									pos: position{line: 320, col: 57, offset: 9667},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 320, col: 57, offset: 9667},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 320, col: 60, offset: 9670},
+											name: "MultiplicativeOperator",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 320, col: 83, offset: 9693},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 320, col: 86, offset: 9696},
+											name: "BinaryModifiers",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 320, col: 102, offset: 9712},
+											name: "__",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 320, col: 105, offset: 9715},
+											name: "UnaryExpression",
+										},
+									},
+								},
 							},
 						},
 					},
@@ -2983,40 +3165,802 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "EOF",
-			pos:  position{line: 309, col: 1, offset: 9127},
-			expr: &notExpr{
-				pos: position{line: 309, col: 7, offset: 9133},
-				expr: &anyMatcher{
-					line: 309, col: 8, offset: 9134,
+			name: "UnaryExpression",
+			pos:  position{line: 324, col: 1, offset: 9783},
+			expr: &choiceExpr{
+				pos: position{line: 324, col: 19, offset: 9801},
+				alternatives: []interface{}{
+					&actionExpr{
+						pos: position{line: 324, col: 19, offset: 9801},
+						run: (*parser).callonUnaryExpression2,
+						expr: &seqExpr{
+							pos: position{line: 324, col: 19, offset: 9801},
+							exprs: []interface{}{
+								&labeledExpr{
+									pos:   position{line: 324, col: 19, offset: 9801},
+									label: "op",
+									expr: &ruleRefExpr{
+										pos:  position{line: 324, col: 22, offset: 9804},
+										name: "AdditiveOperator",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 324, col: 39, offset: 9821},
+									name: "__",
+								},
+								&labeledExpr{
+									pos:   position{line: 324, col: 42, offset: 9824},
+									label: "expr",
+									expr: &ruleRefExpr{
+										pos:  position{line: 324, col: 47, offset: 9829},
+										name: "UnaryExpression",
+									},
+								},
+							},
+						},
+					},
+					&ruleRefExpr{
+						pos:  position{line: 326, col: 5, offset: 9906},
+						name: "PowerExpression",
+					},
 				},
 			},
 		},
-	},
-}
-
-func (c *current) onGrammar1(grammar interface{}) (interface{}, error) {
-	return grammar, nil
-}
-
-func (p *parser) callonGrammar1() (interface{}, error) {
-	stack := p.vstack[len(p.vstack)-1]
-	_ = stack
-	return p.cur.onGrammar1(stack["grammar"])
-}
-
-func (c *current) onComment1() (interface{}, error) {
-	return &Comment{string(c.text)}, nil
-}
-
-func (p *parser) callonComment1() (interface{}, error) {
-	stack := p.vstack[len(p.vstack)-1]
-	_ = stack
-	return p.cur.onComment1()
-}
-
+		{
+			name: "PowerExpression",
+			pos:  position{line: 329, col: 1, offset: 10005},
+			expr: &choiceExpr{
+				pos: position{line: 329, col: 19, offset: 10023},
+				alternatives: []interface{}{
+					&actionExpr{
+						pos: position{line: 329, col: 19, offset: 10023},
+						run: (*parser).callonPowerExpression2,
+						expr: &seqExpr{
+							pos: position{line: 329, col: 19, offset: 10023},
+							exprs: []interface{}{
+								&labeledExpr{
+									pos:   position{line: 329, col: 19, offset: 10023},
+									label: "base",
+									expr: &ruleRefExpr{
+										pos:  position{line: 329, col: 24, offset: 10028},
+										name: "PrimaryExpression",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 329, col: 42, offset: 10046},
+									name: "__",
+								},
+								&litMatcher{
+									pos:        position{line: 329, col: 45, offset: 10049},
+									val:        "^",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 329, col: 49, offset: 10053},
+									name: "__",
+								},
+								&labeledExpr{
+									pos:   position{line: 329, col: 52, offset: 10056},
+									label: "mods",
+									expr: &ruleRefExpr{
+										pos:  position{line: 329, col: 57, offset: 10061},
+										name: "BinaryModifiers",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 329, col: 73, offset: 10077},
+									name: "__",
+								},
+								&labeledExpr{
+									pos:   position{line: 329, col: 76, offset: 10080},
+									label: "exp",
+									expr: &ruleRefExpr{
+										pos:  position{line: 329, col: 80, offset: 10084},
+										name: "UnaryExpression",
+									},
+								},
+							},
+						},
+					},
+					&ruleRefExpr{
+						pos:  position{line: 331, col: 5, offset: 10159},
+						name: "PrimaryExpression",
+					},
+				},
+			},
+		},
+		{
+			name: "PrimaryExpression",
+			pos:  position{line: 333, col: 1, offset: 10178},
+			expr: &choiceExpr{
+				pos: position{line: 333, col: 21, offset: 10198},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 333, col: 21, offset: 10198},
+						name: "ParenExpression",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 333, col: 39, offset: 10216},
+						name: "AggregateExpression",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 333, col: 61, offset: 10238},
+						name: "FunctionCall",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 333, col: 76, offset: 10253},
+						name: "Number",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 333, col: 85, offset: 10262},
+						name: "VectorSelector",
+					},
+				},
+			},
+		},
+		{
+			name: "ParenExpression",
+			pos:  position{line: 335, col: 1, offset: 10278},
+			expr: &actionExpr{
+				pos: position{line: 335, col: 19, offset: 10296},
+				run: (*parser).callonParenExpression1,
+				expr: &seqExpr{
+					pos: position{line: 335, col: 19, offset: 10296},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 335, col: 19, offset: 10296},
+							val:        "(",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 335, col: 23, offset: 10300},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 335, col: 26, offset: 10303},
+							label: "expr",
+							expr: &ruleRefExpr{
+								pos:  position{line: 335, col: 31, offset: 10308},
+								name: "Expression",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 335, col: 42, offset: 10319},
+							name: "__",
+						},
+						&litMatcher{
+							pos:        position{line: 335, col: 45, offset: 10322},
+							val:        ")",
+							ignoreCase: false,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "FunctionCall",
+			pos:  position{line: 339, col: 1, offset: 10352},
+			expr: &actionExpr{
+				pos: position{line: 339, col: 16, offset: 10367},
+				run: (*parser).callonFunctionCall1,
+				expr: &seqExpr{
+					pos: position{line: 339, col: 16, offset: 10367},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 339, col: 16, offset: 10367},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 339, col: 21, offset: 10372},
+								name: "Identifier",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 339, col: 32, offset: 10383},
+							name: "__",
+						},
+						&litMatcher{
+							pos:        position{line: 339, col: 35, offset: 10386},
+							val:        "(",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 339, col: 39, offset: 10390},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 339, col: 42, offset: 10393},
+							label: "args",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 339, col: 47, offset: 10398},
+								expr: &ruleRefExpr{
+									pos:  position{line: 339, col: 47, offset: 10398},
+									name: "FunctionArgs",
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 339, col: 61, offset: 10412},
+							name: "__",
+						},
+						&litMatcher{
+							pos:        position{line: 339, col: 64, offset: 10415},
+							val:        ")",
+							ignoreCase: false,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "FunctionArgs",
+			pos:  position{line: 343, col: 1, offset: 10477},
+			expr: &actionExpr{
+				pos: position{line: 343, col: 16, offset: 10492},
+				run: (*parser).callonFunctionArgs1,
+				expr: &seqExpr{
+					pos: position{line: 343, col: 16, offset: 10492},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 343, col: 16, offset: 10492},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 343, col: 22, offset: 10498},
+								name: "FunctionArg",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 343, col: 34, offset: 10510},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 343, col: 39, offset: 10515},
+								expr: &ruleRefExpr{
+									pos:  position{line: 343, col: 39, offset: 10515},
+									name: "FunctionArgsRest",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "FunctionArgsRest",
+			pos:  position{line: 347, col: 1, offset: 10579},
+			expr: &actionExpr{
+				pos: position{line: 347, col: 20, offset: 10598},
+				run: (*parser).callonFunctionArgsRest1,
+				expr: &seqExpr{
+					pos: position{line: 347, col: 20, offset: 10598},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 347, col: 20, offset: 10598},
+							name: "__",
+						},
+						&litMatcher{
+							pos:        position{line: 347, col: 23, offset: 10601},
+							val:        ",",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 347, col: 27, offset: 10605},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 347, col: 30, offset: 10608},
+							label: "arg",
+							expr: &ruleRefExpr{
+								pos:  position{line: 347, col: 34, offset: 10612},
+								name: "FunctionArg",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "FunctionArg",
+			pos:  position{line: 351, col: 1, offset: 10649},
+			expr: &choiceExpr{
+				pos: position{line: 351, col: 15, offset: 10663},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 351, col: 15, offset: 10663},
+						name: "StringLiteral",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 351, col: 31, offset: 10679},
+						name: "Expression",
+					},
+				},
+			},
+		},
+		{
+			name: "OrOperator",
+			pos:  position{line: 353, col: 1, offset: 10691},
+			expr: &actionExpr{
+				pos: position{line: 353, col: 14, offset: 10704},
+				run: (*parser).callonOrOperator1,
+				expr: &seqExpr{
+					pos: position{line: 353, col: 14, offset: 10704},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 353, col: 14, offset: 10704},
+							val:        "or",
+							ignoreCase: true,
+						},
+						&notExpr{
+							pos: position{line: 353, col: 20, offset: 10710},
+							expr: &ruleRefExpr{
+								pos:  position{line: 353, col: 21, offset: 10711},
+								name: "IdentifierPart",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AndUnlessOperator",
+			pos:  position{line: 357, col: 1, offset: 10752},
+			expr: &actionExpr{
+				pos: position{line: 357, col: 21, offset: 10772},
+				run: (*parser).callonAndUnlessOperator1,
+				expr: &seqExpr{
+					pos: position{line: 357, col: 21, offset: 10772},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 357, col: 21, offset: 10772},
+							label: "op",
+							expr: &choiceExpr{
+								pos: position{line: 357, col: 26, offset: 10777},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 357, col: 26, offset: 10777},
+										val:        "and",
+										ignoreCase: true,
+									},
+									&litMatcher{
+										pos:        position{line: 357, col: 35, offset: 10786},
+										val:        "unless",
+										ignoreCase: true,
+									},
+								},
+							},
+						},
+						&notExpr{
+							pos: position{line: 357, col: 47, offset: 10798},
+							expr: &ruleRefExpr{
+								pos:  position{line: 357, col: 48, offset: 10799},
+								name: "IdentifierPart",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ComparisonOperator",
+			pos:  position{line: 361, col: 1, offset: 10871},
+			expr: &actionExpr{
+				pos: position{line: 361, col: 22, offset: 10892},
+				run: (*parser).callonComparisonOperator1,
+				expr: &labeledExpr{
+					pos:   position{line: 361, col: 22, offset: 10892},
+					label: "op",
+					expr: &choiceExpr{
+						pos: position{line: 361, col: 27, offset: 10897},
+						alternatives: []interface{}{
+							&litMatcher{
+								pos:        position{line: 361, col: 27, offset: 10897},
+								val:        "==",
+								ignoreCase: false,
+							},
+							&litMatcher{
+								pos:        position{line: 361, col: 34, offset: 10904},
+								val:        "!=",
+								ignoreCase: false,
+							},
+							&litMatcher{
+								pos:        position{line: 361, col: 41, offset: 10911},
+								val:        "<=",
+								ignoreCase: false,
+							},
+							&litMatcher{
+								pos:        position{line: 361, col: 48, offset: 10918},
+								val:        "<",
+								ignoreCase: false,
+							},
+							&litMatcher{
+								pos:        position{line: 361, col: 54, offset: 10924},
+								val:        ">=",
+								ignoreCase: false,
+							},
+							&litMatcher{
+								pos:        position{line: 361, col: 61, offset: 10931},
+								val:        ">",
+								ignoreCase: false,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "AdditiveOperator",
+			pos:  position{line: 365, col: 1, offset: 10994},
+			expr: &actionExpr{
+				pos: position{line: 365, col: 20, offset: 11013},
+				run: (*parser).callonAdditiveOperator1,
+				expr: &labeledExpr{
+					pos:   position{line: 365, col: 20, offset: 11013},
+					label: "op",
+					expr: &choiceExpr{
+						pos: position{line: 365, col: 25, offset: 11018},
+						alternatives: []interface{}{
+							&litMatcher{
+								pos:        position{line: 365, col: 25, offset: 11018},
+								val:        "+",
+								ignoreCase: false,
+							},
+							&litMatcher{
+								pos:        position{line: 365, col: 31, offset: 11024},
+								val:        "-",
+								ignoreCase: false,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "MultiplicativeOperator",
+			pos:  position{line: 369, col: 1, offset: 11087},
+			expr: &actionExpr{
+				pos: position{line: 369, col: 26, offset: 11112},
+				run: (*parser).callonMultiplicativeOperator1,
+				expr: &labeledExpr{
+					pos:   position{line: 369, col: 26, offset: 11112},
+					label: "op",
+					expr: &choiceExpr{
+						pos: position{line: 369, col: 31, offset: 11117},
+						alternatives: []interface{}{
+							&litMatcher{
+								pos:        position{line: 369, col: 31, offset: 11117},
+								val:        "*",
+								ignoreCase: false,
+							},
+							&litMatcher{
+								pos:        position{line: 369, col: 37, offset: 11123},
+								val:        "/",
+								ignoreCase: false,
+							},
+							&litMatcher{
+								pos:        position{line: 369, col: 43, offset: 11129},
+								val:        "%",
+								ignoreCase: false,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "BinaryModifiers",
+			pos:  position{line: 373, col: 1, offset: 11192},
+			expr: &actionExpr{
+				pos: position{line: 373, col: 19, offset: 11210},
+				run: (*parser).callonBinaryModifiers1,
+				expr: &seqExpr{
+					pos: position{line: 373, col: 19, offset: 11210},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 373, col: 19, offset: 11210},
+							label: "returnBool",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 373, col: 30, offset: 11221},
+								expr: &ruleRefExpr{
+									pos:  position{line: 373, col: 30, offset: 11221},
+									name: "BoolModifier",
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 373, col: 44, offset: 11235},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 373, col: 47, offset: 11238},
+							label: "matching",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 373, col: 56, offset: 11247},
+								expr: &ruleRefExpr{
+									pos:  position{line: 373, col: 56, offset: 11247},
+									name: "VectorMatching",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "BoolModifier",
+			pos:  position{line: 377, col: 1, offset: 11327},
+			expr: &actionExpr{
+				pos: position{line: 377, col: 16, offset: 11342},
+				run: (*parser).callonBoolModifier1,
+				expr: &seqExpr{
+					pos: position{line: 377, col: 16, offset: 11342},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 377, col: 16, offset: 11342},
+							val:        "bool",
+							ignoreCase: true,
+						},
+						&notExpr{
+							pos: position{line: 377, col: 24, offset: 11350},
+							expr: &ruleRefExpr{
+								pos:  position{line: 377, col: 25, offset: 11351},
+								name: "IdentifierPart",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "VectorMatching",
+			pos:  position{line: 381, col: 1, offset: 11392},
+			expr: &actionExpr{
+				pos: position{line: 381, col: 18, offset: 11409},
+				run: (*parser).callonVectorMatching1,
+				expr: &seqExpr{
+					pos: position{line: 381, col: 18, offset: 11409},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 381, col: 18, offset: 11409},
+							label: "kind",
+							expr: &choiceExpr{
+								pos: position{line: 381, col: 25, offset: 11416},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 381, col: 25, offset: 11416},
+										val:        "on",
+										ignoreCase: true,
+									},
+									&litMatcher{
+										pos:        position{line: 381, col: 33, offset: 11424},
+										val:        "ignoring",
+										ignoreCase: true,
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 381, col: 47, offset: 11438},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 381, col: 50, offset: 11441},
+							label: "labels",
+							expr: &ruleRefExpr{
+								pos:  position{line: 381, col: 57, offset: 11448},
+								name: "LabelList",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 381, col: 67, offset: 11458},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 381, col: 70, offset: 11461},
+							label: "group",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 381, col: 76, offset: 11467},
+								expr: &ruleRefExpr{
+									pos:  position{line: 381, col: 76, offset: 11467},
+									name: "GroupModifier",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "GroupModifier",
+			pos:  position{line: 385, col: 1, offset: 11554},
+			expr: &actionExpr{
+				pos: position{line: 385, col: 17, offset: 11570},
+				run: (*parser).callonGroupModifier1,
+				expr: &seqExpr{
+					pos: position{line: 385, col: 17, offset: 11570},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 385, col: 17, offset: 11570},
+							label: "side",
+							expr: &choiceExpr{
+								pos: position{line: 385, col: 24, offset: 11577},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 385, col: 24, offset: 11577},
+										val:        "group_left",
+										ignoreCase: true,
+									},
+									&litMatcher{
+										pos:        position{line: 385, col: 40, offset: 11593},
+										val:        "group_right",
+										ignoreCase: true,
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 385, col: 57, offset: 11610},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 385, col: 60, offset: 11613},
+							label: "labels",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 385, col: 67, offset: 11620},
+								expr: &ruleRefExpr{
+									pos:  position{line: 385, col: 67, offset: 11620},
+									name: "LabelList",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "__",
+			pos:  position{line: 389, col: 1, offset: 11695},
+			expr: &zeroOrMoreExpr{
+				pos: position{line: 389, col: 6, offset: 11700},
+				expr: &choiceExpr{
+					pos: position{line: 389, col: 8, offset: 11702},
+					alternatives: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 389, col: 8, offset: 11702},
+							name: "Whitespace",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 389, col: 21, offset: 11715},
+							name: "EOL",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 389, col: 27, offset: 11721},
+							name: "Comment",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "_",
+			pos:  position{line: 390, col: 1, offset: 11732},
+			expr: &zeroOrMoreExpr{
+				pos: position{line: 390, col: 5, offset: 11736},
+				expr: &ruleRefExpr{
+					pos:  position{line: 390, col: 5, offset: 11736},
+					name: "Whitespace",
+				},
+			},
+		},
+		{
+			name: "Whitespace",
+			pos:  position{line: 392, col: 1, offset: 11749},
+			expr: &charClassMatcher{
+				pos:        position{line: 392, col: 14, offset: 11762},
+				val:        "[ \\t\\r]",
+				chars:      []rune{' ', '\t', '\r'},
+				ignoreCase: false,
+				inverted:   false,
+			},
+		},
+		{
+			name: "EOL",
+			pos:  position{line: 393, col: 1, offset: 11770},
+			expr: &litMatcher{
+				pos:        position{line: 393, col: 7, offset: 11776},
+				val:        "\n",
+				ignoreCase: false,
+			},
+		},
+		{
+			name: "EOS",
+			pos:  position{line: 394, col: 1, offset: 11781},
+			expr: &choiceExpr{
+				pos: position{line: 394, col: 7, offset: 11787},
+				alternatives: []interface{}{
+					&seqExpr{
+						pos: position{line: 394, col: 7, offset: 11787},
+						exprs: []interface{}{
+							&ruleRefExpr{
+								pos:  position{line: 394, col: 7, offset: 11787},
+								name: "__",
+							},
+							&litMatcher{
+								pos:        position{line: 394, col: 10, offset: 11790},
+								val:        ";",
+								ignoreCase: false,
+							},
+						},
+					},
+					&seqExpr{
+						pos: position{line: 394, col: 16, offset: 11796},
+						exprs: []interface{}{
+							&ruleRefExpr{
+								pos:  position{line: 394, col: 16, offset: 11796},
+								name: "_",
+							},
+							&zeroOrOneExpr{
+								pos: position{line: 394, col: 18, offset: 11798},
+								expr: &ruleRefExpr{
+									pos:  position{line: 394, col: 18, offset: 11798},
+									name: "SingleLineComment",
+								},
+							},
+							&ruleRefExpr{
+								pos:  position{line: 394, col: 37, offset: 11817},
+								name: "EOL",
+							},
+						},
+					},
+					&seqExpr{
+						pos: position{line: 394, col: 43, offset: 11823},
+						exprs: []interface{}{
+							&ruleRefExpr{
+								pos:  position{line: 394, col: 43, offset: 11823},
+								name: "__",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 394, col: 46, offset: 11826},
+								name: "EOF",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "EOF",
+			pos:  position{line: 396, col: 1, offset: 11831},
+			expr: &notExpr{
+				pos: position{line: 396, col: 7, offset: 11837},
+				expr: &anyMatcher{
+					line: 396, col: 8, offset: 11838,
+				},
+			},
+		},
+	},
+}
+
+func (c *current) onGrammar1(grammar interface{}) (interface{}, error) {
+	return grammar, nil
+}
+
+func (p *parser) callonGrammar1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onGrammar1(stack["grammar"])
+}
+
+func (c *current) onComment1() (interface{}, error) {
+	return &Comment{string(c.text)}, nil
+}
+
+func (p *parser) callonComment1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onComment1()
+}
+
 func (c *current) onIdentifier1(ident interface{}) (interface{}, error) {
-	if reservedWords[string(c.text)] {
+	i := string(c.text)
+	if reservedWords[i] {
 		return nil, errors.New("identifier is a reserved word")
 	}
 	return &Identifier{ident.(string)}, nil
@@ -3555,7 +4499,7 @@ func (p *parser) callonAggregateWithout1() (interface{}, error) {
 func (c *current) onAggregateExpression2(op, param, vector, group interface{}) (interface{}, error) {
 	oper := op.(*Operator)
 	oper.Arg = param.(*StringLiteral)
-	return NewAggregateExpr(oper, vector.(*Selector), group)
+	return NewAggregateExpr(oper, vector.(Arg), group)
 }
 
 func (p *parser) callonAggregateExpression2() (interface{}, error) {
@@ -3567,7 +4511,7 @@ func (p *parser) callonAggregateExpression2() (interface{}, error) {
 func (c *current) onAggregateExpression22(op, group, param, vector interface{}) (interface{}, error) {
 	oper := op.(*Operator)
 	oper.Arg = param.(*StringLiteral)
-	return NewAggregateExpr(oper, vector.(*Selector), group)
+	return NewAggregateExpr(oper, vector.(Arg), group)
 }
 
 func (p *parser) callonAggregateExpression22() (interface{}, error) {
@@ -3579,7 +4523,7 @@ func (p *parser) callonAggregateExpression22() (interface{}, error) {
 func (c *current) onAggregateExpression42(op, param, vector, group interface{}) (interface{}, error) {
 	oper := op.(*Operator)
 	oper.Arg = param.(*Number)
-	return NewAggregateExpr(oper, vector.(*Selector), group)
+	return NewAggregateExpr(oper, vector.(Arg), group)
 }
 
 func (p *parser) callonAggregateExpression42() (interface{}, error) {
@@ -3591,7 +4535,7 @@ func (p *parser) callonAggregateExpression42() (interface{}, error) {
 func (c *current) onAggregateExpression62(op, group, param, vector interface{}) (interface{}, error) {
 	oper := op.(*Operator)
 	oper.Arg = param.(*Number)
-	return NewAggregateExpr(oper, vector.(*Selector), group)
+	return NewAggregateExpr(oper, vector.(Arg), group)
 }
 
 func (p *parser) callonAggregateExpression62() (interface{}, error) {
@@ -3601,7 +4545,7 @@ func (p *parser) callonAggregateExpression62() (interface{}, error) {
 }
 
 func (c *current) onAggregateExpression82(op, vector, group interface{}) (interface{}, error) {
-	return NewAggregateExpr(op.(*Operator), vector.(*Selector), group)
+	return NewAggregateExpr(op.(*Operator), vector.(Arg), group)
 }
 
 func (p *parser) callonAggregateExpression82() (interface{}, error) {
@@ -3611,7 +4555,7 @@ func (p *parser) callonAggregateExpression82() (interface{}, error) {
 }
 
 func (c *current) onAggregateExpression97(op, group, vector interface{}) (interface{}, error) {
-	return NewAggregateExpr(op.(*Operator), vector.(*Selector), group)
+	return NewAggregateExpr(op.(*Operator), vector.(Arg), group)
 }
 
 func (p *parser) callonAggregateExpression97() (interface{}, error) {
@@ -3620,6 +4564,206 @@ func (p *parser) callonAggregateExpression97() (interface{}, error) {
 	return p.cur.onAggregateExpression97(stack["op"], stack["group"], stack["vector"])
 }
 
+func (c *current) onOrExpression1(first, rest interface{}) (interface{}, error) {
+	return NewBinaryExpr(first.(Arg), rest)
+}
+
+func (p *parser) callonOrExpression1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onOrExpression1(stack["first"], stack["rest"])
+}
+
+func (c *current) onAndUnlessExpression1(first, rest interface{}) (interface{}, error) {
+	return NewBinaryExpr(first.(Arg), rest)
+}
+
+func (p *parser) callonAndUnlessExpression1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAndUnlessExpression1(stack["first"], stack["rest"])
+}
+
+func (c *current) onComparisonExpression1(first, rest interface{}) (interface{}, error) {
+	return NewBinaryExpr(first.(Arg), rest)
+}
+
+func (p *parser) callonComparisonExpression1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onComparisonExpression1(stack["first"], stack["rest"])
+}
+
+func (c *current) onAdditiveExpression1(first, rest interface{}) (interface{}, error) {
+	return NewBinaryExpr(first.(Arg), rest)
+}
+
+func (p *parser) callonAdditiveExpression1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAdditiveExpression1(stack["first"], stack["rest"])
+}
+
+func (c *current) onMultiplicativeExpression1(first, rest interface{}) (interface{}, error) {
+	return NewBinaryExpr(first.(Arg), rest)
+}
+
+func (p *parser) callonMultiplicativeExpression1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onMultiplicativeExpression1(stack["first"], stack["rest"])
+}
+
+func (c *current) onUnaryExpression2(op, expr interface{}) (interface{}, error) {
+	return NewUnaryExpr(op.(BinaryOpKind), expr.(Arg))
+}
+
+func (p *parser) callonUnaryExpression2() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onUnaryExpression2(stack["op"], stack["expr"])
+}
+
+func (c *current) onPowerExpression2(base, mods, exp interface{}) (interface{}, error) {
+	return NewPowerExpr(base.(Arg), mods, exp.(Arg))
+}
+
+func (p *parser) callonPowerExpression2() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPowerExpression2(stack["base"], stack["mods"], stack["exp"])
+}
+
+func (c *current) onParenExpression1(expr interface{}) (interface{}, error) {
+	return expr, nil
+}
+
+func (p *parser) callonParenExpression1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onParenExpression1(stack["expr"])
+}
+
+func (c *current) onFunctionCall1(name, args interface{}) (interface{}, error) {
+	return NewFunctionCall(name.(*Identifier), args)
+}
+
+func (p *parser) callonFunctionCall1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFunctionCall1(stack["name"], stack["args"])
+}
+
+func (c *current) onFunctionArgs1(first, rest interface{}) (interface{}, error) {
+	return NewArgList(first.(Arg), rest)
+}
+
+func (p *parser) callonFunctionArgs1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFunctionArgs1(stack["first"], stack["rest"])
+}
+
+func (c *current) onFunctionArgsRest1(arg interface{}) (interface{}, error) {
+	return arg, nil
+}
+
+func (p *parser) callonFunctionArgsRest1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFunctionArgsRest1(stack["arg"])
+}
+
+func (c *current) onOrOperator1() (interface{}, error) {
+	return OrOp, nil
+}
+
+func (p *parser) callonOrOperator1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onOrOperator1()
+}
+
+func (c *current) onAndUnlessOperator1(op interface{}) (interface{}, error) {
+	return ToBinaryOpKind(string(op.([]byte))), nil
+}
+
+func (p *parser) callonAndUnlessOperator1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAndUnlessOperator1(stack["op"])
+}
+
+func (c *current) onComparisonOperator1(op interface{}) (interface{}, error) {
+	return ToBinaryOpKind(string(op.([]byte))), nil
+}
+
+func (p *parser) callonComparisonOperator1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onComparisonOperator1(stack["op"])
+}
+
+func (c *current) onAdditiveOperator1(op interface{}) (interface{}, error) {
+	return ToBinaryOpKind(string(op.([]byte))), nil
+}
+
+func (p *parser) callonAdditiveOperator1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAdditiveOperator1(stack["op"])
+}
+
+func (c *current) onMultiplicativeOperator1(op interface{}) (interface{}, error) {
+	return ToBinaryOpKind(string(op.([]byte))), nil
+}
+
+func (p *parser) callonMultiplicativeOperator1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onMultiplicativeOperator1(stack["op"])
+}
+
+func (c *current) onBinaryModifiers1(returnBool, matching interface{}) (interface{}, error) {
+	return NewBinaryModifiers(returnBool != nil, matching)
+}
+
+func (p *parser) callonBinaryModifiers1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onBinaryModifiers1(stack["returnBool"], stack["matching"])
+}
+
+func (c *current) onBoolModifier1() (interface{}, error) {
+	return true, nil
+}
+
+func (p *parser) callonBoolModifier1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onBoolModifier1()
+}
+
+func (c *current) onVectorMatching1(kind, labels, group interface{}) (interface{}, error) {
+	return NewVectorMatching(string(kind.([]byte)), labels, group)
+}
+
+func (p *parser) callonVectorMatching1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onVectorMatching1(stack["kind"], stack["labels"], stack["group"])
+}
+
+func (c *current) onGroupModifier1(side, labels interface{}) (interface{}, error) {
+	return NewGroupModifier(string(side.([]byte)), labels)
+}
+
+func (p *parser) callonGroupModifier1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onGroupModifier1(stack["side"], stack["labels"])
+}
+
 var (
 	// errNoRule is returned when the grammar to parse has no rule.
 	errNoRule = errors.New("grammar has no rule")
@@ -3678,18 +4822,17 @@ func Entrypoint(ruleName string) Option {
 //
 // Example usage:
 //
-//     input := "input"
-//     stats := Stats{}
-//     _, err := Parse("input-file", []byte(input), Statistics(&stats, "no match"))
-//     if err != nil {
-//         log.Panicln(err)
-//     }
-//     b, err := json.MarshalIndent(stats.ChoiceAltCnt, "", "  ")
-//     if err != nil {
-//         log.Panicln(err)
-//     }
-//     fmt.Println(string(b))
-//
+//	input := "input"
+//	stats := Stats{}
+//	_, err := Parse("input-file", []byte(input), Statistics(&stats, "no match"))
+//	if err != nil {
+//	    log.Panicln(err)
+//	}
+//	b, err := json.MarshalIndent(stats.ChoiceAltCnt, "", "  ")
+//	if err != nil {
+//	    log.Panicln(err)
+//	}
+//	fmt.Println(string(b))
 func Statistics(stats *Stats, choiceNoMatch string) Option {
 	return func(p *parser) Option {
 		oldStats := p.Stats
@@ -334,6 +334,91 @@ func TestParsePromQL(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "rate of a range",
+			promql: `rate(http_requests_total[5m])`,
+			want: &FunctionCall{
+				Name: "rate",
+				Args: []Arg{
+					&Selector{
+						Name:  "http_requests_total",
+						Range: 5 * time.Minute,
+					},
+				},
+			},
+		},
+		{
+			name:   "sum by of a function",
+			promql: `sum by (job) (rate(http_requests_total[5m]))`,
+			want: &AggregateExpr{
+				Op: &Operator{
+					Kind: SumKind,
+				},
+				Expr: &FunctionCall{
+					Name: "rate",
+					Args: []Arg{
+						&Selector{
+							Name:  "http_requests_total",
+							Range: 5 * time.Minute,
+						},
+					},
+				},
+				Aggregate: &Aggregate{
+					By: true,
+					Labels: []*Identifier{
+						&Identifier{
+							Name: "job",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "binary operators have precedence",
+			promql: `a + b * 2`,
+			want: &BinaryExpr{
+				Op:  AddOp,
+				LHS: &Selector{Name: "a"},
+				RHS: &BinaryExpr{
+					Op:  MulOp,
+					LHS: &Selector{Name: "b"},
+					RHS: &Number{Val: 2},
+				},
+			},
+		},
+		{
+			name:   "vector matching",
+			promql: `a / on(job) group_left(instance) b`,
+			want: &BinaryExpr{
+				Op:  DivOp,
+				LHS: &Selector{Name: "a"},
+				RHS: &Selector{Name: "b"},
+				Matching: &VectorMatching{
+					On: true,
+					Labels: []*Identifier{
+						&Identifier{
+							Name: "job",
+						},
+					},
+					GroupLeft: true,
+					Include: []*Identifier{
+						&Identifier{
+							Name: "instance",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "bool comparison",
+			promql: `a > bool 1`,
+			want: &BinaryExpr{
+				Op:         GtrOp,
+				LHS:        &Selector{Name: "a"},
+				RHS:        &Number{Val: 1},
+				ReturnBool: true,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
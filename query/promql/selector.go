@@ -0,0 +1,308 @@
+package promql
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/ast"
+)
+
+const (
+	// nameLabel is the label holding the metric name.
+	nameLabel = "__name__"
+	// bucketLabel is the label holding the upper bound of histogram buckets.
+	bucketLabel = "le"
+	// quantileLabel is the label holding the quantile of summaries.
+	quantileLabel = "quantile"
+)
+
+// sampleFields are the fields the scrapers use for the samples of counters,
+// gauges and untyped metrics. Every other field of a histogram or summary,
+// except for the count and sum fields, is a bucket or a quantile.
+var sampleFields = []string{"counter", "gauge", "value"}
+
+// matcher is a label matcher with a literal value.
+type matcher struct {
+	label string
+	kind  MatchKind
+	value string
+	re    *regexp.Regexp
+}
+
+func newMatcher(m *LabelMatcher) (*matcher, error) {
+	var v string
+	switch m.Value.Type() {
+	case StringKind:
+		v = m.Value.Value().(string)
+	case NumberKind:
+		v = strconv.FormatFloat(m.Value.Value().(float64), 'f', -1, 64)
+	default:
+		return nil, fmt.Errorf("unsupported value for label matcher %q", m.Name)
+	}
+
+	lm := &matcher{label: m.Name, kind: m.Kind, value: v}
+	if m.Kind == RegexMatch || m.Kind == RegexNoMatch {
+		// Prometheus regular expressions are always fully anchored.
+		re, err := regexp.Compile("^(?:" + v + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression for label %q: %s", m.Name, err)
+		}
+		lm.re = re
+	}
+	return lm, nil
+}
+
+// matches reports whether the matcher matches the value.
+func (m *matcher) matches(v string) bool {
+	switch m.kind {
+	case Equal:
+		return v == m.value
+	case NotEqual:
+		return v != m.value
+	case RegexMatch:
+		return m.re.MatchString(v)
+	default:
+		return !m.re.MatchString(v)
+	}
+}
+
+// expr returns the predicate that matches the column.
+func (m *matcher) expr(column string) ast.Expression {
+	switch m.kind {
+	case Equal:
+		return compare(ast.EqualOperator, member(column), &ast.StringLiteral{Value: m.value})
+	case NotEqual:
+		return compare(ast.NotEqualOperator, member(column), &ast.StringLiteral{Value: m.value})
+	case RegexMatch:
+		return compare(ast.RegexpMatchOperator, member(column), &ast.RegexpLiteral{Value: m.re})
+	default:
+		return compare(ast.NotRegexpMatchOperator, member(column), &ast.RegexpLiteral{Value: m.re})
+	}
+}
+
+// branch reads the samples of one of the measurements and fields a metric
+// name can be stored as. The field is exposed as the label when it is set.
+type branch struct {
+	measurement string
+	fields      ast.Expression
+	label       string
+}
+
+// branches returns where the samples of the metric are stored. Histograms and
+// summaries store the samples of the _bucket, _count and _sum series of
+// Prometheus as fields of the measurement without the suffix.
+func branches(name string) []branch {
+	var bucketFields []ast.Expression
+	for _, f := range append(sampleFields, "count", "sum") {
+		bucketFields = append(bucketFields, compare(ast.NotEqualOperator, member("_field"), &ast.StringLiteral{Value: f}))
+	}
+	var fields []ast.Expression
+	for _, f := range sampleFields {
+		fields = append(fields, compare(ast.EqualOperator, member("_field"), &ast.StringLiteral{Value: f}))
+	}
+
+	bs := []branch{
+		{measurement: name, fields: or(fields...)},
+		{measurement: name, fields: and(bucketFields...), label: quantileLabel},
+	}
+	if base := strings.TrimSuffix(name, "_bucket"); base != name && base != "" {
+		bs = append(bs, branch{measurement: base, fields: and(bucketFields...), label: bucketLabel})
+	}
+	for _, f := range []string{"count", "sum"} {
+		if base := strings.TrimSuffix(name, "_"+f); base != name && base != "" {
+			bs = append(bs, branch{
+				measurement: base,
+				fields:      compare(ast.EqualOperator, member("_field"), &ast.StringLiteral{Value: f}),
+			})
+		}
+	}
+	return bs
+}
+
+// series reads the samples of the selected series between start and stop.
+// Each series is a table with the labels as the group key.
+func (t *transpiler) series(sel *Selector, start, stop time.Time) (ast.Expression, error) {
+	var (
+		matchers []*matcher
+		preds    []ast.Expression
+	)
+	for _, lm := range sel.LabelMatchers {
+		if lm.Name == nameLabel {
+			return nil, errors.New("matching the metric name with a label matcher is not supported")
+		}
+		m, err := newMatcher(lm)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+		// Matchers of the labels that are stored as fields are applied when reading the fields.
+		if m.label != bucketLabel && m.label != quantileLabel {
+			preds = append(preds, m.expr(m.label))
+		}
+	}
+
+	bs := branches(sel.Name)
+	var measurements []ast.Expression
+	seen := make(map[string]bool)
+	for _, b := range bs {
+		if !seen[b.measurement] {
+			seen[b.measurement] = true
+			measurements = append(measurements, compare(ast.EqualOperator, member("_measurement"), &ast.StringLiteral{Value: b.measurement}))
+		}
+	}
+
+	// The label matchers are part of the filter right after the range so they are
+	// pushed down to the storage which also matches series without the label.
+	src := t.assignment(pipe(
+		call("from", property("bucketID", &ast.StringLiteral{Value: t.config.BucketID.String()})),
+		call("range",
+			property("start", &ast.DateTimeLiteral{Value: start.UTC()}),
+			property("stop", &ast.DateTimeLiteral{Value: stop.UTC()}),
+		),
+		filter(and(append([]ast.Expression{or(measurements...)}, preds...)...)),
+	))
+
+	var tables []ast.Expression
+	for _, b := range bs {
+		preds := []ast.Expression{
+			compare(ast.EqualOperator, member("_measurement"), &ast.StringLiteral{Value: b.measurement}),
+			b.fields,
+		}
+		skip := false
+		for _, m := range matchers {
+			if m.label != bucketLabel && m.label != quantileLabel {
+				continue
+			} else if m.label == b.label {
+				preds = append(preds, m.expr("_field"))
+			} else if !m.matches("") {
+				// The series of the branch do not have the label.
+				skip = true
+			}
+		}
+		if skip {
+			continue
+		}
+
+		expr := pipe(src, filter(and(preds...)))
+		if b.label != "" {
+			expr = pipe(expr, call("rename", property("columns", &ast.ObjectExpression{
+				Properties: []*ast.Property{property("_field", &ast.StringLiteral{Value: b.label})},
+			})))
+		}
+		tables = append(tables, expr)
+	}
+
+	var expr ast.Expression
+	switch len(tables) {
+	case 0:
+		// Nothing can match so read nothing from the source.
+		expr = pipe(src, filter(&ast.BooleanLiteral{Value: false}))
+	case 1:
+		expr = tables[0]
+	default:
+		expr = call("union", property("tables", &ast.ArrayExpression{Elements: tables}))
+	}
+	return pipe(expr,
+		dropColumns("_start", "_stop", "_measurement", "_field"),
+		call("set",
+			property("key", &ast.StringLiteral{Value: nameLabel}),
+			property("value", &ast.StringLiteral{Value: sel.Name}),
+		),
+		groupExcept("_time", "_value"),
+	), nil
+}
+
+// evaluate evaluates the calls on the samples of the series within the period
+// before every evaluation time. The prepare calls are applied to the series
+// before they are split up by evaluation time and see the samples up to the
+// lookback delta before the first period, so a difference includes the sample
+// before the period. The calls must return a single row for each evaluation time.
+//
+// An evaluation time T covers the samples in [T - period, T), so a sample
+// written exactly at an evaluation time is only seen by the next one.
+func (t *transpiler) evaluate(sel *Selector, period time.Duration, prepare []*ast.CallExpression, calls ...*ast.CallExpression) (ast.Expression, error) {
+	every := t.config.Step
+	if every == 0 {
+		every = period
+	}
+	start := t.config.Start.Add(-sel.Offset)
+	stop := t.config.End.Add(-sel.Offset)
+
+	// Periods that overlap the next evaluation time make windows past the last
+	// evaluation time that get clipped to the same bounds as the last window.
+	// Reading one more step makes these windows distinct so they can be
+	// removed along with the windows before the first evaluation time.
+	overlap := period > every
+	if overlap {
+		stop = stop.Add(every)
+	}
+	// The windows of the samples before the first period are removed as well.
+	read := start.Add(-period)
+	history := len(prepare) > 0
+	if history {
+		read = read.Add(-t.config.LookbackDelta)
+	}
+
+	expr, err := t.series(sel, read, stop)
+	if err != nil {
+		return nil, err
+	}
+	expr = pipe(expr, prepare...)
+
+	offset := time.Duration(start.UnixNano() % int64(every))
+	if offset < 0 {
+		offset += every
+	}
+	expr = pipe(expr, call("window",
+		property("every", duration(every)),
+		property("period", duration(period)),
+		property("offset", duration(offset)),
+	))
+	expr = pipe(expr, calls...)
+
+	// The evaluation time is the stop of the window.
+	expr = pipe(expr,
+		dropColumns("_time"),
+		call("duplicate",
+			property("column", &ast.StringLiteral{Value: "_stop"}),
+			property("as", &ast.StringLiteral{Value: "_time"}),
+		),
+	)
+	if sel.Offset != 0 {
+		expr = pipe(expr, call("timeShift",
+			property("duration", duration(sel.Offset)),
+			property("columns", stringArray("_time")),
+		))
+	}
+	expr = pipe(expr,
+		groupExcept("_time", "_value", "_start", "_stop"),
+		dropColumns("_start", "_stop"),
+	)
+	if overlap || history {
+		expr = pipe(expr, filter(and(
+			compare(ast.GreaterThanEqualOperator, member("_time"), &ast.DateTimeLiteral{Value: t.config.Start.UTC()}),
+			compare(ast.LessThanEqualOperator, member("_time"), &ast.DateTimeLiteral{Value: t.config.End.UTC()}),
+		)))
+	}
+	return expr, nil
+}
+
+// rangeSamples reads the raw samples of a range vector at the evaluation time.
+func (t *transpiler) rangeSamples(sel *Selector) (ast.Expression, error) {
+	stop := t.config.End.Add(-sel.Offset)
+	expr, err := t.series(sel, stop.Add(-sel.Range), stop)
+	if err != nil {
+		return nil, err
+	}
+	if sel.Offset != 0 {
+		expr = pipe(expr, call("timeShift",
+			property("duration", duration(sel.Offset)),
+			property("columns", stringArray("_time")),
+		))
+	}
+	return expr, nil
+}
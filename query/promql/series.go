@@ -0,0 +1,103 @@
+package promql
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux/ast"
+)
+
+// Series returns a Flux program that reads the latest sample of every series
+// that matches one of the vector selectors between the start and the end of the
+// config. The series have the labels of the Prometheus series as the group key.
+func Series(selectors []string, config Config) (*ast.Package, error) {
+	t := &transpiler{
+		config: config,
+		file:   &ast.File{Package: &ast.PackageClause{Name: &ast.Identifier{Name: "main"}}},
+	}
+
+	var tables []ast.Expression
+	for _, s := range selectors {
+		parsed, err := ParsePromQL(s)
+		if err != nil {
+			return nil, err
+		}
+		sel, ok := parsed.(*Selector)
+		if !ok || sel.Range > 0 {
+			return nil, fmt.Errorf("%q is not an instant vector selector", s)
+		}
+		expr, err := t.series(sel, config.Start, config.End)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, pipe(expr, call("last")))
+	}
+
+	var expr ast.Expression
+	switch len(tables) {
+	case 0:
+		return nil, fmt.Errorf("no selectors given")
+	case 1:
+		expr = tables[0]
+	default:
+		expr = call("union", property("tables", &ast.ArrayExpression{Elements: tables}))
+	}
+	t.file.Body = append(t.file.Body, &ast.ExpressionStatement{Expression: expr})
+	return &ast.Package{
+		Package: "main",
+		Files:   []*ast.File{t.file},
+	}, nil
+}
+
+// AllSeries returns a Flux program that reads the latest sample of every field
+// in the bucket between the start and the end of the config. The tables are in
+// the schema of the scrapers and SeriesLabels returns the labels of the
+// Prometheus series they store.
+func AllSeries(config Config) *ast.Package {
+	expr := pipe(
+		call("from", property("bucketID", &ast.StringLiteral{Value: config.BucketID.String()})),
+		call("range",
+			property("start", &ast.DateTimeLiteral{Value: config.Start.UTC()}),
+			property("stop", &ast.DateTimeLiteral{Value: config.End.UTC()}),
+		),
+		call("last"),
+	)
+	return &ast.Package{
+		Package: "main",
+		Files: []*ast.File{{
+			Package: &ast.PackageClause{Name: &ast.Identifier{Name: "main"}},
+			Body:    []ast.Statement{&ast.ExpressionStatement{Expression: expr}},
+		}},
+	}
+}
+
+// SeriesLabels returns the labels of the Prometheus series that is stored in
+// the field of the measurement with the tags. Histogram tells if the measurement
+// stores a histogram, in which case the field is a bucket instead of a quantile
+// when it is not one of the other fields. Histograms have a +Inf bucket while
+// summaries do not have a matching quantile.
+func SeriesLabels(measurement, field string, tags map[string]string, histogram bool) map[string]string {
+	labels := make(map[string]string, len(tags)+2)
+	for k, v := range tags {
+		labels[k] = v
+	}
+
+	switch field {
+	case "count", "sum":
+		labels[nameLabel] = measurement + "_" + field
+		return labels
+	}
+	for _, f := range sampleFields {
+		if field == f {
+			labels[nameLabel] = measurement
+			return labels
+		}
+	}
+	if histogram {
+		labels[nameLabel] = measurement + "_bucket"
+		labels[bucketLabel] = field
+	} else {
+		labels[nameLabel] = measurement
+		labels[quantileLabel] = field
+	}
+	return labels
+}
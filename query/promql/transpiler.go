@@ -0,0 +1,205 @@
+package promql
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxdb"
+)
+
+// DefaultLookbackDelta is the default time an instant vector selector looks back
+// for the latest sample of a series.
+const DefaultLookbackDelta = 5 * time.Minute
+
+// ValueType is the type of the value an expression evaluates to.
+// The names match the result types of the Prometheus HTTP API.
+type ValueType string
+
+// Possible ValueTypes.
+const (
+	ScalarValue ValueType = "scalar"
+	VectorValue ValueType = "vector"
+	MatrixValue ValueType = "matrix"
+)
+
+// Config configures how a PromQL expression is transpiled.
+type Config struct {
+	// BucketID is the bucket containing the metrics written by the scrapers.
+	BucketID influxdb.ID
+	// Start and End are the first and the last evaluation time of the expression.
+	// They are equal for instant queries.
+	Start time.Time
+	End   time.Time
+	// Step is the duration between evaluation times. It is zero for instant queries.
+	Step time.Duration
+	// LookbackDelta is how far back an instant vector selector looks for the
+	// latest sample. DefaultLookbackDelta is used when it is zero.
+	LookbackDelta time.Duration
+}
+
+// Program is a PromQL expression transpiled to Flux.
+type Program struct {
+	// Type is the type of the value of the expression.
+	Type ValueType
+	// AST is the Flux program that evaluates vectors and matrices. The
+	// program produces one table per series with the labels of the series
+	// as the group key and the samples in the _time and _value columns.
+	AST *ast.Package
+	// Scalar is the value of a scalar expression. Scalar expressions are
+	// constant so they do not need a Flux program.
+	Scalar float64
+}
+
+// Transpile parses the PromQL expression and transpiles it to a Flux program
+// that evaluates it against the metrics written by the Prometheus scrapers.
+//
+// The metrics use the schema of the scrapers: the measurement is the metric
+// name, the labels are tags and the samples are stored in the counter, gauge or
+// value field. Histograms and summaries store their buckets and quantiles as
+// fields of a single measurement along with the count and sum fields.
+func Transpile(promql string, config Config) (*Program, error) {
+	parsed, err := ParsePromQL(promql)
+	if err != nil {
+		return nil, err
+	}
+	expr, ok := parsed.(Arg)
+	if !ok {
+		return nil, errors.New("no expression found in query")
+	}
+
+	if config.Step < 0 {
+		return nil, errors.New("step must be a positive duration")
+	} else if config.End.Before(config.Start) {
+		return nil, errors.New("end time must not be before start time")
+	} else if config.Step == 0 && !config.End.Equal(config.Start) {
+		return nil, errors.New("step is required for range queries")
+	}
+	if config.LookbackDelta == 0 {
+		config.LookbackDelta = DefaultLookbackDelta
+	}
+	// The last evaluation time is the last step that is not after the end.
+	if config.Step > 0 {
+		config.End = config.Start.Add(config.End.Sub(config.Start) / config.Step * config.Step)
+	}
+
+	t := &transpiler{
+		config: config,
+		file:   &ast.File{Package: &ast.PackageClause{Name: &ast.Identifier{Name: "main"}}},
+	}
+	v, err := t.transpile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	prog := &Program{Type: v.typ}
+	switch v.typ {
+	case ScalarValue:
+		prog.Scalar = v.scalar
+		return prog, nil
+	case MatrixValue:
+		if config.Step > 0 {
+			return nil, errors.New("range vectors cannot be evaluated by range queries, only instant vectors and scalars can")
+		}
+		if v.expr, err = t.rangeSamples(v.sel); err != nil {
+			return nil, err
+		}
+	}
+	t.file.Body = append(t.file.Body, &ast.ExpressionStatement{Expression: v.expr})
+	prog.AST = &ast.Package{
+		Package: "main",
+		Files:   []*ast.File{t.file},
+	}
+	return prog, nil
+}
+
+// value is the transpiled value of an expression.
+type value struct {
+	typ ValueType
+	// expr produces the series of vectors.
+	expr ast.Expression
+	// scalar is the value of scalars.
+	scalar float64
+	// sel is the selector of a range vector. Range vectors are read by the
+	// functions that use them since the functions decide how to read them.
+	sel *Selector
+}
+
+type transpiler struct {
+	config      Config
+	file        *ast.File
+	assignments int
+}
+
+func (t *transpiler) transpile(expr Arg) (*value, error) {
+	switch expr := expr.(type) {
+	case *Number:
+		return &value{typ: ScalarValue, scalar: expr.Val}, nil
+	case *Selector:
+		if expr.Range > 0 {
+			return &value{typ: MatrixValue, sel: expr}, nil
+		}
+		v, err := t.evaluate(expr, t.config.LookbackDelta, nil, call("last"))
+		if err != nil {
+			return nil, err
+		}
+		return t.vector(v), nil
+	case *AggregateExpr:
+		return t.aggregate(expr)
+	case *FunctionCall:
+		return t.function(expr)
+	case *BinaryExpr:
+		return t.binary(expr)
+	case *UnaryExpr:
+		v, err := t.transpile(expr.Expr)
+		if err != nil {
+			return nil, err
+		}
+		switch v.typ {
+		case ScalarValue:
+			return &value{typ: ScalarValue, scalar: -v.scalar}, nil
+		case VectorValue:
+			return t.vector(pipe(v.expr,
+				dropColumns(nameLabel),
+				mapValue(&ast.BinaryExpression{
+					Operator: ast.SubtractionOperator,
+					Left:     &ast.FloatLiteral{Value: 0},
+					Right:    member("_value"),
+				}),
+			)), nil
+		}
+		return nil, fmt.Errorf("unary expression only allowed on expressions of type scalar or instant vector, got %s", v.typ)
+	case *StringLiteral:
+		return nil, errors.New("string literals are only supported as function arguments")
+	default:
+		return nil, fmt.Errorf("unsupported expression %T", expr)
+	}
+}
+
+func (t *transpiler) vector(expr ast.Expression) *value {
+	return &value{typ: VectorValue, expr: expr}
+}
+
+// instantVector transpiles the expression and verifies it is an instant vector.
+func (t *transpiler) instantVector(expr Arg) (ast.Expression, error) {
+	v, err := t.transpile(expr)
+	if err != nil {
+		return nil, err
+	} else if v.typ != VectorValue {
+		return nil, fmt.Errorf("expected instant vector, got %s", v.typ)
+	}
+	return v.expr, nil
+}
+
+// assignment assigns the expression to a new variable so multiple
+// pipelines can read from it.
+func (t *transpiler) assignment(expr ast.Expression) *ast.Identifier {
+	ident := &ast.Identifier{Name: fmt.Sprintf("t%d", t.assignments)}
+	t.assignments++
+	t.file.Body = append(t.file.Body, &ast.VariableAssignment{
+		ID:   ident,
+		Init: expr,
+	})
+	return &ast.Identifier{Name: ident.Name}
+}
@@ -0,0 +1,346 @@
+package promql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/querytest"
+	_ "github.com/influxdata/influxdb/query/builtin"
+)
+
+// transpilerData is stored in the schema of the scrapers. reqs is a counter,
+// mem is a gauge and lat is a histogram.
+const transpilerData = `
+#datatype,string,long,dateTime:RFC3339,double,string,string,string,string
+#group,false,false,false,false,true,true,true,true
+#default,_result,,,,,,,
+,result,table,_time,_value,_field,_measurement,job,instance
+,,0,2019-01-01T00:00:00Z,1.0,counter,reqs,a,x
+,,0,2019-01-01T00:00:10Z,3.0,counter,reqs,a,x
+,,0,2019-01-01T00:00:20Z,6.0,counter,reqs,a,x
+,,0,2019-01-01T00:00:30Z,10.0,counter,reqs,a,x
+,,0,2019-01-01T00:00:40Z,15.0,counter,reqs,a,x
+,,1,2019-01-01T00:00:00Z,2.0,counter,reqs,b,y
+,,1,2019-01-01T00:00:20Z,4.0,counter,reqs,b,y
+,,1,2019-01-01T00:00:40Z,8.0,counter,reqs,b,y
+,,2,2019-01-01T00:00:00Z,100.0,gauge,mem,a,x
+,,2,2019-01-01T00:00:20Z,200.0,gauge,mem,a,x
+,,3,2019-01-01T00:00:00Z,50.0,gauge,mem,b,y
+,,3,2019-01-01T00:00:20Z,70.0,gauge,mem,b,y
+,,4,2019-01-01T00:00:00Z,1.0,0.1,lat,a,x
+,,4,2019-01-01T00:00:20Z,2.0,0.1,lat,a,x
+,,5,2019-01-01T00:00:00Z,3.0,0.5,lat,a,x
+,,5,2019-01-01T00:00:20Z,6.0,0.5,lat,a,x
+,,6,2019-01-01T00:00:00Z,4.0,+Inf,lat,a,x
+,,6,2019-01-01T00:00:20Z,10.0,+Inf,lat,a,x
+,,7,2019-01-01T00:00:00Z,4.0,count,lat,a,x
+,,7,2019-01-01T00:00:20Z,10.0,count,lat,a,x
+,,8,2019-01-01T00:00:00Z,1.5,sum,lat,a,x
+,,8,2019-01-01T00:00:20Z,3.5,sum,lat,a,x
+`
+
+var transpilerBase = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// runProgram runs the program over the csv data instead of the bucket and
+// returns the samples as "labels @ seconds = value" sorted.
+func runProgram(t *testing.T, prog *Program) []string {
+	t.Helper()
+
+	src := ast.Format(prog.AST)
+	src = strings.Replace(src, "package main", "", 1)
+	src = strings.Replace(src, `from(bucketID: "0000000000000001")`, "csv.from(csv: data)", -1)
+	src = "import \"csv\"\ndata = \"" + transpilerData + "\"\n" + src
+
+	q := querytest.NewQuerier()
+	query, err := q.C.Query(context.Background(), lang.FluxCompiler{Query: src})
+	if err != nil {
+		t.Fatalf("failed to run %s: %v", src, err)
+	}
+
+	var samples []string
+	results := flux.NewResultIteratorFromQuery(query)
+	defer results.Release()
+	for results.More() {
+		err := results.Next().Tables().Do(func(tbl flux.Table) error {
+			var labels []string
+			for j, c := range tbl.Key().Cols() {
+				if c.Type == flux.TString && !tbl.Key().IsNull(j) {
+					labels = append(labels, c.Label+"="+tbl.Key().ValueString(j))
+				}
+			}
+			sort.Strings(labels)
+			return tbl.Do(func(cr flux.ColReader) error {
+				ti := execute.ColIdx(execute.DefaultTimeColLabel, cr.Cols())
+				vi := execute.ColIdx(execute.DefaultValueColLabel, cr.Cols())
+				for i := 0; i < cr.Len(); i++ {
+					ts := time.Unix(0, cr.Times(ti).Value(i)).Sub(transpilerBase)
+					samples = append(samples, fmt.Sprintf("%s @ %v = %v",
+						strings.Join(labels, ","), ts.Seconds(), cr.Floats(vi).Value(i)))
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			t.Fatalf("failed to read results of %s: %v", src, err)
+		}
+	}
+	if err := results.Err(); err != nil {
+		t.Fatalf("failed to run %s: %v", src, err)
+	}
+	sort.Strings(samples)
+	return samples
+}
+
+func TestTranspile(t *testing.T) {
+	at := func(sec int) time.Time {
+		return transpilerBase.Add(time.Duration(sec) * time.Second)
+	}
+	tests := []struct {
+		name   string
+		promql string
+		start  time.Time
+		end    time.Time
+		step   time.Duration
+		want   []string
+	}{
+		{
+			name:   "instant selector",
+			promql: `mem{job="a"}`,
+			start:  at(30),
+			end:    at(30),
+			want:   []string{"__name__=mem,instance=x,job=a @ 30 = 200"},
+		},
+		{
+			name:   "selector with regex matcher",
+			promql: `mem{job=~"a|b"}`,
+			start:  at(30),
+			end:    at(30),
+			want: []string{
+				"__name__=mem,instance=x,job=a @ 30 = 200",
+				"__name__=mem,instance=y,job=b @ 30 = 70",
+			},
+		},
+		{
+			name:   "range query of a selector",
+			promql: `mem{job="b"}`,
+			start:  at(10),
+			end:    at(30),
+			step:   10 * time.Second,
+			want: []string{
+				"__name__=mem,instance=y,job=b @ 10 = 50",
+				"__name__=mem,instance=y,job=b @ 20 = 50",
+				"__name__=mem,instance=y,job=b @ 30 = 70",
+			},
+		},
+		{
+			name:   "rate",
+			promql: `rate(reqs{job="a"}[20s])`,
+			start:  at(40),
+			end:    at(40),
+			want:   []string{"instance=x,job=a @ 40 = 0.35"},
+		},
+		{
+			name:   "range query of rate",
+			promql: `rate(reqs{job="a"}[20s])`,
+			start:  at(20),
+			end:    at(40),
+			step:   10 * time.Second,
+			want: []string{
+				"instance=x,job=a @ 20 = 0.1",
+				"instance=x,job=a @ 30 = 0.25",
+				"instance=x,job=a @ 40 = 0.35",
+			},
+		},
+		{
+			name:   "increase",
+			promql: `increase(reqs{job="b"}[40s])`,
+			start:  at(40),
+			end:    at(40),
+			want:   []string{"instance=y,job=b @ 40 = 2"},
+		},
+		{
+			name:   "sum by",
+			promql: `sum by (job) (mem)`,
+			start:  at(30),
+			end:    at(30),
+			want: []string{
+				"job=a @ 30 = 200",
+				"job=b @ 30 = 70",
+			},
+		},
+		{
+			name:   "sum without",
+			promql: `sum without (instance, job) (mem)`,
+			start:  at(30),
+			end:    at(30),
+			want:   []string{" @ 30 = 270"},
+		},
+		{
+			name:   "max",
+			promql: `max(mem)`,
+			start:  at(30),
+			end:    at(30),
+			want:   []string{" @ 30 = 200"},
+		},
+		{
+			name:   "histogram bucket",
+			promql: `lat_bucket{le="0.5"}`,
+			start:  at(30),
+			end:    at(30),
+			want:   []string{"__name__=lat_bucket,instance=x,job=a,le=0.5 @ 30 = 6"},
+		},
+		{
+			name:   "histogram count",
+			promql: `lat_count`,
+			start:  at(30),
+			end:    at(30),
+			want:   []string{"__name__=lat_count,instance=x,job=a @ 30 = 10"},
+		},
+		{
+			name:   "histogram quantile",
+			promql: `histogram_quantile(0.5, lat_bucket)`,
+			start:  at(30),
+			end:    at(30),
+			want:   []string{"instance=x,job=a @ 30 = 0.4"},
+		},
+		{
+			name:   "vector and scalar",
+			promql: `mem{job="a"} * 2`,
+			start:  at(30),
+			end:    at(30),
+			want:   []string{"instance=x,job=a @ 30 = 400"},
+		},
+		{
+			name:   "comparison filters the samples",
+			promql: `mem > 100`,
+			start:  at(30),
+			end:    at(30),
+			want:   []string{"__name__=mem,instance=x,job=a @ 30 = 200"},
+		},
+		{
+			name:   "bool comparison",
+			promql: `mem > bool 100`,
+			start:  at(30),
+			end:    at(30),
+			want: []string{
+				"instance=x,job=a @ 30 = 1",
+				"instance=y,job=b @ 30 = 0",
+			},
+		},
+		{
+			name:   "vector and vector",
+			promql: `mem / reqs`,
+			start:  at(30),
+			end:    at(30),
+			want: []string{
+				"instance=x,job=a @ 30 = 33.333333333333336",
+				"instance=y,job=b @ 30 = 17.5",
+			},
+		},
+		{
+			name:   "vector matching on labels",
+			promql: `mem - on(job) lat_count`,
+			start:  at(30),
+			end:    at(30),
+			want:   []string{"job=a @ 30 = 190"},
+		},
+		{
+			name:   "unary minus",
+			promql: `-mem{job="b"}`,
+			start:  at(30),
+			end:    at(30),
+			want:   []string{"instance=y,job=b @ 30 = -70"},
+		},
+		{
+			name:   "offset",
+			promql: `mem{job="a"} offset 20s`,
+			start:  at(30),
+			end:    at(30),
+			want:   []string{"__name__=mem,instance=x,job=a @ 30 = 100"},
+		},
+		{
+			name:   "range vector",
+			promql: `reqs{job="b"}[30s]`,
+			start:  at(40),
+			end:    at(40),
+			want: []string{
+				"__name__=reqs,instance=y,job=b @ 20 = 4",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prog, err := Transpile(tt.promql, Config{
+				BucketID: 1,
+				Start:    tt.start,
+				End:      tt.end,
+				Step:     tt.step,
+			})
+			if err != nil {
+				t.Fatalf("Transpile() error = %v", err)
+			}
+			if got := runProgram(t, prog); !cmp.Equal(tt.want, got) {
+				t.Errorf("Transpile() = -want/+got\n%s", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
+func TestTranspile_Scalar(t *testing.T) {
+	tests := []struct {
+		promql string
+		want   float64
+	}{
+		{promql: `1 + 2 * 3`, want: 7},
+		{promql: `2 ^ 3 ^ 2`, want: 512},
+		{promql: `-(5 % 3)`, want: -2},
+		{promql: `1 < bool 2`, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.promql, func(t *testing.T) {
+			prog, err := Transpile(tt.promql, Config{BucketID: 1, Start: transpilerBase, End: transpilerBase})
+			if err != nil {
+				t.Fatalf("Transpile() error = %v", err)
+			}
+			if prog.Type != ScalarValue || prog.AST != nil {
+				t.Fatalf("Transpile() type = %v, want scalar", prog.Type)
+			}
+			if prog.Scalar != tt.want {
+				t.Errorf("Transpile() = %v, want %v", prog.Scalar, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranspile_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		promql string
+		step   time.Duration
+	}{
+		{name: "range vector in range query", promql: `reqs[5m]`, step: time.Minute},
+		{name: "unsupported function", promql: `label_replace(reqs, "a", "b", "c", "d")`},
+		{name: "instant vector to rate", promql: `rate(reqs)`},
+		{name: "set operator", promql: `reqs and mem`},
+		{name: "scalar comparison without bool", promql: `1 < 2`},
+		{name: "group_left without on", promql: `reqs / ignoring(job) group_left mem`},
+		{name: "string literal", promql: `"reqs"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end := transpilerBase.Add(time.Hour)
+			if _, err := Transpile(tt.promql, Config{BucketID: 1, Start: transpilerBase, End: end, Step: tt.step}); err == nil {
+				t.Errorf("Transpile(%q) expected error", tt.promql)
+			}
+		})
+	}
+}
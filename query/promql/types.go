@@ -416,12 +416,25 @@ func (o *Operator) QuerySpec() (*flux.Operation, error) {
 }
 
 type AggregateExpr struct {
-	Op        *Operator  `json:"op,omitempty"`
-	Selector  *Selector  `json:"selector,omitempty"`
+	Op       *Operator `json:"op,omitempty"`
+	Selector *Selector `json:"selector,omitempty"`
+	// Expr is the aggregated expression when it is not a plain vector selector.
+	Expr      Arg        `json:"expr,omitempty"`
 	Aggregate *Aggregate `json:"aggregate,omitempty"`
 }
 
+func (a *AggregateExpr) Type() ArgKind {
+	return ExprKind
+}
+
+func (a *AggregateExpr) Value() interface{} {
+	return a
+}
+
 func (a *AggregateExpr) QuerySpec() (*flux.Spec, error) {
+	if a.Selector == nil {
+		return nil, fmt.Errorf("unable to build aggregates of expressions")
+	}
 	spec, err := a.Selector.QuerySpec()
 	if err != nil {
 		return nil, err
@@ -464,10 +477,14 @@ func (a *AggregateExpr) QuerySpec() (*flux.Spec, error) {
 	return spec, nil
 }
 
-func NewAggregateExpr(op *Operator, selector *Selector, group interface{}) (*AggregateExpr, error) {
+func NewAggregateExpr(op *Operator, vector Arg, group interface{}) (*AggregateExpr, error) {
 	expr := &AggregateExpr{
-		Op:       op,
-		Selector: selector,
+		Op: op,
+	}
+	if selector, ok := vector.(*Selector); ok {
+		expr.Selector = selector
+	} else {
+		expr.Expr = vector
 	}
 	if group != nil {
 		expr.Aggregate = group.(*Aggregate)
@@ -475,6 +492,240 @@ func NewAggregateExpr(op *Operator, selector *Selector, group interface{}) (*Agg
 	return expr, nil
 }
 
+// BinaryOpKind is the kind of a binary operator.
+type BinaryOpKind int
+
+// Possible BinaryOpKinds.
+const (
+	UnknownBinaryOp BinaryOpKind = iota
+	AddOp
+	SubOp
+	MulOp
+	DivOp
+	ModOp
+	PowOp
+	EqlOp
+	NeqOp
+	GtrOp
+	LssOp
+	GteOp
+	LteOp
+	AndOp
+	OrOp
+	UnlessOp
+)
+
+func ToBinaryOpKind(op string) BinaryOpKind {
+	switch strings.ToLower(op) {
+	case "+":
+		return AddOp
+	case "-":
+		return SubOp
+	case "*":
+		return MulOp
+	case "/":
+		return DivOp
+	case "%":
+		return ModOp
+	case "^":
+		return PowOp
+	case "==":
+		return EqlOp
+	case "!=":
+		return NeqOp
+	case ">":
+		return GtrOp
+	case "<":
+		return LssOp
+	case ">=":
+		return GteOp
+	case "<=":
+		return LteOp
+	case "and":
+		return AndOp
+	case "or":
+		return OrOp
+	case "unless":
+		return UnlessOp
+	default:
+		return UnknownBinaryOp
+	}
+}
+
+// IsComparison reports whether the operator compares its operands.
+func (k BinaryOpKind) IsComparison() bool {
+	return k >= EqlOp && k <= LteOp
+}
+
+// IsSetOperator reports whether the operator is one of the logical set operators.
+func (k BinaryOpKind) IsSetOperator() bool {
+	return k >= AndOp && k <= UnlessOp
+}
+
+// VectorMatching describes how the series of the two sides of a binary
+// operation between vectors are matched with each other.
+type VectorMatching struct {
+	// On is true when only the labels are used for matching and false when
+	// the labels are ignored for matching.
+	On     bool          `json:"on,omitempty"`
+	Labels []*Identifier `json:"labels,omitempty"`
+	// GroupLeft and GroupRight mark many-to-one and one-to-many matching.
+	GroupLeft  bool `json:"group_left,omitempty"`
+	GroupRight bool `json:"group_right,omitempty"`
+	// Include are the labels of the "one" side that are copied to the result.
+	Include []*Identifier `json:"include,omitempty"`
+}
+
+func NewVectorMatching(kind string, labels, group interface{}) (*VectorMatching, error) {
+	m := &VectorMatching{
+		On: strings.ToLower(kind) == "on",
+	}
+	if labels != nil {
+		m.Labels = labels.([]*Identifier)
+	}
+	if group != nil {
+		g := group.(*VectorMatching)
+		m.GroupLeft, m.GroupRight, m.Include = g.GroupLeft, g.GroupRight, g.Include
+	}
+	return m, nil
+}
+
+func NewGroupModifier(side string, labels interface{}) (*VectorMatching, error) {
+	m := &VectorMatching{}
+	if strings.ToLower(side) == "group_left" {
+		m.GroupLeft = true
+	} else {
+		m.GroupRight = true
+	}
+	if labels != nil {
+		m.Include = labels.([]*Identifier)
+	}
+	return m, nil
+}
+
+type binaryModifiers struct {
+	returnBool bool
+	matching   *VectorMatching
+}
+
+func NewBinaryModifiers(returnBool bool, matching interface{}) (interface{}, error) {
+	mods := &binaryModifiers{returnBool: returnBool}
+	if matching != nil {
+		mods.matching = matching.(*VectorMatching)
+	}
+	return mods, nil
+}
+
+type BinaryExpr struct {
+	Op  BinaryOpKind `json:"op,omitempty"`
+	LHS Arg          `json:"lhs,omitempty"`
+	RHS Arg          `json:"rhs,omitempty"`
+	// ReturnBool is set by the bool modifier of comparison operators.
+	ReturnBool bool            `json:"return_bool,omitempty"`
+	Matching   *VectorMatching `json:"matching,omitempty"`
+}
+
+func (b *BinaryExpr) Type() ArgKind {
+	return ExprKind
+}
+
+func (b *BinaryExpr) Value() interface{} {
+	return b
+}
+
+func newBinaryExpr(op BinaryOpKind, lhs Arg, modifiers interface{}, rhs Arg) (*BinaryExpr, error) {
+	expr := &BinaryExpr{
+		Op:  op,
+		LHS: lhs,
+		RHS: rhs,
+	}
+	if mods, ok := modifiers.(*binaryModifiers); ok {
+		expr.ReturnBool = mods.returnBool
+		expr.Matching = mods.matching
+	}
+	if expr.ReturnBool && !op.IsComparison() {
+		return nil, fmt.Errorf("bool modifier can only be used on comparison operators")
+	}
+	if m := expr.Matching; m != nil && (m.GroupLeft || m.GroupRight) && op.IsSetOperator() {
+		return nil, fmt.Errorf("no grouping allowed for set operations")
+	}
+	return expr, nil
+}
+
+// NewBinaryExpr folds the operations of a left associative chain of binary
+// operators into a tree of binary expressions.
+func NewBinaryExpr(first Arg, rest interface{}) (Arg, error) {
+	expr := first
+	for _, r := range toIfaceSlice(rest) {
+		// Each operation is the sequence: __ op __ modifiers __ rhs.
+		parts := r.([]interface{})
+		b, err := newBinaryExpr(parts[1].(BinaryOpKind), expr, parts[3], parts[5].(Arg))
+		if err != nil {
+			return nil, err
+		}
+		expr = b
+	}
+	return expr, nil
+}
+
+func NewPowerExpr(base Arg, modifiers interface{}, exp Arg) (Arg, error) {
+	return newBinaryExpr(PowOp, base, modifiers, exp)
+}
+
+// UnaryExpr negates the value of its expression.
+type UnaryExpr struct {
+	Expr Arg `json:"expr,omitempty"`
+}
+
+func (u *UnaryExpr) Type() ArgKind {
+	return ExprKind
+}
+
+func (u *UnaryExpr) Value() interface{} {
+	return u
+}
+
+func NewUnaryExpr(op BinaryOpKind, expr Arg) (Arg, error) {
+	if op == AddOp {
+		return expr, nil
+	}
+	if n, ok := expr.(*Number); ok {
+		return &Number{Val: -n.Val}, nil
+	}
+	return &UnaryExpr{Expr: expr}, nil
+}
+
+type FunctionCall struct {
+	Name string `json:"name,omitempty"`
+	Args []Arg  `json:"args,omitempty"`
+}
+
+func (f *FunctionCall) Type() ArgKind {
+	return ExprKind
+}
+
+func (f *FunctionCall) Value() interface{} {
+	return f
+}
+
+func NewFunctionCall(name *Identifier, args interface{}) (*FunctionCall, error) {
+	call := &FunctionCall{
+		Name: name.Name,
+	}
+	if args != nil {
+		call.Args = args.([]Arg)
+	}
+	return call, nil
+}
+
+func NewArgList(first Arg, rest interface{}) ([]Arg, error) {
+	args := []Arg{first}
+	for _, a := range toIfaceSlice(rest) {
+		args = append(args, a.(Arg))
+	}
+	return args, nil
+}
+
 type Comment struct {
 	Source string `json:"source,omitempty"`
 }
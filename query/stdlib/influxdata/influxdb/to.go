@@ -19,6 +19,7 @@ import (
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/kit/tracing"
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/storage"
 	"github.com/influxdata/influxdb/tsdb"
 )
@@ -246,6 +247,7 @@ func createToTransformation(id execute.DatasetID, mode execute.AccumulationMode,
 	if err != nil {
 		return nil, nil, err
 	}
+	t.stats = query.WriteStatisticsFromContext(a.Context())
 	return t, d, nil
 }
 
@@ -256,6 +258,9 @@ type ToTransformation struct {
 	cache execute.TableBuilderCache
 	spec  *ToProcedureSpec
 	deps  ToDependencies
+
+	// Counts the points written, when the query was run with write statistics.
+	stats *query.WriteStatistics
 }
 
 // RetractTable retracts the table for the transformation for the `to` flux function.
@@ -592,11 +597,19 @@ func writeTable(t *ToTransformation, tbl flux.Table) error {
 				return err
 			}
 		}
+		n, size := len(points), 0
+		for _, pt := range points {
+			size += pt.StringSize()
+		}
 		points, err = tsdb.ExplodePoints(*orgID, *bucketID, points)
 		if err != nil {
 			return err
 		}
-		return d.PointsWriter.WritePoints(context.TODO(), points)
+		if err := d.PointsWriter.WritePoints(context.TODO(), points); err != nil {
+			return err
+		}
+		t.stats.Add(n, size)
+		return nil
 	})
 }
 
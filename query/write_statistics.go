@@ -0,0 +1,72 @@
+package query
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/influxdata/flux"
+)
+
+// Keys of the metadata of query statistics that report the writes counted by WriteStatistics.
+const (
+	PointsWrittenMetadataKey = "influxdb/points-written"
+	BytesWrittenMetadataKey  = "influxdb/bytes-written"
+)
+
+// WriteStatistics counts the points and bytes written by the queries run with a context.
+// It is safe for concurrent use.
+type WriteStatistics struct {
+	points int64
+	bytes  int64
+}
+
+// Add records that n points of the given total size in bytes were written.
+// Add is a no-op on a nil *WriteStatistics.
+func (s *WriteStatistics) Add(n, bytes int) {
+	if s == nil {
+		return
+	}
+	atomic.AddInt64(&s.points, int64(n))
+	atomic.AddInt64(&s.bytes, int64(bytes))
+}
+
+// Points returns the number of points written.
+func (s *WriteStatistics) Points() int64 {
+	if s == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&s.points)
+}
+
+// Bytes returns the number of bytes written.
+func (s *WriteStatistics) Bytes() int64 {
+	if s == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&s.bytes)
+}
+
+// AddMetadata reports the writes counted so far in the metadata of stats.
+func (s *WriteStatistics) AddMetadata(stats *flux.Statistics) {
+	if stats.Metadata == nil {
+		stats.Metadata = make(flux.Metadata)
+	}
+	stats.Metadata.Add(PointsWrittenMetadataKey, s.Points())
+	stats.Metadata.Add(BytesWrittenMetadataKey, s.Bytes())
+}
+
+type writeStatisticsContextKey struct{}
+
+// ContextWithWriteStatistics returns a new context counting the writes of the queries run with it,
+// and the statistics that count them.
+func ContextWithWriteStatistics(ctx context.Context) (context.Context, *WriteStatistics) {
+	s := &WriteStatistics{}
+	return context.WithValue(ctx, writeStatisticsContextKey{}, s), s
+}
+
+// WriteStatisticsFromContext retrieves the *WriteStatistics from a context.
+// If the context does not count writes, nil is returned.
+func WriteStatisticsFromContext(ctx context.Context) *WriteStatistics {
+	s, _ := ctx.Value(writeStatisticsContextKey{}).(*WriteStatistics)
+	return s
+}
@@ -2,6 +2,16 @@ package influxdb
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb/pkg/jsonpath"
 )
 
 // ErrScraperTargetNotFound is the error msg for a missing scraper target.
@@ -24,6 +34,336 @@ type ScraperTarget struct {
 	URL      string      `json:"url"`
 	OrgID    ID          `json:"orgID,omitempty"`
 	BucketID ID          `json:"bucketID,omitempty"`
+
+	// Interval is the time between scrapes of the target. If unset, the interval of the scheduler is used.
+	Interval *Duration `json:"interval,omitempty"`
+	// Timeout is the longest a scrape of the target may take. If unset, the timeout of the scheduler is used.
+	Timeout *Duration `json:"timeout,omitempty"`
+
+	// BearerTokenSecret is the key of the organization secret holding the bearer token to scrape the target with.
+	BearerTokenSecret string `json:"bearerTokenSecret,omitempty"`
+	// BasicAuth are the credentials to scrape the target with, if any.
+	BasicAuth *ScraperBasicAuth `json:"basicAuth,omitempty"`
+	// Headers are added to every scrape request of the target.
+	Headers map[string]string `json:"headers,omitempty"`
+	// TLS configures the TLS connections to the target.
+	TLS *ScraperTLSConfig `json:"tls,omitempty"`
+
+	// Tags are added to every metric scraped from the target, replacing the scraped labels of the same names,
+	// before the relabel rules are applied.
+	Tags map[string]string `json:"tags,omitempty"`
+	// RelabelRules transform the metrics scraped from the target, in order, before they are written.
+	RelabelRules []ScraperRelabelRule `json:"relabelRules,omitempty"`
+
+	// Discovery makes the target a definition of the targets it discovers, if set.
+	// Its URL then only gives the scheme and path of the discovered targets, which default to http and /metrics.
+	Discovery *ScraperDiscovery `json:"discovery,omitempty"`
+
+	// LineProtocol configures the scrapes of lineprotocol targets.
+	LineProtocol *ScraperLineProtocolConfig `json:"lineProtocol,omitempty"`
+	// JSON configures the scrapes of json targets, which require it.
+	JSON *ScraperJSONConfig `json:"json,omitempty"`
+}
+
+// ScraperMetricNameLabel is the label holding the name of a metric in relabel rules.
+const ScraperMetricNameLabel = "__name__"
+
+// Relabel actions, as in Prometheus.
+const (
+	// RelabelReplace sets the target label to the replacement, if the regex matches the source labels.
+	RelabelReplace = "replace"
+	// RelabelKeep drops the metrics whose source labels do not match the regex.
+	RelabelKeep = "keep"
+	// RelabelDrop drops the metrics whose source labels match the regex.
+	RelabelDrop = "drop"
+	// RelabelLabelDrop removes the labels whose names match the regex.
+	RelabelLabelDrop = "labeldrop"
+	// RelabelLabelKeep removes the labels whose names do not match the regex.
+	RelabelLabelKeep = "labelkeep"
+)
+
+// ScraperRelabelRule is a rule transforming the metrics scraped from a target, like a Prometheus metric relabel config.
+// The values of the source labels are joined by the separator, and matched against the regex as a whole.
+type ScraperRelabelRule struct {
+	// Action is what the rule does, and defaults to RelabelReplace.
+	Action string `json:"action,omitempty"`
+	// SourceLabels are the labels to match, and default to the metric name for the keep and drop actions.
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+	// Separator joins the values of the source labels, and defaults to ";".
+	Separator string `json:"separator,omitempty"`
+	// Regex is the regular expression to match, and defaults to "(.*)".
+	Regex string `json:"regex,omitempty"`
+	// TargetLabel is the label set by the replace action.
+	TargetLabel string `json:"targetLabel,omitempty"`
+	// Replacement is the value of the target label, where $1 and the like are the groups of the regex, and defaults to "$1".
+	Replacement *string `json:"replacement,omitempty"`
+}
+
+// Validate returns an error if the rule is invalid.
+func (r ScraperRelabelRule) Validate() error {
+	switch r.Action {
+	case "", RelabelReplace:
+		if r.TargetLabel == "" {
+			return fmt.Errorf("relabel rule %q requires a target label", RelabelReplace)
+		}
+		if len(r.SourceLabels) == 0 {
+			return fmt.Errorf("relabel rule %q requires source labels", RelabelReplace)
+		}
+	case RelabelKeep, RelabelDrop:
+	case RelabelLabelDrop, RelabelLabelKeep:
+		if len(r.SourceLabels) > 0 || r.TargetLabel != "" {
+			return fmt.Errorf("relabel rule %q only takes a regex", r.Action)
+		}
+	default:
+		return fmt.Errorf("unknown relabel action %q", r.Action)
+	}
+	if _, err := r.CompileRegex(); err != nil {
+		return fmt.Errorf("invalid relabel regex %q: %v", r.Regex, err)
+	}
+	return nil
+}
+
+// CompileRegex compiles the regex of the rule, anchored to match whole values.
+func (r ScraperRelabelRule) CompileRegex() (*regexp.Regexp, error) {
+	re := r.Regex
+	if re == "" {
+		re = "(.*)"
+	}
+	return regexp.Compile("^(?:" + re + ")$")
+}
+
+// ScraperBasicAuth are the basic authentication credentials of a scraper target.
+type ScraperBasicAuth struct {
+	Username string `json:"username"`
+	// PasswordSecret is the key of the organization secret holding the password.
+	PasswordSecret string `json:"passwordSecret,omitempty"`
+}
+
+// ScraperTLSConfig configures the TLS connections to a scraper target.
+type ScraperTLSConfig struct {
+	// CA are PEM encoded certificates of the authorities to verify the target with, instead of the system ones.
+	CA string `json:"ca,omitempty"`
+	// Cert is the PEM encoded client certificate to present to the target.
+	Cert string `json:"cert,omitempty"`
+	// KeySecret is the key of the organization secret holding the PEM encoded private key of Cert.
+	KeySecret string `json:"keySecret,omitempty"`
+	// InsecureSkipVerify disables the verification of the certificate of the target.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// SecretKeys returns the keys of the organization secrets the target is scraped with.
+func (t *ScraperTarget) SecretKeys() []string {
+	var ks []string
+	if t.BearerTokenSecret != "" {
+		ks = append(ks, t.BearerTokenSecret)
+	}
+	if t.BasicAuth != nil && t.BasicAuth.PasswordSecret != "" {
+		ks = append(ks, t.BasicAuth.PasswordSecret)
+	}
+	if t.TLS != nil && t.TLS.KeySecret != "" {
+		ks = append(ks, t.TLS.KeySecret)
+	}
+	return ks
+}
+
+// ValidateOptions returns an error if the scrape options of the target are invalid.
+func (t *ScraperTarget) ValidateOptions() error {
+	if err := t.validateTypeConfig(); err != nil {
+		return err
+	}
+	if t.Interval != nil && t.Interval.Duration <= 0 {
+		return fmt.Errorf("scraper interval must be positive, got %s", t.Interval)
+	}
+	if t.Timeout != nil && t.Timeout.Duration <= 0 {
+		return fmt.Errorf("scraper timeout must be positive, got %s", t.Timeout)
+	}
+	if t.Interval != nil && t.Timeout != nil && t.Timeout.Duration > t.Interval.Duration {
+		return fmt.Errorf("scraper timeout %s is longer than its interval %s", t.Timeout, t.Interval)
+	}
+	if t.BearerTokenSecret != "" && t.BasicAuth != nil {
+		return fmt.Errorf("scraper target must not use both a bearer token and basic authentication")
+	}
+	if t.BasicAuth != nil && t.BasicAuth.Username == "" {
+		return fmt.Errorf("scraper basic authentication requires a username")
+	}
+	for k := range t.Headers {
+		if k == "" || (http.CanonicalHeaderKey(k) == "Authorization" && (t.BearerTokenSecret != "" || t.BasicAuth != nil)) {
+			return fmt.Errorf("invalid scraper header %q", k)
+		}
+	}
+	for i, r := range t.RelabelRules {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("scraper relabel rule %d: %v", i, err)
+		}
+	}
+	for k := range t.Tags {
+		if k == "" || k == ScraperMetricNameLabel {
+			return fmt.Errorf("invalid scraper tag %q", k)
+		}
+	}
+	if t.Discovery != nil {
+		if err := t.Discovery.Validate(); err != nil {
+			return err
+		}
+	}
+	if t.TLS != nil {
+		if t.TLS.CA != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(t.TLS.CA)) {
+			return fmt.Errorf("scraper TLS CA contains no PEM encoded certificate")
+		}
+		if (t.TLS.Cert == "") != (t.TLS.KeySecret == "") {
+			return fmt.Errorf("scraper TLS client certificate and key secret must be set together")
+		}
+		if t.TLS.Cert != "" {
+			if b, _ := pem.Decode([]byte(t.TLS.Cert)); b == nil || b.Type != "CERTIFICATE" {
+				return fmt.Errorf("scraper TLS client certificate is not a PEM encoded certificate")
+			}
+		}
+	}
+	return nil
+}
+
+// validateTypeConfig returns an error if the target is of an unknown type, or not configured for its type.
+// Targets without a type, stored before types were validated, are never scraped but remain valid.
+func (t *ScraperTarget) validateTypeConfig() error {
+	if t.Type != "" && !ValidScraperType(string(t.Type)) {
+		return fmt.Errorf("unknown scraper type %q", t.Type)
+	}
+	if t.LineProtocol != nil {
+		if t.Type != LineProtocolScraperType {
+			return fmt.Errorf("line protocol configuration set on a %s scraper", t.Type)
+		}
+		if err := t.LineProtocol.Validate(); err != nil {
+			return err
+		}
+	}
+	if t.JSON != nil && t.Type != JSONScraperType {
+		return fmt.Errorf("json configuration set on a %s scraper", t.Type)
+	}
+	if t.Type == JSONScraperType {
+		if t.JSON == nil {
+			return fmt.Errorf("json scraper requires a json configuration")
+		}
+		if err := t.JSON.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ScraperLineProtocolConfig configures the scrapes of the targets serving line protocol.
+type ScraperLineProtocolConfig struct {
+	// Precision is the precision of the timestamps of the points, ns, us, ms or s. Defaults to ns.
+	Precision string `json:"precision,omitempty"`
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *ScraperLineProtocolConfig) Validate() error {
+	switch c.Precision {
+	case "", "ns", "us", "ms", "s":
+		return nil
+	default:
+		return fmt.Errorf("invalid line protocol precision %q, expected ns, us, ms or s", c.Precision)
+	}
+}
+
+// ScraperJSONConfig maps the values of the JSON document of a target to the fields and tags of a metric.
+// Paths are JSONPath expressions, like $.memory.used or $.measurements[0].value.
+type ScraperJSONConfig struct {
+	// Measurement is the name of the metric.
+	Measurement string `json:"measurement"`
+	// Fields maps the names of the fields to the paths of their values. Numbers, booleans and strings are kept.
+	// Fields missing from the document are skipped.
+	Fields map[string]string `json:"fields"`
+	// Tags maps the names of the tags to the paths of their values.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *ScraperJSONConfig) Validate() error {
+	if c.Measurement == "" {
+		return fmt.Errorf("json scraper requires a measurement")
+	}
+	if len(c.Fields) == 0 {
+		return fmt.Errorf("json scraper requires fields")
+	}
+	for name, path := range c.Fields {
+		if name == "" {
+			return fmt.Errorf("invalid json scraper field %q", name)
+		}
+		if _, err := jsonpath.Parse(path); err != nil {
+			return fmt.Errorf("json scraper field %q: %v", name, err)
+		}
+	}
+	for name, path := range c.Tags {
+		if name == "" || name == ScraperMetricNameLabel {
+			return fmt.Errorf("invalid json scraper tag %q", name)
+		}
+		if _, err := jsonpath.Parse(path); err != nil {
+			return fmt.Errorf("json scraper tag %q: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// Scraper discovery types.
+const (
+	// FileScraperDiscovery discovers targets listed in files.
+	FileScraperDiscovery = "file"
+	// DNSScraperDiscovery discovers targets from DNS SRV or A records.
+	DNSScraperDiscovery = "dns"
+)
+
+// ScraperDiscovery discovers the targets of a scraper target definition.
+type ScraperDiscovery struct {
+	Type string `json:"type"`
+
+	// Files are the paths of the JSON or YAML files listing the targets, relative to the discovery directory of the scheduler.
+	// Each file is a list of groups of targets, like [{"targets": ["host:9100"], "labels": {"env": "prod"}}],
+	// and is read again when it changes.
+	Files []string `json:"files,omitempty"`
+
+	// Names are the DNS names to look up.
+	Names []string `json:"names,omitempty"`
+	// RecordType is the type of the DNS records to look up, SRV or A. Defaults to SRV.
+	RecordType string `json:"recordType,omitempty"`
+	// Port is the port of the targets discovered from A records.
+	Port int `json:"port,omitempty"`
+	// RefreshInterval is the time between DNS lookups. Defaults to 30s.
+	RefreshInterval *Duration `json:"refreshInterval,omitempty"`
+}
+
+// Validate returns an error if the discovery is invalid.
+func (d *ScraperDiscovery) Validate() error {
+	switch d.Type {
+	case FileScraperDiscovery:
+		if len(d.Files) == 0 {
+			return fmt.Errorf("file discovery requires files")
+		}
+		for _, f := range d.Files {
+			if f == "" || filepath.IsAbs(f) || strings.HasPrefix(filepath.Clean(f), "..") {
+				return fmt.Errorf("invalid discovery file %q, must be relative to the discovery directory", f)
+			}
+		}
+	case DNSScraperDiscovery:
+		if len(d.Names) == 0 {
+			return fmt.Errorf("dns discovery requires names")
+		}
+		switch d.RecordType {
+		case "", "SRV":
+		case "A":
+			if d.Port <= 0 || d.Port > 65535 {
+				return fmt.Errorf("dns discovery of A records requires a port")
+			}
+		default:
+			return fmt.Errorf("unknown dns record type %q, expected SRV or A", d.RecordType)
+		}
+	default:
+		return fmt.Errorf("unknown discovery type %q", d.Type)
+	}
+	if d.RefreshInterval != nil && d.RefreshInterval.Duration <= 0 {
+		return fmt.Errorf("discovery refresh interval must be positive, got %s", d.RefreshInterval)
+	}
+	return nil
 }
 
 // ScraperTargetStoreService defines the crud service for ScraperTarget.
@@ -49,14 +389,88 @@ type ScraperType string
 const (
 	// PrometheusScraperType parses metrics from a prometheus endpoint.
 	PrometheusScraperType = "prometheus"
+	// LineProtocolScraperType parses points from an endpoint serving line protocol.
+	LineProtocolScraperType = "lineprotocol"
+	// JSONScraperType maps the values of a JSON endpoint to fields, as configured by the target.
+	JSONScraperType = "json"
+	// ExpvarScraperType parses the statistics of an InfluxDB 1.x /debug/vars endpoint.
+	ExpvarScraperType = "expvar"
 )
 
 // ValidScraperType returns true is the type string is valid
 func ValidScraperType(s string) bool {
 	switch s {
-	case PrometheusScraperType:
+	case PrometheusScraperType, LineProtocolScraperType, JSONScraperType, ExpvarScraperType:
 		return true
 	default:
 		return false
 	}
 }
+
+// ScraperPreview is the outcome of one scrape of a target, transformed by its tags and relabel rules.
+type ScraperPreview struct {
+	// Scraped is the number of metrics scraped, and Kept the number of them left after relabeling.
+	Scraped int `json:"scraped"`
+	Kept    int `json:"kept"`
+	// Points are the points that would have been written, as line protocol.
+	Points string `json:"points"`
+}
+
+// ScraperPreviewService previews the metrics scraped from targets.
+type ScraperPreviewService interface {
+	// PreviewTarget scrapes target once, and returns what would have been written without writing it.
+	PreviewTarget(ctx context.Context, target ScraperTarget) (*ScraperPreview, error)
+}
+
+// ScraperStatusHistoryLength is the number of scrapes the status of a target keeps.
+const ScraperStatusHistoryLength = 10
+
+// ScrapeResult is the outcome of a scrape of a target.
+type ScrapeResult struct {
+	Time     time.Time `json:"time"`
+	Duration Duration  `json:"duration"`
+	// Samples is the number of metrics written, after relabeling.
+	Samples int `json:"samples"`
+	// StatusCode is the HTTP status the target responded with, if it responded.
+	StatusCode int `json:"statusCode,omitempty"`
+	// Error is why the scrape failed, if it did.
+	Error string `json:"error,omitempty"`
+	// Instance is the address of the target scraped, if it was discovered by the scraper target.
+	Instance string `json:"instance,omitempty"`
+}
+
+// ScraperTargetStatus is the health of a scraper target.
+type ScraperTargetStatus struct {
+	TargetID ID `json:"targetID"`
+	// Up is whether the last scrape of the target succeeded.
+	Up bool `json:"up"`
+	// LastScrape is the last scrape of the target, if it was scraped.
+	LastScrape *ScrapeResult `json:"lastScrape,omitempty"`
+	// History are the last scrapes of the target, latest first.
+	History []ScrapeResult `json:"history"`
+	// Instances are the statuses of the targets discovered by the target, ordered by instance.
+	// A target defined by discovery is up if all of them are.
+	Instances []ScraperInstanceStatus `json:"instances,omitempty"`
+}
+
+// ScraperInstanceStatus is the health of a target discovered by a scraper target.
+type ScraperInstanceStatus struct {
+	// Instance is the address of the discovered target.
+	Instance string `json:"instance"`
+	// Up is whether the last scrape of the discovered target succeeded.
+	Up bool `json:"up"`
+	// LastScrape is the last scrape of the discovered target.
+	LastScrape ScrapeResult `json:"lastScrape"`
+}
+
+// ScraperStatusService records and returns the health of scraper targets.
+type ScraperStatusService interface {
+	// FindTargetStatus returns the status of the target id.
+	FindTargetStatus(ctx context.Context, id ID) (*ScraperTargetStatus, error)
+	// RecordScrape adds r to the history of the target id,
+	// and to the status of the discovered target r.Instance if it is set.
+	RecordScrape(ctx context.Context, id ID, r ScrapeResult) error
+	// RetainInstances drops the statuses of the targets discovered by the target id that are not in instances,
+	// since they are no longer discovered.
+	RetainInstances(ctx context.Context, id ID, instances []string) error
+}
@@ -43,6 +43,7 @@ type Engine struct {
 	engine            *tsm1.Engine
 	wal               *wal.WAL
 	retentionEnforcer *retentionEnforcer
+	writeObserver     WriteObserver
 
 	defaultMetricLabels prometheus.Labels
 
@@ -90,6 +91,22 @@ func WithRetentionEnforcer(finder BucketFinder) Option {
 	}
 }
 
+// WriteObserver is notified of the time ranges of buckets that change, either
+// because points are written to them or because data is deleted from them.
+type WriteObserver interface {
+	// RangeModified is called with the minimum and maximum timestamps of the
+	// points written to the bucket or of the range deleted from it.
+	RangeModified(orgID, bucketID platform.ID, min, max int64)
+}
+
+// WithWriteObserver makes the engine notify the observer of the modified
+// ranges of buckets once the writes and deletes have been applied.
+func WithWriteObserver(obs WriteObserver) Option {
+	return func(e *Engine) {
+		e.writeObserver = obs
+	}
+}
+
 // WithFileStoreObserver makes the engine have the provided file store observer.
 func WithFileStoreObserver(obs tsm1.FileStoreObserver) Option {
 	return func(e *Engine) {
@@ -437,7 +454,38 @@ func (e *Engine) WritePoints(ctx context.Context, points []models.Point) error {
 		return err
 	}
 
-	return e.writePointsLocked(ctx, collection, values)
+	err = e.writePointsLocked(ctx, collection, values)
+	// Some of the points may have been written even if there was an error.
+	e.notifyWritten(collection)
+	return err
+}
+
+// notifyWritten notifies the write observer of the range of the points written
+// to each bucket.
+func (e *Engine) notifyWritten(collection *tsdb.SeriesCollection) {
+	if e.writeObserver == nil {
+		return
+	}
+
+	type timeRange struct{ min, max int64 }
+	ranges := make(map[[16]byte]*timeRange)
+	for i, pt := range collection.Points {
+		var name [16]byte
+		copy(name[:], collection.Names[i])
+
+		t := pt.UnixNano()
+		if r, ok := ranges[name]; !ok {
+			ranges[name] = &timeRange{min: t, max: t}
+		} else if t < r.min {
+			r.min = t
+		} else if t > r.max {
+			r.max = t
+		}
+	}
+	for name, r := range ranges {
+		orgID, bucketID := tsdb.DecodeName(name)
+		e.writeObserver.RangeModified(orgID, bucketID, r.min, r.max)
+	}
 }
 
 // writePointsLocked does the work of writing points and must be called under some sort of lock.
@@ -524,7 +572,13 @@ func (e *Engine) DeleteBucketRange(orgID, bucketID platform.ID, min, max int64)
 		return err
 	}
 
-	return e.deleteBucketRangeLocked(orgID, bucketID, min, max)
+	if err := e.deleteBucketRangeLocked(orgID, bucketID, min, max); err != nil {
+		return err
+	}
+	if e.writeObserver != nil {
+		e.writeObserver.RangeModified(orgID, bucketID, min, max)
+	}
+	return nil
 }
 
 // deleteBucketRangeLocked does the work of deleting a bucket range and must be called under
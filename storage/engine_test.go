@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"math"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
@@ -226,6 +227,44 @@ func TestEngine_DeleteBucket(t *testing.T) {
 	}
 }
 
+type modifiedRange struct {
+	orgID, bucketID influxdb.ID
+	min, max        int64
+}
+
+type writeObserver []modifiedRange
+
+func (o *writeObserver) RangeModified(orgID, bucketID influxdb.ID, min, max int64) {
+	*o = append(*o, modifiedRange{orgID: orgID, bucketID: bucketID, min: min, max: max})
+}
+
+func TestEngine_WriteObserver(t *testing.T) {
+	var obs writeObserver
+	engine := NewEngine(storage.NewConfig(), storage.WithWriteObserver(&obs))
+	defer engine.Close()
+	engine.MustOpen()
+
+	pts := []models.Point{
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "a"}), map[string]interface{}{"value": 1.0}, time.Unix(0, 20)),
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "b"}), map[string]interface{}{"value": 1.0}, time.Unix(0, 10)),
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "c"}), map[string]interface{}{"value": 1.0}, time.Unix(0, 30)),
+	}
+	if err := engine.Write1xPoints(pts); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.DeleteBucketRange(engine.org, engine.bucket, 0, 15); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := writeObserver{
+		{orgID: engine.org, bucketID: engine.bucket, min: 10, max: 30},
+		{orgID: engine.org, bucketID: engine.bucket, min: 0, max: 15},
+	}
+	if !reflect.DeepEqual(obs, exp) {
+		t.Fatalf("got modified ranges %v, exp %v", obs, exp)
+	}
+}
+
 func TestEngine_OpenClose(t *testing.T) {
 	engine := NewDefaultEngine()
 	engine.MustOpen()
@@ -370,10 +409,10 @@ type Engine struct {
 }
 
 // NewEngine create a new wrapper around a storage engine.
-func NewEngine(c storage.Config) *Engine {
+func NewEngine(c storage.Config, options ...storage.Option) *Engine {
 	path, _ := ioutil.TempDir("", "storage_engine_test")
 
-	engine := storage.NewEngine(path, c)
+	engine := storage.NewEngine(path, c, options...)
 
 	org, err := influxdb.IDFromString("3131313131313131")
 	if err != nil {
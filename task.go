@@ -24,28 +24,35 @@ const (
 
 // Task is a task. 🎊
 type Task struct {
-	ID              ID     `json:"id"`
-	OrganizationID  ID     `json:"orgID"`
-	Organization    string `json:"org"`
-	AuthorizationID ID     `json:"authorizationID"`
-	Name            string `json:"name"`
-	Status          string `json:"status"`
-	Flux            string `json:"flux"`
-	Every           string `json:"every,omitempty"`
-	Cron            string `json:"cron,omitempty"`
-	Offset          string `json:"offset,omitempty"`
-	LatestCompleted string `json:"latestCompleted,omitempty"`
-	CreatedAt       string `json:"createdAt,omitempty"`
-	UpdatedAt       string `json:"updatedAt,omitempty"`
+	ID              ID       `json:"id"`
+	OrganizationID  ID       `json:"orgID"`
+	Organization    string   `json:"org"`
+	AuthorizationID ID       `json:"authorizationID"`
+	Name            string   `json:"name"`
+	Status          string   `json:"status"`
+	Flux            string   `json:"flux"`
+	Every           string   `json:"every,omitempty"`
+	Cron            string   `json:"cron,omitempty"`
+	Offset          string   `json:"offset,omitempty"`
+	DependsOn       []string `json:"dependsOn,omitempty"`
+	Location        string   `json:"location,omitempty"`
+	Revision        int      `json:"revision,omitempty"`
+	LatestCompleted string   `json:"latestCompleted,omitempty"`
+	CreatedAt       string   `json:"createdAt,omitempty"`
+	UpdatedAt       string   `json:"updatedAt,omitempty"`
 }
 
 // EffectiveCron returns the effective cron string of the options.
 // If the cron option was specified, it is returned.
 // If the every option was specified, it is converted into a cron string using "@every".
-// Otherwise, the empty string is returned.
+// Otherwise, as for tasks that depend on other tasks, the empty string is returned.
+// A cron with a location is prefixed with "TZ=<location> ".
 // The value of the offset option is not considered.
 func (t *Task) EffectiveCron() string {
 	if t.Cron != "" {
+		if t.Location != "" {
+			return "TZ=" + t.Location + " " + t.Cron
+		}
 		return t.Cron
 	}
 	if t.Every != "" {
@@ -63,7 +70,31 @@ type Run struct {
 	StartedAt    string `json:"startedAt,omitempty"`
 	FinishedAt   string `json:"finishedAt,omitempty"`
 	RequestedAt  string `json:"requestedAt,omitempty"`
+	TaskRevision int    `json:"taskRevision,omitempty"`
 	Log          []Log  `json:"log"`
+
+	// Statistics are recorded when the execution of the run is over.
+	Statistics *RunStatistics `json:"statistics,omitempty"`
+}
+
+// RunStatistics are measurements of the execution of a run.
+// Durations are in nanoseconds when encoded to JSON.
+type RunStatistics struct {
+	// QueueDelay is the time between when the run was due and when its execution began.
+	QueueDelay time.Duration `json:"queueDelay"`
+	// QueryDuration is the time the query of the run took.
+	QueryDuration time.Duration `json:"queryDuration"`
+
+	PointsWritten int64 `json:"pointsWritten"`
+	BytesWritten  int64 `json:"bytesWritten"`
+	RowsRead      int64 `json:"rowsRead"`
+
+	// The statistics reported by Flux for the query.
+	CompileDuration time.Duration `json:"compileDuration"`
+	PlanDuration    time.Duration `json:"planDuration"`
+	ExecuteDuration time.Duration `json:"executeDuration"`
+	Concurrency     int64         `json:"concurrency"`
+	MaxAllocated    int64         `json:"maxAllocated"`
 }
 
 // ScheduledForTime gives the time.Time that the run is scheduled for.
@@ -123,6 +154,17 @@ type TaskService interface {
 	// ForceRun forces a run to occur with unix timestamp scheduledFor, to be executed as soon as possible.
 	// The value of scheduledFor may or may not align with the task's schedule.
 	ForceRun(ctx context.Context, taskID ID, scheduledFor int64) (*Run, error)
+
+	// FindTaskRevisions returns the revisions of a task, oldest first.
+	FindTaskRevisions(ctx context.Context, taskID ID) ([]*TaskRevision, error)
+
+	// FindTaskRevision returns a single revision of a task.
+	FindTaskRevision(ctx context.Context, taskID ID, revision int) (*TaskRevision, error)
+
+	// RollbackTask restores the script of a task from one of its revisions.
+	// The task keeps its current authorization.
+	// The restored task is recorded as a new revision.
+	RollbackTask(ctx context.Context, taskID ID, revision int) (*Task, error)
 }
 
 // TaskCreate is the set of values to create a task.
@@ -409,3 +451,135 @@ type LogFilter struct {
 	// The optional Run ID limits logs to a single run.
 	Run *ID
 }
+
+const (
+	BackfillStatusRunning   = "running"
+	BackfillStatusCompleted = "completed"
+	BackfillStatusCanceled  = "canceled"
+	BackfillStatusFailed    = "failed"
+
+	// BackfillDefaultConcurrency is the number of runs a backfill queues at once when no concurrency is given.
+	BackfillDefaultConcurrency = 1
+	// BackfillMaxConcurrency is the most runs a backfill may queue at once.
+	BackfillMaxConcurrency = 32
+)
+
+// Backfill is a request to run a task for every time it was scheduled in a time range,
+// and the progress of those runs.
+type Backfill struct {
+	ID          ID     `json:"id"`
+	TaskID      ID     `json:"taskID"`
+	Start       string `json:"start"`
+	Stop        string `json:"stop"`
+	Concurrency int    `json:"concurrency"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+	RequestedAt string `json:"requestedAt"`
+	FinishedAt  string `json:"finishedAt,omitempty"`
+
+	// Completed and Failed count the finished runs.
+	// Pending counts the runs that are queued, running, or not yet queued.
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+	Pending   int `json:"pending"`
+}
+
+// BackfillCreate is the set of values to create a backfill.
+// Runs are scheduled for every time the task was scheduled in [Start, Stop).
+type BackfillCreate struct {
+	TaskID      ID
+	Start       time.Time
+	Stop        time.Time
+	Concurrency int
+}
+
+func (b BackfillCreate) Validate() error {
+	switch {
+	case !b.TaskID.Valid():
+		return errors.New("missing task ID")
+	case b.Start.IsZero() || b.Stop.IsZero():
+		return errors.New("missing start or stop")
+	case !b.Stop.After(b.Start):
+		return errors.New("stop must be later than start")
+	case b.Concurrency < 0 || b.Concurrency > BackfillMaxConcurrency:
+		return fmt.Errorf("concurrency must be between 1 and %d", BackfillMaxConcurrency)
+	}
+	return nil
+}
+
+// BackfillService represents a service for running tasks over past time ranges.
+type BackfillService interface {
+	// CreateBackfill starts queueing runs of a task for every time it was scheduled in a time range.
+	CreateBackfill(ctx context.Context, b BackfillCreate) (*Backfill, error)
+
+	// FindBackfillByID returns a single backfill of a task.
+	FindBackfillByID(ctx context.Context, taskID, id ID) (*Backfill, error)
+
+	// FindBackfills returns the backfills of a task.
+	FindBackfills(ctx context.Context, taskID ID) ([]*Backfill, error)
+
+	// CancelBackfill stops a backfill from queueing further runs and cancels its running runs.
+	CancelBackfill(ctx context.Context, taskID, id ID) error
+}
+
+// TaskTestMaxRuns is the most scheduled times a task may be tested for at once.
+const TaskTestMaxRuns = 32
+
+// TaskTest is a request to run the script of a task, without writing the data its to() calls would write.
+// The script is either Flux, run in the organization OrganizationID or Organization, or the script of the task TaskID.
+type TaskTest struct {
+	TaskID         ID     `json:"taskID,omitempty"`
+	Flux           string `json:"flux,omitempty"`
+	OrganizationID ID     `json:"orgID,omitempty"`
+	Organization   string `json:"org,omitempty"`
+
+	// Now is the simulated current time, which defaults to the actual current time.
+	Now time.Time `json:"now,omitempty"`
+
+	// ScheduledFor are the times to run the script for, each being the now() of its run.
+	// If empty, the script runs for the latest time the task was scheduled for at Now.
+	ScheduledFor []time.Time `json:"scheduledFor,omitempty"`
+}
+
+func (t TaskTest) Validate() error {
+	switch {
+	case t.TaskID.Valid() == (t.Flux != ""):
+		return errors.New("exactly one of flux or task ID is required")
+	case t.Flux != "" && !t.OrganizationID.Valid():
+		return errors.New("missing organization")
+	case len(t.ScheduledFor) > TaskTestMaxRuns:
+		return fmt.Errorf("at most %d scheduled times may be tested", TaskTestMaxRuns)
+	}
+	for _, s := range t.ScheduledFor {
+		if !t.Now.IsZero() && s.After(t.Now) {
+			return fmt.Errorf("scheduled time %s is later than now", s.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// TaskTestRun is the outcome of running the script of a tested task for one scheduled time.
+type TaskTestRun struct {
+	ScheduledFor string `json:"scheduledFor"`
+
+	// Results are the results of the run, including the data every to() call would have written.
+	Results []TaskTestResult `json:"results"`
+
+	Logs  []Log  `json:"logs"`
+	Error string `json:"error,omitempty"`
+}
+
+// TaskTestResult is a result of the run of a tested task.
+type TaskTestResult struct {
+	// Name is the name of the result. The data of a to() call is in a result named after its operation, like "to2".
+	Name string `json:"name"`
+	// CSV holds the tables of the result, as annotated CSV.
+	CSV string `json:"csv"`
+}
+
+// TaskTestService represents a service for testing task scripts.
+type TaskTestService interface {
+	// TestTask runs the script of a task for each of the scheduled times of t, and returns the outcome of every run.
+	// An error is only returned if the script could not be run; the errors of the runs are part of their outcome.
+	TestTask(ctx context.Context, t TaskTest) ([]*TaskTestRun, error)
+}
@@ -0,0 +1,581 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/task/options"
+	"go.uber.org/zap"
+	cron "gopkg.in/robfig/cron.v2"
+)
+
+const (
+	// DefaultBackfillPollInterval is how often a backfill checks on its queued runs.
+	DefaultBackfillPollInterval = time.Second
+
+	// DefaultBackfillRetryTimeout is how long a backfill keeps retrying after an error,
+	// or waits for the records of its finished runs, before counting them as failed.
+	DefaultBackfillRetryTimeout = 5 * time.Minute
+
+	// maxBackfillRuns is the most runs a single backfill may schedule.
+	maxBackfillRuns = 1000000
+
+	// finishedBackfillRetention is how long a finished backfill can still be found.
+	finishedBackfillRetention = 24 * time.Hour
+)
+
+// ErrBackfillNotFound is returned when searching for a backfill that doesn't exist.
+var ErrBackfillNotFound = &platform.Error{
+	Code: platform.ENotFound,
+	Msg:  "backfill not found",
+}
+
+// BackfillerOption is a option you can use to modify the Backfiller.
+type BackfillerOption func(*Backfiller)
+
+// WithBackfillPollInterval sets how often backfills check on their queued runs.
+func WithBackfillPollInterval(d time.Duration) BackfillerOption {
+	return func(b *Backfiller) {
+		b.pollInterval = d
+	}
+}
+
+// WithBackfillRetryTimeout sets how long a backfill keeps retrying after an error,
+// or waits for the records of its finished runs.
+func WithBackfillRetryTimeout(d time.Duration) BackfillerOption {
+	return func(b *Backfiller) {
+		b.retryTimeout = d
+	}
+}
+
+// Backfiller implements platform.BackfillService.
+//
+// A backfill queues the times the task was scheduled in the backfill's range through the Store's ManuallyRunTimeRange,
+// at most the backfill's concurrency of them at a time, and waits for the queued runs to finish
+// before counting their outcomes from the run records and queueing the next times.
+// Backfills are kept in the Store; Resume continues the running backfills after a restart.
+type Backfiller struct {
+	logger       *zap.Logger
+	ts           platform.TaskService
+	st           Store
+	lr           LogReader
+	as           platform.AuthorizationService
+	idGen        platform.IDGenerator
+	pollInterval time.Duration
+	retryTimeout time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	running map[platform.ID]*runningBackfill
+}
+
+// runningBackfill is a backfill run by this Backfiller.
+type runningBackfill struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewBackfiller returns a Backfiller storing backfills in st.
+// ts checks that the caller may read or write the task, lr reads the records of the queued runs,
+// and as finds the authorization of the task to read them with.
+// Call Resume to continue the backfills that were running when the process stopped, and Stop to stop them.
+func NewBackfiller(logger *zap.Logger, ts platform.TaskService, st Store, lr LogReader, as platform.AuthorizationService, idGen platform.IDGenerator, opts ...BackfillerOption) *Backfiller {
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &Backfiller{
+		logger:       logger,
+		ts:           ts,
+		st:           st,
+		lr:           lr,
+		as:           as,
+		idGen:        idGen,
+		pollInterval: DefaultBackfillPollInterval,
+		retryTimeout: DefaultBackfillRetryTimeout,
+		ctx:          ctx,
+		cancel:       cancel,
+		running:      make(map[platform.ID]*runningBackfill),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Resume continues the stored backfills that are still running.
+func (b *Backfiller) Resume(ctx context.Context) error {
+	bfs, err := b.st.ListBackfills(ctx)
+	if err != nil {
+		return err
+	}
+	for _, bf := range bfs {
+		if bf.Status == platform.BackfillStatusRunning {
+			b.start(bf)
+		}
+	}
+	return nil
+}
+
+// Stop stops all running backfills and waits for them to return.
+// Their state is kept in the Store, and runs that were already queued are left to finish.
+func (b *Backfiller) Stop() {
+	b.cancel()
+	b.wg.Wait()
+}
+
+// CreateBackfill starts a backfill of the task over [c.Start, c.Stop).
+// The authorizer on ctx must be allowed to write the task.
+func (b *Backfiller) CreateBackfill(ctx context.Context, c platform.BackfillCreate) (*platform.Backfill, error) {
+	if err := c.Validate(); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+	if c.Concurrency == 0 {
+		c.Concurrency = platform.BackfillDefaultConcurrency
+	}
+
+	task, _, err := b.authorizeWrite(ctx, c.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	start, stop := c.Start.UTC(), c.Stop.UTC()
+	sch, err := options.ParseSchedule(task.EffectiveCron())
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "task has no valid schedule",
+			Err:  err,
+		}
+	}
+	first, err := firstScheduled(task.EffectiveCron(), sch, start)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "task has no valid schedule",
+			Err:  err,
+		}
+	}
+	total := 0
+	for t := first; t.Before(stop); t = sch.Next(t) {
+		total++
+		if total > maxBackfillRuns {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("backfill would schedule more than %d runs", maxBackfillRuns),
+			}
+		}
+	}
+
+	bf := StoreBackfill{
+		Backfill: platform.Backfill{
+			ID:          b.idGen.ID(),
+			TaskID:      c.TaskID,
+			Start:       start.Format(time.RFC3339),
+			Stop:        stop.Format(time.RFC3339),
+			Concurrency: c.Concurrency,
+			Status:      platform.BackfillStatusRunning,
+			RequestedAt: time.Now().UTC().Format(time.RFC3339),
+			Pending:     total,
+		},
+		Cron:  task.EffectiveCron(),
+		Total: total,
+		Next:  first.Unix(),
+	}
+	if err := b.st.PutBackfill(ctx, bf); err != nil {
+		return nil, err
+	}
+
+	// The runs are queued after the request returns.
+	b.start(bf)
+
+	created := bf.Backfill
+	return &created, nil
+}
+
+// FindBackfillByID returns the backfill with the given ID, if the authorizer on ctx can read the task.
+func (b *Backfiller) FindBackfillByID(ctx context.Context, taskID, id platform.ID) (*platform.Backfill, error) {
+	if _, err := b.ts.FindTaskByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	bf, err := b.findBackfill(ctx, taskID, id)
+	if err != nil {
+		return nil, err
+	}
+	return &bf.Backfill, nil
+}
+
+// FindBackfills returns the backfills of the task, oldest first, if the authorizer on ctx can read the task.
+func (b *Backfiller) FindBackfills(ctx context.Context, taskID platform.ID) ([]*platform.Backfill, error) {
+	if _, err := b.ts.FindTaskByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	stored, err := b.findBackfills(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	bfs := make([]*platform.Backfill, 0, len(stored))
+	for i := range stored {
+		bfs = append(bfs, &stored[i].Backfill)
+	}
+	return bfs, nil
+}
+
+// CancelBackfill stops the backfill from queueing more runs and cancels its queued runs that have started.
+// Runs still waiting in the task's manual queue are left to run.
+func (b *Backfiller) CancelBackfill(ctx context.Context, taskID, id platform.ID) error {
+	if _, _, err := b.authorizeWrite(ctx, taskID); err != nil {
+		return err
+	}
+
+	// Stop this process from running the backfill, so that it doesn't overwrite the canceled backfill.
+	b.mu.Lock()
+	r, ok := b.running[id]
+	b.mu.Unlock()
+	if ok {
+		r.cancel()
+		<-r.done
+	}
+
+	bf, err := b.findBackfill(ctx, taskID, id)
+	if err != nil {
+		return err
+	}
+	if bf.Status != platform.BackfillStatusRunning {
+		return &platform.Error{
+			Code: platform.EConflict,
+			Msg:  fmt.Sprintf("backfill is already %s", bf.Status),
+		}
+	}
+
+	if bf.QueuedAt != 0 {
+		meta, err := b.st.FindTaskMetaByID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		for _, cr := range meta.CurrentlyRunning {
+			if !bf.queued(cr.RangeStart, cr.RangeEnd, cr.RequestedAt) {
+				continue
+			}
+			runID := platform.ID(cr.RunID)
+			if err := b.ts.CancelRun(ctx, taskID, runID); err != nil {
+				b.logger.Debug("Did not cancel backfill run", zap.String("task_id", taskID.String()), zap.String("backfill_id", id.String()),
+					zap.String("run_id", runID.String()), zap.Error(err))
+			}
+		}
+	}
+
+	bf.finish(platform.BackfillStatusCanceled, nil)
+	return b.st.PutBackfill(ctx, bf)
+}
+
+// authorizeWrite returns the task and the authorizer on ctx, if the authorizer is allowed to write the task.
+func (b *Backfiller) authorizeWrite(ctx context.Context, taskID platform.ID) (*platform.Task, platform.Authorizer, error) {
+	auth, err := icontext.GetAuthorizer(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	task, err := b.ts.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	perm, err := platform.NewPermissionAtID(taskID, platform.WriteAction, platform.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !auth.Allowed(*perm) {
+		return nil, nil, &platform.Error{
+			Code: platform.EUnauthorized,
+			Msg:  fmt.Sprintf("permission failed for auth (%s): %s", auth.Identifier(), perm),
+		}
+	}
+	return task, auth, nil
+}
+
+// findBackfill returns the stored backfill of the task with the given ID.
+func (b *Backfiller) findBackfill(ctx context.Context, taskID, id platform.ID) (StoreBackfill, error) {
+	bfs, err := b.findBackfills(ctx, taskID)
+	if err != nil {
+		return StoreBackfill{}, err
+	}
+	for _, bf := range bfs {
+		if bf.ID == id {
+			return bf, nil
+		}
+	}
+	return StoreBackfill{}, ErrBackfillNotFound
+}
+
+// findBackfills returns the stored backfills of the task,
+// deleting the backfills that finished longer than finishedBackfillRetention ago.
+func (b *Backfiller) findBackfills(ctx context.Context, taskID platform.ID) ([]StoreBackfill, error) {
+	bfs, err := b.st.FindBackfills(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	kept := bfs[:0]
+	for _, bf := range bfs {
+		if finished, err := time.Parse(time.RFC3339, bf.FinishedAt); err == nil && now.Sub(finished) > finishedBackfillRetention {
+			if err := b.st.DeleteBackfill(ctx, taskID, bf.ID); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		kept = append(kept, bf)
+	}
+	return kept, nil
+}
+
+// start runs bf in the background, unless it is already running.
+func (b *Backfiller) start(bf StoreBackfill) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.running[bf.ID]; ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(b.ctx)
+	r := &runningBackfill{cancel: cancel, done: make(chan struct{})}
+	b.running[bf.ID] = r
+	b.wg.Add(1)
+
+	go func() {
+		defer b.wg.Done()
+		defer close(r.done)
+		defer func() {
+			b.mu.Lock()
+			delete(b.running, bf.ID)
+			b.mu.Unlock()
+			cancel()
+		}()
+
+		b.run(ctx, bf)
+	}()
+}
+
+// run queues the runs of bf and follows them until the backfill finishes or ctx is canceled.
+func (b *Backfiller) run(ctx context.Context, bf StoreBackfill) {
+	logger := b.logger.With(zap.String("task_id", bf.TaskID.String()), zap.String("backfill_id", bf.ID.String()))
+
+	ticker := time.NewTicker(b.pollInterval)
+	defer ticker.Stop()
+
+	// failingSince is when the errors that are still occurring started.
+	// settlingSince is when the queued runs finished, while their records are incomplete.
+	var failingSince, settlingSince time.Time
+	for {
+		next, err := b.step(ctx, bf, &settlingSince)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err == ErrTaskNotFound:
+			b.fail(ctx, bf, err, logger)
+			return
+		case err != nil:
+			if failingSince.IsZero() {
+				failingSince = time.Now()
+			}
+			if time.Since(failingSince) > b.retryTimeout {
+				b.fail(ctx, bf, err, logger)
+				return
+			}
+			logger.Info("Error running backfill; will retry", zap.Error(err))
+		default:
+			failingSince = time.Time{}
+			bf = next
+			if bf.Status != platform.BackfillStatusRunning {
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// step advances bf by counting the outcomes of its queued runs once they are done, or queueing its next runs.
+// It returns the stored backfill. If it returns an error, bf was not changed.
+func (b *Backfiller) step(ctx context.Context, bf StoreBackfill, settlingSince *time.Time) (StoreBackfill, error) {
+	task, meta, err := b.st.FindTaskByIDWithMeta(ctx, bf.TaskID)
+	if err != nil {
+		return bf, err
+	}
+	if meta.Status != string(TaskActive) {
+		// The runs of an inactive task are never created.
+		bf.finish(platform.BackfillStatusFailed, errors.New("task is inactive"))
+		return bf, b.st.PutBackfill(ctx, bf)
+	}
+
+	if bf.QueuedAt != 0 {
+		for _, mr := range meta.ManualRuns {
+			if bf.queued(mr.Start, mr.End, mr.RequestedAt) {
+				return bf, nil
+			}
+		}
+		for _, cr := range meta.CurrentlyRunning {
+			if bf.queued(cr.RangeStart, cr.RangeEnd, cr.RequestedAt) {
+				return bf, nil
+			}
+		}
+
+		completed, failed, err := b.countRuns(ctx, task, meta, bf)
+		if err != nil {
+			return bf, err
+		}
+		if completed+failed < bf.QueuedRuns {
+			// The records of the finished runs may not be written yet.
+			if settlingSince.IsZero() {
+				*settlingSince = time.Now()
+			}
+			if time.Since(*settlingSince) <= b.retryTimeout {
+				return bf, nil
+			}
+			failed = bf.QueuedRuns - completed
+		}
+		*settlingSince = time.Time{}
+
+		bf.Completed += completed
+		bf.Failed += failed
+		bf.Pending = bf.Total - bf.Completed - bf.Failed
+		bf.QueuedStart, bf.QueuedEnd, bf.QueuedAt, bf.QueuedRuns = 0, 0, 0, 0
+	}
+
+	stop, err := time.Parse(time.RFC3339, bf.Stop)
+	if err != nil {
+		return bf, err
+	}
+	next := time.Unix(bf.Next, 0).UTC()
+	if !next.Before(stop) {
+		bf.finish(platform.BackfillStatusCompleted, nil)
+		return bf, b.st.PutBackfill(ctx, bf)
+	}
+
+	sch, err := options.ParseSchedule(bf.Cron)
+	if err != nil {
+		return bf, err
+	}
+	last, n := next, 0
+	for t := next; t.Before(stop) && n < bf.Concurrency; t = sch.Next(t) {
+		last = t
+		n++
+	}
+
+	start, requestedAt := rangeStart(sch, next), time.Now().Unix()
+	if _, err := b.st.ManuallyRunTimeRange(ctx, bf.TaskID, start, last.Unix(), requestedAt); err != nil {
+		if _, ok := err.(RequestStillQueuedError); ok || err == ErrManualQueueFull {
+			// Other manual runs are queued; try again on the next tick.
+			return bf, nil
+		}
+		return bf, err
+	}
+
+	bf.QueuedStart, bf.QueuedEnd, bf.QueuedAt, bf.QueuedRuns = start, last.Unix(), requestedAt, n
+	bf.Next = sch.Next(last).Unix()
+	return bf, b.st.PutBackfill(ctx, bf)
+}
+
+// countRuns returns the number of the queued runs of bf that have succeeded, and that have failed or were canceled,
+// as recorded by the LogReader, which is read as the task's authorization.
+func (b *Backfiller) countRuns(ctx context.Context, task *StoreTask, meta *StoreTaskMeta, bf StoreBackfill) (completed, failed int, err error) {
+	auth, err := b.as.FindAuthorizationByID(ctx, platform.ID(meta.AuthorizationID))
+	if err != nil {
+		return 0, 0, err
+	}
+	runs, err := b.lr.ListRuns(icontext.SetAuthorizer(ctx, auth), task.Org, platform.RunFilter{
+		Task:       bf.TaskID,
+		AfterTime:  time.Unix(bf.QueuedStart-1, 0).UTC().Format(time.RFC3339),
+		BeforeTime: time.Unix(bf.QueuedEnd+1, 0).UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	requestedAt := time.Unix(bf.QueuedAt, 0).UTC().Format(time.RFC3339)
+	for _, r := range runs {
+		if r.RequestedAt != requestedAt {
+			continue
+		}
+		switch r.Status {
+		case RunSuccess.String():
+			completed++
+		case RunFail.String(), RunCanceled.String():
+			failed++
+		}
+	}
+	return completed, failed, nil
+}
+
+// fail stores bf as failed with err.
+func (b *Backfiller) fail(ctx context.Context, bf StoreBackfill, err error, logger *zap.Logger) {
+	logger.Info("Backfill failed", zap.Error(err))
+	bf.finish(platform.BackfillStatusFailed, err)
+	if err := b.st.PutBackfill(ctx, bf); err != nil {
+		logger.Info("Failed to store failed backfill", zap.Error(err))
+	}
+}
+
+// queued reports whether the manual run of the given range and request time was queued by bf.
+func (bf *StoreBackfill) queued(start, end, requestedAt int64) bool {
+	return bf.QueuedAt != 0 && start == bf.QueuedStart && end == bf.QueuedEnd && requestedAt == bf.QueuedAt
+}
+
+// finish sets the status of bf, and err as its error if err is not nil.
+func (bf *StoreBackfill) finish(status string, err error) {
+	bf.Status = status
+	bf.FinishedAt = time.Now().UTC().Format(time.RFC3339)
+	if err != nil {
+		bf.Error = err.Error()
+	}
+	bf.QueuedStart, bf.QueuedEnd, bf.QueuedAt, bf.QueuedRuns = 0, 0, 0, 0
+}
+
+// rangeStart returns the start of a range passed to ManuallyRunTimeRange, so that its first run is scheduled for first.
+// The first run of a range is the schedule's next time after the start, less a second.
+func rangeStart(sch cron.Schedule, first time.Time) int64 {
+	if every, ok := sch.(cron.ConstantDelaySchedule); ok {
+		return first.Add(-every.Delay).Unix() + 1
+	}
+	return first.Unix()
+}
+
+// firstScheduled returns the first time the schedule is due at or after t.
+// Like AlignLatestCompleted, every schedules are aligned to multiples of their duration.
+func firstScheduled(effectiveCron string, sch cron.Schedule, t time.Time) (time.Time, error) {
+	if strings.HasPrefix(effectiveCron, "@every ") {
+		every := options.Duration{}
+		if err := every.Parse(strings.TrimPrefix(effectiveCron, "@every ")); err != nil {
+			return time.Time{}, err
+		}
+		everyDur, err := every.DurationFrom(t)
+		if err != nil {
+			return time.Time{}, err
+		}
+		first := t.Truncate(everyDur)
+		if first.Before(t) {
+			first = first.Add(everyDur)
+		}
+		return first, nil
+	}
+
+	first := sch.Next(t.Add(-time.Second))
+	for first.Before(t) {
+		first = sch.Next(first)
+	}
+	return first, nil
+}
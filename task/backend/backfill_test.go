@@ -0,0 +1,391 @@
+package backend_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	pmock "github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/task/backend"
+	"go.uber.org/zap/zaptest"
+)
+
+// backfillHarness is an in-memory store with an hourly task, whose queued runs a simulated scheduler creates.
+type backfillHarness struct {
+	st  backend.Store
+	lrw interface {
+		backend.LogReader
+		backend.LogWriter
+	}
+	ts     *pmock.TaskService
+	as     *pmock.AuthorizationService
+	task   *backend.StoreTask
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu        sync.Mutex
+	scheduled []int64
+	canceled  []platform.ID
+}
+
+func newBackfillHarness(t *testing.T) *backfillHarness {
+	t.Helper()
+
+	st := backend.NewInMemStore()
+	taskID, err := st.CreateTask(context.Background(), backend.CreateTaskRequest{
+		Org:             2,
+		AuthorizationID: 3,
+		ScheduleAfter:   time.Now().Unix(),
+		Script: `option task = {name: "backfilled", every: 1h, concurrency: 5}
+from(bucket:"b") |> range(start:-1h)`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := st.FindTaskByID(context.Background(), taskID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := &backfillHarness{
+		st:     st,
+		lrw:    backend.NewInMemRunReaderWriter(),
+		ts:     &pmock.TaskService{},
+		as:     pmock.NewAuthorizationService(),
+		task:   task,
+		cancel: func() {},
+	}
+	h.ts.FindTaskByIDFn = func(_ context.Context, id platform.ID) (*platform.Task, error) {
+		if id != taskID {
+			return nil, backend.ErrTaskNotFound
+		}
+		return &platform.Task{ID: id, OrganizationID: 2, Every: "1h"}, nil
+	}
+	h.ts.CancelRunFn = func(_ context.Context, _, runID platform.ID) error {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.canceled = append(h.canceled, runID)
+		return nil
+	}
+	h.as.FindAuthorizationByIDFn = func(_ context.Context, id platform.ID) (*platform.Authorization, error) {
+		if id != 3 {
+			return nil, &platform.Error{Code: platform.ENotFound, Msg: "authorization not found"}
+		}
+		return &platform.Authorization{ID: id, OrgID: 2, Status: platform.Active}, nil
+	}
+	return h
+}
+
+// schedule creates the queued runs of the task until Stop is called, finishing them with status.
+// Runs are left running if status is RunStarted, and their records are not written if record is false.
+func (h *backfillHarness) schedule(status backend.RunStatus, record bool) {
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		for ctx.Err() == nil {
+			rc, err := h.st.CreateNextRun(ctx, h.task.ID, time.Now().Unix())
+			if err != nil {
+				time.Sleep(time.Millisecond)
+				continue
+			}
+			run := rc.Created
+			if run.RequestedAt != 0 {
+				h.mu.Lock()
+				h.scheduled = append(h.scheduled, run.Now)
+				h.mu.Unlock()
+			}
+
+			rlb := backend.RunLogBase{Task: h.task, RunID: run.RunID, RunScheduledFor: run.Now, RequestedAt: run.RequestedAt}
+			if record {
+				h.lrw.UpdateRunState(ctx, rlb, time.Now(), backend.RunStarted)
+			}
+			if status == backend.RunStarted {
+				continue
+			}
+			if record {
+				h.lrw.UpdateRunState(ctx, rlb, time.Now(), status)
+			}
+			h.st.FinishRun(ctx, h.task.ID, run.RunID)
+		}
+	}()
+}
+
+// Stop stops the simulated scheduler.
+func (h *backfillHarness) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+func (h *backfillHarness) backfiller(t *testing.T, opts ...backend.BackfillerOption) *backend.Backfiller {
+	opts = append([]backend.BackfillerOption{backend.WithBackfillPollInterval(5 * time.Millisecond)}, opts...)
+	return backend.NewBackfiller(zaptest.NewLogger(t), h.ts, h.st, h.lrw, h.as, pmock.NewIDGenerator("0000000000000001", t), opts...)
+}
+
+func (h *backfillHarness) scheduledFor() []int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]int64(nil), h.scheduled...)
+}
+
+func backfillContext(actions ...platform.Action) context.Context {
+	auth := &platform.Authorization{Status: platform.Active}
+	for _, a := range actions {
+		p, _ := platform.NewPermission(a, platform.TasksResourceType, 2)
+		auth.Permissions = append(auth.Permissions, *p)
+	}
+	return icontext.SetAuthorizer(context.Background(), auth)
+}
+
+func waitForBackfill(t *testing.T, b *backend.Backfiller, taskID, id platform.ID, status string) *platform.Backfill {
+	t.Helper()
+
+	ctx := backfillContext(platform.ReadAction)
+	for i := 0; i < 500; i++ {
+		bf, err := b.FindBackfillByID(ctx, taskID, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bf.Status == status {
+			return bf
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("backfill never became %s", status)
+	return nil
+}
+
+func TestBackfiller_Create(t *testing.T) {
+	for _, tc := range []struct {
+		name              string
+		status            backend.RunStatus
+		completed, failed int
+	}{
+		{name: "succeeded", status: backend.RunSuccess, completed: 5},
+		{name: "failed", status: backend.RunFail, failed: 5},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := newBackfillHarness(t)
+			h.schedule(tc.status, true)
+			defer h.Stop()
+			b := h.backfiller(t)
+			defer b.Stop()
+
+			start := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+			bf, err := b.CreateBackfill(backfillContext(platform.ReadAction, platform.WriteAction), platform.BackfillCreate{
+				TaskID:      h.task.ID,
+				Start:       start,
+				Stop:        start.Add(5 * time.Hour),
+				Concurrency: 2,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bf.Pending != 5 || bf.Status != platform.BackfillStatusRunning {
+				t.Fatalf("unexpected created backfill: %+v", bf)
+			}
+
+			bf = waitForBackfill(t, b, h.task.ID, bf.ID, platform.BackfillStatusCompleted)
+			if bf.Completed != tc.completed || bf.Failed != tc.failed || bf.Pending != 0 {
+				t.Fatalf("unexpected finished backfill: %+v", bf)
+			}
+
+			scheduled := h.scheduledFor()
+			if len(scheduled) != 5 {
+				t.Fatalf("expected 5 runs, got %d", len(scheduled))
+			}
+			for i, f := range scheduled {
+				if exp := start.Add(time.Duration(i) * time.Hour).Unix(); f != exp {
+					t.Fatalf("run %d: expected scheduled for %d, got %d", i, exp, f)
+				}
+			}
+		})
+	}
+}
+
+func TestBackfiller_Cancel(t *testing.T) {
+	h := newBackfillHarness(t)
+	h.schedule(backend.RunStarted, true)
+	defer h.Stop()
+	b := h.backfiller(t)
+	defer b.Stop()
+
+	ctx := backfillContext(platform.ReadAction, platform.WriteAction)
+	start := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	bf, err := b.CreateBackfill(ctx, platform.BackfillCreate{
+		TaskID:      h.task.ID,
+		Start:       start,
+		Stop:        start.Add(24 * time.Hour),
+		Concurrency: 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Let the first runs start, none of which ever finish.
+	for i := 0; len(h.scheduledFor()) < 3; i++ {
+		if i == 500 {
+			t.Fatal("backfill runs never started")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := b.CancelBackfill(ctx, h.task.ID, bf.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	bf = waitForBackfill(t, b, h.task.ID, bf.ID, platform.BackfillStatusCanceled)
+	if bf.Completed != 0 || bf.Pending != 24 {
+		t.Fatalf("unexpected canceled backfill: %+v", bf)
+	}
+
+	h.mu.Lock()
+	if len(h.scheduled) != 3 {
+		t.Fatalf("expected the concurrency of 3 runs, got %d", len(h.scheduled))
+	}
+	if len(h.canceled) != 3 {
+		t.Fatalf("expected 3 canceled runs, got %d", len(h.canceled))
+	}
+	h.mu.Unlock()
+
+	if err := b.CancelBackfill(ctx, h.task.ID, bf.ID); platform.ErrorCode(err) != platform.EConflict {
+		t.Fatalf("expected conflict canceling a canceled backfill, got %v", err)
+	}
+}
+
+func TestBackfiller_Resume(t *testing.T) {
+	h := newBackfillHarness(t)
+
+	// Nothing creates the queued runs until the first Backfiller has stopped.
+	b := h.backfiller(t)
+	start := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	bf, err := b.CreateBackfill(backfillContext(platform.ReadAction, platform.WriteAction), platform.BackfillCreate{
+		TaskID:      h.task.ID,
+		Start:       start,
+		Stop:        start.Add(6 * time.Hour),
+		Concurrency: 4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		if i == 500 {
+			t.Fatal("backfill runs were never queued")
+		}
+		meta, err := h.st.FindTaskMetaByID(context.Background(), h.task.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(meta.ManualRuns) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	b.Stop()
+
+	h.schedule(backend.RunSuccess, true)
+	defer h.Stop()
+	b = h.backfiller(t)
+	defer b.Stop()
+	if err := b.Resume(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	bf = waitForBackfill(t, b, h.task.ID, bf.ID, platform.BackfillStatusCompleted)
+	if bf.Completed != 6 || bf.Failed != 0 || bf.Pending != 0 {
+		t.Fatalf("unexpected resumed backfill: %+v", bf)
+	}
+	if scheduled := h.scheduledFor(); len(scheduled) != 6 {
+		t.Fatalf("expected every run to be scheduled once, got %v", scheduled)
+	}
+}
+
+func TestBackfiller_MissingRecords(t *testing.T) {
+	h := newBackfillHarness(t)
+	h.schedule(backend.RunSuccess, false)
+	defer h.Stop()
+	b := h.backfiller(t, backend.WithBackfillRetryTimeout(20*time.Millisecond))
+	defer b.Stop()
+
+	start := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	bf, err := b.CreateBackfill(backfillContext(platform.ReadAction, platform.WriteAction), platform.BackfillCreate{
+		TaskID: h.task.ID,
+		Start:  start,
+		Stop:   start.Add(3 * time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Runs that finished without a record are counted as failed.
+	bf = waitForBackfill(t, b, h.task.ID, bf.ID, platform.BackfillStatusCompleted)
+	if bf.Completed != 0 || bf.Failed != 3 || bf.Pending != 0 {
+		t.Fatalf("unexpected finished backfill: %+v", bf)
+	}
+}
+
+func TestBackfiller_InactiveTask(t *testing.T) {
+	h := newBackfillHarness(t)
+	if _, err := h.st.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: h.task.ID, Status: backend.TaskInactive}); err != nil {
+		t.Fatal(err)
+	}
+	b := h.backfiller(t)
+	defer b.Stop()
+
+	start := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	bf, err := b.CreateBackfill(backfillContext(platform.ReadAction, platform.WriteAction), platform.BackfillCreate{
+		TaskID: h.task.ID,
+		Start:  start,
+		Stop:   start.Add(3 * time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bf = waitForBackfill(t, b, h.task.ID, bf.ID, platform.BackfillStatusFailed)
+	if bf.Error != "task is inactive" || bf.Pending != 3 {
+		t.Fatalf("unexpected failed backfill: %+v", bf)
+	}
+}
+
+func TestBackfiller_Invalid(t *testing.T) {
+	h := newBackfillHarness(t)
+	b := h.backfiller(t)
+	defer b.Stop()
+
+	start := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		c    platform.BackfillCreate
+		code string
+	}{
+		{
+			name: "stop before start",
+			ctx:  backfillContext(platform.ReadAction, platform.WriteAction),
+			c:    platform.BackfillCreate{TaskID: h.task.ID, Start: start, Stop: start.Add(-time.Hour)},
+			code: platform.EInvalid,
+		},
+		{
+			name: "concurrency too high",
+			ctx:  backfillContext(platform.ReadAction, platform.WriteAction),
+			c:    platform.BackfillCreate{TaskID: h.task.ID, Start: start, Stop: start.Add(time.Hour), Concurrency: platform.BackfillMaxConcurrency + 1},
+			code: platform.EInvalid,
+		},
+		{
+			name: "read only",
+			ctx:  backfillContext(platform.ReadAction),
+			c:    platform.BackfillCreate{TaskID: h.task.ID, Start: start, Stop: start.Add(time.Hour)},
+			code: platform.EUnauthorized,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := b.CreateBackfill(tc.ctx, tc.c); platform.ErrorCode(err) != tc.code {
+				t.Fatalf("expected error code %q, got %v", tc.code, err)
+			}
+		})
+	}
+}
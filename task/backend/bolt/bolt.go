@@ -9,6 +9,11 @@
 //    bucket(/tasks/v1/name_by_task_id) key(:task_id) -> The user-supplied name of the script.
 //    bucket(/tasks/v1/run_ids) -> Counter for run IDs
 //    bucket(/tasks/v1/orgs).bucket(:org_id) key(:task_id) -> Empty content; presence of :task_id allows for lookup from org to tasks.
+//    bucket(/tasks/v1/task_revisions).bucket(:task_id) key(:revision) -> JSON encoded backend.StoreTaskRevision,
+//                                    keyed by the revision number as a big-endian uint64.
+//    bucket(/tasks/v1/task_backfills).bucket(:task_id) key(:backfill_id) -> JSON encoded backend.StoreBackfill.
+//    bucket(/tasks/v1/task_upstream_successes).bucket(:task_id) key(:now) -> JSON encoded IDs of the upstream tasks
+//                                    that succeeded for a run time, keyed by the time as a big-endian uint64.
 // Note that task IDs are stored big-endian uint64s for sorting purposes,
 // but presented to the users with leading 0-bytes stripped.
 // Like other components of the system, IDs presented to users may be `0f12` rather than `f12`.
@@ -16,6 +21,8 @@ package bolt
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -54,12 +61,15 @@ type Store struct {
 const basePath = "/tasks/v1/"
 
 var (
-	tasksPath    = []byte(basePath + "tasks")
-	orgsPath     = []byte(basePath + "orgs")
-	taskMetaPath = []byte(basePath + "task_meta")
-	orgByTaskID  = []byte(basePath + "org_by_task_id")
-	nameByTaskID = []byte(basePath + "name_by_task_id")
-	runIDs       = []byte(basePath + "run_ids")
+	tasksPath     = []byte(basePath + "tasks")
+	orgsPath      = []byte(basePath + "orgs")
+	taskMetaPath  = []byte(basePath + "task_meta")
+	orgByTaskID   = []byte(basePath + "org_by_task_id")
+	nameByTaskID  = []byte(basePath + "name_by_task_id")
+	runIDs        = []byte(basePath + "run_ids")
+	revisionsPath = []byte(basePath + "task_revisions")
+	backfillsPath = []byte(basePath + "task_backfills")
+	upstreamPath  = []byte(basePath + "task_upstream_successes")
 )
 
 // Option is a optional configuration for the store.
@@ -85,6 +95,7 @@ func New(db *bolt.DB, rootBucket string, opts ...Option) (*Store, error) {
 		for _, b := range [][]byte{
 			tasksPath, orgsPath, taskMetaPath,
 			orgByTaskID, nameByTaskID, runIDs,
+			revisionsPath, backfillsPath, upstreamPath,
 		} {
 			_, err := root.CreateBucketIfNotExists(b)
 			if err != nil {
@@ -161,7 +172,17 @@ func (s *Store) CreateTask(ctx context.Context, req backend.CreateTaskRequest) (
 			return err
 		}
 		metaB := b.Bucket(taskMetaPath)
-		return metaB.Put(encodedID, stmBytes)
+		if err := metaB.Put(encodedID, stmBytes); err != nil {
+			return err
+		}
+
+		return putRevision(b, encodedID, backend.StoreTaskRevision{
+			Revision:        1,
+			Script:          req.Script,
+			AuthorizationID: req.AuthorizationID,
+			Author:          req.Author,
+			CreatedAt:       stm.CreatedAt,
+		})
 	})
 
 	if err != nil {
@@ -238,6 +259,7 @@ func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (
 		}
 		stm.UpdatedAt = time.Now().Unix()
 		res.OldStatus = backend.TaskStatus(stm.Status)
+		oldAuthz := platform.ID(stm.AuthorizationID)
 
 		if req.Status != "" {
 			stm.Status = string(req.Status)
@@ -254,11 +276,33 @@ func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (
 		}
 		res.NewMeta = stm
 
+		revision := latestRevision(b, encodedID)
+		if newScript != res.OldScript || platform.ID(stm.AuthorizationID) != oldAuthz {
+			if revision == 0 {
+				// The task was stored before revisions were recorded; keep what it was before this update.
+				revision++
+				if err := putRevision(b, encodedID, backend.StoreTaskRevision{Revision: revision, Script: res.OldScript, AuthorizationID: oldAuthz}); err != nil {
+					return err
+				}
+			}
+			revision++
+			if err := putRevision(b, encodedID, backend.StoreTaskRevision{
+				Revision:        revision,
+				Script:          newScript,
+				AuthorizationID: platform.ID(stm.AuthorizationID),
+				Author:          req.Author,
+				CreatedAt:       stm.UpdatedAt,
+			}); err != nil {
+				return err
+			}
+		}
+
 		res.NewTask = backend.StoreTask{
-			ID:     req.ID,
-			Org:    orgID,
-			Name:   op.Name,
-			Script: newScript,
+			ID:       req.ID,
+			Org:      orgID,
+			Name:     op.Name,
+			Script:   newScript,
+			Revision: revision,
 		}
 
 		return nil
@@ -335,6 +379,7 @@ func (s *Store) ListTasks(ctx context.Context, params backend.TaskSearchParams)
 				tasks[i].Task.ID = taskIDs[i]
 				tasks[i].Task.Script = string(b.Bucket(tasksPath).Get(encodedID))
 				tasks[i].Task.Name = string(b.Bucket(nameByTaskID).Get(encodedID))
+				tasks[i].Task.Revision = latestRevision(b, encodedID)
 			}
 		}
 		if params.Org.Valid() {
@@ -404,6 +449,7 @@ func (s *Store) ListTasks(ctx context.Context, params backend.TaskSearchParams)
 func (s *Store) FindTaskByID(ctx context.Context, id platform.ID) (*backend.StoreTask, error) {
 	var orgID platform.ID
 	var script, name string
+	var revision int
 	encodedID, err := id.Encode()
 	if err != nil {
 		return nil, err
@@ -421,6 +467,7 @@ func (s *Store) FindTaskByID(ctx context.Context, id platform.ID) (*backend.Stor
 		}
 
 		name = string(b.Bucket(nameByTaskID).Get(encodedID))
+		revision = latestRevision(b, encodedID)
 		return nil
 	})
 	if err != nil {
@@ -428,10 +475,11 @@ func (s *Store) FindTaskByID(ctx context.Context, id platform.ID) (*backend.Stor
 	}
 
 	return &backend.StoreTask{
-		ID:     id,
-		Org:    orgID,
-		Name:   name,
-		Script: script,
+		ID:       id,
+		Org:      orgID,
+		Name:     name,
+		Script:   script,
+		Revision: revision,
 	}, err
 }
 
@@ -465,6 +513,7 @@ func (s *Store) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*back
 	var stmBytes []byte
 	var orgID platform.ID
 	var script, name string
+	var revision int
 	encodedID, err := id.Encode()
 	if err != nil {
 		return nil, nil, err
@@ -485,6 +534,7 @@ func (s *Store) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*back
 		}
 
 		name = string(b.Bucket(nameByTaskID).Get(encodedID))
+		revision = latestRevision(b, encodedID)
 		return nil
 	})
 	if err != nil {
@@ -502,10 +552,11 @@ func (s *Store) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*back
 	}
 
 	return &backend.StoreTask{
-		ID:     id,
-		Org:    orgID,
-		Name:   name,
-		Script: script,
+		ID:       id,
+		Org:      orgID,
+		Name:     name,
+		Script:   script,
+		Revision: revision,
 	}, &stm, nil
 }
 
@@ -529,6 +580,15 @@ func (s *Store) DeleteTask(ctx context.Context, id platform.ID) (deleted bool, e
 		if err := b.Bucket(nameByTaskID).Delete(encodedID); err != nil {
 			return err
 		}
+		if err := deleteRevisions(b, encodedID); err != nil {
+			return err
+		}
+		if err := deleteBackfills(b, encodedID); err != nil {
+			return err
+		}
+		if err := deleteUpstreamSuccesses(b, encodedID); err != nil {
+			return err
+		}
 
 		org := b.Bucket(orgByTaskID).Get(encodedID)
 		if len(org) > 0 {
@@ -547,6 +607,220 @@ func (s *Store) DeleteTask(ctx context.Context, id platform.ID) (deleted bool, e
 	return true, nil
 }
 
+// FindTaskRevisions returns the revisions of the task with the given ID, oldest first.
+func (s *Store) FindTaskRevisions(ctx context.Context, id platform.ID) ([]backend.StoreTaskRevision, error) {
+	encodedID, err := id.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var revs []backend.StoreTaskRevision
+	err = s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(tasksPath).Get(encodedID) == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		rb := b.Bucket(revisionsPath).Bucket(encodedID)
+		if rb == nil {
+			return nil
+		}
+		return rb.ForEach(func(_, v []byte) error {
+			var rev backend.StoreTaskRevision
+			if err := json.Unmarshal(v, &rev); err != nil {
+				return err
+			}
+			revs = append(revs, rev)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
+
+// AddUpstreamSuccess records that an upstream task succeeded for a run time of a task,
+// and returns the upstream tasks that succeeded for that time.
+func (s *Store) AddUpstreamSuccess(ctx context.Context, taskID, upstreamID platform.ID, now int64) ([]platform.ID, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []platform.ID
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(tasksPath).Get(encodedID) == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		ub, err := b.Bucket(upstreamPath).CreateBucketIfNotExists(encodedID)
+		if err != nil {
+			return err
+		}
+		k := upstreamKey(now)
+		if v := ub.Get(k); v != nil {
+			if err := json.Unmarshal(v, &ids); err != nil {
+				return err
+			}
+		}
+		for _, id := range ids {
+			if id == upstreamID {
+				return nil
+			}
+		}
+		ids = append(ids, upstreamID)
+		v, err := json.Marshal(ids)
+		if err != nil {
+			return err
+		}
+		if err := ub.Put(k, v); err != nil {
+			return err
+		}
+
+		// Forget the oldest run time, if there are too many.
+		n := 0
+		c := ub.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			n++
+		}
+		if n > backend.MaxPendingUpstreamRuns {
+			oldest, _ := c.First()
+			return ub.Delete(oldest)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// DeleteUpstreamSuccesses forgets the upstream tasks that succeeded for a run time of a task.
+func (s *Store) DeleteUpstreamSuccesses(ctx context.Context, taskID platform.ID, now int64) error {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		ub := tx.Bucket(s.bucket).Bucket(upstreamPath).Bucket(encodedID)
+		if ub == nil {
+			return nil
+		}
+		return ub.Delete(upstreamKey(now))
+	})
+}
+
+// PutBackfill creates or replaces a backfill of a task.
+func (s *Store) PutBackfill(ctx context.Context, bf backend.StoreBackfill) error {
+	encodedID, err := bf.TaskID.Encode()
+	if err != nil {
+		return err
+	}
+	encodedBackfillID, err := bf.ID.Encode()
+	if err != nil {
+		return err
+	}
+	v, err := json.Marshal(bf)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(tasksPath).Get(encodedID) == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		bb, err := b.Bucket(backfillsPath).CreateBucketIfNotExists(encodedID)
+		if err != nil {
+			return err
+		}
+		return bb.Put(encodedBackfillID, v)
+	})
+}
+
+// FindBackfills returns the backfills of a task, ordered by ID.
+func (s *Store) FindBackfills(ctx context.Context, taskID platform.ID) ([]backend.StoreBackfill, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var bfs []backend.StoreBackfill
+	err = s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(tasksPath).Get(encodedID) == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		bb := b.Bucket(backfillsPath).Bucket(encodedID)
+		if bb == nil {
+			return nil
+		}
+		return bb.ForEach(func(_, v []byte) error {
+			var bf backend.StoreBackfill
+			if err := json.Unmarshal(v, &bf); err != nil {
+				return err
+			}
+			bfs = append(bfs, bf)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bfs, nil
+}
+
+// ListBackfills returns the backfills of all tasks, ordered by task ID and then by ID.
+func (s *Store) ListBackfills(ctx context.Context) ([]backend.StoreBackfill, error) {
+	var bfs []backend.StoreBackfill
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket).Bucket(backfillsPath)
+		return b.ForEach(func(k, _ []byte) error {
+			bb := b.Bucket(k)
+			if bb == nil {
+				return nil
+			}
+			return bb.ForEach(func(_, v []byte) error {
+				var bf backend.StoreBackfill
+				if err := json.Unmarshal(v, &bf); err != nil {
+					return err
+				}
+				bfs = append(bfs, bf)
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bfs, nil
+}
+
+// DeleteBackfill deletes a backfill of a task.
+func (s *Store) DeleteBackfill(ctx context.Context, taskID, id platform.ID) error {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return err
+	}
+	encodedBackfillID, err := id.Encode()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bb := tx.Bucket(s.bucket).Bucket(backfillsPath).Bucket(encodedID)
+		if bb == nil {
+			return nil
+		}
+		return bb.Delete(encodedBackfillID)
+	})
+}
+
 func (s *Store) CreateNextRun(ctx context.Context, taskID platform.ID, now int64) (backend.RunCreation, error) {
 	var rc backend.RunCreation
 
@@ -620,6 +894,68 @@ func (s *Store) FinishRun(ctx context.Context, taskID, runID platform.ID) error
 	})
 }
 
+// IncrementRunTry increments the try of a currently running run.
+func (s *Store) IncrementRunTry(ctx context.Context, taskID, runID platform.ID) (uint32, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return 0, err
+	}
+
+	var try uint32
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		stmBytes := b.Bucket(taskMetaPath).Get(encodedID)
+		var stm backend.StoreTaskMeta
+		if err := stm.Unmarshal(stmBytes); err != nil {
+			return err
+		}
+		var ok bool
+		if try, ok = stm.IncrementRunTry(runID); !ok {
+			return ErrRunNotFound
+		}
+
+		stmBytes, err := stm.Marshal()
+		if err != nil {
+			return err
+		}
+
+		return tx.Bucket(s.bucket).Bucket(taskMetaPath).Put(encodedID, stmBytes)
+	})
+	return try, err
+}
+
+func (s *Store) SkipMissedRuns(ctx context.Context, taskID platform.ID, now int64, maxRuns int) error {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		stmBytes := b.Bucket(taskMetaPath).Get(encodedID)
+		if stmBytes == nil {
+			return backend.ErrTaskNotFound
+		}
+		var stm backend.StoreTaskMeta
+		if err := stm.Unmarshal(stmBytes); err != nil {
+			return err
+		}
+		if stm.LatestCompleted < s.minLatestCompleted {
+			stm.LatestCompleted = s.minLatestCompleted
+		}
+		if err := stm.SkipMissedRuns(now, maxRuns); err != nil {
+			return err
+		}
+
+		stmBytes, err := stm.Marshal()
+		if err != nil {
+			return err
+		}
+
+		return b.Bucket(taskMetaPath).Put(encodedID, stmBytes)
+	})
+}
+
 func (s *Store) ManuallyRunTimeRange(_ context.Context, taskID platform.ID, start, end, requestedAt int64) (*backend.StoreTaskMetaManualRun, error) {
 	encodedID, err := taskID.Encode()
 	if err != nil {
@@ -694,6 +1030,15 @@ func (s *Store) DeleteOrg(ctx context.Context, id platform.ID) error {
 			if err := b.Bucket(nameByTaskID).Delete(k); err != nil {
 				return err
 			}
+			if err := deleteRevisions(b, k); err != nil {
+				return err
+			}
+			if err := deleteBackfills(b, k); err != nil {
+				return err
+			}
+			if err := deleteUpstreamSuccesses(b, k); err != nil {
+				return err
+			}
 		}
 		// check for cancelation one last time before we return
 		select {
@@ -704,3 +1049,64 @@ func (s *Store) DeleteOrg(ctx context.Context, id platform.ID) error {
 		}
 	})
 }
+
+// latestRevision returns the number of the latest revision of the task with the given encoded ID,
+// or 0 if no revision of the task is stored.
+func latestRevision(b *bolt.Bucket, encodedID []byte) int {
+	rb := b.Bucket(revisionsPath).Bucket(encodedID)
+	if rb == nil {
+		return 0
+	}
+	k, _ := rb.Cursor().Last()
+	if k == nil {
+		return 0
+	}
+	return int(binary.BigEndian.Uint64(k))
+}
+
+// putRevision stores a revision of the task with the given encoded ID.
+func putRevision(b *bolt.Bucket, encodedID []byte, rev backend.StoreTaskRevision) error {
+	rb, err := b.Bucket(revisionsPath).CreateBucketIfNotExists(encodedID)
+	if err != nil {
+		return err
+	}
+	v, err := json.Marshal(rev)
+	if err != nil {
+		return err
+	}
+
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(rev.Revision))
+	return rb.Put(k, v)
+}
+
+// deleteRevisions deletes all revisions of the task with the given encoded ID.
+func deleteRevisions(b *bolt.Bucket, encodedID []byte) error {
+	if err := b.Bucket(revisionsPath).DeleteBucket(encodedID); err != nil && err != bolt.ErrBucketNotFound {
+		return err
+	}
+	return nil
+}
+
+// deleteBackfills deletes all backfills of the task with the given encoded ID.
+func deleteBackfills(b *bolt.Bucket, encodedID []byte) error {
+	if err := b.Bucket(backfillsPath).DeleteBucket(encodedID); err != nil && err != bolt.ErrBucketNotFound {
+		return err
+	}
+	return nil
+}
+
+// upstreamKey returns the key of the upstream successes for the run time now.
+func upstreamKey(now int64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(now))
+	return k
+}
+
+// deleteUpstreamSuccesses deletes the upstream successes of the task with the given encoded ID.
+func deleteUpstreamSuccesses(b *bolt.Bucket, encodedID []byte) error {
+	if err := b.Bucket(upstreamPath).DeleteBucket(encodedID); err != nil && err != bolt.ErrBucketNotFound {
+		return err
+	}
+	return nil
+}
@@ -53,6 +53,38 @@ func TestBoltStore(t *testing.T) {
 	)(t)
 }
 
+func TestBoltStore_MultiNode(t *testing.T) {
+	var f *os.File
+	storetest.NewMultiNodeTest(
+		"boltstore",
+		func(t *testing.T) backend.Store {
+			var err error
+			f, err = ioutil.TempFile("", "influx_bolt_task_store_test")
+			if err != nil {
+				t.Fatalf("failed to create tempfile for test db %v\n", err)
+			}
+			db, err := bolt.Open(f.Name(), os.ModeTemporary, nil)
+			if err != nil {
+				t.Fatalf("failed to open bolt db for test db %v\n", err)
+			}
+			s, err := boltstore.New(db, "testbucket")
+			if err != nil {
+				t.Fatalf("failed to create new bolt store %v\n", err)
+			}
+			return s
+		},
+		func(t *testing.T, s backend.Store) {
+			if err := s.Close(); err != nil {
+				t.Error(err)
+			}
+			err := os.Remove(f.Name())
+			if err != nil {
+				t.Error(err)
+			}
+		},
+	)(t)
+}
+
 func TestSkip(t *testing.T) {
 	f, err := ioutil.TempFile("", "influx_bolt_task_store_test")
 	if err != nil {
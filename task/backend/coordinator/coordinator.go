@@ -3,6 +3,8 @@ package coordinator
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/task/backend"
@@ -17,6 +19,15 @@ type Coordinator struct {
 
 	limit         int
 	claimExisting bool
+
+	// Set by WithLeases, to only schedule the tasks leased to owner.
+	leaseCtx context.Context
+	leaser   backend.TaskLeaser
+	owner    string
+	leaseTTL time.Duration
+
+	leasesMu sync.Mutex
+	leases   map[platform.ID]int64 // Task ID -> Unix nanosecond timestamp when the lease held on it expires.
 }
 
 type Option func(*Coordinator)
@@ -41,13 +52,17 @@ func New(logger *zap.Logger, scheduler backend.Scheduler, ts platform.TaskServic
 		TaskService:   ts,
 		limit:         1000,
 		claimExisting: true,
+		leases:        make(map[platform.ID]int64),
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
-	if c.claimExisting {
+	if c.leaser != nil {
+		// Existing tasks are claimed through their leases.
+		go c.maintainLeases()
+	} else if c.claimExisting {
 		go c.claimExistingTasks()
 	}
 
@@ -55,34 +70,41 @@ func New(logger *zap.Logger, scheduler backend.Scheduler, ts platform.TaskServic
 }
 
 // claimExistingTasks is called on startup to claim all tasks in the store.
+// It is only used without leases, when this node is the only one scheduling the tasks.
 func (c *Coordinator) claimExistingTasks() {
-	tasks, _, err := c.TaskService.FindTasks(context.Background(), platform.TaskFilter{})
-	if err != nil {
+	if err := c.forEachActiveTask(context.Background(), func(task *platform.Task) {
+		// I may need a context with an auth here
+		if err := c.sch.ClaimTask(context.Background(), task); err != nil {
+			c.logger.Error("failed claim task", zap.Error(err))
+		}
+	}); err != nil {
 		c.logger.Error("failed to list tasks", zap.Error(err))
-		return
+	}
+}
+
+// forEachActiveTask calls fn with every active task in the store, a page at a time.
+func (c *Coordinator) forEachActiveTask(ctx context.Context, fn func(*platform.Task)) error {
+	tasks, _, err := c.TaskService.FindTasks(ctx, platform.TaskFilter{})
+	if err != nil {
+		return err
 	}
 
 	for len(tasks) > 0 {
 		for _, task := range tasks {
 			if task.Status != string(backend.TaskActive) {
-				// Don't claim inactive tasks at startup.
-				continue
-			}
-
-			// I may need a context with an auth here
-			if err := c.sch.ClaimTask(context.Background(), task); err != nil {
-				c.logger.Error("failed claim task", zap.Error(err))
+				// Don't claim inactive tasks.
 				continue
 			}
+			fn(task)
 		}
-		tasks, _, err = c.TaskService.FindTasks(context.Background(), platform.TaskFilter{
+		tasks, _, err = c.TaskService.FindTasks(ctx, platform.TaskFilter{
 			After: &tasks[len(tasks)-1].ID,
 		})
 		if err != nil {
-			c.logger.Error("failed list additional tasks", zap.Error(err))
-			return
+			return err
 		}
 	}
+	return nil
 }
 
 func (c *Coordinator) CreateTask(ctx context.Context, t platform.TaskCreate) (*platform.Task, error) {
@@ -91,7 +113,20 @@ func (c *Coordinator) CreateTask(ctx context.Context, t platform.TaskCreate) (*p
 		return task, err
 	}
 
+	if c.leaser != nil {
+		if err := c.claimLease(ctx, task.ID, time.Now()); err != nil {
+			delErr := c.TaskService.DeleteTask(ctx, task.ID)
+			if delErr != nil {
+				return task, fmt.Errorf("lease task failed: %s\n\tcleanup also failed: %s", err, delErr)
+			}
+			return task, err
+		}
+	}
+
 	if err := c.sch.ClaimTask(ctx, task); err != nil {
+		if c.leaser != nil {
+			c.releaseLease(ctx, task.ID)
+		}
 		delErr := c.TaskService.DeleteTask(ctx, task.ID)
 		if delErr != nil {
 			return task, fmt.Errorf("schedule task failed: %s\n\tcleanup also failed: %s", err, delErr)
@@ -113,6 +148,12 @@ func (c *Coordinator) UpdateTask(ctx context.Context, id platform.ID, upd platfo
 		if err := c.sch.ReleaseTask(id); err != nil && err != backend.ErrTaskNotClaimed {
 			return task, err
 		}
+		if c.leaser != nil {
+			c.forgetLease(id)
+			if err := c.leaser.ReleaseTaskLease(ctx, id, c.owner); err != nil && err != backend.ErrTaskLeaseHeld {
+				return task, err
+			}
+		}
 	}
 
 	if err := c.sch.UpdateTask(ctx, task); err != nil && err != backend.ErrTaskNotClaimed {
@@ -121,6 +162,14 @@ func (c *Coordinator) UpdateTask(ctx context.Context, id platform.ID, upd platfo
 
 	// If enabling the task, claim it after modifying the script.
 	if task.Status == string(backend.TaskActive) {
+		if c.leaser != nil {
+			if err := c.claimLease(ctx, id, time.Now()); err == backend.ErrTaskLeaseHeld {
+				// Another node schedules the task, and picks up the update when it renews the lease.
+				return task, nil
+			} else if err != nil {
+				return task, err
+			}
+		}
 		if err := c.sch.ClaimTask(ctx, task); err != nil && err != backend.ErrTaskAlreadyClaimed {
 			return task, err
 		}
@@ -129,12 +178,33 @@ func (c *Coordinator) UpdateTask(ctx context.Context, id platform.ID, upd platfo
 	return task, nil
 }
 
+func (c *Coordinator) RollbackTask(ctx context.Context, id platform.ID, revision int) (*platform.Task, error) {
+	task, err := c.TaskService.RollbackTask(ctx, id, revision)
+	if err != nil {
+		return task, err
+	}
+
+	// Rolling back does not change the status of the task, so only a claimed task needs its script updated.
+	if err := c.sch.UpdateTask(ctx, task); err != nil && err != backend.ErrTaskNotClaimed {
+		return task, err
+	}
+
+	return task, nil
+}
+
 func (c *Coordinator) DeleteTask(ctx context.Context, id platform.ID) error {
 	if err := c.sch.ReleaseTask(id); err != nil && err != backend.ErrTaskNotClaimed {
 		return err
 	}
+	if err := c.TaskService.DeleteTask(ctx, id); err != nil {
+		return err
+	}
 
-	return c.TaskService.DeleteTask(ctx, id)
+	if c.leaser != nil {
+		// A lease held by another node is released when that node next renews its leases.
+		c.releaseLease(ctx, id)
+	}
+	return nil
 }
 
 func (c *Coordinator) CancelRun(ctx context.Context, taskID, runID platform.ID) error {
@@ -159,7 +229,7 @@ func (c *Coordinator) RetryRun(ctx context.Context, taskID, runID platform.ID) (
 		return r, err
 	}
 
-	return r, c.sch.UpdateTask(ctx, task)
+	return r, c.updateScheduledTask(ctx, task)
 }
 
 func (c *Coordinator) ForceRun(ctx context.Context, taskID platform.ID, scheduledFor int64) (*platform.Run, error) {
@@ -173,5 +243,15 @@ func (c *Coordinator) ForceRun(ctx context.Context, taskID platform.ID, schedule
 		return r, err
 	}
 
-	return r, c.sch.UpdateTask(ctx, task)
+	return r, c.updateScheduledTask(ctx, task)
+}
+
+// updateScheduledTask updates the scheduler with task, to pick up the runs queued for it.
+func (c *Coordinator) updateScheduledTask(ctx context.Context, task *platform.Task) error {
+	err := c.sch.UpdateTask(ctx, task)
+	if err == backend.ErrTaskNotClaimed && c.leaser != nil {
+		// Another node holds the lease of the task, and picks up the queued runs when it renews the lease.
+		return nil
+	}
+	return err
 }
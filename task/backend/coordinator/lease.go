@@ -0,0 +1,172 @@
+package coordinator
+
+import (
+	"context"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/task/backend"
+	"go.uber.org/zap"
+)
+
+// DefaultLeaseTTL is the lease TTL used when leases are enabled without an explicit TTL.
+const DefaultLeaseTTL = 30 * time.Second
+
+// WithLeases makes the coordinator schedule only the tasks whose lease it holds in leaser, as owner,
+// so that nodes sharing task metadata never schedule the same task at once.
+//
+// Until ctx is done, every third of ttl the coordinator renews its leases to last another ttl,
+// releases the tasks whose lease it lost, and claims the active tasks whose lease is not held.
+// Those include the tasks of a node that stopped renewing its leases,
+// whose runs that were in progress are resumed once the lease expired.
+// The nodes' clocks are assumed to be synchronized to well within ttl.
+func WithLeases(ctx context.Context, leaser backend.TaskLeaser, owner string, ttl time.Duration) Option {
+	return func(c *Coordinator) {
+		c.leaseCtx = ctx
+		c.leaser = leaser
+		c.owner = owner
+		c.leaseTTL = ttl
+	}
+}
+
+// maintainLeases checks the leases of the coordinator every third of the lease TTL, until the lease context is done.
+func (c *Coordinator) maintainLeases() {
+	ticker := time.NewTicker(c.leaseTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		c.checkLeases(c.leaseCtx, time.Now())
+
+		select {
+		case <-c.leaseCtx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkLeases renews the leases held by the coordinator, then claims the active tasks whose lease is not held.
+func (c *Coordinator) checkLeases(ctx context.Context, now time.Time) {
+	c.leasesMu.Lock()
+	held := make(map[platform.ID]int64, len(c.leases))
+	for id, expiresAt := range c.leases {
+		held[id] = expiresAt
+	}
+	c.leasesMu.Unlock()
+
+	for id, expiresAt := range held {
+		c.renewLease(ctx, id, expiresAt, now)
+	}
+
+	if err := c.forEachActiveTask(ctx, func(task *platform.Task) {
+		if _, ok := held[task.ID]; ok {
+			return
+		}
+		c.claimUnleasedTask(ctx, task, now)
+	}); err != nil {
+		c.logger.Error("failed to list tasks to claim", zap.Error(err))
+	}
+}
+
+// renewLease extends the lease on the task with the given ID, whose current lease expires at expiresAt,
+// and updates the scheduled task with any change made through other nodes.
+func (c *Coordinator) renewLease(ctx context.Context, id platform.ID, expiresAt int64, now time.Time) {
+	task, err := c.TaskService.FindTaskByID(ctx, id)
+	if err == backend.ErrTaskNotFound {
+		// The task was deleted through another node.
+		c.releaseTask(id)
+		c.releaseLease(ctx, id)
+		return
+	}
+	if err != nil {
+		c.logger.Error("failed to find leased task", zap.String("task_id", id.String()), zap.Error(err))
+		c.expireLease(id, expiresAt, now)
+		return
+	}
+
+	if task.Status != string(backend.TaskActive) {
+		// The task was disabled through another node.
+		c.releaseTask(id)
+		c.releaseLease(ctx, id)
+		return
+	}
+
+	switch err := c.claimLease(ctx, id, now); err {
+	case nil:
+	case backend.ErrTaskLeaseHeld:
+		// Another node claimed the task after our lease expired, so it resumes the runs of the task.
+		c.logger.Info("lost task lease", zap.String("task_id", id.String()))
+		c.releaseTask(id)
+		return
+	default:
+		c.logger.Error("failed to renew task lease", zap.String("task_id", id.String()), zap.Error(err))
+		c.expireLease(id, expiresAt, now)
+		return
+	}
+
+	if err := c.sch.UpdateTask(ctx, task); err != nil && err != backend.ErrTaskNotClaimed {
+		c.logger.Error("failed to update leased task", zap.String("task_id", id.String()), zap.Error(err))
+	}
+}
+
+// expireLease stops scheduling the task with the given ID once its lease, which could not be renewed, has expired,
+// as another node may claim it from then on.
+func (c *Coordinator) expireLease(id platform.ID, expiresAt int64, now time.Time) {
+	if now.UnixNano() >= expiresAt {
+		c.releaseTask(id)
+	}
+}
+
+// claimUnleasedTask schedules task if its lease can be claimed.
+func (c *Coordinator) claimUnleasedTask(ctx context.Context, task *platform.Task, now time.Time) {
+	switch err := c.claimLease(ctx, task.ID, now); err {
+	case nil:
+	case backend.ErrTaskLeaseHeld:
+		return
+	default:
+		c.logger.Error("failed to claim task lease", zap.String("task_id", task.ID.String()), zap.Error(err))
+		return
+	}
+
+	// Claiming the task resumes the runs that were in progress on the node that held the lease before.
+	if err := c.sch.ClaimTask(ctx, task); err != nil && err != backend.ErrTaskAlreadyClaimed {
+		c.logger.Error("failed to claim leased task", zap.String("task_id", task.ID.String()), zap.Error(err))
+		c.releaseLease(ctx, task.ID)
+	}
+}
+
+// claimLease claims or renews the lease on the task with the given ID, to last the lease TTL from now.
+func (c *Coordinator) claimLease(ctx context.Context, id platform.ID, now time.Time) error {
+	l, err := c.leaser.ClaimTaskLease(ctx, id, c.owner, now.UnixNano(), now.Add(c.leaseTTL).UnixNano())
+	if err != nil {
+		return err
+	}
+
+	c.leasesMu.Lock()
+	c.leases[id] = l.ExpiresAt
+	c.leasesMu.Unlock()
+	return nil
+}
+
+// releaseTask stops scheduling the task with the given ID, and forgets its lease.
+func (c *Coordinator) releaseTask(id platform.ID) {
+	c.forgetLease(id)
+	if err := c.sch.ReleaseTask(id); err != nil && err != backend.ErrTaskNotClaimed {
+		c.logger.Error("failed to release task", zap.String("task_id", id.String()), zap.Error(err))
+	}
+}
+
+// releaseLease forgets the lease on the task with the given ID, and releases it in the leaser
+// unless another node holds it.
+func (c *Coordinator) releaseLease(ctx context.Context, id platform.ID) {
+	c.forgetLease(id)
+	if err := c.leaser.ReleaseTaskLease(ctx, id, c.owner); err != nil && err != backend.ErrTaskLeaseHeld {
+		c.logger.Error("failed to release task lease", zap.String("task_id", id.String()), zap.Error(err))
+	}
+}
+
+func (c *Coordinator) forgetLease(id platform.ID) {
+	c.leasesMu.Lock()
+	delete(c.leases, id)
+	c.leasesMu.Unlock()
+}
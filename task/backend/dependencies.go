@@ -0,0 +1,96 @@
+package backend
+
+import (
+	"fmt"
+	"strings"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+// MaxPendingUpstreamRuns is the number of run times per task whose upstream successes are kept
+// while waiting on the rest of its upstream tasks, before the oldest is forgotten.
+const MaxPendingUpstreamRuns = 100
+
+// ValidateDependsOn returns an error if the dependsOn option of task names no task or more than one task
+// of its organization, or if following the dependencies leads back to task.
+// task is the task being created or updated; its ID is invalid if it has not been created yet.
+// tasks are the other tasks of the organization of task.
+func ValidateDependsOn(task *platform.Task, tasks []*platform.Task) error {
+	if len(task.DependsOn) == 0 {
+		return nil
+	}
+
+	all := make([]*platform.Task, 0, len(tasks)+1)
+	all = append(all, task)
+	for _, t := range tasks {
+		if task.ID.Valid() && t.ID == task.ID {
+			// Replaced by the updated task.
+			continue
+		}
+		all = append(all, t)
+	}
+
+	for _, d := range task.DependsOn {
+		upstream := upstreamTasks(d, all)
+		switch {
+		case len(upstream) == 0:
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("dependsOn: no task %q in the organization", d),
+			}
+		case len(upstream) > 1:
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("dependsOn: more than one task named %q in the organization; use the task ID", d),
+			}
+		case upstream[0] == task:
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "dependsOn: a task cannot depend on itself",
+			}
+		}
+	}
+
+	visited := make(map[*platform.Task]bool, len(all))
+	var visit func(t *platform.Task, path []string) []string
+	visit = func(t *platform.Task, path []string) []string {
+		for _, d := range t.DependsOn {
+			for _, u := range upstreamTasks(d, all) {
+				if u == task {
+					return append(path, task.Name)
+				}
+				if visited[u] {
+					continue
+				}
+				visited[u] = true
+				if cycle := visit(u, append(path, u.Name)); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		return nil
+	}
+	if cycle := visit(task, []string{task.Name}); cycle != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("dependsOn: tasks would depend on each other in a cycle: %s", strings.Join(cycle, " -> ")),
+		}
+	}
+	return nil
+}
+
+// upstreamTasks returns the tasks matched by the dependsOn entry d.
+func upstreamTasks(d string, tasks []*platform.Task) []*platform.Task {
+	var upstream []*platform.Task
+	for _, t := range tasks {
+		if dependsOnTask(d, t) {
+			upstream = append(upstream, t)
+		}
+	}
+	return upstream
+}
+
+// dependsOnTask reports whether the dependsOn entry d names t, by ID or by name.
+func dependsOnTask(d string, t *platform.Task) bool {
+	return d == t.Name || (t.ID.Valid() && d == t.ID.String())
+}
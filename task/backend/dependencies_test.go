@@ -0,0 +1,73 @@
+package backend_test
+
+import (
+	"strings"
+	"testing"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/task/backend"
+)
+
+func TestValidateDependsOn(t *testing.T) {
+	tasks := []*platform.Task{
+		{ID: 1, Name: "raw"},
+		{ID: 2, Name: "hourly", DependsOn: []string{"raw"}},
+		{ID: 3, Name: "dup"},
+		{ID: 4, Name: "dup"},
+	}
+
+	for _, tc := range []struct {
+		name string
+		task *platform.Task
+		err  string
+	}{
+		{
+			name: "no dependencies",
+			task: &platform.Task{Name: "new"},
+		},
+		{
+			name: "by name",
+			task: &platform.Task{Name: "new", DependsOn: []string{"raw", "hourly"}},
+		},
+		{
+			name: "by ID",
+			task: &platform.Task{Name: "new", DependsOn: []string{"0000000000000003"}},
+		},
+		{
+			name: "not found",
+			task: &platform.Task{Name: "new", DependsOn: []string{"missing"}},
+			err:  `no task "missing"`,
+		},
+		{
+			name: "ambiguous name",
+			task: &platform.Task{Name: "new", DependsOn: []string{"dup"}},
+			err:  `more than one task named "dup"`,
+		},
+		{
+			name: "itself",
+			task: &platform.Task{ID: 1, Name: "raw", DependsOn: []string{"raw"}},
+			err:  "cannot depend on itself",
+		},
+		{
+			name: "cycle",
+			task: &platform.Task{ID: 1, Name: "raw", DependsOn: []string{"hourly"}},
+			err:  "raw -> hourly -> raw",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := backend.ValidateDependsOn(tc.task, tasks)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+			if platform.ErrorCode(err) != platform.EInvalid {
+				t.Fatalf("expected invalid error, got %v", err)
+			}
+		})
+	}
+}
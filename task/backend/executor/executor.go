@@ -8,12 +8,14 @@ import (
 	"time"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/lang"
 	"github.com/influxdata/influxdb"
 	icontext "github.com/influxdata/influxdb/context"
 	"github.com/influxdata/influxdb/logger"
 	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/task/backend"
+	"github.com/influxdata/influxdb/task/options"
 	"go.uber.org/zap"
 )
 
@@ -76,6 +78,7 @@ type syncRunPromise struct {
 	t      *influxdb.Task
 	ctx    context.Context
 	cancel context.CancelFunc
+	writes *query.WriteStatistics
 	logger *zap.Logger
 	logEnd func() // Called to log the end of the run operation.
 
@@ -88,6 +91,7 @@ type syncRunPromise struct {
 var _ backend.RunPromise = (*syncRunPromise)(nil)
 
 func newSyncRunPromise(ctx context.Context, auth *influxdb.Authorization, qr backend.QueuedRun, e *queryServiceExecutor, t *influxdb.Task) *syncRunPromise {
+	ctx, writes := query.ContextWithWriteStatistics(ctx)
 	ctx, cancel := context.WithCancel(ctx)
 	opLogger := e.logger.With(zap.Stringer("task_id", qr.TaskID), zap.Stringer("run_id", qr.RunID))
 	log, logEnd := logger.NewOperation(opLogger, "Executing task", "execute")
@@ -100,6 +104,7 @@ func newSyncRunPromise(ctx context.Context, auth *influxdb.Authorization, qr bac
 		logEnd: logEnd,
 		ctx:    ctx,
 		cancel: cancel,
+		writes: writes,
 		ready:  make(chan struct{}),
 	}
 
@@ -153,11 +158,15 @@ func (p *syncRunPromise) finish(res *runResult, err error) {
 func (p *syncRunPromise) doQuery(wg *sync.WaitGroup) {
 	defer wg.Done()
 
-	spec, err := flux.Compile(p.ctx, p.t.Flux, time.Unix(p.qr.Now, 0))
+	spec, err := compileRun(p.ctx, p.t, time.Unix(p.qr.Now, 0))
 	if err != nil {
 		p.finish(nil, err)
 		return
 	}
+	if err := applyResourceLimits(spec, p.t); err != nil {
+		p.finish(nil, err)
+		return
+	}
 
 	req := &query.Request{
 		Authorization:  p.auth,
@@ -187,8 +196,11 @@ func (p *syncRunPromise) doQuery(wg *sync.WaitGroup) {
 	// It's safe for Release to be called multiple times.
 	it.Release()
 
+	stats := it.Statistics()
+	p.writes.AddMetadata(&stats)
+
 	// Is it okay to assume it.Err will be set if the query context is canceled?
-	p.finish(&runResult{err: it.Err(), statistics: it.Statistics()}, nil)
+	p.finish(&runResult{err: it.Err(), retryable: isRetryable(it.Err()), statistics: stats}, nil)
 }
 
 func (p *syncRunPromise) cancelOnContextDone(wg *sync.WaitGroup) {
@@ -232,10 +244,13 @@ func (e *asyncQueryServiceExecutor) Execute(ctx context.Context, run backend.Que
 		return nil, err
 	}
 
-	spec, err := flux.Compile(ctx, t.Flux, time.Unix(run.Now, 0))
+	spec, err := compileRun(ctx, t, time.Unix(run.Now, 0))
 	if err != nil {
 		return nil, err
 	}
+	if err := applyResourceLimits(spec, t); err != nil {
+		return nil, err
+	}
 
 	req := &query.Request{
 		Authorization:  auth,
@@ -245,12 +260,13 @@ func (e *asyncQueryServiceExecutor) Execute(ctx context.Context, run backend.Que
 		},
 	}
 	// Only set the authorizer on the context where we need it here.
-	q, err := e.qs.Query(icontext.SetAuthorizer(ctx, auth), req)
+	ctx, writes := query.ContextWithWriteStatistics(icontext.SetAuthorizer(ctx, auth))
+	q, err := e.qs.Query(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	return newAsyncRunPromise(run, q, e), nil
+	return newAsyncRunPromise(run, q, writes, e), nil
 }
 
 func (e *asyncQueryServiceExecutor) Wait() {
@@ -259,8 +275,9 @@ func (e *asyncQueryServiceExecutor) Wait() {
 
 // asyncRunPromise implements backend.RunPromise for an AsyncQueryService.
 type asyncRunPromise struct {
-	qr backend.QueuedRun
-	q  flux.Query
+	qr     backend.QueuedRun
+	q      flux.Query
+	writes *query.WriteStatistics
 
 	logger *zap.Logger
 	logEnd func() // Called to log the end of the run operation.
@@ -273,14 +290,15 @@ type asyncRunPromise struct {
 
 var _ backend.RunPromise = (*asyncRunPromise)(nil)
 
-func newAsyncRunPromise(qr backend.QueuedRun, q flux.Query, e *asyncQueryServiceExecutor) *asyncRunPromise {
+func newAsyncRunPromise(qr backend.QueuedRun, q flux.Query, writes *query.WriteStatistics, e *asyncQueryServiceExecutor) *asyncRunPromise {
 	opLogger := e.logger.With(zap.Stringer("task_id", qr.TaskID), zap.Stringer("run_id", qr.RunID))
 	log, logEnd := logger.NewOperation(opLogger, "Executing task", "execute")
 
 	p := &asyncRunPromise{
-		qr:    qr,
-		q:     q,
-		ready: make(chan struct{}),
+		qr:     qr,
+		q:      q,
+		writes: writes,
+		ready:  make(chan struct{}),
 
 		logger: log,
 		logEnd: logEnd,
@@ -325,7 +343,7 @@ func (p *asyncRunPromise) followQuery(wg *sync.WaitGroup) {
 	case results, ok := <-p.q.Ready():
 		if !ok {
 			// Something went wrong with the flux. Set the error in the run result.
-			rr := &runResult{err: p.q.Err()}
+			rr := &runResult{err: p.q.Err(), retryable: isRetryable(p.q.Err())}
 			p.finish(rr, nil)
 			return
 		}
@@ -347,7 +365,9 @@ func (p *asyncRunPromise) followQuery(wg *sync.WaitGroup) {
 		// Otherwise, query was successful.
 		// Must call query.Done before collecting statistics. It's safe to call multiple times.
 		p.q.Done()
-		p.finish(&runResult{statistics: p.q.Statistics()}, nil)
+		stats := p.q.Statistics()
+		p.writes.AddMetadata(&stats)
+		p.finish(&runResult{statistics: stats}, nil)
 	}
 }
 
@@ -380,6 +400,69 @@ func (rr *runResult) Err() error                  { return rr.err }
 func (rr *runResult) IsRetryable() bool           { return rr.retryable }
 func (rr *runResult) Statistics() flux.Statistics { return rr.statistics }
 
+// compileRun compiles the script of task t for a run at now.
+func compileRun(ctx context.Context, t *influxdb.Task, now time.Time) (*flux.Spec, error) {
+	pkg, err := runAST(t)
+	if err != nil {
+		return nil, err
+	}
+	return flux.CompileAST(ctx, pkg, now)
+}
+
+// runAST parses the script of task t for one of its runs.
+// If the task has a location option, the location option of the query is set to it
+// by a statement added right after the imports of the script.
+func runAST(t *influxdb.Task) (*ast.Package, error) {
+	opt, err := options.FromScript(t.Flux)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := flux.Parse(t.Flux)
+	if err != nil {
+		return nil, err
+	}
+	if opt.Location == "" || len(pkg.Files) == 0 {
+		return pkg, nil
+	}
+
+	f := pkg.Files[0]
+	location := &ast.OptionStatement{
+		Assignment: &ast.VariableAssignment{
+			ID:   &ast.Identifier{Name: "location"},
+			Init: &ast.StringLiteral{Value: opt.Location},
+		},
+	}
+	f.Body = append([]ast.Statement{location}, f.Body...)
+	return pkg, nil
+}
+
+// applyResourceLimits sets the resource limits from the options of task t on spec, the query of one of its runs.
+func applyResourceLimits(spec *flux.Spec, t *influxdb.Task) error {
+	opt, err := options.FromScript(t.Flux)
+	if err != nil {
+		return err
+	}
+	if opt.MemoryBytes != nil {
+		spec.Resources.MemoryBytesQuota = *opt.MemoryBytes
+	}
+	return nil
+}
+
+// isRetryable reports whether a run that failed with err may succeed when it is run again.
+// Errors caused by the task itself, like an invalid script or a bucket that does not exist, are not retryable.
+// Any other error, like a storage or network error, is assumed to be transient.
+func isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch influxdb.ErrorCode(err) {
+	case influxdb.EInvalid, influxdb.ENotFound, influxdb.EUnprocessableEntity, influxdb.EEmptyValue,
+		influxdb.EForbidden, influxdb.EUnauthorized, influxdb.EMethodNotAllowed:
+		return false
+	}
+	return true
+}
+
 // exhaustResultIterators drains all the iterators from a flux query Result.
 func exhaustResultIterators(res flux.Result) error {
 	return res.Tables().Do(func(tbl flux.Table) error {
@@ -0,0 +1,39 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxdb"
+)
+
+func TestRunAST(t *testing.T) {
+	const script = `import "strings"
+
+option task = {name: "paris", cron: "0 6 * * *", location: "Europe/Paris"}
+
+from(bucket: strings.toLower(v: "ONE")) |> range(start: -1m)`
+
+	pkg, err := runAST(&influxdb.Task{Flux: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := pkg.Files[0]
+	if len(f.Imports) != 1 || f.Imports[0].Path.Value != "strings" {
+		t.Fatalf("expected the imports of the script to be kept, got %s", ast.Format(pkg))
+	}
+	if got, want := ast.Format(f.Body[0]), `option location = "Europe/Paris"`; got != want {
+		t.Fatalf("expected the script to start with %s after its imports, got %s", want, got)
+	}
+
+	// Without a location, the script is run as is.
+	pkg, err = runAST(&influxdb.Task{Flux: `option task = {name: "utc", every: 1h}
+
+from(bucket: "one") |> range(start: -1m)`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ast.Format(pkg.Files[0].Body[0]); got != `option task = {name: "utc", every: 1h}` {
+		t.Fatalf("expected the script to start with its task option, got %s", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -33,6 +34,9 @@ type fakeQueryService struct {
 	// The most recent ctx received in the Query method.
 	// Used to validate that the executor applied the correct authorizer.
 	mostRecentCtx context.Context
+	// The most recent spec received in the Query method.
+	// Used to validate that the executor applied the resource limits of the task.
+	mostRecentSpec *flux.Spec
 }
 
 var _ query.AsyncQueryService = (*fakeQueryService)(nil)
@@ -76,6 +80,8 @@ func (s *fakeQueryService) Query(ctx context.Context, req *query.Request) (flux.
 		return nil, fmt.Errorf("fakeQueryService only supports the SpecCompiler, got %T", req.Compiler)
 	}
 
+	s.mostRecentSpec = sc.Spec
+
 	fq := &fakeQuery{
 		wait:  make(chan struct{}),
 		ready: make(chan map[string]flux.Result),
@@ -285,6 +291,8 @@ func TestExecutor(t *testing.T) {
 		testExecutorQuerySuccess(t, fn)
 		testExecutorQueryFailure(t, fn)
 		testExecutorPromiseCancel(t, fn)
+		testExecutorMemoryLimit(t, fn)
+		testExecutorLocation(t, fn)
 		testExecutorServiceError(t, fn)
 		testExecutorWait(t, fn)
 	}
@@ -393,6 +401,9 @@ func testExecutorQueryFailure(t *testing.T, fn createSysFn) {
 		if got := res.Err(); got != expErr {
 			t.Fatalf("expected error %v; got %v", expErr, got)
 		}
+		if !res.IsRetryable() {
+			t.Fatal("expected query error to be retryable")
+		}
 	})
 }
 
@@ -425,6 +436,89 @@ func testExecutorPromiseCancel(t *testing.T, fn createSysFn) {
 	})
 }
 
+func testExecutorMemoryLimit(t *testing.T, fn createSysFn) {
+	sys := fn()
+	tc := createCreds(t, sys.i)
+	t.Run(sys.name+"/MemoryLimit", func(t *testing.T) {
+		script := fmt.Sprintf(`
+option task = {
+			name: %q,
+			every: 1m,
+			memoryBytes: 1048576,
+}
+
+from(bucket: "one") |> range(start: -1m)`, t.Name())
+		ctx := icontext.SetAuthorizer(context.Background(), tc.Auth)
+		task, err := sys.ts.CreateTask(ctx, platform.TaskCreate{OrganizationID: tc.OrgID, Token: tc.Auth.Token, Flux: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+		qr := backend.QueuedRun{TaskID: task.ID, RunID: platform.ID(1), Now: 123}
+		rp, err := sys.ex.Execute(context.Background(), qr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rp.Cancel()
+
+		var spec *flux.Spec
+		for i := 0; i < 50 && spec == nil; i++ {
+			time.Sleep(5 * time.Millisecond)
+			sys.svc.mu.Lock()
+			spec = sys.svc.mostRecentSpec
+			sys.svc.mu.Unlock()
+		}
+		if spec == nil {
+			t.Fatal("query never started")
+		}
+		if got := spec.Resources.MemoryBytesQuota; got != 1048576 {
+			t.Fatalf("expected memory quota of 1048576 bytes, got %d", got)
+		}
+	})
+}
+
+func testExecutorLocation(t *testing.T, fn createSysFn) {
+	sys := fn()
+	tc := createCreds(t, sys.i)
+	t.Run(sys.name+"/Location", func(t *testing.T) {
+		// The location option of the query is set after the imports of the script, which still compiles.
+		script := fmt.Sprintf(`
+import "strings"
+
+option task = {
+			name: %q,
+			cron: "0 6 * * *",
+			location: "Europe/Paris",
+}
+
+from(bucket: strings.toLower(v: "ONE")) |> range(start: -1m)`, t.Name())
+		ctx := icontext.SetAuthorizer(context.Background(), tc.Auth)
+		task, err := sys.ts.CreateTask(ctx, platform.TaskCreate{OrganizationID: tc.OrgID, Token: tc.Auth.Token, Flux: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+		qr := backend.QueuedRun{TaskID: task.ID, RunID: platform.ID(1), Now: 123}
+		rp, err := sys.ex.Execute(context.Background(), qr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rp.Cancel()
+
+		var spec *flux.Spec
+		for i := 0; i < 50 && spec == nil; i++ {
+			time.Sleep(5 * time.Millisecond)
+			sys.svc.mu.Lock()
+			spec = sys.svc.mostRecentSpec
+			sys.svc.mu.Unlock()
+		}
+		if spec == nil {
+			t.Fatal("query never started")
+		}
+		if got := fmt.Sprintf("%+v", spec.Operations[0].Spec); !strings.Contains(got, "one") {
+			t.Fatalf("expected query from bucket one, got %s", got)
+		}
+	})
+}
+
 func testExecutorServiceError(t *testing.T, fn createSysFn) {
 	sys := fn()
 	tc := createCreds(t, sys.i)
@@ -0,0 +1,229 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/lang"
+	fluxinfluxdb "github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/task/options"
+	"go.uber.org/zap"
+)
+
+// maxTestLookback is how far back TaskTester looks for the latest time a task was scheduled for.
+const maxTestLookback = 5 * 365 * 24 * time.Hour
+
+// TaskTester runs task scripts the way the executor runs them,
+// except that the data of their to() calls is returned as results instead of being written.
+type TaskTester struct {
+	logger *zap.Logger
+	qs     query.QueryService
+	ts     influxdb.TaskService
+}
+
+var _ influxdb.TaskTestService = (*TaskTester)(nil)
+
+// NewTaskTester returns a TaskTester running queries with qs, and finding the tasks to test with ts.
+func NewTaskTester(logger *zap.Logger, qs query.QueryService, ts influxdb.TaskService) *TaskTester {
+	return &TaskTester{logger: logger, qs: qs, ts: ts}
+}
+
+// TestTask runs the script of a task for each of the scheduled times of tt, with the authorization of ctx.
+func (t *TaskTester) TestTask(ctx context.Context, tt influxdb.TaskTest) ([]*influxdb.TaskTestRun, error) {
+	if err := tt.Validate(); err != nil {
+		return nil, &influxdb.Error{Code: influxdb.EInvalid, Err: err}
+	}
+
+	task := &influxdb.Task{Flux: tt.Flux, OrganizationID: tt.OrganizationID}
+	if tt.TaskID.Valid() {
+		var err error
+		if task, err = t.ts.FindTaskByID(ctx, tt.TaskID); err != nil {
+			return nil, err
+		}
+	}
+
+	opt, err := options.FromScript(task.Flux)
+	if err != nil {
+		return nil, &influxdb.Error{Code: influxdb.EInvalid, Msg: "invalid task options", Err: err}
+	}
+
+	auth, err := testAuthorization(ctx, task.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := tt.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	scheduledFor := tt.ScheduledFor
+	if len(scheduledFor) == 0 {
+		latest, err := latestScheduled(opt, now)
+		if err != nil {
+			return nil, &influxdb.Error{Code: influxdb.EInvalid, Err: err}
+		}
+		scheduledFor = []time.Time{latest}
+	}
+
+	runs := make([]*influxdb.TaskTestRun, len(scheduledFor))
+	for i, s := range scheduledFor {
+		runs[i] = t.testRun(ctx, auth, task, s.UTC())
+	}
+	return runs, nil
+}
+
+// testRun runs the script of task for the scheduled time s.
+func (t *TaskTester) testRun(ctx context.Context, auth *influxdb.Authorization, task *influxdb.Task, s time.Time) *influxdb.TaskTestRun {
+	run := &influxdb.TaskTestRun{ScheduledFor: s.Format(time.RFC3339), Results: []influxdb.TaskTestResult{}}
+	log := func(msg string) {
+		run.Logs = append(run.Logs, influxdb.Log{Time: time.Now().UTC().Format(time.RFC3339Nano), Message: msg})
+	}
+	fail := func(err error) *influxdb.TaskTestRun {
+		// The results of a failed run are not all the data it would have written.
+		run.Results = []influxdb.TaskTestResult{}
+		run.Error = err.Error()
+		log("Run failed: " + err.Error())
+		return run
+	}
+
+	spec, err := compileRun(ctx, task, s)
+	if err != nil {
+		return fail(err)
+	}
+	if err := applyResourceLimits(spec, task); err != nil {
+		return fail(err)
+	}
+	for _, name := range redirectTo(spec) {
+		log(fmt.Sprintf("Redirected %s into result %q instead of writing", name, name))
+	}
+
+	it, err := t.qs.Query(ctx, &query.Request{
+		Authorization:  auth,
+		OrganizationID: task.OrganizationID,
+		Compiler: lang.SpecCompiler{
+			Spec: spec,
+		},
+	})
+	if err != nil {
+		return fail(err)
+	}
+	defer it.Release()
+
+	enc := csv.NewResultEncoder(csv.DefaultEncoderConfig())
+	var encodeErr error
+	for it.More() {
+		res := it.Next()
+		if encodeErr != nil {
+			// Consume the results left so that we don't leak outstanding iterators.
+			exhaustResultIterators(res)
+			continue
+		}
+		var buf bytes.Buffer
+		if _, err := enc.Encode(&buf, res); err != nil {
+			t.logger.Info("Error encoding result of task test", zap.Error(err), zap.String("name", res.Name()))
+			// Consume the rest of the result so that we don't leak outstanding iterators.
+			exhaustResultIterators(res)
+			encodeErr = fmt.Errorf("failed to encode result %q: %v", res.Name(), err)
+			continue
+		}
+		run.Results = append(run.Results, influxdb.TaskTestResult{Name: res.Name(), CSV: buf.String()})
+	}
+
+	// Must call Release to ensure Statistics are ready.
+	it.Release()
+	if err := it.Err(); err != nil {
+		return fail(err)
+	}
+	if encodeErr != nil {
+		return fail(encodeErr)
+	}
+
+	stats := it.Statistics()
+	log(fmt.Sprintf("Completed successfully in %s", time.Duration(stats.TotalDuration)))
+	return run
+}
+
+// redirectTo replaces the to() operations of spec with yields of the data they would write,
+// named after the operations, and returns those names.
+func redirectTo(spec *flux.Spec) []string {
+	var names []string
+	for _, op := range spec.Operations {
+		if op.Spec.Kind() != fluxinfluxdb.ToKind {
+			continue
+		}
+		name := string(op.ID)
+		op.Spec = &universe.YieldOpSpec{Name: name}
+		names = append(names, name)
+	}
+	return names
+}
+
+// testAuthorization returns the authorization of ctx to query with in the organization orgID.
+func testAuthorization(ctx context.Context, orgID influxdb.ID) (*influxdb.Authorization, error) {
+	a, err := icontext.GetAuthorizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch a := a.(type) {
+	case *influxdb.Authorization:
+		return a, nil
+	case *influxdb.Session:
+		return a.EphemeralAuth(orgID), nil
+	default:
+		return nil, influxdb.ErrAuthorizerNotSupported
+	}
+}
+
+// latestScheduled returns the latest time a task with the options opt was scheduled for at now,
+// that is the time of the run a scheduler would have created last at now.
+// Tasks without a schedule of their own, which run for the times of their upstream runs, are scheduled for now.
+func latestScheduled(opt options.Options, now time.Time) (time.Time, error) {
+	effectiveCron := opt.EffectiveCronString()
+	if effectiveCron == "" {
+		return now, nil
+	}
+
+	due := now
+	if opt.Offset != nil {
+		offset, err := opt.Offset.DurationFrom(now)
+		if err != nil {
+			return time.Time{}, err
+		}
+		due = due.Add(-offset)
+	}
+
+	if opt.Cron == "" {
+		// Like the scheduler, align every schedules to multiples of their duration.
+		every, err := opt.Every.DurationFrom(due)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return due.Truncate(every), nil
+	}
+
+	sch, err := options.ParseSchedule(effectiveCron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	// Look back further and further, until the schedule was due in the time looked back over.
+	for lookback := time.Minute; lookback <= maxTestLookback; lookback *= 2 {
+		latest := sch.Next(due.Add(-lookback))
+		if latest.IsZero() || latest.After(due) {
+			continue
+		}
+		for next := sch.Next(latest); !next.IsZero() && !next.After(due); next = sch.Next(next) {
+			latest = next
+		}
+		return latest, nil
+	}
+	return time.Time{}, errors.New("task was not scheduled in the last 5 years")
+}
@@ -0,0 +1,169 @@
+package executor_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/lang"
+	platform "github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/inmem"
+	"github.com/influxdata/influxdb/kit/check"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/task"
+	"github.com/influxdata/influxdb/task/backend"
+	"github.com/influxdata/influxdb/task/backend/executor"
+	"go.uber.org/zap"
+)
+
+// specQueryService is a synchronous query service returning a fake result, and recording the specs it queried.
+type specQueryService struct {
+	specs []*flux.Spec
+	err   error
+	// tablesErr, if set, is returned when reading the tables of the result.
+	tablesErr error
+}
+
+func (s *specQueryService) Query(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+	sc, ok := req.Compiler.(lang.SpecCompiler)
+	if !ok {
+		return nil, fmt.Errorf("specQueryService only supports the SpecCompiler, got %T", req.Compiler)
+	}
+	s.specs = append(s.specs, sc.Spec)
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.tablesErr != nil {
+		return flux.NewSliceResultIterator([]flux.Result{newFakeResult(), errResult{err: s.tablesErr}}), nil
+	}
+	return flux.NewSliceResultIterator([]flux.Result{newFakeResult()}), nil
+}
+
+func (s *specQueryService) Check(ctx context.Context) check.Response {
+	return check.Response{Name: "specQueryService", Status: check.StatusPass}
+}
+
+// errResult is a flux.Result whose tables can't be read.
+type errResult struct {
+	err error
+}
+
+func (r errResult) Name() string                    { return "err" }
+func (r errResult) Tables() flux.TableIterator      { return r }
+func (r errResult) Do(func(flux.Table) error) error { return r.err }
+func (r errResult) Statistics() flux.Statistics     { return flux.Statistics{} }
+
+func TestTaskTester(t *testing.T) {
+	i := inmem.NewService()
+	tc := createCreds(t, i)
+	ts := task.PlatformAdapter(backend.NewInMemStore(), backend.NopLogReader{}, noopRunCanceler{}, i, i, i)
+	ctx := icontext.SetAuthorizer(context.Background(), tc.Auth)
+
+	const script = `option task = {name: "test", every: 1h, offset: 10m}
+
+from(bucket: "one") |> range(start: -1h) |> to(bucket: "two", org: "o")`
+
+	t.Run("redirects to", func(t *testing.T) {
+		qs := &specQueryService{}
+		tester := executor.NewTaskTester(zap.NewNop(), qs, ts)
+
+		runs, err := tester.TestTask(ctx, platform.TaskTest{
+			Flux:           script,
+			OrganizationID: tc.OrgID,
+			Now:            time.Date(2019, 1, 1, 12, 5, 0, 0, time.UTC),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(runs) != 1 {
+			t.Fatalf("expected 1 run, got %d", len(runs))
+		}
+		run := runs[0]
+		if run.Error != "" {
+			t.Fatalf("unexpected run error: %s", run.Error)
+		}
+		// The latest run due at 12:05 with an offset of 10m was scheduled for 11:00.
+		if run.ScheduledFor != "2019-01-01T11:00:00Z" {
+			t.Fatalf("expected run scheduled for 11:00, got %s", run.ScheduledFor)
+		}
+		if len(run.Results) != 1 || run.Results[0].Name != "res" || !strings.Contains(run.Results[0].CSV, "x") {
+			t.Fatalf("unexpected results: %+v", run.Results)
+		}
+
+		if len(qs.specs) != 1 {
+			t.Fatalf("expected 1 query, got %d", len(qs.specs))
+		}
+		for _, op := range qs.specs[0].Operations {
+			if op.Spec.Kind() == "to" {
+				t.Fatalf("expected to() to be redirected, got %s", op.ID)
+			}
+		}
+		if !strings.Contains(run.Logs[0].Message, "Redirected to") {
+			t.Fatalf("expected the redirection to be logged, got %q", run.Logs[0].Message)
+		}
+	})
+
+	t.Run("task ID and scheduled times", func(t *testing.T) {
+		created, err := ts.CreateTask(ctx, platform.TaskCreate{OrganizationID: tc.OrgID, Token: tc.Auth.Token, Flux: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		qs := &specQueryService{}
+		tester := executor.NewTaskTester(zap.NewNop(), qs, ts)
+		runs, err := tester.TestTask(ctx, platform.TaskTest{
+			TaskID: created.ID,
+			ScheduledFor: []time.Time{
+				time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC),
+				time.Date(2019, 1, 1, 11, 0, 0, 0, time.UTC),
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(runs) != 2 || len(qs.specs) != 2 {
+			t.Fatalf("expected 2 runs and queries, got %d and %d", len(runs), len(qs.specs))
+		}
+		if runs[0].ScheduledFor != "2019-01-01T10:00:00Z" || runs[1].ScheduledFor != "2019-01-01T11:00:00Z" {
+			t.Fatalf("unexpected scheduled times %s and %s", runs[0].ScheduledFor, runs[1].ScheduledFor)
+		}
+	})
+
+	t.Run("invalid script", func(t *testing.T) {
+		tester := executor.NewTaskTester(zap.NewNop(), &specQueryService{}, ts)
+		_, err := tester.TestTask(ctx, platform.TaskTest{Flux: `from(bucket: "one")`, OrganizationID: tc.OrgID})
+		if platform.ErrorCode(err) != platform.EInvalid {
+			t.Fatalf("expected invalid error, got %v", err)
+		}
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		tester := executor.NewTaskTester(zap.NewNop(), &specQueryService{err: errors.New("forced")}, ts)
+		runs, err := tester.TestTask(ctx, platform.TaskTest{Flux: script, OrganizationID: tc.OrgID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(runs) != 1 || runs[0].Error != "forced" {
+			t.Fatalf("expected a failed run, got %+v", runs)
+		}
+	})
+
+	t.Run("result error", func(t *testing.T) {
+		tester := executor.NewTaskTester(zap.NewNop(), &specQueryService{tablesErr: errors.New("forced")}, ts)
+		runs, err := tester.TestTask(ctx, platform.TaskTest{Flux: script, OrganizationID: tc.OrgID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(runs) != 1 || !strings.Contains(runs[0].Error, "forced") {
+			t.Fatalf("expected a failed run, got %+v", runs)
+		}
+		if len(runs[0].Results) != 0 {
+			t.Fatalf("expected the results of the failed run to be dropped, got %+v", runs[0].Results)
+		}
+	})
+}
@@ -33,6 +33,7 @@ func (r *runReaderWriter) UpdateRunState(ctx context.Context, rlb RunLogBase, wh
 		switch status {
 		case RunStarted:
 			r.StartedAt = whenStr
+			r.TaskRevision = rlb.Task.Revision
 		case RunFail, RunSuccess, RunCanceled:
 			r.FinishedAt = whenStr
 		}
@@ -77,6 +78,18 @@ func (r *runReaderWriter) AddRunLog(ctx context.Context, rlb RunLogBase, when ti
 	return nil
 }
 
+func (r *runReaderWriter) UpdateRunStatistics(ctx context.Context, rlb RunLogBase, when time.Time, stats platform.RunStatistics) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existingRun, ok := r.byRunID[rlb.RunID.String()]
+	if !ok {
+		return ErrRunNotFound
+	}
+
+	existingRun.Statistics = &stats
+	return nil
+}
+
 func (r *runReaderWriter) ListRuns(ctx context.Context, orgID platform.ID, runFilter platform.RunFilter) ([]*platform.Run, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"sync"
 	"time"
 
@@ -25,14 +27,24 @@ type inmem struct {
 	tasks []StoreTask
 
 	meta map[platform.ID]StoreTaskMeta
+
+	revisions map[platform.ID][]StoreTaskRevision
+
+	backfills map[platform.ID]map[platform.ID]StoreBackfill
+
+	upstreamSuccesses map[platform.ID]map[int64][]platform.ID
 }
 
 // NewInMemStore returns a new in-memory store.
 // This store is not designed to be efficient, it is here for testing purposes.
 func NewInMemStore() Store {
 	return &inmem{
-		idgen: snowflake.NewIDGenerator(),
-		meta:  map[platform.ID]StoreTaskMeta{},
+		idgen:     snowflake.NewIDGenerator(),
+		meta:      map[platform.ID]StoreTaskMeta{},
+		revisions: map[platform.ID][]StoreTaskRevision{},
+		backfills: map[platform.ID]map[platform.ID]StoreBackfill{},
+
+		upstreamSuccesses: map[platform.ID]map[int64][]platform.ID{},
 	}
 }
 
@@ -52,6 +64,8 @@ func (s *inmem) CreateTask(_ context.Context, req CreateTaskRequest) (platform.I
 		Name: o.Name,
 
 		Script: req.Script,
+
+		Revision: 1,
 	}
 
 	s.mu.Lock()
@@ -59,6 +73,13 @@ func (s *inmem) CreateTask(_ context.Context, req CreateTaskRequest) (platform.I
 
 	s.tasks = append(s.tasks, task)
 	s.meta[id] = NewStoreTaskMeta(req, o)
+	s.revisions[id] = []StoreTaskRevision{{
+		Revision:        1,
+		Script:          req.Script,
+		AuthorizationID: req.AuthorizationID,
+		Author:          req.Author,
+		CreatedAt:       time.Now().Unix(),
+	}}
 
 	return id, nil
 }
@@ -75,11 +96,13 @@ func (s *inmem) UpdateTask(_ context.Context, req UpdateTaskRequest) (UpdateTask
 	defer s.mu.Unlock()
 
 	found := false
+	idx := 0
 	for n, t := range s.tasks {
 		if t.ID != req.ID {
 			continue
 		}
 		found = true
+		idx = n
 
 		res.OldScript = t.Script
 		if err = req.UpdateFlux(t.Script); err != nil {
@@ -110,6 +133,7 @@ func (s *inmem) UpdateTask(_ context.Context, req UpdateTaskRequest) (UpdateTask
 
 	stm.UpdatedAt = time.Now().Unix()
 	res.OldStatus = TaskStatus(stm.Status)
+	oldAuthz := platform.ID(stm.AuthorizationID)
 
 	if req.Status != "" {
 		// Changing the status.
@@ -122,6 +146,24 @@ func (s *inmem) UpdateTask(_ context.Context, req UpdateTaskRequest) (UpdateTask
 
 	s.meta[req.ID] = stm
 
+	if res.NewTask.Script != res.OldScript || platform.ID(stm.AuthorizationID) != oldAuthz {
+		revs := s.revisions[req.ID]
+		if len(revs) == 0 {
+			// The task was stored before revisions were recorded; keep what it was before this update.
+			revs = append(revs, StoreTaskRevision{Revision: 1, Script: res.OldScript, AuthorizationID: oldAuthz})
+		}
+		rev := StoreTaskRevision{
+			Revision:        revs[len(revs)-1].Revision + 1,
+			Script:          res.NewTask.Script,
+			AuthorizationID: platform.ID(stm.AuthorizationID),
+			Author:          req.Author,
+			CreatedAt:       stm.UpdatedAt,
+		}
+		s.revisions[req.ID] = append(revs, rev)
+		s.tasks[idx].Revision = rev.Revision
+		res.NewTask.Revision = rev.Revision
+	}
+
 	res.NewMeta = stm
 	return res, nil
 }
@@ -247,9 +289,128 @@ func (s *inmem) DeleteTask(_ context.Context, id platform.ID) (deleted bool, err
 	// Delete entry from slice.
 	s.tasks = append(s.tasks[:idx], s.tasks[idx+1:]...)
 	delete(s.meta, id)
+	delete(s.revisions, id)
+	delete(s.backfills, id)
+	delete(s.upstreamSuccesses, id)
 	return true, nil
 }
 
+func (s *inmem) FindTaskRevisions(_ context.Context, id platform.ID) ([]StoreTaskRevision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.meta[id]; !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	// Return a copy of the revisions.
+	return append([]StoreTaskRevision(nil), s.revisions[id]...), nil
+}
+
+func (s *inmem) AddUpstreamSuccess(_ context.Context, taskID, upstreamID platform.ID, now int64) ([]platform.ID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.meta[taskID]; !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	pending, ok := s.upstreamSuccesses[taskID]
+	if !ok {
+		pending = make(map[int64][]platform.ID)
+		s.upstreamSuccesses[taskID] = pending
+	}
+	ids := pending[now]
+	for _, id := range ids {
+		if id == upstreamID {
+			return append([]platform.ID(nil), ids...), nil
+		}
+	}
+	ids = append(ids, upstreamID)
+	pending[now] = ids
+
+	if len(pending) > MaxPendingUpstreamRuns {
+		oldest := int64(math.MaxInt64)
+		for t := range pending {
+			if t < oldest {
+				oldest = t
+			}
+		}
+		delete(pending, oldest)
+	}
+	return append([]platform.ID(nil), ids...), nil
+}
+
+func (s *inmem) DeleteUpstreamSuccesses(_ context.Context, taskID platform.ID, now int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.upstreamSuccesses[taskID], now)
+	return nil
+}
+
+func (s *inmem) PutBackfill(_ context.Context, bf StoreBackfill) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.meta[bf.TaskID]; !ok {
+		return ErrTaskNotFound
+	}
+
+	bfs, ok := s.backfills[bf.TaskID]
+	if !ok {
+		bfs = make(map[platform.ID]StoreBackfill)
+		s.backfills[bf.TaskID] = bfs
+	}
+	bfs[bf.ID] = bf
+	return nil
+}
+
+func (s *inmem) FindBackfills(_ context.Context, taskID platform.ID) ([]StoreBackfill, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.meta[taskID]; !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	bfs := make([]StoreBackfill, 0, len(s.backfills[taskID]))
+	for _, bf := range s.backfills[taskID] {
+		bfs = append(bfs, bf)
+	}
+	sort.Slice(bfs, func(i, j int) bool {
+		return bfs[i].ID < bfs[j].ID
+	})
+	return bfs, nil
+}
+
+func (s *inmem) ListBackfills(_ context.Context) ([]StoreBackfill, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var bfs []StoreBackfill
+	for _, byID := range s.backfills {
+		for _, bf := range byID {
+			bfs = append(bfs, bf)
+		}
+	}
+	sort.Slice(bfs, func(i, j int) bool {
+		if bfs[i].TaskID != bfs[j].TaskID {
+			return bfs[i].TaskID < bfs[j].TaskID
+		}
+		return bfs[i].ID < bfs[j].ID
+	})
+	return bfs, nil
+}
+
+func (s *inmem) DeleteBackfill(_ context.Context, taskID, id platform.ID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.backfills[taskID], id)
+	return nil
+}
+
 func (s *inmem) Close() error {
 	return nil
 }
@@ -297,6 +458,41 @@ func (s *inmem) FinishRun(ctx context.Context, taskID, runID platform.ID) error
 	return nil
 }
 
+func (s *inmem) IncrementRunTry(ctx context.Context, taskID, runID platform.ID) (uint32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stm, ok := s.meta[taskID]
+	if !ok {
+		return 0, errors.New("taskRunner not found")
+	}
+
+	try, ok := stm.IncrementRunTry(runID)
+	if !ok {
+		return 0, errors.New("run not found")
+	}
+
+	s.meta[taskID] = stm
+	return try, nil
+}
+
+func (s *inmem) SkipMissedRuns(_ context.Context, taskID platform.ID, now int64, maxRuns int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stm, ok := s.meta[taskID]
+	if !ok {
+		return ErrTaskNotFound
+	}
+
+	if err := stm.SkipMissedRuns(now, maxRuns); err != nil {
+		return err
+	}
+
+	s.meta[taskID] = stm
+	return nil
+}
+
 func (s *inmem) ManuallyRunTimeRange(_ context.Context, taskID platform.ID, start, end, requestedAt int64) (*StoreTaskMetaManualRun, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -342,7 +538,10 @@ func (s *inmem) delete(ctx context.Context, id platform.ID, f func(StoreTask) pl
 	default:
 	}
 	for i := range deletingTasks {
-		delete(s.meta, s.tasks[i].ID)
+		delete(s.meta, deletingTasks[i])
+		delete(s.revisions, deletingTasks[i])
+		delete(s.backfills, deletingTasks[i])
+		delete(s.upstreamSuccesses, deletingTasks[i])
 	}
 	s.tasks = newTasks
 	return nil
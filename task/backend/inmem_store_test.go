@@ -22,3 +22,13 @@ func TestInMemStore(t *testing.T) {
 		func(t *testing.T, s backend.Store) {},
 	)(t)
 }
+
+func TestInMemStore_MultiNode(t *testing.T) {
+	storetest.NewMultiNodeTest(
+		"in-mem store",
+		func(t *testing.T) backend.Store {
+			return backend.NewInMemStore()
+		},
+		func(t *testing.T, s backend.Store) {},
+	)(t)
+}
@@ -72,6 +72,18 @@ func (stm *StoreTaskMeta) AlignLatestCompleted() {
 
 }
 
+// IncrementRunTry increments the try of the currently running run matching runID,
+// returning the new try and whether the run was found.
+func (stm *StoreTaskMeta) IncrementRunTry(runID platform.ID) (uint32, bool) {
+	for _, runner := range stm.CurrentlyRunning {
+		if platform.ID(runner.RunID) == runID {
+			runner.Try++
+			return runner.Try, true
+		}
+	}
+	return 0, false
+}
+
 // FinishRun removes the run matching runID from m's CurrentlyRunning slice,
 // and if that run's Now value is greater than m's LatestCompleted value,
 // updates the value of LatestCompleted to the run's Now value.
@@ -121,9 +133,17 @@ func (stm *StoreTaskMeta) CreateNextRun(now int64, makeID func() (platform.ID, e
 		return RunCreation{}, errors.New("cannot create next run when max concurrency already reached")
 	}
 
+	if stm.EffectiveCron == "" {
+		// A task without a schedule, depending on other tasks, only runs from its queue.
+		if len(stm.ManualRuns) > 0 {
+			return stm.createNextRunFromQueue(now, math.MaxInt64, nil, makeID)
+		}
+		return RunCreation{}, RunNotYetDueError{DueAt: math.MaxInt64}
+	}
+
 	// Not calling stm.DueAt here because we reuse sch.
 	// We can definitely optimize (minimize) cron parsing at a later point in time.
-	sch, err := cron.Parse(stm.EffectiveCron)
+	sch, err := options.ParseSchedule(stm.EffectiveCron)
 	if err != nil {
 		return RunCreation{}, err
 	}
@@ -180,6 +200,7 @@ func (stm *StoreTaskMeta) CreateNextRun(now int64, makeID func() (platform.ID, e
 
 // createNextRunFromQueue creates the next run from a queue.
 // This should only be called when the queue is not empty.
+// sch is nil for tasks without a schedule, which can only queue runs for a single time.
 func (stm *StoreTaskMeta) createNextRunFromQueue(now, nextDue int64, sch cron.Schedule, makeID func() (platform.ID, error)) (RunCreation, error) {
 	if len(stm.ManualRuns) == 0 {
 		return RunCreation{}, errors.New("cannot create run from empty queue")
@@ -197,7 +218,16 @@ func (stm *StoreTaskMeta) createNextRunFromQueue(now, nextDue int64, sch cron.Sc
 		}
 	}
 
-	runNow := sch.Next(time.Unix(latest, 0)).Unix()
+	var runNow int64
+	switch {
+	case q.Start == q.End:
+		// A single run, as requested by ForceRun, runs for exactly the requested time.
+		runNow = q.Start
+	case sch == nil:
+		return RunCreation{}, errors.New("cannot run a time range of a task without a schedule")
+	default:
+		runNow = sch.Next(time.Unix(latest, 0)).Unix()
+	}
 
 	// Already validated that we have room to create another run, in CreateNextRun.
 	id := platform.ID(q.RunID)
@@ -238,8 +268,13 @@ func (stm *StoreTaskMeta) createNextRunFromQueue(now, nextDue int64, sch cron.Sc
 
 // NextDueRun returns the Unix timestamp of when the next call to CreateNextRun will be ready.
 // The returned timestamp reflects the task's delay, so it does not necessarily exactly match the schedule time.
+// Tasks without a schedule are never due; they only run from their queue.
 func (stm *StoreTaskMeta) NextDueRun() (int64, error) {
-	sch, err := cron.Parse(stm.EffectiveCron)
+	if stm.EffectiveCron == "" {
+		return math.MaxInt64, nil
+	}
+
+	sch, err := options.ParseSchedule(stm.EffectiveCron)
 	if err != nil {
 		return 0, err
 	}
@@ -263,6 +298,70 @@ func (stm *StoreTaskMeta) NextDueRun() (int64, error) {
 	return nextDue.Unix(), nil
 }
 
+// SkipMissedRuns advances stm's LatestCompleted timestamp so that,
+// of the runs due no later than now that are later than any in-progress run and LatestCompleted,
+// at most the maxRuns most recent ones remain to be created.
+// A negative maxRuns skips no runs.
+func (stm *StoreTaskMeta) SkipMissedRuns(now int64, maxRuns int) error {
+	latest := stm.LatestCompleted
+	for _, cr := range stm.CurrentlyRunning {
+		if cr.Now > latest {
+			latest = cr.Now
+		}
+	}
+
+	lc, err := CatchupLatestCompleted(stm.EffectiveCron, stm.Offset, latest, now, maxRuns)
+	if err != nil {
+		return err
+	}
+	if lc > latest {
+		stm.LatestCompleted = lc
+	}
+	return nil
+}
+
+// CatchupLatestCompleted returns the latest completed Unix timestamp from which a task with the given effective cron and offset
+// has at most maxRuns runs due no later than now, skipping the earliest runs due after latest.
+// If maxRuns is negative or no runs need to be skipped, latest is returned unchanged.
+func CatchupLatestCompleted(effectiveCron, offset string, latest, now int64, maxRuns int) (int64, error) {
+	if maxRuns < 0 || effectiveCron == "" {
+		return latest, nil
+	}
+
+	sch, err := options.ParseSchedule(effectiveCron)
+	if err != nil {
+		return 0, err
+	}
+	off := &options.Duration{}
+	if offset != "" {
+		if err := off.Parse(offset); err != nil {
+			return 0, err
+		}
+	}
+
+	// The times of the last maxRuns+1 runs due, oldest first.
+	// Once there are that many, the oldest of them becomes the latest completed.
+	due := make([]int64, 0, 8)
+	for next := sch.Next(time.Unix(latest, 0)); !next.IsZero(); next = sch.Next(next) {
+		dueAt, err := off.Add(next)
+		if err != nil {
+			return 0, err
+		}
+		if dueAt.Unix() > now {
+			break
+		}
+		if len(due) == maxRuns+1 {
+			due = append(due[:0], due[1:]...)
+		}
+		due = append(due, next.Unix())
+	}
+
+	if len(due) <= maxRuns {
+		return latest, nil
+	}
+	return due[0], nil
+}
+
 // ManuallyRunTimeRange requests a manual run covering the approximate range specified by the Unix timestamps start and end.
 // More specifically, it requests runs scheduled no earlier than start, but possibly later than start,
 // if start does not land on the task's schedule; and as late as, but not necessarily equal to, end.
@@ -213,6 +213,64 @@ func TestMeta_CreateNextRun_Delay(t *testing.T) {
 	}
 }
 
+func TestMeta_CreateNextRun_Location(t *testing.T) {
+	latest, _ := time.Parse(time.RFC3339, "2019-07-01T00:00:00Z")
+	stm := backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		Status:          "enabled",
+		EffectiveCron:   "TZ=Europe/Paris 0 6 * * *", // 06:00 in Paris, at UTC+2 in July.
+		LatestCompleted: latest.Unix(),
+	}
+
+	rc, err := stm.CreateNextRun(latest.Add(24*time.Hour).Unix(), makeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := latest.Add(4 * time.Hour).Unix(); rc.Created.Now != exp {
+		t.Fatalf("expected created run to have time %d, got %d", exp, rc.Created.Now)
+	}
+	if exp := latest.Add(28 * time.Hour).Unix(); rc.NextDue != exp {
+		t.Fatalf("expected next run due at %d, got %d", exp, rc.NextDue)
+	}
+}
+
+func TestMeta_SkipMissedRuns(t *testing.T) {
+	for _, c := range []struct {
+		maxRuns int
+		running []int64
+		expLC   int64
+	}{
+		// Runs at 60, 120, 180 and 240 are due by 300, with the 5s offset.
+		{maxRuns: -1, expLC: 30},
+		{maxRuns: 10, expLC: 30},
+		{maxRuns: 4, expLC: 30},
+		{maxRuns: 2, expLC: 120},
+		{maxRuns: 1, expLC: 180},
+		{maxRuns: 0, expLC: 240},
+		// A run in progress at 120 leaves only the runs at 180 and 240 due.
+		{maxRuns: 1, running: []int64{120}, expLC: 180},
+		{maxRuns: 2, running: []int64{120}, expLC: 30},
+	} {
+		stm := backend.StoreTaskMeta{
+			MaxConcurrency:  2,
+			Status:          "enabled",
+			EffectiveCron:   "* * * * *", // Every minute.
+			Offset:          "5s",
+			LatestCompleted: 30,
+		}
+		for _, r := range c.running {
+			stm.CurrentlyRunning = append(stm.CurrentlyRunning, &backend.StoreTaskMetaRun{Now: r, Try: 1, RunID: uint64(r)})
+		}
+
+		if err := stm.SkipMissedRuns(300, c.maxRuns); err != nil {
+			t.Fatal(err)
+		}
+		if stm.LatestCompleted != c.expLC {
+			t.Fatalf("maxRuns %d, running %v: expected latest completed %d, got %d", c.maxRuns, c.running, c.expLC, stm.LatestCompleted)
+		}
+	}
+}
+
 func TestMeta_ManuallyRunTimeRange(t *testing.T) {
 	now := time.Now().Unix()
 	stm := backend.StoreTaskMeta{
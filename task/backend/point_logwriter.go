@@ -15,6 +15,18 @@ const (
 	scheduledForField = "scheduledFor"
 	requestedAtField  = "requestedAt"
 	statusField       = "status"
+	taskRevisionField = "taskRevision"
+
+	queueDelayField      = "queueDelay"
+	queryDurationField   = "queryDuration"
+	pointsWrittenField   = "pointsWritten"
+	bytesWrittenField    = "bytesWritten"
+	rowsReadField        = "rowsRead"
+	compileDurationField = "compileDuration"
+	planDurationField    = "planDuration"
+	executeDurationField = "executeDuration"
+	concurrencyField     = "concurrency"
+	maxAllocatedField    = "maxAllocated"
 
 	taskIDTag = "taskID"
 
@@ -54,9 +66,23 @@ func (p *PointLogWriter) UpdateRunState(ctx context.Context, rlb RunLogBase, whe
 	if err != nil {
 		return err
 	}
+	pts := []models.Point{pt}
+
+	// Record the revision of the task that the run executes.
+	// It is stored apart from the records, which are pivoted on a fixed set of fields when read.
+	if status == RunStarted && rlb.Task.Revision > 0 {
+		pt, err := models.NewPoint("revisions", tags, map[string]interface{}{
+			runIDField:        rlb.RunID.String(),
+			taskRevisionField: int64(rlb.Task.Revision),
+		}, when)
+		if err != nil {
+			return err
+		}
+		pts = append(pts, pt)
+	}
 
 	// TODO(mr): it would probably be lighter-weight to just build exploded points in the first place.
-	exploded, err := tsdb.ExplodePoints(rlb.Task.Org, taskSystemBucketID, []models.Point{pt})
+	exploded, err := tsdb.ExplodePoints(rlb.Task.Org, taskSystemBucketID, pts)
 	if err != nil {
 		return err
 	}
@@ -85,3 +111,34 @@ func (p *PointLogWriter) AddRunLog(ctx context.Context, rlb RunLogBase, when tim
 
 	return p.pointsWriter.WritePoints(ctx, exploded)
 }
+
+func (p *PointLogWriter) UpdateRunStatistics(ctx context.Context, rlb RunLogBase, when time.Time, stats platform.RunStatistics) error {
+	tags := models.Tags{
+		models.NewTag([]byte(taskIDTag), []byte(rlb.Task.ID.String())),
+	}
+	fields := map[string]interface{}{
+		runIDField:           rlb.RunID.String(),
+		queueDelayField:      int64(stats.QueueDelay),
+		queryDurationField:   int64(stats.QueryDuration),
+		pointsWrittenField:   stats.PointsWritten,
+		bytesWrittenField:    stats.BytesWritten,
+		rowsReadField:        stats.RowsRead,
+		compileDurationField: int64(stats.CompileDuration),
+		planDurationField:    int64(stats.PlanDuration),
+		executeDurationField: int64(stats.ExecuteDuration),
+		concurrencyField:     stats.Concurrency,
+		maxAllocatedField:    stats.MaxAllocated,
+	}
+	pt, err := models.NewPoint("statistics", tags, fields, when)
+	if err != nil {
+		return err
+	}
+
+	// TODO(mr): it would probably be lighter-weight to just build exploded points in the first place.
+	exploded, err := tsdb.ExplodePoints(rlb.Task.Org, taskSystemBucketID, []models.Point{pt})
+	if err != nil {
+		return err
+	}
+
+	return p.pointsWriter.WritePoints(ctx, exploded)
+}
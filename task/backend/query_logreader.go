@@ -121,8 +121,16 @@ from(bucketID: "000000000000000a")
 	|> filter(fn: (r) => r.scheduledFor < %q and r.scheduledFor > %q and r.runID > %q)
 	%s
 	%s
+	|> yield(name: "result")
+
+from(bucketID: "000000000000000a")
+  |> range(start: -24h)
+	|> filter(fn: (r) => (r._measurement == "statistics" or r._measurement == "revisions") and r.taskID == %q)
+	|> drop(columns: ["_start", "_stop"])
+	|> v1.fieldsAsCols()
+	|> yield(name: "details")
 	`
-	listScript := fmt.Sprintf(listFmtString, runFilter.Task.String(), scheduledBefore, scheduledAfter, afterID, pivotWithRequestedAt, limit)
+	listScript := fmt.Sprintf(listFmtString, runFilter.Task.String(), scheduledBefore, scheduledAfter, afterID, pivotWithRequestedAt, limit, runFilter.Task.String())
 
 	auth, err := pctx.GetAuthorizer(ctx)
 	if err != nil {
@@ -141,7 +149,7 @@ from(bucketID: "000000000000000a")
 	runs, err := queryIttrToRuns(ittr)
 	if err != nil {
 		// try re running the script without the requested at
-		listScript := fmt.Sprintf(listFmtString, runFilter.Task.String(), scheduledBefore, scheduledAfter, afterID, pivotWithOutRequestedAt, limit)
+		listScript := fmt.Sprintf(listFmtString, runFilter.Task.String(), scheduledBefore, scheduledAfter, afterID, pivotWithOutRequestedAt, limit, runFilter.Task.String())
 		request := &query.Request{Authorization: auth.(*platform.Authorization), OrganizationID: orgID, Compiler: lang.FluxCompiler{Query: listScript}}
 
 		ittr, err := qlr.queryService.Query(ctx, request)
@@ -172,6 +180,14 @@ logs = from(bucketID: "000000000000000a")
 	|> filter(fn: (r) => r.runID == %q)
 	|> yield(name: "logs")
 
+details = from(bucketID: "000000000000000a")
+	|> range(start: -24h)
+	|> filter(fn: (r) => r._measurement == "statistics" or r._measurement == "revisions")
+	|> drop(columns: ["_start", "_stop"])
+	|> v1.fieldsAsCols()
+	|> filter(fn: (r) => r.runID == %q)
+	|> yield(name: "details")
+
 from(bucketID: "000000000000000a")
   |> range(start: -24h)
 	|> filter(fn: (r) => r._measurement == "records")
@@ -182,7 +198,7 @@ from(bucketID: "000000000000000a")
 	%s
 	|> yield(name: "result")
   `
-	showScript := fmt.Sprintf(showFmtScript, runID.String(), runID.String(), pivotWithRequestedAt)
+	showScript := fmt.Sprintf(showFmtScript, runID.String(), runID.String(), runID.String(), pivotWithRequestedAt)
 
 	auth, err := pctx.GetAuthorizer(ctx)
 	if err != nil {
@@ -199,7 +215,7 @@ from(bucketID: "000000000000000a")
 	}
 	runs, err := queryIttrToRuns(ittr)
 	if err != nil {
-		showScript := fmt.Sprintf(showFmtScript, runID.String(), runID.String(), pivotWithOutRequestedAt)
+		showScript := fmt.Sprintf(showFmtScript, runID.String(), runID.String(), runID.String(), pivotWithOutRequestedAt)
 		request := &query.Request{Authorization: auth.(*platform.Authorization), OrganizationID: orgID, Compiler: lang.FluxCompiler{Query: showScript}}
 
 		ittr, err := qlr.queryService.Query(ctx, request)
@@ -242,11 +258,17 @@ func queryIttrToRuns(results flux.ResultIterator) ([]*platform.Run, error) {
 
 // runExtractor is used to decode query results to runs.
 type runExtractor struct {
-	runs map[platform.ID]platform.Run
+	runs       map[platform.ID]platform.Run
+	statistics map[platform.ID]platform.RunStatistics
+	revisions  map[platform.ID]int
 }
 
 func newRunExtractor() *runExtractor {
-	return &runExtractor{runs: make(map[platform.ID]platform.Run)}
+	return &runExtractor{
+		runs:       make(map[platform.ID]platform.Run),
+		statistics: make(map[platform.ID]platform.RunStatistics),
+		revisions:  make(map[platform.ID]int),
+	}
 }
 
 // Runs returns the runExtractor's stored runs as a slice.
@@ -254,6 +276,10 @@ func (re *runExtractor) Runs() []*platform.Run {
 	runs := make([]*platform.Run, 0, len(re.runs))
 	for _, r := range re.runs {
 		r := r
+		if stats, ok := re.statistics[r.ID]; ok {
+			r.Statistics = &stats
+		}
+		r.TaskRevision = re.revisions[r.ID]
 		runs = append(runs, &r)
 	}
 
@@ -277,6 +303,10 @@ func (re *runExtractor) Extract(tbl flux.Table) error {
 		return tbl.Do(re.extractRecord)
 	case "logs":
 		return tbl.Do(re.extractLog)
+	case "statistics":
+		return tbl.Do(re.extractStatistics)
+	case "revisions":
+		return tbl.Do(re.extractRevisions)
 	default:
 		return fmt.Errorf("unknown measurement: %q", mv.Str())
 	}
@@ -366,3 +396,83 @@ func (re *runExtractor) extractLog(cr flux.ColReader) error {
 
 	return nil
 }
+
+func (re *runExtractor) extractStatistics(cr flux.ColReader) error {
+	for i := 0; i < cr.Len(); i++ {
+		var runID platform.ID
+		var stats platform.RunStatistics
+		for j, col := range cr.Cols() {
+			if col.Label == runIDField {
+				id, err := platform.IDFromString(cr.Strings(j).ValueString(i))
+				if err != nil {
+					return err
+				}
+				runID = *id
+				continue
+			}
+			if col.Type != flux.TInt {
+				continue
+			}
+			v := cr.Ints(j).Value(i)
+			switch col.Label {
+			case queueDelayField:
+				stats.QueueDelay = time.Duration(v)
+			case queryDurationField:
+				stats.QueryDuration = time.Duration(v)
+			case pointsWrittenField:
+				stats.PointsWritten = v
+			case bytesWrittenField:
+				stats.BytesWritten = v
+			case rowsReadField:
+				stats.RowsRead = v
+			case compileDurationField:
+				stats.CompileDuration = time.Duration(v)
+			case planDurationField:
+				stats.PlanDuration = time.Duration(v)
+			case executeDurationField:
+				stats.ExecuteDuration = time.Duration(v)
+			case concurrencyField:
+				stats.Concurrency = v
+			case maxAllocatedField:
+				stats.MaxAllocated = v
+			}
+		}
+
+		if !runID.Valid() {
+			return errors.New("extractStatistics: did not find valid run ID in table")
+		}
+
+		re.statistics[runID] = stats
+	}
+
+	return nil
+}
+
+func (re *runExtractor) extractRevisions(cr flux.ColReader) error {
+	for i := 0; i < cr.Len(); i++ {
+		var runID platform.ID
+		var revision int
+		for j, col := range cr.Cols() {
+			switch col.Label {
+			case runIDField:
+				id, err := platform.IDFromString(cr.Strings(j).ValueString(i))
+				if err != nil {
+					return err
+				}
+				runID = *id
+			case taskRevisionField:
+				if col.Type == flux.TInt {
+					revision = int(cr.Ints(j).Value(i))
+				}
+			}
+		}
+
+		if !runID.Valid() {
+			return errors.New("extractRevisions: did not find valid run ID in table")
+		}
+
+		re.revisions[runID] = revision
+	}
+
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/influxdata/flux"
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/task/options"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -29,6 +30,15 @@ var (
 	ErrTaskAlreadyClaimed = errors.New("task already claimed")
 )
 
+// RunTimedOutError is the error of a run attempt canceled for running longer than the timeout option of its task.
+type RunTimedOutError struct {
+	Timeout time.Duration
+}
+
+func (e RunTimedOutError) Error() string {
+	return "run exceeded timeout of " + e.Timeout.String()
+}
+
 // Executor handles execution of a run.
 type Executor interface {
 	// Execute attempts to begin execution of a run.
@@ -149,6 +159,22 @@ func WithLogger(logger *zap.Logger) TickSchedulerOption {
 	}
 }
 
+// Default backoffs of the retries of failed runs.
+const (
+	DefaultRetryBackoff    = time.Second
+	DefaultMaxRetryBackoff = time.Minute
+)
+
+// WithRetryBackoff sets the backoff before the first retry of a failed run, which doubles for
+// every following retry of the run up to max. Runs are retried up to the number of times set by
+// the retry option of their task.
+func WithRetryBackoff(backoff, max time.Duration) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		s.retryBackoff = backoff
+		s.maxRetryBackoff = max
+	}
+}
+
 // NewScheduler returns a new scheduler with the given desired state and the given now UTC timestamp.
 func NewScheduler(taskControlService TaskControlService, executor Executor, now int64, opts ...TickSchedulerOption) *TickScheduler {
 	o := &TickScheduler{
@@ -159,6 +185,9 @@ func NewScheduler(taskControlService TaskControlService, executor Executor, now
 		logger:             zap.NewNop(),
 		wg:                 &sync.WaitGroup{},
 		metrics:            newSchedulerMetrics(),
+		retryBackoff:       DefaultRetryBackoff,
+		maxRetryBackoff:    DefaultMaxRetryBackoff,
+		succeeded:          make(chan succeededRun, 64),
 	}
 
 	for _, opt := range opts {
@@ -177,12 +206,24 @@ type TickScheduler struct {
 
 	metrics *schedulerMetrics
 
+	// Backoff before the first retry of a failed run and the maximum backoff.
+	retryBackoff, maxRetryBackoff time.Duration
+
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     *sync.WaitGroup
 
 	schedulerMu    sync.Mutex                     // Protects access and modification of taskSchedulers map.
 	taskSchedulers map[platform.ID]*taskScheduler // task ID -> task scheduler.
+
+	// Runs that succeeded, to trigger the tasks depending on their task.
+	succeeded chan succeededRun
+}
+
+// succeededRun is a run of a task that succeeded.
+type succeededRun struct {
+	taskID platform.ID
+	now    int64
 }
 
 // CancelRun cancels a run, it has the unused Context argument so that it can implement a task.RunController
@@ -242,6 +283,91 @@ func (s *TickScheduler) Start(ctx context.Context) {
 	defer s.schedulerMu.Unlock()
 
 	s.ctx, s.cancel = context.WithCancel(ctx)
+	go s.watchSucceeded(s.ctx)
+}
+
+// watchSucceeded triggers the tasks depending on the tasks of succeeded runs, until ctx is done.
+// It runs apart from the runners, as Stop holds schedulerMu while waiting on them.
+func (s *TickScheduler) watchSucceeded(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sr := <-s.succeeded:
+			s.triggerDependents(ctx, sr)
+		}
+	}
+}
+
+// triggerDependents records that the run of sr succeeded through the TaskControlService,
+// and queues a run for the same time of every task whose upstream tasks have all succeeded for it.
+// Failed runs are not recorded, so after an upstream run fails,
+// its dependents run for that time once a later run of the failed task for the same time succeeds.
+func (s *TickScheduler) triggerDependents(ctx context.Context, sr succeededRun) {
+	s.schedulerMu.Lock()
+	defer s.schedulerMu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return
+	default:
+	}
+
+	up, ok := s.taskSchedulers[sr.taskID]
+	if !ok {
+		return
+	}
+
+	for id, ts := range s.taskSchedulers {
+		if !ts.dependsOnTask(up.task) {
+			continue
+		}
+
+		ids, err := s.taskControlService.AddUpstreamSuccess(ctx, id, sr.taskID, sr.now)
+		if err != nil {
+			ts.logger.Info("Failed to record upstream success", zap.String("upstream_task_id", sr.taskID.String()), zap.Int64("now", sr.now), zap.Error(err))
+			continue
+		}
+		succeeded := make(map[platform.ID]bool, len(ids))
+		for _, id := range ids {
+			succeeded[id] = true
+		}
+		if !s.upstreamSucceeded(ts, succeeded) {
+			continue
+		}
+
+		ts.nextDueMu.RLock()
+		authCtx := ts.authCtx
+		ts.nextDueMu.RUnlock()
+		if _, err := s.taskControlService.ForceRun(authCtx, id, sr.now); err != nil {
+			ts.logger.Info("Failed to queue run after upstream tasks succeeded", zap.Int64("now", sr.now), zap.Error(err))
+			continue
+		}
+		if err := s.taskControlService.DeleteUpstreamSuccesses(ctx, id, sr.now); err != nil {
+			ts.logger.Info("Failed to delete upstream successes", zap.Int64("now", sr.now), zap.Error(err))
+		}
+		ts.nextDueMu.Lock()
+		ts.hasQueue = true
+		ts.nextDueMu.Unlock()
+		ts.Work()
+	}
+}
+
+// upstreamSucceeded reports whether every dependsOn entry of ts names a claimed task in succeeded.
+func (s *TickScheduler) upstreamSucceeded(ts *taskScheduler, succeeded map[platform.ID]bool) bool {
+	for _, d := range ts.dependsOn {
+		found := false
+		for id, u := range s.taskSchedulers {
+			if succeeded[id] && u.task.OrganizationID == ts.task.OrganizationID && dependsOnTask(d, u.task) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
 }
 
 func (s *TickScheduler) Stop() {
@@ -284,14 +410,29 @@ func (s *TickScheduler) ClaimTask(authCtx context.Context, task *platform.Task)
 
 	defer s.metrics.ClaimTask(err == nil)
 
-	ts, err := newTaskScheduler(s.ctx, authCtx, s.wg, s, task, s.metrics)
+	_, ok := s.taskSchedulers[task.ID]
+	if ok {
+		return ErrTaskAlreadyClaimed
+	}
+
+	// Skip the runs missed while the task was not scheduled, that its catchup option doesn't allow.
+	opt, err := options.FromScript(task.Flux)
+	if err != nil {
+		return err
+	}
+	maxRuns, err := opt.MaxCatchupRuns()
 	if err != nil {
 		return err
 	}
+	if maxRuns >= 0 {
+		if err := s.taskControlService.SkipMissedRuns(authCtx, task.ID, atomic.LoadInt64(&s.now), maxRuns); err != nil {
+			return err
+		}
+	}
 
-	_, ok := s.taskSchedulers[task.ID]
-	if ok {
-		return ErrTaskAlreadyClaimed
+	ts, err := newTaskScheduler(s.ctx, authCtx, s.wg, s, task, s.metrics)
+	if err != nil {
+		return err
 	}
 
 	s.taskSchedulers[task.ID] = ts
@@ -328,6 +469,7 @@ func (s *TickScheduler) UpdateTask(authCtx context.Context, task *platform.Task)
 		return ErrTaskNotClaimed
 	}
 	ts.task = task
+	ts.dependsOn = opt.DependsOn
 
 	next, err := s.taskControlService.NextDueRun(authCtx, task.ID)
 	if err != nil {
@@ -407,6 +549,13 @@ type taskScheduler struct {
 	// Task we are scheduling for.
 	task *platform.Task
 
+	// Tasks whose success triggers a run of this task, from its dependsOn option.
+	// Guarded by the outer scheduler's schedulerMu.
+	dependsOn []string
+
+	// Where to report succeeded runs, to trigger the tasks depending on this task.
+	succeeded chan<- succeededRun
+
 	// Authorization context for using the TaskControlService
 	authCtx context.Context
 
@@ -423,6 +572,9 @@ type taskScheduler struct {
 
 	metrics *schedulerMetrics
 
+	// Backoff before the first retry of a failed run and the maximum backoff.
+	retryBackoff, maxRetryBackoff time.Duration
+
 	nextDueMu     sync.RWMutex // Protects following fields.
 	nextDue       int64        // Unix timestamp of next due.
 	nextDueSource int64        // Run time that produced nextDue.
@@ -459,6 +611,8 @@ func newTaskScheduler(
 	ts := &taskScheduler{
 		now:           &s.now,
 		task:          task,
+		dependsOn:     opt.DependsOn,
+		succeeded:     s.succeeded,
 		authCtx:       authCtx,
 		cancel:        cancel,
 		wg:            wg,
@@ -469,6 +623,9 @@ func newTaskScheduler(
 		nextDue:       firstDue,
 		nextDueSource: math.MinInt64,
 		hasQueue:      len(runs) > 0,
+
+		retryBackoff:    s.retryBackoff,
+		maxRetryBackoff: s.maxRetryBackoff,
 	}
 
 	for i := range ts.runners {
@@ -514,6 +671,19 @@ func (ts *taskScheduler) WorkCurrentlyRunning(runs []*platform.Run) error {
 	return nil
 }
 
+// dependsOnTask reports whether t is one of the upstream tasks of this task.
+func (ts *taskScheduler) dependsOnTask(t *platform.Task) bool {
+	if t.OrganizationID != ts.task.OrganizationID {
+		return false
+	}
+	for _, d := range ts.dependsOn {
+		if dependsOnTask(d, t) {
+			return true
+		}
+	}
+	return false
+}
+
 // Cancel interrupts this taskScheduler and its runners.
 func (ts *taskScheduler) Cancel() {
 	ts.cancel()
@@ -537,6 +707,18 @@ func (ts *taskScheduler) SetNextDue(nextDue int64, hasQueue bool, source int64)
 	ts.hasQueue = hasQueue
 }
 
+// RetryBackoff returns the backoff before the retry of a run after the given failed try.
+func (ts *taskScheduler) RetryBackoff(try uint32) time.Duration {
+	backoff := ts.retryBackoff
+	for i := uint32(1); i < try && backoff < ts.maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > ts.maxRetryBackoff {
+		backoff = ts.maxRetryBackoff
+	}
+	return backoff
+}
+
 // A runner is one eligible "concurrency slot" for a given task.
 type runner struct {
 	state *uint32
@@ -696,78 +878,227 @@ func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *za
 			atomic.StoreUint32(r.state, runnerIdle)
 		}
 	}()
+	defer r.clearRunning(qr.RunID)
 
 	sp, spCtx := tracing.StartSpanFromContext(ctx)
 	defer sp.Finish()
 
-	rp, err := r.executor.Execute(spCtx, qr)
+	var (
+		rr    RunResult
+		err   error
+		stage string
+
+		try      uint32 = 1
+		maxTries uint32 // Read from the task options after the first failure.
+
+		queueDelay    = time.Since(r.dueAt(qr))
+		queryDuration time.Duration
+	)
+	for {
+		start := time.Now()
+		rr, stage, err = r.execute(spCtx, qr, runLogger)
+		queryDuration = time.Since(start)
+		if err == nil {
+			break
+		}
+		if err == ErrRunCanceled {
+			r.updateRunState(qr, RunCanceled, runLogger)
+			errMsg = "Waiting for execution result failed, " + errMsg
+			// Move on to the next execution, for a canceled run.
+			r.startFromWorking(atomic.LoadInt64(r.ts.now))
+			return
+		}
+		errMsg = stage + ", " + errMsg
+
+		if maxTries == 0 {
+			maxTries = r.maxTries()
+		}
+		if rr == nil || !rr.IsRetryable() || try >= maxTries {
+			if maxTries > 1 {
+				stage = fmt.Sprintf("%s (attempt %d of %d)", stage, try, maxTries)
+			}
+			if rr != nil {
+				r.recordStatistics(qr, rr, queueDelay, queryDuration, runLogger)
+			}
+			r.fail(qr, runLogger, stage, err)
+			return
+		}
+
+		backoff := r.ts.RetryBackoff(try)
+		runLogger.Info("Retrying failed run", zap.Uint32("try", try), zap.Duration("backoff", backoff), zap.Error(err))
+		r.addRunLog(qr, runLogger, fmt.Sprintf("%s (attempt %d of %d): %s; retrying in %s", stage, try, maxTries, err, backoff))
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			err = ErrRunCanceled
+		case <-r.ctx.Done():
+			timer.Stop()
+			err = ErrRunCanceled
+		}
+		if err == ErrRunCanceled {
+			r.updateRunState(qr, RunCanceled, runLogger)
+			r.startFromWorking(atomic.LoadInt64(r.ts.now))
+			return
+		}
+
+		if try, err = r.taskControlService.IncrementRunTry(r.ctx, qr.TaskID, qr.RunID); err != nil {
+			runLogger.Info("Failed to record run attempt", zap.Error(err))
+			r.fail(qr, runLogger, "Recording run attempt", err)
+			return
+		}
+		r.ts.metrics.RetryRun(r.task.ID.String())
+		r.addRunLog(qr, runLogger, fmt.Sprintf("Started attempt %d of %d", try, maxTries))
+	}
+
+	stats := rr.Statistics()
+
+	b, err := json.Marshal(stats)
+	if err == nil {
+		r.addRunLog(qr, runLogger, string(b))
+	}
+	r.recordStatistics(qr, rr, queueDelay, queryDuration, runLogger)
+	r.updateRunState(qr, RunSuccess, runLogger)
+	runLogger.Info("Execution succeeded")
+
+	select {
+	case r.ts.succeeded <- succeededRun{taskID: qr.TaskID, now: qr.Now}:
+	case <-r.ctx.Done():
+	}
+
+	// Check again if there is a new run available, without returning to idle state.
+	r.startFromWorking(atomic.LoadInt64(r.ts.now))
+}
+
+// execute makes a single attempt at the run and waits for its result.
+// If the attempt failed, execute returns the stage of the run that failed and the error.
+// The RunResult is only returned when the run executed, whether or not it succeeded.
+func (r *runner) execute(ctx context.Context, qr QueuedRun, runLogger *zap.Logger) (RunResult, string, error) {
+	rp, err := r.executor.Execute(ctx, qr)
 	if err != nil {
 		runLogger.Info("Failed to begin run execution", zap.Error(err))
-		errMsg = "Beginning run execution failed, " + errMsg
-		// TODO(mr): retry?
-		r.fail(qr, runLogger, "Run failed to begin execution", err)
-		return
+		return nil, "Run failed to begin execution", err
+	}
+
+	timeout := r.timeout()
+	var timer <-chan time.Time
+	if timeout > 0 {
+		t := time.NewTimer(timeout)
+		defer t.Stop()
+		timer = t.C
 	}
 
 	ready := make(chan struct{})
+	timedOut := make(chan struct{})
 	go func() {
 		// If the runner's context is canceled, cancel the RunPromise.
 		select {
 		case <-ctx.Done():
-			r.clearRunning(qr.RunID)
 			rp.Cancel()
 		// Canceled context.
 		case <-r.ctx.Done():
-			r.clearRunning(qr.RunID)
+			rp.Cancel()
+		// Ran out of time.
+		case <-timer:
+			close(timedOut)
 			rp.Cancel()
 		// Wait finished.
 		case <-ready:
-			r.clearRunning(qr.RunID)
 		}
 	}()
 
-	// TODO(mr): handle rr.IsRetryable().
 	rr, err := rp.Wait()
 	close(ready)
+	if err == ErrRunCanceled {
+		select {
+		case <-timedOut:
+			err = RunTimedOutError{Timeout: timeout}
+			runLogger.Info("Run timed out", zap.Duration("timeout", timeout))
+			r.ts.metrics.TimeoutRun(r.task.ID.String())
+			return timedOutRunResult{err: err}, "Run timed out", err
+		default:
+		}
+	}
 	if err != nil {
-		if err == ErrRunCanceled {
-			r.updateRunState(qr, RunCanceled, runLogger)
-			errMsg = "Waiting for execution result failed, " + errMsg
-			// Move on to the next execution, for a canceled run.
-			r.startFromWorking(atomic.LoadInt64(r.ts.now))
-			return
+		if err != ErrRunCanceled {
+			runLogger.Info("Failed to wait for execution result", zap.Error(err))
 		}
-
-		runLogger.Info("Failed to wait for execution result", zap.Error(err))
-
-		// TODO(mr): retry?
-		r.fail(qr, runLogger, "Waiting for execution result", err)
-		return
+		return nil, "Waiting for execution result", err
 	}
 	if err := rr.Err(); err != nil {
 		runLogger.Info("Run failed to execute", zap.Error(err))
-		errMsg = "Run failed to execute, " + errMsg
+		return rr, "Run failed to execute", err
+	}
+	return rr, "", nil
+}
 
-		// TODO(mr): retry?
-		r.fail(qr, runLogger, "Run failed to execute", err)
-		return
+// dueAt returns when the run became due: when it was requested for a manual run,
+// or when it was scheduled for, plus the offset of the task, otherwise.
+func (r *runner) dueAt(qr QueuedRun) time.Time {
+	if qr.RequestedAt != 0 {
+		return time.Unix(qr.RequestedAt, 0)
+	}
+	due := time.Unix(qr.Now, 0)
+	opt, err := options.FromScript(r.task.Flux)
+	if err != nil || opt.Offset == nil {
+		return due
 	}
+	offset, err := opt.Offset.DurationFrom(due)
+	if err != nil {
+		return due
+	}
+	return due.Add(offset)
+}
 
-	stats := rr.Statistics()
+// recordStatistics records the statistics of the execution of the run, on the run and in the scheduler metrics.
+func (r *runner) recordStatistics(qr QueuedRun, rr RunResult, queueDelay, queryDuration time.Duration, runLogger *zap.Logger) {
+	stats := newRunStatistics(rr.Statistics())
+	stats.QueueDelay = queueDelay
+	stats.QueryDuration = queryDuration
 
-	b, err := json.Marshal(stats)
-	if err == nil {
-		// authctx can be updated mid process
-		r.ts.nextDueMu.RLock()
-		authCtx := r.ts.authCtx
-		r.ts.nextDueMu.RUnlock()
-		r.taskControlService.AddRunLog(authCtx, r.task.ID, qr.RunID, time.Now(), string(b))
+	r.ts.metrics.ObserveRun(r.task.ID.String(), stats)
+
+	r.ts.nextDueMu.RLock()
+	authCtx := r.ts.authCtx
+	r.ts.nextDueMu.RUnlock()
+	if err := r.taskControlService.UpdateRunStatistics(authCtx, r.task.ID, qr.RunID, time.Now(), stats); err != nil {
+		runLogger.Info("Failed to update run statistics", zap.Error(err))
 	}
-	r.updateRunState(qr, RunSuccess, runLogger)
-	runLogger.Info("Execution succeeded")
+}
 
-	// Check again if there is a new run available, without returning to idle state.
-	r.startFromWorking(atomic.LoadInt64(r.ts.now))
+// timeout returns the timeout option of the task, or 0 if attempts at its runs may take any time.
+func (r *runner) timeout() time.Duration {
+	opt, err := options.FromScript(r.task.Flux)
+	if err != nil || opt.Timeout == nil {
+		return 0
+	}
+	d, err := opt.Timeout.DurationFrom(time.Now())
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// maxTries returns the number of times a run of the task is attempted, according to the retry option of the task.
+func (r *runner) maxTries() uint32 {
+	opt, err := options.FromScript(r.task.Flux)
+	if err != nil || opt.Retry == nil || *opt.Retry < 1 {
+		return 1
+	}
+	return uint32(*opt.Retry)
+}
+
+// addRunLog adds a log line to the run with the current authorization context.
+func (r *runner) addRunLog(qr QueuedRun, runLogger *zap.Logger, log string) {
+	// authctx can be updated mid process
+	r.ts.nextDueMu.RLock()
+	authCtx := r.ts.authCtx
+	r.ts.nextDueMu.RUnlock()
+	if err := r.taskControlService.AddRunLog(authCtx, r.task.ID, qr.RunID, time.Now(), log); err != nil {
+		runLogger.Info("Failed to update run log", zap.Error(err))
+	}
 }
 
 func (r *runner) updateRunState(qr QueuedRun, s RunStatus, runLogger *zap.Logger) {
@@ -797,3 +1128,45 @@ func (r *runner) updateRunState(qr QueuedRun, s RunStatus, runLogger *zap.Logger
 		runLogger.Info("Error updating run state", zap.Stringer("state", s), zap.Error(err))
 	}
 }
+
+// timedOutRunResult is the RunResult of a run attempt that exceeded the timeout of its task.
+// A timed out attempt may be retried, as a later attempt may not hang.
+type timedOutRunResult struct {
+	err error
+}
+
+func (rr timedOutRunResult) Err() error                  { return rr.err }
+func (rr timedOutRunResult) IsRetryable() bool           { return true }
+func (rr timedOutRunResult) Statistics() flux.Statistics { return flux.Statistics{} }
+
+// scannedValuesMetadataKey is the key of the Flux statistics metadata for the values read by the storage sources of a query.
+const scannedValuesMetadataKey = "influxdb/scanned-values"
+
+// newRunStatistics returns the statistics of a run from the Flux statistics of its query.
+// The queue delay and the query duration are left for the runner to set.
+func newRunStatistics(fs flux.Statistics) platform.RunStatistics {
+	return platform.RunStatistics{
+		PointsWritten:   sumMetadata(fs.Metadata, query.PointsWrittenMetadataKey),
+		BytesWritten:    sumMetadata(fs.Metadata, query.BytesWrittenMetadataKey),
+		RowsRead:        sumMetadata(fs.Metadata, scannedValuesMetadataKey),
+		CompileDuration: fs.CompileDuration,
+		PlanDuration:    fs.PlanDuration,
+		ExecuteDuration: fs.ExecuteDuration,
+		Concurrency:     int64(fs.Concurrency),
+		MaxAllocated:    fs.MaxAllocated,
+	}
+}
+
+// sumMetadata returns the sum of the integer values of key in md.
+func sumMetadata(md flux.Metadata, key string) int64 {
+	var sum int64
+	for _, v := range md[key] {
+		switch v := v.(type) {
+		case int:
+			sum += int64(v)
+		case int64:
+			sum += v
+		}
+	}
+	return sum
+}
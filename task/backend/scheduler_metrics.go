@@ -1,6 +1,9 @@
 package backend
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	platform "github.com/influxdata/influxdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
 
 // schedulerMetrics is a collection of metrics relating to task scheduling.
 // All of its methods which accept task IDs, take them as strings,
@@ -11,6 +14,14 @@ type schedulerMetrics struct {
 
 	runsComplete *prometheus.CounterVec
 	runsActive   *prometheus.GaugeVec
+	runsRetried  *prometheus.CounterVec
+	runsTimedOut *prometheus.CounterVec
+
+	runQueueDelay    *prometheus.HistogramVec
+	runQueryDuration *prometheus.HistogramVec
+	runPointsWritten *prometheus.HistogramVec
+	runBytesWritten  *prometheus.HistogramVec
+	runRowsRead      *prometheus.HistogramVec
 
 	claimsComplete *prometheus.CounterVec
 	claimsActive   prometheus.Gauge
@@ -20,6 +31,10 @@ func newSchedulerMetrics() *schedulerMetrics {
 	const namespace = "task"
 	const subsystem = "scheduler"
 
+	// Include a bucket for 0, so that runs that write or read nothing stand out.
+	countBuckets := append([]float64{0}, prometheus.ExponentialBuckets(1, 10, 9)...)
+	durationBuckets := prometheus.ExponentialBuckets(0.01, 4, 10)
+
 	return &schedulerMetrics{
 		totalRunsComplete: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
@@ -46,6 +61,54 @@ func newSchedulerMetrics() *schedulerMetrics {
 			Name:      "runs_active",
 			Help:      "Total number of runs that have started but not yet completed, split out by task ID.",
 		}, []string{"task_id"}),
+		runsRetried: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "runs_retried",
+			Help:      "Number of attempts at runs after a failed attempt, split out by task ID.",
+		}, []string{"task_id"}),
+		runsTimedOut: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "runs_timed_out",
+			Help:      "Number of attempts at runs canceled for exceeding the timeout of their task, split out by task ID.",
+		}, []string{"task_id"}),
+
+		runQueueDelay: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "run_queue_delay_seconds",
+			Help:      "Time between when runs were due and when their execution began, split out by task ID.",
+			Buckets:   durationBuckets,
+		}, []string{"task_id"}),
+		runQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "run_query_duration_seconds",
+			Help:      "Time the queries of runs took, split out by task ID.",
+			Buckets:   durationBuckets,
+		}, []string{"task_id"}),
+		runPointsWritten: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "run_points_written",
+			Help:      "Number of points written by runs, split out by task ID.",
+			Buckets:   countBuckets,
+		}, []string{"task_id"}),
+		runBytesWritten: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "run_bytes_written",
+			Help:      "Number of bytes written by runs, split out by task ID.",
+			Buckets:   countBuckets,
+		}, []string{"task_id"}),
+		runRowsRead: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "run_rows_read",
+			Help:      "Number of rows read by runs, split out by task ID.",
+			Buckets:   countBuckets,
+		}, []string{"task_id"}),
 
 		claimsComplete: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
@@ -69,6 +132,13 @@ func (sm *schedulerMetrics) PrometheusCollectors() []prometheus.Collector {
 		sm.totalRunsActive,
 		sm.runsComplete,
 		sm.runsActive,
+		sm.runsRetried,
+		sm.runsTimedOut,
+		sm.runQueueDelay,
+		sm.runQueryDuration,
+		sm.runPointsWritten,
+		sm.runBytesWritten,
+		sm.runRowsRead,
 		sm.claimsComplete,
 		sm.claimsActive,
 	}
@@ -91,6 +161,25 @@ func (sm *schedulerMetrics) FinishRun(tid string, succeeded bool) {
 	sm.runsComplete.WithLabelValues(tid, status).Inc()
 }
 
+// RetryRun adjusts the metrics to indicate a run of the given task ID is being attempted again.
+func (sm *schedulerMetrics) RetryRun(tid string) {
+	sm.runsRetried.WithLabelValues(tid).Inc()
+}
+
+// TimeoutRun adjusts the metrics to indicate an attempt at a run of the given task ID exceeded its timeout.
+func (sm *schedulerMetrics) TimeoutRun(tid string) {
+	sm.runsTimedOut.WithLabelValues(tid).Inc()
+}
+
+// ObserveRun adjusts the metrics to reflect the statistics of the execution of a run of the given task ID.
+func (sm *schedulerMetrics) ObserveRun(tid string, stats platform.RunStatistics) {
+	sm.runQueueDelay.WithLabelValues(tid).Observe(stats.QueueDelay.Seconds())
+	sm.runQueryDuration.WithLabelValues(tid).Observe(stats.QueryDuration.Seconds())
+	sm.runPointsWritten.WithLabelValues(tid).Observe(float64(stats.PointsWritten))
+	sm.runBytesWritten.WithLabelValues(tid).Observe(float64(stats.BytesWritten))
+	sm.runRowsRead.WithLabelValues(tid).Observe(float64(stats.RowsRead))
+}
+
 // ClaimTask adjusts the metrics to indicate the result of an attempted claim.
 func (sm *schedulerMetrics) ClaimTask(succeeded bool) {
 	status := statusString(succeeded)
@@ -106,6 +195,13 @@ func (sm *schedulerMetrics) ClaimTask(succeeded bool) {
 func (sm *schedulerMetrics) ReleaseTask(tid string) {
 	sm.claimsActive.Dec()
 	sm.runsActive.DeleteLabelValues(tid)
+	sm.runsRetried.DeleteLabelValues(tid)
+	sm.runsTimedOut.DeleteLabelValues(tid)
+	sm.runQueueDelay.DeleteLabelValues(tid)
+	sm.runQueryDuration.DeleteLabelValues(tid)
+	sm.runPointsWritten.DeleteLabelValues(tid)
+	sm.runBytesWritten.DeleteLabelValues(tid)
+	sm.runRowsRead.DeleteLabelValues(tid)
 	sm.runsComplete.DeleteLabelValues(tid, statusString(true))
 	sm.runsComplete.DeleteLabelValues(tid, statusString(false))
 }
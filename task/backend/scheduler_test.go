@@ -15,6 +15,7 @@ import (
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/kit/prom"
 	"github.com/influxdata/influxdb/kit/prom/promtest"
+	"github.com/influxdata/influxdb/query"
 	_ "github.com/influxdata/influxdb/query/builtin"
 	"github.com/influxdata/influxdb/task/backend"
 	"github.com/influxdata/influxdb/task/mock"
@@ -772,6 +773,430 @@ func TestScheduler_RunFailureCleanup(t *testing.T) {
 	}
 }
 
+func TestScheduler_Retry(t *testing.T) {
+	t.Parallel()
+
+	tcs := mock.NewTaskControlService()
+	e := mock.NewExecutor()
+	ll := newLogListener(tcs)
+	s := backend.NewScheduler(ll, e, 5, backend.WithLogger(zaptest.NewLogger(t)), backend.WithRetryBackoff(time.Millisecond, 2*time.Millisecond))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	reg := prom.NewRegistry()
+	reg.MustRegister(s.PrometheusCollectors()...)
+
+	task := &platform.Task{
+		ID:              platform.ID(1),
+		Every:           "1s",
+		LatestCompleted: "1970-01-01T00:00:05Z",
+		Flux:            `option task = {name:"x", every:1m, retry: 3} from(bucket:"a") |> to(bucket:"b", org: "o")`,
+	}
+
+	tcs.SetTask(task)
+	if err := s.ClaimTask(context.Background(), task); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Tick(6)
+	promises, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runID := promises[0].Run().RunID
+
+	// Retryable failures are retried until the run is out of tries.
+	for try := 1; try <= 3; try++ {
+		if try > 1 {
+			pollForRunLog(t, ll, task.ID, runID, fmt.Sprintf("Started attempt %d of 3", try))
+			promises, err = e.PollForNumberRunning(task.ID, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := promises[0].Run().RunID; got != runID {
+				t.Fatalf("expected run %s to be retried, got run %s", runID, got)
+			}
+		}
+		promises[0].Finish(mock.NewRunResult(errors.New("transient"), true), nil)
+		if try < 3 {
+			backoff := time.Millisecond << uint(try-1)
+			pollForRunLog(t, ll, task.ID, runID, fmt.Sprintf("Run failed to execute (attempt %d of 3): transient; retrying in %s", try, backoff))
+		}
+	}
+	pollForRunLog(t, ll, task.ID, runID, "Run failed to execute (attempt 3 of 3): transient")
+	if got := tcs.RunTry(runID); got != 3 {
+		t.Fatalf("expected run to be tried 3 times, got %d", got)
+	}
+
+	mfs := promtest.MustGather(t, reg)
+	m := promtest.MustFindMetric(t, mfs, "task_scheduler_runs_retried", map[string]string{"task_id": task.ID.String()})
+	if got := *m.Counter.Value; got != 2 {
+		t.Fatalf("expected 2 retries for task ID %s, got %v", task.ID.String(), got)
+	}
+
+	// Failures that are not retryable fail the run immediately.
+	s.Tick(7)
+	promises, err = e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runID = promises[0].Run().RunID
+	promises[0].Finish(mock.NewRunResult(errors.New("permanent"), false), nil)
+	pollForRunLog(t, ll, task.ID, runID, "Run failed to execute (attempt 1 of 3): permanent")
+	if got := tcs.RunTry(runID); got != 1 {
+		t.Fatalf("expected run to be tried once, got %d", got)
+	}
+}
+
+func TestScheduler_Timeout(t *testing.T) {
+	t.Parallel()
+
+	tcs := mock.NewTaskControlService()
+	e := mock.NewExecutor()
+	ll := newLogListener(tcs)
+	s := backend.NewScheduler(ll, e, 5, backend.WithLogger(zaptest.NewLogger(t)), backend.WithRetryBackoff(time.Millisecond, time.Millisecond))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	reg := prom.NewRegistry()
+	reg.MustRegister(s.PrometheusCollectors()...)
+
+	task := &platform.Task{
+		ID:              platform.ID(1),
+		Every:           "1s",
+		LatestCompleted: "1970-01-01T00:00:05Z",
+		Flux:            `option task = {name:"x", every:1m, retry: 2, timeout: 1s} from(bucket:"a") |> to(bucket:"b", org: "o")`,
+	}
+
+	tcs.SetTask(task)
+	if err := s.ClaimTask(context.Background(), task); err != nil {
+		t.Fatal(err)
+	}
+
+	// Neither attempt at the run ever finishes, so both time out.
+	s.Tick(6)
+	promises, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runID := promises[0].Run().RunID
+
+	time.Sleep(time.Second)
+	pollForRunLog(t, ll, task.ID, runID, "Run timed out (attempt 1 of 2): run exceeded timeout of 1s; retrying in 1ms")
+	time.Sleep(time.Second)
+	pollForRunLog(t, ll, task.ID, runID, "Run timed out (attempt 2 of 2): run exceeded timeout of 1s")
+
+	if _, err := e.PollForNumberRunning(task.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	if run := tcs.FinishedRun(runID); run == nil || run.Status != backend.RunFail.String() {
+		t.Fatalf("expected run to be failed, got %#v", run)
+	}
+
+	mfs := promtest.MustGather(t, reg)
+	m := promtest.MustFindMetric(t, mfs, "task_scheduler_runs_timed_out", map[string]string{"task_id": task.ID.String()})
+	if got := *m.Counter.Value; got != 2 {
+		t.Fatalf("expected 2 timed out attempts for task ID %s, got %v", task.ID.String(), got)
+	}
+}
+
+func TestScheduler_Catchup(t *testing.T) {
+	t.Parallel()
+
+	for _, c := range []struct {
+		catchup string
+		expNow  int64 // The now of the first run, or 0 if no run is due.
+	}{
+		{catchup: "all", expNow: 6},
+		{catchup: "max:3", expNow: 98},
+		{catchup: "latest", expNow: 100},
+		{catchup: "none", expNow: 0},
+	} {
+		c := c
+		t.Run(c.catchup, func(t *testing.T) {
+			t.Parallel()
+
+			tcs := mock.NewTaskControlService()
+			e := mock.NewExecutor()
+			s := backend.NewScheduler(tcs, e, 100)
+			s.Start(context.Background())
+			defer s.Stop()
+
+			// The task last completed a run at 5, so the runs from 6 to 100 were missed.
+			task := &platform.Task{
+				ID:              platform.ID(1),
+				Every:           "1s",
+				LatestCompleted: "1970-01-01T00:00:05Z",
+				Flux:            `option task = {name:"x", every:1s, catchup:"` + c.catchup + `"} from(bucket:"a") |> to(bucket:"b", org: "o")`,
+			}
+
+			tcs.SetTask(task)
+			if err := s.ClaimTask(context.Background(), task); err != nil {
+				t.Fatal(err)
+			}
+
+			if c.expNow == 0 {
+				if _, err := tcs.PollForNumberCreated(task.ID, 0); err != nil {
+					t.Fatal(err)
+				}
+				s.Tick(101)
+				c.expNow = 101
+			}
+
+			promises, err := e.PollForNumberRunning(task.ID, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := promises[0].Run().Now; got != c.expNow {
+				t.Fatalf("expected first run at %d, got %d", c.expNow, got)
+			}
+		})
+	}
+}
+
+func TestScheduler_RunStatistics(t *testing.T) {
+	t.Parallel()
+
+	tcs := mock.NewTaskControlService()
+	e := mock.NewExecutor()
+	s := backend.NewScheduler(tcs, e, 5, backend.WithLogger(zaptest.NewLogger(t)))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	reg := prom.NewRegistry()
+	reg.MustRegister(s.PrometheusCollectors()...)
+
+	task := &platform.Task{
+		ID:              platform.ID(1),
+		Every:           "1s",
+		LatestCompleted: "1970-01-01T00:00:05Z",
+		Flux:            `option task = {name:"x", every:1m} from(bucket:"a") |> to(bucket:"b", org: "o")`,
+	}
+
+	tcs.SetTask(task)
+	if err := s.ClaimTask(context.Background(), task); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Tick(6)
+	promises, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runID := promises[0].Run().RunID
+
+	rr := mock.NewRunResult(nil, false)
+	rr.Stats = flux.Statistics{
+		ExecuteDuration: time.Second,
+		Concurrency:     2,
+		MaxAllocated:    1024,
+		Metadata: flux.Metadata{
+			"influxdb/scanned-values":      {3, 4},
+			query.PointsWrittenMetadataKey: {int64(5)},
+			query.BytesWrittenMetadataKey:  {int64(100)},
+		},
+	}
+	promises[0].Finish(rr, nil)
+
+	if _, err := e.PollForNumberRunning(task.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	run := tcs.FinishedRun(runID)
+	if run == nil || run.Statistics == nil {
+		t.Fatalf("expected run with statistics, got %#v", run)
+	}
+	stats := *run.Statistics
+	if stats.PointsWritten != 5 || stats.BytesWritten != 100 || stats.RowsRead != 7 {
+		t.Fatalf("unexpected write and read statistics: %#v", stats)
+	}
+	if stats.ExecuteDuration != time.Second || stats.Concurrency != 2 || stats.MaxAllocated != 1024 {
+		t.Fatalf("unexpected query statistics: %#v", stats)
+	}
+	if stats.QueueDelay <= 0 {
+		t.Fatalf("expected positive queue delay, got %v", stats.QueueDelay)
+	}
+
+	mfs := promtest.MustGather(t, reg)
+	m := promtest.MustFindMetric(t, mfs, "task_scheduler_run_points_written", map[string]string{"task_id": task.ID.String()})
+	if got := *m.Histogram.SampleSum; got != 5 {
+		t.Fatalf("expected 5 points written for task ID %s, got %v", task.ID.String(), got)
+	}
+	if got := *m.Histogram.SampleCount; got != 1 {
+		t.Fatalf("expected 1 observed run for task ID %s, got %v", task.ID.String(), got)
+	}
+}
+
+func TestScheduler_DependsOn(t *testing.T) {
+	t.Parallel()
+
+	tcs := mock.NewTaskControlService()
+	e := mock.NewExecutor()
+	s := backend.NewScheduler(tcs, e, 5, backend.WithLogger(zaptest.NewLogger(t)))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	var upstream []*platform.Task
+	for i, name := range []string{"a", "b"} {
+		task := &platform.Task{
+			ID:              platform.ID(i + 1),
+			OrganizationID:  platform.ID(10),
+			Name:            name,
+			Every:           "1s",
+			LatestCompleted: "1970-01-01T00:00:05Z",
+			Flux:            fmt.Sprintf(`option task = {name:%q, every:1s} from(bucket:"a") |> to(bucket:"b", org: "o")`, name),
+		}
+		tcs.SetTask(task)
+		if err := s.ClaimTask(context.Background(), task); err != nil {
+			t.Fatal(err)
+		}
+		upstream = append(upstream, task)
+	}
+
+	downstream := &platform.Task{
+		ID:              platform.ID(3),
+		OrganizationID:  platform.ID(10),
+		Name:            "c",
+		DependsOn:       []string{"a", "b"},
+		LatestCompleted: "1970-01-01T00:00:05Z",
+		Flux:            `option task = {name:"c", dependsOn:["a", "b"]} from(bucket:"a") |> to(bucket:"b", org: "o")`,
+	}
+	tcs.SetTask(downstream)
+	if err := s.ClaimTask(context.Background(), downstream); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Tick(6)
+	for i, task := range upstream {
+		promises, err := e.PollForNumberRunning(task.ID, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		promises[0].Finish(mock.NewRunResult(nil, false), nil)
+		if _, err := e.PollForNumberRunning(task.ID, 0); err != nil {
+			t.Fatal(err)
+		}
+
+		if i < len(upstream)-1 {
+			// Not every upstream task succeeded yet.
+			time.Sleep(10 * time.Millisecond)
+			if n := tcs.TotalRunsCreatedForTask(downstream.ID); n != 0 {
+				t.Fatalf("expected no run of the downstream task before all upstream tasks succeeded, got %d", n)
+			}
+		}
+	}
+
+	cs, err := tcs.PollForNumberCreated(downstream.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cs[0].Now != 6 {
+		t.Fatalf("expected run of the downstream task for 6, got %d", cs[0].Now)
+	}
+
+	// The downstream task is never due on its own.
+	s.Tick(60)
+	promises, err := e.PollForNumberRunning(downstream.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	promises[0].Finish(mock.NewRunResult(nil, false), nil)
+	if _, err := e.PollForNumberRunning(downstream.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	if n := tcs.TotalRunsCreatedForTask(downstream.ID); n != 1 {
+		t.Fatalf("expected a single run of the downstream task, got %d", n)
+	}
+}
+
+func TestScheduler_DependsOnUpstreamFailure(t *testing.T) {
+	t.Parallel()
+
+	tcs := mock.NewTaskControlService()
+	e := mock.NewExecutor()
+	s := backend.NewScheduler(tcs, e, 59, backend.WithLogger(zaptest.NewLogger(t)))
+	s.Start(context.Background())
+
+	var tasks []*platform.Task
+	for i, name := range []string{"a", "b"} {
+		tasks = append(tasks, &platform.Task{
+			ID:              platform.ID(i + 1),
+			OrganizationID:  platform.ID(10),
+			Name:            name,
+			Every:           "1m",
+			LatestCompleted: "1970-01-01T00:00:00Z",
+			Flux:            fmt.Sprintf(`option task = {name:%q, every:1m} from(bucket:"a") |> to(bucket:"b", org: "o")`, name),
+		})
+	}
+	a, b := tasks[0], tasks[1]
+	downstream := &platform.Task{
+		ID:              platform.ID(3),
+		OrganizationID:  platform.ID(10),
+		Name:            "c",
+		DependsOn:       []string{"a", "b"},
+		LatestCompleted: "1970-01-01T00:00:00Z",
+		Flux:            `option task = {name:"c", dependsOn:["a", "b"]} from(bucket:"a") |> to(bucket:"b", org: "o")`,
+	}
+	tasks = append(tasks, downstream)
+	claim := func(s *backend.TickScheduler) {
+		for _, task := range tasks {
+			tcs.SetTask(task)
+			if err := s.ClaimTask(context.Background(), task); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	finish := func(task *platform.Task, err error) {
+		t.Helper()
+		promises, perr := e.PollForNumberRunning(task.ID, 1)
+		if perr != nil {
+			t.Fatal(perr)
+		}
+		promises[0].Finish(mock.NewRunResult(err, false), nil)
+		if _, err := e.PollForNumberRunning(task.ID, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	claim(s)
+
+	// a succeeds and b fails for 60, so the downstream task doesn't run.
+	s.Tick(60)
+	finish(a, nil)
+	finish(b, errors.New("permanent"))
+	time.Sleep(10 * time.Millisecond)
+	if n := tcs.TotalRunsCreatedForTask(downstream.ID); n != 0 {
+		t.Fatalf("expected no run of the downstream task after an upstream task failed, got %d", n)
+	}
+	if ids := tcs.UpstreamSuccesses(downstream.ID, 60); len(ids) != 1 || ids[0] != a.ID {
+		t.Fatalf("expected the success of a to be stored, got %v", ids)
+	}
+
+	// The success of a is kept across a restart of the scheduler,
+	// so that b succeeding for 60 later runs the downstream task for 60.
+	s.Stop()
+	s = backend.NewScheduler(tcs, e, 60, backend.WithLogger(zaptest.NewLogger(t)))
+	s.Start(context.Background())
+	defer s.Stop()
+	if _, err := tcs.ForceRun(context.Background(), b.ID, 60); err != nil {
+		t.Fatal(err)
+	}
+	claim(s)
+
+	s.Tick(61)
+	finish(b, nil)
+
+	cs, err := tcs.PollForNumberCreated(downstream.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cs[0].Now != 60 {
+		t.Fatalf("expected run of the downstream task for 60, got %d", cs[0].Now)
+	}
+	if ids := tcs.UpstreamSuccesses(downstream.ID, 60); len(ids) != 0 {
+		t.Fatalf("expected the upstream successes to be deleted once the downstream run was queued, got %v", ids)
+	}
+}
+
 func TestScheduler_Metrics(t *testing.T) {
 	t.Parallel()
 
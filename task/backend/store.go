@@ -33,6 +33,12 @@ var (
 
 	// ErrRunNotFinished is returned when a retry is invalid due to the run not being finished yet.
 	ErrRunNotFinished = errors.New("run is still in progress")
+
+	// ErrTaskRevisionNotFound is returned when searching for a revision of a task that doesn't exist.
+	ErrTaskRevisionNotFound = errors.New("task revision not found")
+
+	// ErrTaskLeaseHeld is returned when claiming or releasing the lease of a task that another owner holds.
+	ErrTaskLeaseHeld = errors.New("task lease held by another owner")
 )
 
 type TaskStatus string
@@ -163,6 +169,10 @@ type CreateTaskRequest struct {
 	// The initial task status.
 	// If empty, will be treated as DefaultTaskStatus.
 	Status TaskStatus
+
+	// The user creating the task, recorded as the author of its first revision.
+	// If zero, the author is unknown.
+	Author platform.ID
 }
 
 // UpdateTaskRequest encapsulates requested changes to a task.
@@ -182,6 +192,10 @@ type UpdateTaskRequest struct {
 	// If zero, do not modify the existing authorization ID.
 	AuthorizationID platform.ID
 
+	// The user updating the task, recorded as the author of the revision if the script or authorization changes.
+	// If zero, the author is unknown.
+	Author platform.ID
+
 	// These options are for editing options via request.  Zeroed options will be ignored.
 	options.Options
 }
@@ -215,8 +229,28 @@ type UpdateTaskResult struct {
 	NewMeta StoreTaskMeta
 }
 
+// BackfillStore persists the backfills of tasks, so that a backfill resumes after a restart.
+type BackfillStore interface {
+	// PutBackfill creates the backfill, or replaces the backfill with the same task ID and ID.
+	// If no task matches bf.TaskID, ErrTaskNotFound is returned.
+	PutBackfill(ctx context.Context, bf StoreBackfill) error
+
+	// FindBackfills returns the backfills of the task with the given ID, ordered by ID.
+	// If no task matches the ID, ErrTaskNotFound is returned.
+	FindBackfills(ctx context.Context, taskID platform.ID) ([]StoreBackfill, error)
+
+	// ListBackfills returns the backfills of all tasks, ordered by task ID and then by ID.
+	ListBackfills(ctx context.Context) ([]StoreBackfill, error)
+
+	// DeleteBackfill deletes the backfill with the given task ID and ID.
+	// Deleting a backfill that doesn't exist is not an error.
+	DeleteBackfill(ctx context.Context, taskID, id platform.ID) error
+}
+
 // Store is the interface around persisted tasks.
 type Store interface {
+	BackfillStore
+
 	// CreateTask creates a task with from the given CreateTaskRequest.
 	// If the task is created successfully, the ID of the new task is returned.
 	CreateTask(ctx context.Context, req CreateTaskRequest) (platform.ID, error)
@@ -253,11 +287,35 @@ type Store interface {
 	// FinishRun removes runID from the list of running tasks and if its `now` is later then last completed update it.
 	FinishRun(ctx context.Context, taskID, runID platform.ID) error
 
+	// IncrementRunTry increments the try of a currently running run, before the run is retried.
+	// It returns the new try, which starts at 1 for the first attempt of a run.
+	IncrementRunTry(ctx context.Context, taskID, runID platform.ID) (uint32, error)
+
+	// SkipMissedRuns advances the latest completed run of the task with the given ID,
+	// so that at most maxRuns of the runs due no later than the Unix timestamp now remain to be created.
+	// A negative maxRuns skips no runs.
+	// SkipMissedRuns must delegate to an underlying StoreTaskMeta's SkipMissedRuns method.
+	SkipMissedRuns(ctx context.Context, taskID platform.ID, now int64, maxRuns int) error
+
 	// ManuallyRunTimeRange enqueues a request to run the task with the given ID for all schedules no earlier than start and no later than end (Unix timestamps).
 	// requestedAt is the Unix timestamp when the request was initiated.
 	// ManuallyRunTimeRange must delegate to an underlying StoreTaskMeta's ManuallyRunTimeRange method.
 	ManuallyRunTimeRange(ctx context.Context, taskID platform.ID, start, end, requestedAt int64) (*StoreTaskMetaManualRun, error)
 
+	// AddUpstreamSuccess records that the upstream task succeeded for the run time now of the task with the given ID,
+	// which depends on it, and returns the IDs of every upstream task recorded as succeeded for that time.
+	// At most MaxPendingUpstreamRuns run times are kept per task; the oldest is forgotten first.
+	// If no task matches the ID, ErrTaskNotFound is returned.
+	AddUpstreamSuccess(ctx context.Context, taskID, upstreamID platform.ID, now int64) ([]platform.ID, error)
+
+	// DeleteUpstreamSuccesses forgets the upstream tasks that succeeded for the run time now of the task with the given ID.
+	DeleteUpstreamSuccesses(ctx context.Context, taskID platform.ID, now int64) error
+
+	// FindTaskRevisions returns the revisions of the task with the given ID, oldest first.
+	// A revision is stored when a task is created, and every time its script or authorization is updated.
+	// If no task matches the ID, ErrTaskNotFound is returned.
+	FindTaskRevisions(ctx context.Context, id platform.ID) ([]StoreTaskRevision, error)
+
 	// DeleteOrg deletes the org.
 	DeleteOrg(ctx context.Context, orgID platform.ID) error
 
@@ -287,6 +345,9 @@ type LogWriter interface {
 
 	// AddRunLog adds a log line to the run.
 	AddRunLog(ctx context.Context, base RunLogBase, when time.Time, log string) error
+
+	// UpdateRunStatistics sets the statistics of the execution of the run.
+	UpdateRunStatistics(ctx context.Context, base RunLogBase, when time.Time, stats platform.RunStatistics) error
 }
 
 // NopLogWriter is a LogWriter that doesn't do anything when its methods are called.
@@ -303,6 +364,10 @@ func (NopLogWriter) AddRunLog(context.Context, RunLogBase, time.Time, string) er
 	return nil
 }
 
+func (NopLogWriter) UpdateRunStatistics(context.Context, RunLogBase, time.Time, platform.RunStatistics) error {
+	return nil
+}
+
 // LogReader reads log information and log data from a store.
 type LogReader interface {
 	// ListRuns returns a list of runs belonging to a task.
@@ -362,6 +427,47 @@ type StoreTask struct {
 
 	// The script content of the task.
 	Script string
+
+	// The number of the latest revision of the task.
+	// It is zero for tasks stored before revisions were recorded, until they are updated.
+	Revision int
+}
+
+// StoreTaskRevision is a snapshot of the script and authorization of a task.
+type StoreTaskRevision struct {
+	// Revision numbers start at 1.
+	Revision int `json:"revision"`
+
+	Script          string      `json:"script"`
+	AuthorizationID platform.ID `json:"authorizationID,omitempty"`
+
+	// The user who made the change, if known.
+	Author platform.ID `json:"author,omitempty"`
+
+	// Unix timestamp of the change, if known.
+	CreatedAt int64 `json:"createdAt,omitempty"`
+}
+
+// StoreBackfill is a backfill of a task, along with the progress needed to resume it.
+type StoreBackfill struct {
+	platform.Backfill
+
+	// The effective cron of the task when the backfill was created.
+	Cron string `json:"cron"`
+
+	// The number of runs the backfill schedules.
+	Total int `json:"total"`
+
+	// Unix timestamp of the first scheduled time that has not been queued yet.
+	Next int64 `json:"next"`
+
+	// The range and request time passed to ManuallyRunTimeRange for the runs queued last,
+	// and the number of scheduled times in that range.
+	// QueuedAt is zero if no runs are queued.
+	QueuedStart int64 `json:"queuedStart,omitempty"`
+	QueuedEnd   int64 `json:"queuedEnd,omitempty"`
+	QueuedAt    int64 `json:"queuedAt,omitempty"`
+	QueuedRuns  int   `json:"queuedRuns,omitempty"`
 }
 
 // StoreTaskWithMeta is a single struct with a StoreTask and a StoreTaskMeta.
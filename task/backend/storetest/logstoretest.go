@@ -37,6 +37,10 @@ func NewRunStoreTest(name string, crf CreateRunStoreFunc, drf DestroyRunStoreFun
 				t.Parallel()
 				runLogTest(t, crf, drf)
 			})
+			t.Run("RunStatistics", func(t *testing.T) {
+				t.Parallel()
+				runStatisticsTest(t, crf, drf)
+			})
 			t.Run("ListRuns", func(t *testing.T) {
 				if testing.Short() {
 					t.Skip("Skipping test in short mode.")
@@ -171,6 +175,82 @@ func runLogTest(t *testing.T, crf CreateRunStoreFunc, drf DestroyRunStoreFunc) {
 	}
 }
 
+func runStatisticsTest(t *testing.T, crf CreateRunStoreFunc, drf DestroyRunStoreFunc) {
+	writer, reader, makeAuthz := crf(t)
+	defer drf(t, writer, reader)
+
+	task := &backend.StoreTask{
+		ID:       platformtesting.MustIDBase16("ab01ab01ab01ab01"),
+		Org:      platformtesting.MustIDBase16("ab01ab01ab01ab05"),
+		Revision: 3,
+	}
+
+	sf := time.Now().UTC().Add(-10 * time.Second)
+	sa := sf.Add(time.Second)
+	fa := sa.Add(time.Second)
+	run := platform.Run{
+		ID:           platformtesting.MustIDBase16("2c20766972747573"),
+		TaskID:       task.ID,
+		Status:       "success",
+		ScheduledFor: sf.Format(time.RFC3339),
+		StartedAt:    sa.Format(time.RFC3339Nano),
+		FinishedAt:   fa.Format(time.RFC3339Nano),
+		TaskRevision: task.Revision,
+		Statistics: &platform.RunStatistics{
+			QueueDelay:      time.Second,
+			QueryDuration:   900 * time.Millisecond,
+			PointsWritten:   10,
+			BytesWritten:    400,
+			RowsRead:        100,
+			CompileDuration: time.Millisecond,
+			PlanDuration:    2 * time.Millisecond,
+			ExecuteDuration: 800 * time.Millisecond,
+			Concurrency:     2,
+			MaxAllocated:    1024,
+		},
+	}
+	rlb := backend.RunLogBase{
+		Task:            task,
+		RunID:           run.ID,
+		RunScheduledFor: sf.Unix(),
+	}
+
+	ctx := context.Background()
+	ctx = pcontext.SetAuthorizer(ctx, makeNewAuthorization(ctx, t, makeAuthz))
+
+	if err := writer.UpdateRunState(ctx, rlb, sa, backend.RunStarted); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.UpdateRunStatistics(ctx, rlb, fa, *run.Statistics); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.UpdateRunState(ctx, rlb, fa, backend.RunSuccess); err != nil {
+		t.Fatal(err)
+	}
+
+	returnedRun, err := reader.FindRunByID(ctx, task.Org, run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(run, *returnedRun); diff != "" {
+		t.Fatalf("unexpected run found: -want/+got: %s", diff)
+	}
+
+	runs, err := reader.ListRuns(ctx, task.Org, platform.RunFilter{Task: task.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 {
+		t.Fatalf("expected 1 run, got %d", len(runs))
+	}
+	if diff := cmp.Diff(run.Statistics, runs[0].Statistics); diff != "" {
+		t.Fatalf("unexpected run statistics listed: -want/+got: %s", diff)
+	}
+	if runs[0].TaskRevision != task.Revision {
+		t.Fatalf("expected task revision %d listed, got %d", task.Revision, runs[0].TaskRevision)
+	}
+}
+
 func listRunsTest(t *testing.T, crf CreateRunStoreFunc, drf DestroyRunStoreFunc) {
 	writer, reader, makeAuthz := crf(t)
 	defer drf(t, writer, reader)
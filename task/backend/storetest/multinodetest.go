@@ -0,0 +1,365 @@
+package storetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/inmem"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/task"
+	"github.com/influxdata/influxdb/task/backend"
+	"github.com/influxdata/influxdb/task/backend/coordinator"
+	"github.com/influxdata/influxdb/task/mock"
+	"go.uber.org/zap"
+)
+
+// waitTimeout is how long the multi-node tests wait for nodes to agree on the owners of tasks.
+const waitTimeout = 10 * time.Second
+
+// MultiNodeHarness runs nodes in-process that share a task store and a kv store for the task leases,
+// as influxd instances sharing task metadata do.
+// Each node has its own scheduler, executor, coordinator and kv service,
+// and schedules the tasks whose lease it holds.
+type MultiNodeHarness struct {
+	t *testing.T
+
+	Store    backend.Store
+	Leases   kv.Store
+	LeaseTTL time.Duration
+
+	// The organization owning the tasks created through the harness.
+	OrgID platform.ID
+
+	// Unix timestamp after which the tasks created through the harness are scheduled.
+	// The schedulers of the nodes only advance when ticked through the harness.
+	Now int64
+
+	Nodes []*Node
+
+	orgSvc  *inmem.Service
+	leaser  backend.TaskLeaser
+	taskIDs []platform.ID
+}
+
+// Node is a node of a MultiNodeHarness.
+type Node struct {
+	Owner string
+
+	Scheduler   *backend.TickScheduler
+	Executor    *mock.Executor
+	TaskService platform.TaskService
+
+	killed bool
+	cancel context.CancelFunc
+}
+
+// NewMultiNodeHarness returns a harness without nodes, sharing s and leases, whose nodes lease tasks for leaseTTL.
+func NewMultiNodeHarness(t *testing.T, s backend.Store, leases kv.Store, leaseTTL time.Duration) *MultiNodeHarness {
+	t.Helper()
+
+	leaser := kv.NewService(leases)
+	if err := leaser.Initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	orgSvc := inmem.NewService()
+	org := &platform.Organization{Name: "multi-node"}
+	if err := orgSvc.CreateOrganization(context.Background(), org); err != nil {
+		t.Fatal(err)
+	}
+
+	return &MultiNodeHarness{
+		t:        t,
+		Store:    s,
+		Leases:   leases,
+		LeaseTTL: leaseTTL,
+		OrgID:    org.ID,
+		Now:      time.Now().Unix(),
+		orgSvc:   orgSvc,
+		leaser:   leaser,
+	}
+}
+
+// AddNode starts a node with the given lease owner name.
+func (h *MultiNodeHarness) AddNode(owner string) *Node {
+	h.t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaser := kv.NewService(h.Leases)
+	if err := leaser.Initialize(ctx); err != nil {
+		h.t.Fatal(err)
+	}
+	// The nodes keep logging until they are closed, which can be after the test completed.
+	logger := zap.NewNop()
+
+	e := mock.NewExecutor()
+	sch := backend.NewScheduler(backend.TaskControlAdaptor(h.Store, backend.NopLogWriter{}, backend.NopLogReader{}), e, h.Now, backend.WithLogger(logger))
+	// A killed node's scheduler is only stopped when the harness is closed,
+	// as stopping it would finish the runs in progress that another node should resume.
+	sch.Start(context.Background())
+
+	ts := task.PlatformAdapter(h.Store, backend.NopLogReader{}, sch, h.orgSvc, h.orgSvc, h.orgSvc)
+	n := &Node{
+		Owner:       owner,
+		Scheduler:   sch,
+		Executor:    e,
+		TaskService: coordinator.New(logger, sch, ts, coordinator.WithLeases(ctx, leaser, owner, h.LeaseTTL)),
+		cancel:      cancel,
+	}
+	h.Nodes = append(h.Nodes, n)
+	return n
+}
+
+// Kill stops n from renewing or claiming leases and from ticking, without releasing its leases,
+// as if its process had crashed.
+func (n *Node) Kill() {
+	n.killed = true
+	n.cancel()
+}
+
+// CreateTask creates an active task directly in the store, to be claimed by any node.
+// The task runs every second after h.Now.
+func (h *MultiNodeHarness) CreateTask(name string) platform.ID {
+	h.t.Helper()
+
+	script := fmt.Sprintf(`option task = {name: %q, every: 1s}
+
+from(bucket: "b") |> range(start: -1h)`, name)
+	id, err := h.Store.CreateTask(context.Background(), backend.CreateTaskRequest{
+		Org:             h.OrgID,
+		AuthorizationID: 1,
+		Script:          script,
+		ScheduleAfter:   h.Now,
+	})
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	h.taskIDs = append(h.taskIDs, id)
+	return id
+}
+
+// WaitForOwner waits until the lease of the task with the given ID is held by an owner for which ok returns true,
+// and returns that owner.
+func (h *MultiNodeHarness) WaitForOwner(taskID platform.ID, ok func(owner string) bool) string {
+	h.t.Helper()
+
+	var l *backend.TaskLease
+	for deadline := time.Now().Add(waitTimeout); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		var err error
+		l, err = h.leaser.FindTaskLease(context.Background(), taskID)
+		if err != nil {
+			h.t.Fatal(err)
+		}
+		if ok(l.Owner) {
+			return l.Owner
+		}
+	}
+	h.t.Fatalf("task %s is still leased to %q", taskID, l.Owner)
+	return ""
+}
+
+// Tick ticks the schedulers of the nodes that are not killed to now.
+func (h *MultiNodeHarness) Tick(now int64) {
+	for _, n := range h.Nodes {
+		if !n.killed {
+			n.Scheduler.Tick(now)
+		}
+	}
+}
+
+// WaitForRunning ticks the nodes to now until exactly one run of the task with the given ID is executing,
+// and returns the node executing it and the run.
+// It fails the test if the nodes that are not killed execute more than one run of the task.
+func (h *MultiNodeHarness) WaitForRunning(taskID platform.ID, now int64) (*Node, backend.QueuedRun) {
+	h.t.Helper()
+
+	for deadline := time.Now().Add(waitTimeout); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		h.Tick(now)
+
+		var (
+			running *Node
+			run     backend.QueuedRun
+			count   int
+		)
+		for _, n := range h.Nodes {
+			if n.killed {
+				continue
+			}
+			for _, rp := range n.Executor.RunningFor(taskID) {
+				running, run = n, rp.Run()
+				count++
+			}
+		}
+		if count > 1 {
+			h.t.Fatalf("task %s has %d runs executing on the nodes", taskID, count)
+		}
+		if count == 1 {
+			return running, run
+		}
+	}
+	h.t.Fatalf("no run of task %s executed", taskID)
+	return nil, backend.QueuedRun{}
+}
+
+// Close stops all nodes, finishing the runs still executing.
+func (h *MultiNodeHarness) Close() {
+	for _, n := range h.Nodes {
+		n.cancel()
+		for _, id := range h.taskIDs {
+			for _, rp := range n.Executor.RunningFor(id) {
+				rp.Finish(mock.NewRunResult(nil, false), nil)
+			}
+		}
+		n.Scheduler.Stop()
+	}
+}
+
+// NewMultiNodeTest returns a test of nodes sharing a store, which should pass for any backend.Store.
+// The nodes share an in-memory kv store for the task leases.
+func NewMultiNodeTest(name string, cf CreateStoreFunc, df DestroyStoreFunc) func(t *testing.T) {
+	return func(t *testing.T) {
+		t.Run(name, func(t *testing.T) {
+			t.Run("One node per task", func(t *testing.T) {
+				testOneNodePerTask(t, cf, df)
+			})
+			t.Run("Failover", func(t *testing.T) {
+				testFailover(t, cf, df)
+			})
+			t.Run("Disable through another node", func(t *testing.T) {
+				testDisableThroughAnotherNode(t, cf, df)
+			})
+			t.Run("Delete through another node", func(t *testing.T) {
+				testDeleteThroughAnotherNode(t, cf, df)
+			})
+		})
+	}
+}
+
+func testOneNodePerTask(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	s := create(t)
+	defer destroy(t, s)
+
+	h := NewMultiNodeHarness(t, s, inmem.NewKVStore(), time.Second)
+	defer h.Close()
+	h.AddNode("a")
+	h.AddNode("b")
+
+	ids := make([]platform.ID, 6)
+	for i := range ids {
+		ids[i] = h.CreateTask(fmt.Sprintf("task %d", i))
+	}
+
+	for _, id := range ids {
+		owner := h.WaitForOwner(id, func(owner string) bool { return owner != "" })
+		n, _ := h.WaitForRunning(id, h.Now+60)
+		if n.Owner != owner {
+			t.Fatalf("task %s leased to %s ran on %s", id, owner, n.Owner)
+		}
+	}
+}
+
+func testFailover(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	s := create(t)
+	defer destroy(t, s)
+
+	h := NewMultiNodeHarness(t, s, inmem.NewKVStore(), time.Second)
+	defer h.Close()
+	a := h.AddNode("a")
+
+	ids := make([]platform.ID, 4)
+	for i := range ids {
+		ids[i] = h.CreateTask(fmt.Sprintf("task %d", i))
+	}
+
+	runs := make(map[platform.ID]backend.QueuedRun, len(ids))
+	for _, id := range ids {
+		h.WaitForOwner(id, func(owner string) bool { return owner == a.Owner })
+		_, runs[id] = h.WaitForRunning(id, h.Now+60)
+	}
+
+	// b does not claim the tasks while a renews their leases.
+	b := h.AddNode("b")
+	time.Sleep(h.LeaseTTL)
+	for _, id := range ids {
+		if n, _ := h.WaitForRunning(id, h.Now+60); n != a {
+			t.Fatalf("task %s leased to %s ran on %s", id, a.Owner, n.Owner)
+		}
+	}
+
+	a.Kill()
+
+	for _, id := range ids {
+		h.WaitForOwner(id, func(owner string) bool { return owner == b.Owner })
+
+		// The run that was executing on a is resumed on b, rather than run again.
+		n, run := h.WaitForRunning(id, h.Now+60)
+		if n != b {
+			t.Fatalf("task %s ran on %s after failover", id, n.Owner)
+		}
+		if run != runs[id] {
+			t.Fatalf("expected run %#v of task %s to be resumed, got %#v", runs[id], id, run)
+		}
+	}
+}
+
+func testDisableThroughAnotherNode(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	s := create(t)
+	defer destroy(t, s)
+
+	h := NewMultiNodeHarness(t, s, inmem.NewKVStore(), time.Second)
+	defer h.Close()
+	a := h.AddNode("a")
+	b := h.AddNode("b")
+
+	id := h.CreateTask("task")
+	owner := h.WaitForOwner(id, func(owner string) bool { return owner != "" })
+	other := a
+	if owner == a.Owner {
+		other = b
+	}
+
+	inactive := string(backend.TaskInactive)
+	if _, err := other.TaskService.UpdateTask(context.Background(), id, platform.TaskUpdate{Status: &inactive}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The owner releases the lease of the disabled task when it next renews its leases.
+	h.WaitForOwner(id, func(owner string) bool { return owner == "" })
+
+	// Either node may claim the task once it is enabled again.
+	active := string(backend.TaskActive)
+	if _, err := other.TaskService.UpdateTask(context.Background(), id, platform.TaskUpdate{Status: &active}); err != nil {
+		t.Fatal(err)
+	}
+	owner = h.WaitForOwner(id, func(owner string) bool { return owner != "" })
+	if n, _ := h.WaitForRunning(id, h.Now+60); n.Owner != owner {
+		t.Fatalf("task %s leased to %s ran on %s", id, owner, n.Owner)
+	}
+}
+
+func testDeleteThroughAnotherNode(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	s := create(t)
+	defer destroy(t, s)
+
+	h := NewMultiNodeHarness(t, s, inmem.NewKVStore(), time.Second)
+	defer h.Close()
+	a := h.AddNode("a")
+	b := h.AddNode("b")
+
+	id := h.CreateTask("task")
+	owner := h.WaitForOwner(id, func(owner string) bool { return owner != "" })
+	other := a
+	if owner == a.Owner {
+		other = b
+	}
+
+	if err := other.TaskService.DeleteTask(context.Background(), id); err != nil {
+		t.Fatal(err)
+	}
+
+	// The owner stops scheduling the deleted task, then releases its lease when it next renews its leases.
+	h.WaitForOwner(id, func(owner string) bool { return owner == "" })
+}
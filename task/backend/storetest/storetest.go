@@ -34,7 +34,11 @@ func NewStoreTest(name string, cf CreateStoreFunc, df DestroyStoreFunc, funcName
 			"DeleteTask",
 			"CreateNextRun",
 			"FinishRun",
+			"IncrementRunTry",
+			"SkipMissedRuns",
 			"ManuallyRunTimeRange",
+			"FindTaskRevisions",
+			"Backfills",
 		}
 	}
 	availableFuncs := map[string]TestFunc{
@@ -47,8 +51,12 @@ func NewStoreTest(name string, cf CreateStoreFunc, df DestroyStoreFunc, funcName
 		"DeleteTask":           testStoreDelete,
 		"CreateNextRun":        testStoreCreateNextRun,
 		"FinishRun":            testStoreFinishRun,
+		"IncrementRunTry":      testStoreIncrementRunTry,
+		"SkipMissedRuns":       testStoreSkipMissedRuns,
 		"ManuallyRunTimeRange": testStoreManuallyRunTimeRange,
 		"DeleteOrg":            testStoreDeleteOrg,
+		"FindTaskRevisions":    testStoreFindTaskRevisions,
+		"Backfills":            testStoreBackfills,
 	}
 
 	return func(t *testing.T) {
@@ -273,6 +281,190 @@ from(bucket:"y") |> range(start:-1h)`
 	})
 }
 
+func testStoreFindTaskRevisions(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+	}
+
+from(bucket:"x") |> range(start:-1h)`
+
+	const script2 = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+	}
+
+from(bucket:"y") |> range(start:-1h)`
+
+	s := create(t)
+	defer destroy(t, s)
+
+	id, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, AuthorizationID: 3, Author: 5, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Changing the status does not record a revision.
+	if _, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, Status: backend.TaskInactive}); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, Script: script2, Author: 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.NewTask.Revision != 2 {
+		t.Fatalf("expected updated task at revision 2, got %d", res.NewTask.Revision)
+	}
+
+	res, err = s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, AuthorizationID: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.NewTask.Revision != 3 {
+		t.Fatalf("expected task with new authorization at revision 3, got %d", res.NewTask.Revision)
+	}
+
+	task, err := s.FindTaskByID(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Revision != 3 {
+		t.Fatalf("expected found task at revision 3, got %d", task.Revision)
+	}
+
+	revs, err := s.FindTaskRevisions(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revs) != 3 {
+		t.Fatalf("expected 3 revisions, got %d", len(revs))
+	}
+	for i, exp := range []backend.StoreTaskRevision{
+		{Revision: 1, Script: script, AuthorizationID: 3, Author: 5},
+		{Revision: 2, Script: script2, AuthorizationID: 3, Author: 6},
+		{Revision: 3, Script: script2, AuthorizationID: 4},
+	} {
+		got := revs[i]
+		if got.CreatedAt == 0 {
+			t.Fatalf("expected revision %d to have a creation time", got.Revision)
+		}
+		got.CreatedAt = 0
+		if got != exp {
+			t.Fatalf("unexpected revision %d: got %#v, want %#v", i+1, got, exp)
+		}
+	}
+
+	if _, err := s.DeleteTask(context.Background(), id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.FindTaskRevisions(context.Background(), id); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected %v for deleted task, got %v", backend.ErrTaskNotFound, err)
+	}
+}
+
+func testStoreBackfills(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		every: 1h,
+	}
+
+from(bucket:"x") |> range(start:-1h)`
+
+	s := create(t)
+	defer destroy(t, s)
+
+	ctx := context.Background()
+	id1, err := s.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, AuthorizationID: 3, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id2, err := s.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, AuthorizationID: 3, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bf1 := backend.StoreBackfill{
+		Backfill: platform.Backfill{ID: 10, TaskID: id1, Status: platform.BackfillStatusRunning, Pending: 3},
+		Total:    3,
+		Next:     3600,
+	}
+	bf2 := backend.StoreBackfill{
+		Backfill: platform.Backfill{ID: 20, TaskID: id1, Status: platform.BackfillStatusRunning, Pending: 1},
+		Total:    1,
+	}
+	bf3 := backend.StoreBackfill{
+		Backfill: platform.Backfill{ID: 5, TaskID: id2, Status: platform.BackfillStatusRunning, Pending: 1},
+		Total:    1,
+	}
+	for _, bf := range []backend.StoreBackfill{bf2, bf1, bf3} {
+		if err := s.PutBackfill(ctx, bf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.PutBackfill(ctx, backend.StoreBackfill{Backfill: platform.Backfill{ID: 1, TaskID: 1}}); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected %v for a backfill of a missing task, got %v", backend.ErrTaskNotFound, err)
+	}
+
+	// Putting a backfill again replaces it.
+	bf1.Completed, bf1.Pending = 1, 2
+	bf1.QueuedStart, bf1.QueuedEnd, bf1.QueuedAt, bf1.QueuedRuns = 1, 7200, 100, 2
+	if err := s.PutBackfill(ctx, bf1); err != nil {
+		t.Fatal(err)
+	}
+
+	bfs, err := s.FindBackfills(ctx, id1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]backend.StoreBackfill{bf1, bf2}, bfs); diff != "" {
+		t.Fatalf("unexpected backfills of the task: -want/+got:\n%s", diff)
+	}
+
+	var want []backend.StoreBackfill
+	if id1 < id2 {
+		want = []backend.StoreBackfill{bf1, bf2, bf3}
+	} else {
+		want = []backend.StoreBackfill{bf3, bf1, bf2}
+	}
+	bfs, err = s.ListBackfills(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, bfs); diff != "" {
+		t.Fatalf("unexpected backfills: -want/+got:\n%s", diff)
+	}
+
+	if err := s.DeleteBackfill(ctx, id1, bf2.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteBackfill(ctx, id1, bf2.ID); err != nil {
+		t.Fatalf("expected no error deleting a missing backfill, got %v", err)
+	}
+	bfs, err = s.FindBackfills(ctx, id1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]backend.StoreBackfill{bf1}, bfs); diff != "" {
+		t.Fatalf("unexpected backfills after deleting one: -want/+got:\n%s", diff)
+	}
+
+	// Deleting the task deletes its backfills.
+	if _, err := s.DeleteTask(ctx, id1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.FindBackfills(ctx, id1); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected %v for deleted task, got %v", backend.ErrTaskNotFound, err)
+	}
+	bfs, err = s.ListBackfills(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]backend.StoreBackfill{bf3}, bfs); diff != "" {
+		t.Fatalf("unexpected backfills after deleting the task: -want/+got:\n%s", diff)
+	}
+}
+
 func testStoreListTasks(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
 	const scriptFmt = `option task = {
 		name: "testStoreListTasks %d",
@@ -949,6 +1141,105 @@ from(bucket:"test") |> range(start:-1h)`
 	}
 }
 
+func testStoreIncrementRunTry(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+		retry: 3,
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	task, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, AuthorizationID: 3, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rc, err := s.CreateNextRun(context.Background(), task, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for exp := uint32(2); exp <= 3; exp++ {
+		try, err := s.IncrementRunTry(context.Background(), task, rc.Created.RunID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if try != exp {
+			t.Fatalf("expected try %d, got %d", exp, try)
+		}
+	}
+
+	meta, err := s.FindTaskMetaByID(context.Background(), task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := meta.CurrentlyRunning[0].Try; got != 3 {
+		t.Fatalf("expected try 3 to be stored, got %d", got)
+	}
+
+	if err := s.FinishRun(context.Background(), task, rc.Created.RunID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.IncrementRunTry(context.Background(), task, rc.Created.RunID); err == nil {
+		t.Fatal("expected failure when retrying run that doesnt exist")
+	}
+}
+
+func testStoreSkipMissedRuns(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	task, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, AuthorizationID: 3, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Runs at 60, 120, 180, 240 and 300 are due by 300; only the last two remain.
+	if err := s.SkipMissedRuns(context.Background(), task, 300, 2); err != nil {
+		t.Fatal(err)
+	}
+	meta, err := s.FindTaskMetaByID(context.Background(), task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.LatestCompleted != 180 {
+		t.Fatalf("expected latest completed 180, got %d", meta.LatestCompleted)
+	}
+
+	rc, err := s.CreateNextRun(context.Background(), task, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rc.Created.Now != 240 {
+		t.Fatalf("expected run at 240, got %d", rc.Created.Now)
+	}
+
+	// Skipping all the missed runs leaves the run in progress alone.
+	if err := s.SkipMissedRuns(context.Background(), task, 300, 0); err != nil {
+		t.Fatal(err)
+	}
+	meta, err = s.FindTaskMetaByID(context.Background(), task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.LatestCompleted != 300 || len(meta.CurrentlyRunning) != 1 {
+		t.Fatalf("expected latest completed 300 and 1 run in progress, got %d and %d", meta.LatestCompleted, len(meta.CurrentlyRunning))
+	}
+
+	if err := s.SkipMissedRuns(context.Background(), platform.ID(math.MaxUint64), 300, 0); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected ErrTaskNotFound for missing task, got %v", err)
+	}
+}
+
 func testStoreManuallyRunTimeRange(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
 	const script = `option task = {
 		name: "a task",
@@ -23,6 +23,28 @@ type TaskControlService interface {
 	// FinishRun removes runID from the list of running tasks and if its `ScheduledFor` is later then last completed update it.
 	FinishRun(ctx context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error)
 
+	// IncrementRunTry records another attempt at a currently running run and returns the attempt number.
+	IncrementRunTry(ctx context.Context, taskID, runID influxdb.ID) (uint32, error)
+
+	// ForceRun queues a run scheduled for the unix timestamp scheduledFor, to be executed as soon as possible.
+	// The scheduler uses it to run tasks that depend on the task of a run that succeeded.
+	ForceRun(ctx context.Context, taskID influxdb.ID, scheduledFor int64) (*influxdb.Run, error)
+
+	// AddUpstreamSuccess records that the upstream task succeeded for the run time now of the task with the given ID,
+	// which depends on it, and returns the IDs of every upstream task recorded as succeeded for that time.
+	// At most MaxPendingUpstreamRuns run times are kept per task; the oldest is forgotten first.
+	AddUpstreamSuccess(ctx context.Context, taskID, upstreamID influxdb.ID, now int64) ([]influxdb.ID, error)
+
+	// DeleteUpstreamSuccesses forgets the upstream tasks that succeeded for the run time now of the task with the given ID,
+	// once the run of the task for that time is queued.
+	DeleteUpstreamSuccesses(ctx context.Context, taskID influxdb.ID, now int64) error
+
+	// SkipMissedRuns advances the latest completed run of the task,
+	// so that at most maxRuns of the runs due no later than now remain to be created.
+	// A negative maxRuns skips no runs.
+	// The scheduler uses it to apply the catchup option of a task it claims.
+	SkipMissedRuns(ctx context.Context, taskID influxdb.ID, now int64, maxRuns int) error
+
 	// NextDueRun returns the Unix timestamp of when the next call to CreateNextRun will be ready.
 	// The returned timestamp reflects the task's offset, so it does not necessarily exactly match the schedule time.
 	NextDueRun(ctx context.Context, taskID influxdb.ID) (int64, error)
@@ -32,6 +54,36 @@ type TaskControlService interface {
 
 	// AddRunLog adds a log line to the run.
 	AddRunLog(ctx context.Context, taskID, runID influxdb.ID, when time.Time, log string) error
+
+	// UpdateRunStatistics sets the statistics of the execution of the run.
+	UpdateRunStatistics(ctx context.Context, taskID, runID influxdb.ID, when time.Time, stats influxdb.RunStatistics) error
+}
+
+// TaskLeaser grants expiring leases on tasks, so that of the nodes sharing task metadata,
+// only the node holding the lease of a task schedules it.
+type TaskLeaser interface {
+	// ClaimTaskLease grants owner the lease of the task with the given ID until expiresAt,
+	// if the task is not leased, its lease expired no later than now, or owner already holds it, in which case the lease is renewed.
+	// now and expiresAt are Unix timestamps in nanoseconds.
+	// If another owner holds a lease that has not expired, ErrTaskLeaseHeld is returned.
+	ClaimTaskLease(ctx context.Context, taskID influxdb.ID, owner string, now, expiresAt int64) (*TaskLease, error)
+
+	// ReleaseTaskLease ends the lease of owner on the task with the given ID, so that any owner may claim it.
+	// Releasing a lease that is not held is not an error, but releasing a lease that another owner holds returns ErrTaskLeaseHeld.
+	ReleaseTaskLease(ctx context.Context, taskID influxdb.ID, owner string) error
+
+	// FindTaskLease returns the lease of the task with the given ID.
+	// The Owner of the returned lease is empty if the task has never been leased, or its lease was released.
+	FindTaskLease(ctx context.Context, taskID influxdb.ID) (*TaskLease, error)
+}
+
+// TaskLease is the lease of a task, granted to the node that schedules it.
+type TaskLease struct {
+	// The node holding the lease.
+	Owner string `json:"owner"`
+
+	// Unix timestamp in nanoseconds after which any node may claim the lease.
+	ExpiresAt int64 `json:"expiresAt"`
 }
 
 // TaskControlAdaptor creates a TaskControlService for the older TaskStore system.
@@ -62,6 +114,14 @@ func (tcs *taskControlAdaptor) FinishRun(ctx context.Context, taskID, runID infl
 	return nil, tcs.s.FinishRun(ctx, taskID, runID)
 }
 
+func (tcs *taskControlAdaptor) IncrementRunTry(ctx context.Context, taskID, runID influxdb.ID) (uint32, error) {
+	return tcs.s.IncrementRunTry(ctx, taskID, runID)
+}
+
+func (tcs *taskControlAdaptor) SkipMissedRuns(ctx context.Context, taskID influxdb.ID, now int64, maxRuns int) error {
+	return tcs.s.SkipMissedRuns(ctx, taskID, now, maxRuns)
+}
+
 func (tcs *taskControlAdaptor) CurrentlyRunning(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Run, error) {
 	t, m, err := tcs.s.FindTaskByIDWithMeta(ctx, taskID)
 	if err != nil {
@@ -102,6 +162,29 @@ func (tcs *taskControlAdaptor) ManualRuns(ctx context.Context, taskID influxdb.I
 	return rtn, nil
 }
 
+func (tcs *taskControlAdaptor) ForceRun(ctx context.Context, taskID influxdb.ID, scheduledFor int64) (*influxdb.Run, error) {
+	requestedAt := time.Now()
+	m, err := tcs.s.ManuallyRunTimeRange(ctx, taskID, scheduledFor, scheduledFor, requestedAt.Unix())
+	if err != nil {
+		return nil, err
+	}
+	return &influxdb.Run{
+		ID:           influxdb.ID(m.RunID),
+		TaskID:       taskID,
+		RequestedAt:  requestedAt.UTC().Format(time.RFC3339),
+		Status:       RunScheduled.String(),
+		ScheduledFor: time.Unix(scheduledFor, 0).UTC().Format(time.RFC3339),
+	}, nil
+}
+
+func (tcs *taskControlAdaptor) AddUpstreamSuccess(ctx context.Context, taskID, upstreamID influxdb.ID, now int64) ([]influxdb.ID, error) {
+	return tcs.s.AddUpstreamSuccess(ctx, taskID, upstreamID, now)
+}
+
+func (tcs *taskControlAdaptor) DeleteUpstreamSuccesses(ctx context.Context, taskID influxdb.ID, now int64) error {
+	return tcs.s.DeleteUpstreamSuccesses(ctx, taskID, now)
+}
+
 func (tcs *taskControlAdaptor) NextDueRun(ctx context.Context, taskID influxdb.ID) (int64, error) {
 	m, err := tcs.s.FindTaskMetaByID(ctx, taskID)
 	if err != nil {
@@ -111,75 +194,50 @@ func (tcs *taskControlAdaptor) NextDueRun(ctx context.Context, taskID influxdb.I
 }
 
 func (tcs *taskControlAdaptor) UpdateRunState(ctx context.Context, taskID, runID influxdb.ID, when time.Time, state RunStatus) error {
-	st, m, err := tcs.s.FindTaskByIDWithMeta(ctx, taskID)
+	rlb, err := tcs.runLogBase(ctx, taskID, runID)
 	if err != nil {
 		return err
 	}
-	var (
-		schedFor, reqAt time.Time
-	)
-	// check the log store
-	r, err := tcs.lr.FindRunByID(ctx, st.Org, runID)
-	if err == nil && r != nil {
-		schedFor, err = time.Parse(time.RFC3339, r.ScheduledFor)
-		if err != nil {
-			return err
-		}
-		if r.RequestedAt != "" {
-			reqAt, err = time.Parse(time.RFC3339, r.RequestedAt)
-			if err != nil {
-				return err
-			}
-		}
-	}
+	return tcs.lw.UpdateRunState(ctx, rlb, when, state)
+}
 
-	// in the old system the log store may not have the run until after the first
-	// state update, so we will need to pull the currently running.
-	if schedFor.IsZero() {
-		for _, cr := range m.CurrentlyRunning {
-			if influxdb.ID(cr.RunID) == runID {
-				schedFor = time.Unix(cr.Now, 0)
-				if cr.RequestedAt != 0 {
-					reqAt = time.Unix(cr.RequestedAt, 0)
-				}
-			}
-		}
+func (tcs *taskControlAdaptor) AddRunLog(ctx context.Context, taskID, runID influxdb.ID, when time.Time, log string) error {
+	rlb, err := tcs.runLogBase(ctx, taskID, runID)
+	if err != nil {
+		return err
 	}
+	return tcs.lw.AddRunLog(ctx, rlb, when, log)
+}
 
-	rlb := RunLogBase{
-		Task:            st,
-		RunID:           runID,
-		RunScheduledFor: schedFor.Unix(),
-	}
-	if !reqAt.IsZero() {
-		rlb.RequestedAt = reqAt.Unix()
-	}
-	if err := tcs.lw.UpdateRunState(ctx, rlb, when, state); err != nil {
+func (tcs *taskControlAdaptor) UpdateRunStatistics(ctx context.Context, taskID, runID influxdb.ID, when time.Time, stats influxdb.RunStatistics) error {
+	rlb, err := tcs.runLogBase(ctx, taskID, runID)
+	if err != nil {
 		return err
 	}
-	return nil
+	return tcs.lw.UpdateRunStatistics(ctx, rlb, when, stats)
 }
 
-func (tcs *taskControlAdaptor) AddRunLog(ctx context.Context, taskID, runID influxdb.ID, when time.Time, log string) error {
+// runLogBase returns the RunLogBase to write to the log store for the run.
+func (tcs *taskControlAdaptor) runLogBase(ctx context.Context, taskID, runID influxdb.ID) (RunLogBase, error) {
 	st, m, err := tcs.s.FindTaskByIDWithMeta(ctx, taskID)
 	if err != nil {
-		return err
+		return RunLogBase{}, err
 	}
 
 	var (
 		schedFor, reqAt time.Time
 	)
-
+	// check the log store
 	r, err := tcs.lr.FindRunByID(ctx, st.Org, runID)
 	if err == nil && r != nil {
 		schedFor, err = time.Parse(time.RFC3339, r.ScheduledFor)
 		if err != nil {
-			return err
+			return RunLogBase{}, err
 		}
 		if r.RequestedAt != "" {
 			reqAt, err = time.Parse(time.RFC3339, r.RequestedAt)
 			if err != nil {
-				return err
+				return RunLogBase{}, err
 			}
 		}
 	}
@@ -205,7 +263,7 @@ func (tcs *taskControlAdaptor) AddRunLog(ctx context.Context, taskID, runID infl
 	if !reqAt.IsZero() {
 		rlb.RequestedAt = reqAt.Unix()
 	}
-	return tcs.lw.AddRunLog(ctx, rlb, when, log)
+	return rlb, nil
 }
 
 // ToInfluxTask converts a backend tas and meta to a influxdb.Task
@@ -222,6 +280,7 @@ func ToInfluxTask(t *StoreTask, m *StoreTaskMeta) (*influxdb.Task, error) {
 		Name:            t.Name,
 		Flux:            t.Script,
 		Cron:            opts.Cron,
+		Location:        opts.Location,
 		AuthorizationID: influxdb.ID(m.AuthorizationID),
 	}
 	if !opts.Every.IsZero() {
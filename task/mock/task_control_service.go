@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"sync"
 	"time"
@@ -11,7 +12,7 @@ import (
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/snowflake"
 	"github.com/influxdata/influxdb/task/backend"
-	cron "gopkg.in/robfig/cron.v2"
+	"github.com/influxdata/influxdb/task/options"
 )
 
 var idgen = snowflake.NewDefaultIDGenerator()
@@ -31,6 +32,10 @@ type TaskControlService struct {
 	// Map of task ID to total number of runs created for that task.
 	totalRunsCreated map[influxdb.ID]int
 	finishedRuns     map[influxdb.ID]*influxdb.Run
+	// Map of run ID to the try of the run.
+	tries map[influxdb.ID]uint32
+	// Map of task ID to run time to the upstream tasks that succeeded for it.
+	upstreamSuccesses map[influxdb.ID]map[int64][]influxdb.ID
 }
 
 var _ backend.TaskControlService = (*TaskControlService)(nil)
@@ -42,6 +47,9 @@ func NewTaskControlService() *TaskControlService {
 		tasks:            make(map[influxdb.ID]*influxdb.Task),
 		created:          make(map[string]backend.QueuedRun),
 		totalRunsCreated: make(map[influxdb.ID]int),
+		tries:            make(map[influxdb.ID]uint32),
+
+		upstreamSuccesses: make(map[influxdb.ID]map[int64][]influxdb.ID),
 	}
 }
 
@@ -73,9 +81,9 @@ func (d *TaskControlService) CreateNextRun(ctx context.Context, taskID influxdb.
 		panic(fmt.Sprintf("meta not set for task with ID %s", tid))
 	}
 
-	if len(d.manualRuns) != 0 {
-		run := d.manualRuns[0]
-		d.manualRuns = d.manualRuns[1:]
+	if i := d.nextManualRun(tid); i >= 0 {
+		run := d.manualRuns[i]
+		d.manualRuns = append(d.manualRuns[:i:i], d.manualRuns[i+1:]...)
 		runs, ok := d.runs[tid]
 		if !ok {
 			runs = make(map[influxdb.ID]*influxdb.Run)
@@ -92,7 +100,7 @@ func (d *TaskControlService) CreateNextRun(ctx context.Context, taskID influxdb.
 					Now:    now.Unix(),
 				},
 				NextDue:  next,
-				HasQueue: len(d.manualRuns) != 0,
+				HasQueue: d.nextManualRun(tid) >= 0,
 			}
 			d.created[tid.String()+rc.Created.RunID.String()] = rc.Created
 			d.totalRunsCreated[taskID]++
@@ -110,8 +118,22 @@ func (d *TaskControlService) CreateNextRun(ctx context.Context, taskID influxdb.
 	return rc, nil
 }
 
+// nextManualRun returns the index of the first manual run of the task, or -1.
+// Manual runs without a task ID, as set by SetManualRuns, belong to every task.
+func (d *TaskControlService) nextManualRun(taskID influxdb.ID) int {
+	for i, r := range d.manualRuns {
+		if !r.TaskID.Valid() || r.TaskID == taskID {
+			return i
+		}
+	}
+	return -1
+}
+
 func (t *TaskControlService) createNextRun(task *influxdb.Task, now int64) (backend.RunCreation, error) {
-	sch, err := cron.Parse(task.EffectiveCron())
+	if task.EffectiveCron() == "" {
+		return backend.RunCreation{}, backend.RunNotYetDueError{DueAt: math.MaxInt64}
+	}
+	sch, err := options.ParseSchedule(task.EffectiveCron())
 	if err != nil {
 		return backend.RunCreation{}, err
 	}
@@ -191,6 +213,63 @@ func (d *TaskControlService) FinishRun(_ context.Context, taskID, runID influxdb
 	return r, nil
 }
 
+func (d *TaskControlService) IncrementRunTry(_ context.Context, taskID, runID influxdb.ID) (uint32, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.runs[taskID][runID] == nil {
+		return 0, errors.New("run not found")
+	}
+	if d.tries[runID] == 0 {
+		d.tries[runID] = 1
+	}
+	d.tries[runID]++
+	return d.tries[runID], nil
+}
+
+func (d *TaskControlService) SkipMissedRuns(_ context.Context, taskID influxdb.ID, now int64, maxRuns int) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	task, ok := d.tasks[taskID]
+	if !ok {
+		panic(fmt.Sprintf("meta not set for task with ID %s", taskID))
+	}
+
+	latest := int64(0)
+	lt, err := time.Parse(time.RFC3339, task.LatestCompleted)
+	if err == nil {
+		latest = lt.Unix()
+	}
+	running := latest
+	for _, r := range d.runs[taskID] {
+		rt, err := time.Parse(time.RFC3339, r.ScheduledFor)
+		if err == nil && rt.Unix() > running {
+			running = rt.Unix()
+		}
+	}
+
+	lc, err := backend.CatchupLatestCompleted(task.EffectiveCron(), task.Offset, running, now, maxRuns)
+	if err != nil {
+		return err
+	}
+	if lc > running {
+		task.LatestCompleted = time.Unix(lc, 0).UTC().Format(time.RFC3339)
+	}
+	return nil
+}
+
+// RunTry returns the try of the run, which is 1 unless it was retried.
+func (d *TaskControlService) RunTry(runID influxdb.ID) uint32 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.tries[runID] == 0 {
+		return 1
+	}
+	return d.tries[runID]
+}
+
 func (t *TaskControlService) CurrentlyRunning(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Run, error) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -205,10 +284,67 @@ func (t *TaskControlService) ManualRuns(ctx context.Context, taskID influxdb.ID)
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	if t.manualRuns != nil {
-		return t.manualRuns, nil
+	runs := []*influxdb.Run{}
+	for _, r := range t.manualRuns {
+		if !r.TaskID.Valid() || r.TaskID == taskID {
+			runs = append(runs, r)
+		}
+	}
+	return runs, nil
+}
+
+// ForceRun queues a manual run of the task.
+func (d *TaskControlService) ForceRun(_ context.Context, taskID influxdb.ID, scheduledFor int64) (*influxdb.Run, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	run := &influxdb.Run{
+		ID:           idgen.ID(),
+		TaskID:       taskID,
+		Status:       backend.RunScheduled.String(),
+		ScheduledFor: time.Unix(scheduledFor, 0).UTC().Format(time.RFC3339),
+		RequestedAt:  time.Now().UTC().Format(time.RFC3339),
 	}
-	return []*influxdb.Run{}, nil
+	d.manualRuns = append(d.manualRuns, run)
+	return run, nil
+}
+
+// AddUpstreamSuccess records that an upstream task succeeded for a run time of the task.
+func (d *TaskControlService) AddUpstreamSuccess(_ context.Context, taskID, upstreamID influxdb.ID, now int64) ([]influxdb.ID, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	pending, ok := d.upstreamSuccesses[taskID]
+	if !ok {
+		pending = make(map[int64][]influxdb.ID)
+		d.upstreamSuccesses[taskID] = pending
+	}
+	ids := pending[now]
+	for _, id := range ids {
+		if id == upstreamID {
+			return append([]influxdb.ID(nil), ids...), nil
+		}
+	}
+	ids = append(ids, upstreamID)
+	pending[now] = ids
+	return append([]influxdb.ID(nil), ids...), nil
+}
+
+// DeleteUpstreamSuccesses forgets the upstream tasks that succeeded for a run time of the task.
+func (d *TaskControlService) DeleteUpstreamSuccesses(_ context.Context, taskID influxdb.ID, now int64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.upstreamSuccesses[taskID], now)
+	return nil
+}
+
+// UpstreamSuccesses returns the upstream tasks recorded as succeeded for a run time of the task.
+func (d *TaskControlService) UpstreamSuccesses(taskID influxdb.ID, now int64) []influxdb.ID {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]influxdb.ID(nil), d.upstreamSuccesses[taskID][now]...)
 }
 
 // NextDueRun returns the Unix timestamp of when the next call to CreateNextRun will be ready.
@@ -222,7 +358,10 @@ func (d *TaskControlService) NextDueRun(ctx context.Context, taskID influxdb.ID)
 
 func (d *TaskControlService) nextDueRun(ctx context.Context, taskID influxdb.ID) (int64, error) {
 	task := d.tasks[taskID]
-	sch, err := cron.Parse(task.EffectiveCron())
+	if task.EffectiveCron() == "" {
+		return math.MaxInt64, nil
+	}
+	sch, err := options.ParseSchedule(task.EffectiveCron())
 	if err != nil {
 		return 0, err
 	}
@@ -287,6 +426,19 @@ func (d *TaskControlService) AddRunLog(ctx context.Context, taskID, runID influx
 	return nil
 }
 
+// UpdateRunStatistics sets the statistics of the execution of the run.
+func (d *TaskControlService) UpdateRunStatistics(ctx context.Context, taskID, runID influxdb.ID, when time.Time, stats influxdb.RunStatistics) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	run := d.runs[taskID][runID]
+	if run == nil {
+		panic("cannot set the statistics of a non existant run")
+	}
+	run.Statistics = &stats
+	return nil
+}
+
 func (d *TaskControlService) CreatedFor(taskID influxdb.ID) []backend.QueuedRun {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -4,6 +4,7 @@ package options
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -34,6 +35,18 @@ func EnableScriptCacheForTest() {
 const maxConcurrency = 100
 const maxRetry = 10
 
+// The values of the catchup option, besides "max:<n>".
+const (
+	// CatchupAll runs every run missed while the task was not scheduled.
+	CatchupAll = "all"
+	// CatchupLatest runs only the most recent missed run.
+	CatchupLatest = "latest"
+	// CatchupNone skips every missed run.
+	CatchupNone = "none"
+
+	catchupMaxPrefix = "max:"
+)
+
 // Options are the task-related options that can be specified in a Flux script.
 type Options struct {
 	// Name is a non optional name designator for each task.
@@ -53,6 +66,28 @@ type Options struct {
 	Concurrency *int64 `json:"concurrency,omitempty"`
 
 	Retry *int64 `json:"retry,omitempty"`
+
+	// DependsOn are the IDs or names of the tasks in the same organization that trigger this task.
+	// A task that depends on other tasks runs for the same now as their runs, once they have all succeeded,
+	// in place of a schedule of its own.
+	// A failed upstream run doesn't run the task; it runs for that now if a later run of the failed task for the same now succeeds.
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Timeout is how long a single attempt at a run may execute before it is canceled and failed.
+	// this can be unmarshaled from json as a string i.e.: "1d" will unmarshal as 1 day
+	Timeout *Duration `json:"timeout,omitempty"`
+
+	// MemoryBytes is the number of bytes of memory the query of a run may allocate.
+	MemoryBytes *int64 `json:"memoryBytes,omitempty"`
+
+	// Catchup is which of the runs missed while the task was not scheduled, such as while influxd was down or the task was inactive,
+	// are run when the task is scheduled again: "all", "latest", "none", or at most n of the most recent ones with "max:<n>".
+	// If empty, all missed runs are run.
+	Catchup string `json:"catchup,omitempty"`
+
+	// Location is the IANA time zone name, like "Europe/Paris", in which Cron is evaluated instead of UTC.
+	// It is also set as the location option of the queries of the task's runs, right after the imports of the script.
+	Location string `json:"location,omitempty"`
 }
 
 // Duration is a time span that supports the same units as the flux parser's time duration, as well as negative length time spans.
@@ -142,6 +177,11 @@ func (o *Options) Clear() {
 	o.Offset = nil
 	o.Concurrency = nil
 	o.Retry = nil
+	o.DependsOn = nil
+	o.Timeout = nil
+	o.MemoryBytes = nil
+	o.Catchup = ""
+	o.Location = ""
 }
 
 // IsZero tells us if the options has been zeroed out.
@@ -151,7 +191,12 @@ func (o *Options) IsZero() bool {
 		o.Every.IsZero() &&
 		o.Offset == nil &&
 		o.Concurrency == nil &&
-		o.Retry == nil
+		o.Retry == nil &&
+		len(o.DependsOn) == 0 &&
+		o.Timeout == nil &&
+		o.MemoryBytes == nil &&
+		o.Catchup == "" &&
+		o.Location == ""
 }
 
 // All the task option names we accept.
@@ -162,6 +207,11 @@ const (
 	optOffset      = "offset"
 	optConcurrency = "concurrency"
 	optRetry       = "retry"
+	optDependsOn   = "dependsOn"
+	optTimeout     = "timeout"
+	optMemoryBytes = "memoryBytes"
+	optCatchup     = "catchup"
+	optLocation    = "location"
 )
 
 // contains is a helper function to see if an array of strings contains a string
@@ -175,7 +225,7 @@ func contains(s []string, e string) bool {
 }
 
 func grabTaskOptionAST(p *ast.Package, keys ...string) map[string]ast.Expression {
-	res := make(map[string]ast.Expression, 2) // we preallocate two keys for the map, as that is how many we will use at maximum (offset and every)
+	res := make(map[string]ast.Expression, 3) // we preallocate three keys for the map, as that is how many we will use at maximum (offset, every and timeout)
 	for i := range p.Files {
 		for j := range p.Files[i].Body {
 			if p.Files[i].Body[j].Type() != "OptionStatement" {
@@ -225,7 +275,7 @@ func FromScript(script string) (Options, error) {
 	if err != nil {
 		return opt, err
 	}
-	durTypes := grabTaskOptionAST(fluxAST, optEvery, optOffset)
+	durTypes := grabTaskOptionAST(fluxAST, optEvery, optOffset, optTimeout)
 	_, scope, err := flux.EvalAST(fluxAST)
 	if err != nil {
 		return opt, err
@@ -256,12 +306,36 @@ func FromScript(script string) (Options, error) {
 	opt.Name = nameVal.Str()
 	crVal, cronOK := optObject.Get(optCron)
 	everyVal, everyOK := optObject.Get(optEvery)
+	dependsOnVal, dependsOnOK := optObject.Get(optDependsOn)
 	if cronOK && everyOK {
 		return opt, errors.New("cannot use both cron and every in task options")
 	}
+	if dependsOnOK && (cronOK || everyOK) {
+		return opt, errors.New("cannot use dependsOn with cron or every in task options")
+	}
 
-	if !cronOK && !everyOK {
-		return opt, errors.New("cron or every is required")
+	if !cronOK && !everyOK && !dependsOnOK {
+		return opt, errors.New("cron, every or dependsOn is required")
+	}
+
+	if dependsOnOK {
+		if err := checkNature(dependsOnVal.PolyType().Nature(), semantic.Array); err != nil {
+			return opt, err
+		}
+		var err error
+		opt.DependsOn = make([]string, 0, dependsOnVal.Array().Len())
+		dependsOnVal.Array().Range(func(_ int, v values.Value) {
+			if err != nil {
+				return
+			}
+			if err = checkNature(v.PolyType().Nature(), semantic.String); err != nil {
+				return
+			}
+			opt.DependsOn = append(opt.DependsOn, v.Str())
+		})
+		if err != nil {
+			return opt, err
+		}
 	}
 
 	if cronOK {
@@ -324,6 +398,44 @@ func FromScript(script string) (Options, error) {
 		opt.Retry = pointer.Int64(retryVal.Int())
 	}
 
+	if timeoutVal, ok := optObject.Get(optTimeout); ok {
+		if err := checkNature(timeoutVal.PolyType().Nature(), semantic.Duration); err != nil {
+			return opt, err
+		}
+		dur, ok := durTypes["timeout"]
+		if !ok || dur == nil {
+			return opt, errors.New("failed to parse `timeout` in task")
+		}
+		durNode, err := parseSignedDuration(dur.Location().Source)
+		if err != nil {
+			return opt, err
+		}
+		durNode.BaseNode = ast.BaseNode{}
+		opt.Timeout = &Duration{}
+		opt.Timeout.Node = *durNode
+	}
+
+	if memoryBytesVal, ok := optObject.Get(optMemoryBytes); ok {
+		if err := checkNature(memoryBytesVal.PolyType().Nature(), semantic.Int); err != nil {
+			return opt, err
+		}
+		opt.MemoryBytes = pointer.Int64(memoryBytesVal.Int())
+	}
+
+	if catchupVal, ok := optObject.Get(optCatchup); ok {
+		if err := checkNature(catchupVal.PolyType().Nature(), semantic.String); err != nil {
+			return opt, err
+		}
+		opt.Catchup = catchupVal.Str()
+	}
+
+	if locationVal, ok := optObject.Get(optLocation); ok {
+		if err := checkNature(locationVal.PolyType().Nature(), semantic.String); err != nil {
+			return opt, err
+		}
+		opt.Location = locationVal.Str()
+	}
+
 	if err := opt.Validate(); err != nil {
 		return opt, err
 	}
@@ -347,7 +459,24 @@ func (o *Options) Validate() error {
 
 	cronPresent := o.Cron != ""
 	everyPresent := !o.Every.IsZero()
-	if cronPresent == everyPresent {
+	dependsOnPresent := len(o.DependsOn) > 0
+	if dependsOnPresent {
+		if cronPresent || everyPresent {
+			errs = append(errs, "must not specify cron or every with dependsOn")
+		}
+		if o.Offset != nil {
+			errs = append(errs, "must not specify offset with dependsOn")
+		}
+		seen := make(map[string]bool, len(o.DependsOn))
+		for _, d := range o.DependsOn {
+			if d == "" {
+				errs = append(errs, "dependsOn must not contain empty task names")
+			} else if seen[d] {
+				errs = append(errs, fmt.Sprintf("dependsOn contains %q more than once", d))
+			}
+			seen[d] = true
+		}
+	} else if cronPresent == everyPresent {
 		// They're both present or both missing.
 		errs = append(errs, "must specify exactly one of either cron or every")
 	} else if cronPresent {
@@ -390,6 +519,31 @@ func (o *Options) Validate() error {
 			errs = append(errs, fmt.Sprintf("retry exceeded max of %d", maxRetry))
 		}
 	}
+	if o.Timeout != nil {
+		timeout, err := o.Timeout.DurationFrom(now)
+		if err != nil {
+			return err
+		}
+		if timeout < time.Second {
+			errs = append(errs, "timeout option must be at least 1 second")
+		} else if timeout.Truncate(time.Second) != timeout {
+			errs = append(errs, "timeout option must be expressible as whole seconds")
+		}
+	}
+	if o.MemoryBytes != nil && *o.MemoryBytes < 1 {
+		errs = append(errs, "memoryBytes must be at least 1")
+	}
+	if _, err := o.MaxCatchupRuns(); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if o.Location != "" {
+		if _, err := time.LoadLocation(o.Location); err != nil {
+			errs = append(errs, "location invalid: "+err.Error())
+		}
+		if strings.HasPrefix(o.Cron, cronLocationPrefix) {
+			errs = append(errs, "must not specify location with a cron that has a "+cronLocationPrefix+" prefix")
+		}
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -398,16 +552,40 @@ func (o *Options) Validate() error {
 	return fmt.Errorf("invalid options: %s", strings.Join(errs, ", "))
 }
 
+// MaxCatchupRuns returns how many of the most recent runs missed while the task was not scheduled are run according to the catchup option,
+// or -1 if all of them are.
+func (o *Options) MaxCatchupRuns() (int, error) {
+	switch o.Catchup {
+	case "", CatchupAll:
+		return -1, nil
+	case CatchupLatest:
+		return 1, nil
+	case CatchupNone:
+		return 0, nil
+	}
+	if strings.HasPrefix(o.Catchup, catchupMaxPrefix) {
+		n, err := strconv.Atoi(strings.TrimPrefix(o.Catchup, catchupMaxPrefix))
+		if err == nil && n >= 0 {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("catchup must be one of %q, %q, %q or \"max:<n>\" with n a non-negative integer, got %q", CatchupAll, CatchupLatest, CatchupNone, o.Catchup)
+}
+
 // EffectiveCronString returns the effective cron string of the options.
 // If the cron option was specified, it is returned.
 // If the every option was specified, it is converted into a cron string using "@every".
-// Otherwise, the empty string is returned.
+// Otherwise, as for tasks that depend on other tasks, the empty string is returned.
+// A cron with a location is prefixed with "TZ=<location> ", as understood by ParseSchedule.
 // The value of the offset option is not considered.
 // TODO(docmerlin): create an EffectiveCronStringFrom(t time.Time) string,
 // that works from a unit of time.
 // Do not use this if you haven't checked for validity already.
 func (o *Options) EffectiveCronString() string {
 	if o.Cron != "" {
+		if o.Location != "" {
+			return cronLocationPrefix + o.Location + " " + o.Cron
+		}
 		return o.Cron
 	}
 	every, _ := o.Every.DurationFrom(time.Now()) // we can ignore errors here because we have alreach checked for validity.
@@ -431,7 +609,7 @@ func validateOptionNames(o values.Object) error {
 	var unexpected []string
 	o.Range(func(name string, _ values.Value) {
 		switch name {
-		case optName, optCron, optEvery, optOffset, optConcurrency, optRetry:
+		case optName, optCron, optEvery, optOffset, optConcurrency, optRetry, optDependsOn, optTimeout, optMemoryBytes, optCatchup, optLocation:
 			// Known option. Nothing to do.
 		default:
 			unexpected = append(unexpected, name)
@@ -440,7 +618,7 @@ func validateOptionNames(o values.Object) error {
 
 	if len(unexpected) > 0 {
 		u := strings.Join(unexpected, ", ")
-		v := strings.Join([]string{optName, optCron, optEvery, optOffset, optConcurrency, optRetry}, ", ")
+		v := strings.Join([]string{optName, optCron, optEvery, optOffset, optConcurrency, optRetry, optDependsOn, optTimeout, optMemoryBytes, optCatchup, optLocation}, ", ")
 		return fmt.Errorf("unknown task option(s): %s. valid options are %s", u, v)
 	}
 
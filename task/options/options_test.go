@@ -78,6 +78,21 @@ func TestFromScript(t *testing.T) {
 		{script: "option task = {\n  name: \"name8\",\n  retry: 0,\n  every: 1m0s,\n\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
 		{script: scriptGenerator(options.Options{Name: "name9"}, ""), shouldErr: true},
 		{script: scriptGenerator(options.Options{}, ""), shouldErr: true},
+		{script: "option task = {\n  name: \"name10\",\n  dependsOn: [\"raw\", \"0000000000000001\"],\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)",
+			exp: options.Options{Name: "name10", DependsOn: []string{"raw", "0000000000000001"}, Concurrency: pointer.Int64(1), Retry: pointer.Int64(1)}},
+		{script: "option task = {\n  name: \"name11\",\n  every: 1m,\n  dependsOn: [\"raw\"],\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
+		{script: "option task = {\n  name: \"name12\",\n  dependsOn: [1],\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
+		{script: "option task = {\n  name: \"name13\",\n  dependsOn: [\"raw\", \"raw\"],\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
+		{script: "option task = {\n  name: \"name14\",\n  every: 1h,\n  timeout: 5m,\n  memoryBytes: 1000000,\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)",
+			exp: options.Options{Name: "name14", Every: *(options.MustParseDuration("1h")), Timeout: options.MustParseDuration("5m"), MemoryBytes: pointer.Int64(1000000), Concurrency: pointer.Int64(1), Retry: pointer.Int64(1)}},
+		{script: "option task = {\n  name: \"name15\",\n  every: 1h,\n  timeout: 1500ms,\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
+		{script: "option task = {\n  name: \"name16\",\n  every: 1h,\n  memoryBytes: 0,\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
+		{script: "option task = {\n  name: \"name17\",\n  every: 1h,\n  catchup: \"max:3\",\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)",
+			exp: options.Options{Name: "name17", Every: *(options.MustParseDuration("1h")), Catchup: "max:3", Concurrency: pointer.Int64(1), Retry: pointer.Int64(1)}},
+		{script: "option task = {\n  name: \"name19\",\n  cron: \"0 6 * * *\",\n  location: \"Europe/Paris\",\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)",
+			exp: options.Options{Name: "name19", Cron: "0 6 * * *", Location: "Europe/Paris", Concurrency: pointer.Int64(1), Retry: pointer.Int64(1)}},
+		{script: "option task = {\n  name: \"name20\",\n  cron: \"0 6 * * *\",\n  location: \"Nowhere/Special\",\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
+		{script: "option task = {\n  name: \"name18\",\n  every: 1h,\n  catchup: \"some\",\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
 	} {
 		o, err := options.FromScript(c.script)
 		if c.shouldErr && err == nil {
@@ -113,7 +128,7 @@ func TestFromScriptWithUnknownOptions(t *testing.T) {
 		t.Errorf("expected error to mention unrecognized options, but it said: %v", err)
 	}
 
-	validOpts := []string{"name", "cron", "every", "offset", "concurrency", "retry"}
+	validOpts := []string{"name", "cron", "every", "offset", "concurrency", "retry", "dependsOn", "timeout", "memoryBytes", "catchup", "location"}
 	for _, o := range validOpts {
 		if !strings.Contains(msg, o) {
 			t.Errorf("expected error to mention valid option %q but it said: %v", o, err)
@@ -188,19 +203,95 @@ func TestValidate(t *testing.T) {
 	if err := bad.Validate(); err == nil {
 		t.Error("expected error for retry too large")
 	}
+
+	*bad = good
+	bad.Timeout = options.MustParseDuration("0s")
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for 0 timeout")
+	}
+
+	*bad = good
+	bad.MemoryBytes = pointer.Int64(-1)
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for negative memoryBytes")
+	}
+
+	*bad = good
+	bad.Catchup = "max:-1"
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for negative catchup max")
+	}
+
+	*bad = good
+	bad.Location = "Europe/Paris"
+	bad.Cron = "TZ=Asia/Tokyo * * * * *"
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for location with a TZ cron")
+	}
+
+	dependent := good
+	dependent.Cron = ""
+	dependent.DependsOn = []string{"upstream"}
+	if err := dependent.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	*bad = dependent
+	bad.Cron = "* * * * *"
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for options with both cron and dependsOn")
+	}
+
+	*bad = dependent
+	bad.Offset = options.MustParseDuration("1m")
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for options with both offset and dependsOn")
+	}
+}
+
+func TestMaxCatchupRuns(t *testing.T) {
+	for _, c := range []struct {
+		catchup string
+		exp     int
+	}{
+		{catchup: "", exp: -1},
+		{catchup: "all", exp: -1},
+		{catchup: "latest", exp: 1},
+		{catchup: "none", exp: 0},
+		{catchup: "max:5", exp: 5},
+	} {
+		o := options.Options{Catchup: c.catchup}
+		got, err := o.MaxCatchupRuns()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.exp {
+			t.Fatalf("catchup %q: expected %d, got %d", c.catchup, c.exp, got)
+		}
+	}
+
+	for _, catchup := range []string{"some", "max:", "max:x", "max:-2"} {
+		o := options.Options{Catchup: catchup}
+		if _, err := o.MaxCatchupRuns(); err == nil {
+			t.Fatalf("expected error for catchup %q", catchup)
+		}
+	}
 }
 
 func TestEffectiveCronString(t *testing.T) {
 	for _, c := range []struct {
 		c   string
 		e   options.Duration
+		l   string
 		exp string
 	}{
 		{c: "10 * * * *", exp: "10 * * * *"},
+		{c: "10 * * * *", l: "Asia/Tokyo", exp: "TZ=Asia/Tokyo 10 * * * *"},
 		{e: *(options.MustParseDuration("10s")), exp: "@every 10s"},
+		{e: *(options.MustParseDuration("10s")), l: "Asia/Tokyo", exp: "@every 10s"},
 		{exp: ""},
 	} {
-		o := options.Options{Cron: c.c, Every: c.e}
+		o := options.Options{Cron: c.c, Every: c.e, Location: c.l}
 		got := o.EffectiveCronString()
 		if got != c.exp {
 			t.Fatalf("exp cron string %q, got %q for %v", c.exp, got, o)
@@ -0,0 +1,108 @@
+package options
+
+import (
+	"strings"
+	"time"
+
+	cron "gopkg.in/robfig/cron.v2"
+)
+
+const cronLocationPrefix = "TZ="
+
+// cronWildcardBit is set by cron in the fields of a SpecSchedule given as "*" or "*/n", like its unexported starBit.
+const cronWildcardBit = 1 << 63
+
+// ParseSchedule parses an effective cron string, as returned by EffectiveCronString, into a schedule.
+// Cron schedules without a location are evaluated in UTC.
+//
+// Cron schedules with a location run once at every local time they match, across daylight saving time transitions:
+// a local time that is repeated when clocks go back only runs the first time,
+// and a local time that is skipped when clocks go forward runs at the transition.
+// As with the original cron, schedules with a wildcard hour run hourly or more often, so they just follow the clock.
+func ParseSchedule(effectiveCron string) (cron.Schedule, error) {
+	if !strings.HasPrefix(effectiveCron, cronLocationPrefix) {
+		effectiveCron = cronLocationPrefix + "UTC " + effectiveCron
+	}
+	sch, err := cron.Parse(effectiveCron)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, ok := sch.(*cron.SpecSchedule)
+	if !ok || spec.Location == time.UTC || spec.Hour&cronWildcardBit != 0 {
+		return sch, nil
+	}
+	return localSchedule{spec: spec}, nil
+}
+
+// localSchedule is a cron schedule in a location with daylight saving time.
+type localSchedule struct {
+	spec *cron.SpecSchedule
+}
+
+// Next returns the next time the schedule is due after t.
+func (s localSchedule) Next(t time.Time) time.Time {
+	next := s.spec.Next(t)
+	if next.IsZero() {
+		return next
+	}
+
+	if jump, ok := s.skippedUntil(t, next); ok {
+		return jump
+	}
+
+	for s.repeated(next) {
+		next = s.spec.Next(next)
+		if next.IsZero() {
+			return next
+		}
+	}
+	return next
+}
+
+// skippedUntil reports whether the clocks went forward between t and next, skipping a local time the schedule matches,
+// and if so returns the time of the transition.
+func (s localSchedule) skippedUntil(t, next time.Time) (time.Time, bool) {
+	_, before := t.In(s.spec.Location).Zone()
+	_, after := next.In(s.spec.Location).Zone()
+	if after <= before {
+		return time.Time{}, false
+	}
+
+	// Find the transition, to the second.
+	lo, hi := t, next
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+		if _, off := mid.In(s.spec.Location).Zone(); off == before {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	transition := hi
+
+	// The local times skipped by the transition, still in the offset from before it,
+	// lie between the transition and the length of the jump after it.
+	fixed := *s.spec
+	fixed.Location = time.FixedZone("", before)
+	skipped := fixed.Next(t)
+	if skipped.IsZero() || skipped.Before(transition) || !skipped.Before(transition.Add(time.Duration(after-before)*time.Second)) {
+		return time.Time{}, false
+	}
+	return transition, true
+}
+
+// repeated reports whether the local time of t already occurred before t, because the clocks went back.
+func (s localSchedule) repeated(t time.Time) bool {
+	local := t.In(s.spec.Location)
+	_, off := local.Zone()
+	// Clocks never go back by as much as a few hours, so a few hours earlier is before any transition that repeats t.
+	_, earlierOff := local.Add(-3 * time.Hour).Zone()
+	if earlierOff <= off {
+		return false
+	}
+
+	earlier := local.Add(-time.Duration(earlierOff-off) * time.Second)
+	return earlier.Hour() == local.Hour() && earlier.Minute() == local.Minute() && earlier.Second() == local.Second() &&
+		earlier.YearDay() == local.YearDay()
+}
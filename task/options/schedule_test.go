@@ -0,0 +1,78 @@
+package options_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/task/options"
+)
+
+func TestParseSchedule(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		cron  string
+		after string
+		exp   []string
+	}{
+		{
+			name:  "UTC without location",
+			cron:  "0 6 * * *",
+			after: "2019-01-01T00:00:00Z",
+			exp:   []string{"2019-01-01T06:00:00Z", "2019-01-02T06:00:00Z"},
+		},
+		{
+			name:  "local time",
+			cron:  "TZ=Europe/Paris 0 6 * * *",
+			after: "2019-03-30T00:00:00Z",
+			// Paris switches from UTC+1 to UTC+2 on March 31st.
+			exp: []string{"2019-03-30T05:00:00Z", "2019-03-31T04:00:00Z", "2019-04-01T04:00:00Z"},
+		},
+		{
+			name:  "skipped local time runs at transition",
+			cron:  "TZ=America/New_York 30 2 * * *",
+			after: "2019-03-09T12:00:00Z",
+			// 02:30 does not exist on March 10th, when clocks go forward from 02:00 to 03:00.
+			exp: []string{"2019-03-10T07:00:00Z", "2019-03-11T06:30:00Z"},
+		},
+		{
+			name:  "repeated local time runs once",
+			cron:  "TZ=America/New_York 30 1 * * *",
+			after: "2019-11-03T00:00:00Z",
+			// 01:30 happens twice on November 3rd, when clocks go back from 02:00 to 01:00.
+			exp: []string{"2019-11-03T05:30:00Z", "2019-11-04T06:30:00Z"},
+		},
+		{
+			name:  "wildcard hour follows the clock",
+			cron:  "TZ=America/New_York 30 * * * *",
+			after: "2019-11-03T05:00:00Z",
+			exp:   []string{"2019-11-03T05:30:00Z", "2019-11-03T06:30:00Z", "2019-11-03T07:30:00Z"},
+		},
+		{
+			name:  "every",
+			cron:  "@every 1h",
+			after: "2019-01-01T00:00:00Z",
+			exp:   []string{"2019-01-01T01:00:00Z", "2019-01-01T02:00:00Z"},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			sch, err := options.ParseSchedule(c.cron)
+			if err != nil {
+				t.Fatal(err)
+			}
+			next, err := time.Parse(time.RFC3339, c.after)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, exp := range c.exp {
+				next = sch.Next(next)
+				if got := next.UTC().Format(time.RFC3339); got != exp {
+					t.Fatalf("expected %s, got %s", exp, got)
+				}
+			}
+		})
+	}
+
+	if _, err := options.ParseSchedule("TZ=Nowhere/Special 0 6 * * *"); err == nil {
+		t.Fatal("expected error for unknown location")
+	}
+}
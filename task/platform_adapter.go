@@ -150,11 +150,16 @@ func (p pAdapter) CreateTask(ctx context.Context, t platform.TaskCreate) (*platf
 		return nil, err
 	}
 
+	if err := p.validateDependsOn(ctx, &platform.Task{Name: opts.Name, OrganizationID: org.ID, DependsOn: opts.DependsOn}); err != nil {
+		return nil, err
+	}
+
 	req := backend.CreateTaskRequest{
 		Org:           org.ID,
 		ScheduleAfter: scheduleAfter,
 		Status:        backend.TaskStatus(t.Status),
 		Script:        t.Flux,
+		Author:        auth.GetUserID(),
 	}
 	req.AuthorizationID, err = p.authorizationIDFromToken(ctx, t.Token)
 	if err != nil {
@@ -174,6 +179,9 @@ func (p pAdapter) CreateTask(ctx context.Context, t platform.TaskCreate) (*platf
 		Organization:    org.Name,
 		Status:          t.Status,
 		AuthorizationID: req.AuthorizationID,
+		DependsOn:       opts.DependsOn,
+		Location:        opts.Location,
+		Revision:        1,
 	}
 
 	if !opts.Every.IsZero() {
@@ -227,6 +235,32 @@ func (p pAdapter) UpdateTask(ctx context.Context, id platform.ID, upd platform.T
 		}
 	}
 
+	return p.updateTask(ctx, req)
+}
+
+// updateTask stores the update of a task, and returns the updated task.
+func (p pAdapter) updateTask(ctx context.Context, req backend.UpdateTaskRequest) (*platform.Task, error) {
+	if req.Script != "" {
+		// Check the dependencies of the updated script before storing it.
+		opts, err := options.FromScript(req.Script)
+		if err != nil {
+			return nil, err
+		}
+		if len(opts.DependsOn) > 0 {
+			t, err := p.s.FindTaskByID(ctx, req.ID)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.validateDependsOn(ctx, &platform.Task{ID: req.ID, Name: opts.Name, OrganizationID: t.Org, DependsOn: opts.DependsOn}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if auth, err := icontext.GetAuthorizer(ctx); err == nil {
+		req.Author = auth.GetUserID()
+	}
+
 	res, err := p.s.UpdateTask(ctx, req)
 	if err != nil {
 		return nil, err
@@ -234,7 +268,7 @@ func (p pAdapter) UpdateTask(ctx context.Context, id platform.ID, upd platform.T
 	if res.NewTask.Script == "" {
 		return nil, errors.New("script not defined in the store")
 	}
-	return p.FindTaskByID(ctx, id)
+	return p.FindTaskByID(ctx, req.ID)
 }
 
 func (p pAdapter) DeleteTask(ctx context.Context, id platform.ID) error {
@@ -360,6 +394,54 @@ func (p pAdapter) ForceRun(ctx context.Context, taskID platform.ID, scheduledFor
 	}, nil
 }
 
+func (p pAdapter) FindTaskRevisions(ctx context.Context, taskID platform.ID) ([]*platform.TaskRevision, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	revs, err := p.s.FindTaskRevisions(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	prs := make([]*platform.TaskRevision, len(revs))
+	for i := range revs {
+		prs[i], err = toPlatformTaskRevision(taskID, revs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return prs, nil
+}
+
+func (p pAdapter) FindTaskRevision(ctx context.Context, taskID platform.ID, revision int) (*platform.TaskRevision, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	revs, err := p.s.FindTaskRevisions(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rev := range revs {
+		if rev.Revision == revision {
+			return toPlatformTaskRevision(taskID, rev)
+		}
+	}
+	return nil, backend.ErrTaskRevisionNotFound
+}
+
+func (p pAdapter) RollbackTask(ctx context.Context, taskID platform.ID, revision int) (*platform.Task, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	rev, err := p.FindTaskRevision(ctx, taskID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.updateTask(ctx, backend.UpdateTaskRequest{ID: taskID, Script: rev.Flux})
+}
+
 func (p pAdapter) CancelRun(ctx context.Context, taskID, runID platform.ID) error {
 	span, ctx := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
@@ -410,6 +492,39 @@ func (p pAdapter) authorizationIDFromToken(ctx context.Context, token string) (p
 	return a.ID, nil
 }
 
+// validateDependsOn checks the tasks that task depends on against the other tasks of its organization.
+func (p pAdapter) validateDependsOn(ctx context.Context, task *platform.Task) error {
+	if len(task.DependsOn) == 0 {
+		return nil
+	}
+
+	var tasks []*platform.Task
+	params := backend.TaskSearchParams{Org: task.OrganizationID, PageSize: platform.TaskMaxPageSize}
+	for {
+		ts, err := p.s.ListTasks(ctx, params)
+		if err != nil {
+			return err
+		}
+		for _, t := range ts {
+			opts, err := options.FromScript(t.Task.Script)
+			if err != nil {
+				return err
+			}
+			tasks = append(tasks, &platform.Task{
+				ID:             t.Task.ID,
+				OrganizationID: t.Task.Org,
+				Name:           t.Task.Name,
+				DependsOn:      opts.DependsOn,
+			})
+		}
+		if len(ts) < params.PageSize {
+			break
+		}
+		params.After = ts[len(ts)-1].Task.ID
+	}
+	return backend.ValidateDependsOn(task, tasks)
+}
+
 func (p *pAdapter) toPlatformTask(ctx context.Context, t backend.StoreTask, m *backend.StoreTaskMeta) (*platform.Task, error) {
 	opts, err := options.FromScript(t.Script)
 	if err != nil {
@@ -428,6 +543,9 @@ func (p *pAdapter) toPlatformTask(ctx context.Context, t backend.StoreTask, m *b
 		Name:           t.Name,
 		Flux:           t.Script,
 		Cron:           opts.Cron,
+		DependsOn:      opts.DependsOn,
+		Location:       opts.Location,
+		Revision:       t.Revision,
 	}
 	if !opts.Every.IsZero() {
 		pt.Every = opts.Every.String()
@@ -449,6 +567,23 @@ func (p *pAdapter) toPlatformTask(ctx context.Context, t backend.StoreTask, m *b
 	return pt, nil
 }
 
+func toPlatformTaskRevision(taskID platform.ID, rev backend.StoreTaskRevision) (*platform.TaskRevision, error) {
+	pr := &platform.TaskRevision{
+		TaskID:          taskID,
+		Revision:        rev.Revision,
+		Flux:            rev.Script,
+		AuthorizationID: rev.AuthorizationID,
+		AuthorID:        rev.Author,
+	}
+	if rev.CreatedAt != 0 {
+		pr.CreatedAt = time.Unix(rev.CreatedAt, 0).UTC().Format(time.RFC3339)
+	}
+	if err := pr.SetOptions(); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
 func (p *pAdapter) populateOrg(ctx context.Context, org *platform.Organization) error {
 	if org.ID.Valid() && org.Name != "" {
 		return nil
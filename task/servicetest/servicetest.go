@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"reflect"
 	"runtime"
 	"sync"
 	"testing"
@@ -89,10 +90,22 @@ func TestTaskService(t *testing.T, fn BackendComponentFactory, testCategory ...s
 					t.Parallel()
 					testUpdate(t, sys)
 				})
+				t.Run("Task Revisions", func(t *testing.T) {
+					t.Parallel()
+					testTaskRevisions(t, sys)
+				})
 				t.Run("Task Manual Run", func(t *testing.T) {
 					t.Parallel()
 					testManualRun(t, sys)
 				})
+				t.Run("Task Run Try", func(t *testing.T) {
+					t.Parallel()
+					testRunTry(t, sys)
+				})
+				t.Run("Task Upstream Successes", func(t *testing.T) {
+					t.Parallel()
+					testUpstreamSuccesses(t, sys)
+				})
 			})
 		case "analytical":
 			t.Run("AnalyticalTaskService", func(t *testing.T) {
@@ -550,6 +563,113 @@ func testUpdate(t *testing.T, sys *System) {
 	}
 }
 
+func testTaskRevisions(t *testing.T, sys *System) {
+	cr := creds(t, sys)
+
+	origFlux := fmt.Sprintf(scriptFmt, 0)
+	ct := influxdb.TaskCreate{
+		OrganizationID: cr.OrgID,
+		Flux:           origFlux,
+		Token:          cr.Token,
+	}
+	authorizedCtx := icontext.SetAuthorizer(sys.Ctx, cr.Authorizer())
+	task, err := sys.TaskService.CreateTask(authorizedCtx, ct)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Revision != 1 {
+		t.Fatalf("expected new task to be at revision 1, got %d", task.Revision)
+	}
+
+	newFlux := fmt.Sprintf(scriptFmt, 1)
+	task, err = sys.TaskService.UpdateTask(authorizedCtx, task.ID, influxdb.TaskUpdate{Flux: &newFlux})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Revision != 2 {
+		t.Fatalf("expected updated task to be at revision 2, got %d", task.Revision)
+	}
+
+	// Changing only the status does not record a revision.
+	inactive := string(backend.TaskInactive)
+	task, err = sys.TaskService.UpdateTask(authorizedCtx, task.ID, influxdb.TaskUpdate{Status: &inactive})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Revision != 2 {
+		t.Fatalf("expected status update to keep revision 2, got %d", task.Revision)
+	}
+
+	revs, err := sys.TaskService.FindTaskRevisions(sys.Ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revs) != 2 {
+		t.Fatalf("expected 2 revisions, got %d", len(revs))
+	}
+	if revs[0].Revision != 1 || revs[1].Revision != 2 {
+		t.Fatalf("expected revisions 1 and 2 in order, got %d and %d", revs[0].Revision, revs[1].Revision)
+	}
+	if revs[1].Flux != newFlux || revs[1].Name != "task #1" {
+		t.Fatalf("unexpected revision 2: %#v", revs[1])
+	}
+	if revs[1].AuthorID != cr.UserID {
+		t.Fatalf("expected revision 2 to be authored by %s, got %s", cr.UserID, revs[1].AuthorID)
+	}
+
+	rev, err := sys.TaskService.FindTaskRevision(sys.Ctx, task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rev.Flux != origFlux {
+		t.Fatalf("expected revision 1 to hold the original script, got %q", rev.Flux)
+	}
+
+	task, err = sys.TaskService.RollbackTask(authorizedCtx, task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Flux != origFlux {
+		t.Fatalf("expected rolled back task to have the original script, got %q", task.Flux)
+	}
+	if task.Name != "task #0" {
+		t.Fatalf("expected rolled back task to have the original name, got %q", task.Name)
+	}
+	if task.Revision != 3 {
+		t.Fatalf("expected rollback to record revision 3, got %d", task.Revision)
+	}
+	if task.Status != string(backend.TaskInactive) {
+		t.Fatalf("expected rollback to keep the task status, got %q", task.Status)
+	}
+
+	// A rollback keeps the current authorization of the task, not the one of the revision.
+	newAuthz := &influxdb.Authorization{OrgID: cr.OrgID, UserID: cr.UserID, Permissions: influxdb.OperPermissions()}
+	if err := sys.I.CreateAuthorization(sys.Ctx, newAuthz); err != nil {
+		t.Fatal(err)
+	}
+	task, err = sys.TaskService.UpdateTask(authorizedCtx, task.ID, influxdb.TaskUpdate{Token: newAuthz.Token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err = sys.TaskService.RollbackTask(authorizedCtx, task.ID, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Flux != newFlux {
+		t.Fatalf("expected rolled back task to have the script of revision 2, got %q", task.Flux)
+	}
+	if task.AuthorizationID != newAuthz.ID {
+		t.Fatalf("expected rollback to keep authorization %v, got %v", newAuthz.ID, task.AuthorizationID)
+	}
+
+	if _, err := sys.TaskService.FindTaskRevision(sys.Ctx, task.ID, 99); err != backend.ErrTaskRevisionNotFound {
+		t.Fatalf("expected %v for missing revision, got %v", backend.ErrTaskRevisionNotFound, err)
+	}
+	if _, err := sys.TaskService.RollbackTask(authorizedCtx, task.ID, 99); err != backend.ErrTaskRevisionNotFound {
+		t.Fatalf("expected %v when rolling back to a missing revision, got %v", backend.ErrTaskRevisionNotFound, err)
+	}
+}
+
 func testTaskRuns(t *testing.T, sys *System) {
 	cr := creds(t, sys)
 
@@ -941,6 +1061,97 @@ func testTaskConcurrency(t *testing.T, sys *System) {
 	extraWg.Wait()
 }
 
+func testRunTry(t *testing.T, s *System) {
+	cr := creds(t, s)
+
+	tc := influxdb.TaskCreate{
+		OrganizationID: cr.OrgID,
+		Flux:           fmt.Sprintf(scriptFmt, 0),
+		Token:          cr.Token,
+	}
+	authorizedCtx := icontext.SetAuthorizer(s.Ctx, cr.Authorizer())
+
+	task, err := s.TaskService.CreateTask(authorizedCtx, tc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rc, err := s.TaskControlService.CreateNextRun(s.Ctx, task.ID, time.Now().Add(5*time.Minute).Unix())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for exp := uint32(2); exp <= 3; exp++ {
+		try, err := s.TaskControlService.IncrementRunTry(s.Ctx, task.ID, rc.Created.RunID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if try != exp {
+			t.Fatalf("expected try %d, got %d", exp, try)
+		}
+	}
+
+	// The try is not included in the currently running runs.
+	runs, err := s.TaskControlService.CurrentlyRunning(s.Ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 || runs[0].ID != rc.Created.RunID {
+		t.Fatalf("expected run %s to be running, got %v", rc.Created.RunID, runs)
+	}
+
+	if _, err := s.TaskControlService.FinishRun(s.Ctx, task.ID, rc.Created.RunID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.TaskControlService.IncrementRunTry(s.Ctx, task.ID, rc.Created.RunID); err == nil {
+		t.Fatal("expected failure when retrying run that is not running")
+	}
+}
+
+func testUpstreamSuccesses(t *testing.T, s *System) {
+	cr := creds(t, s)
+
+	tc := influxdb.TaskCreate{
+		OrganizationID: cr.OrgID,
+		Flux:           fmt.Sprintf(scriptFmt, 0),
+		Token:          cr.Token,
+	}
+	authorizedCtx := icontext.SetAuthorizer(s.Ctx, cr.Authorizer())
+
+	task, err := s.TaskService.CreateTask(authorizedCtx, tc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	add := func(upstreamID influxdb.ID, now int64, exp ...influxdb.ID) {
+		t.Helper()
+		ids, err := s.TaskControlService.AddUpstreamSuccess(s.Ctx, task.ID, upstreamID, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ids, exp) {
+			t.Fatalf("expected upstream successes %v for %d, got %v", exp, now, ids)
+		}
+	}
+
+	const a, b = influxdb.ID(1), influxdb.ID(2)
+	add(a, 60, a)
+	add(a, 60, a)
+	add(b, 60, a, b)
+	add(b, 120, b)
+
+	if err := s.TaskControlService.DeleteUpstreamSuccesses(s.Ctx, task.ID, 60); err != nil {
+		t.Fatal(err)
+	}
+	add(b, 60, b)
+
+	// The oldest run time is forgotten once too many are kept.
+	for now := int64(180); now < 180+backend.MaxPendingUpstreamRuns; now++ {
+		add(a, now, a)
+	}
+	add(a, 60, a)
+}
+
 func testManualRun(t *testing.T, s *System) {
 	cr := creds(t, s)
 
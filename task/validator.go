@@ -310,6 +310,86 @@ func (ts *taskServiceValidator) ForceRun(ctx context.Context, taskID platform.ID
 	return ts.TaskService.ForceRun(ctx, taskID, scheduledFor)
 }
 
+func (ts *taskServiceValidator) FindTaskRevisions(ctx context.Context, taskID platform.ID) ([]*platform.TaskRevision, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := platform.NewPermissionAtID(taskID, platform.ReadAction, platform.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.validatePermission(ctx, *p,
+		zap.String("method", "FindTaskRevisions"), zap.Stringer("task_id", taskID),
+	); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.FindTaskRevisions(ctx, taskID)
+}
+
+func (ts *taskServiceValidator) FindTaskRevision(ctx context.Context, taskID platform.ID, revision int) (*platform.TaskRevision, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := platform.NewPermissionAtID(taskID, platform.ReadAction, platform.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.validatePermission(ctx, *p,
+		zap.String("method", "FindTaskRevision"), zap.Stringer("task_id", taskID), zap.Int("revision", revision),
+	); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.FindTaskRevision(ctx, taskID, revision)
+}
+
+func (ts *taskServiceValidator) RollbackTask(ctx context.Context, taskID platform.ID, revision int) (*platform.Task, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := platform.NewPermissionAtID(taskID, platform.WriteAction, platform.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	loggerFields := []zap.Field{zap.String("method", "RollbackTask"), zap.Stringer("task_id", taskID), zap.Int("revision", revision)}
+	if err := ts.validatePermission(ctx, *p, loggerFields...); err != nil {
+		return nil, err
+	}
+
+	// The restored script must only use buckets we are allowed to use now.
+	rev, err := ts.TaskService.FindTaskRevision(ctx, taskID, revision)
+	if err != nil {
+		return nil, err
+	}
+	if err := ts.validateBucket(ctx, rev.Flux, task.OrganizationID, loggerFields...); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.RollbackTask(ctx, taskID, revision)
+}
+
 func (ts *taskServiceValidator) validatePermission(ctx context.Context, perm platform.Permission, loggerFields ...zap.Field) error {
 	auth, err := platcontext.GetAuthorizer(ctx)
 	if err != nil {
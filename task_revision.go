@@ -0,0 +1,134 @@
+package influxdb
+
+import (
+	"strings"
+
+	"github.com/influxdata/influxdb/task/options"
+)
+
+// TaskRevision is an immutable snapshot of the script and authorization of a task.
+// A revision is recorded when a task is created, and every time its script or authorization changes.
+type TaskRevision struct {
+	TaskID ID `json:"taskID"`
+	// Revision numbers start at 1, and increase by 1 with every change to the task.
+	Revision int    `json:"revision"`
+	Flux     string `json:"flux"`
+
+	// The options of the task, as set by the Flux script of the revision.
+	Name   string `json:"name"`
+	Every  string `json:"every,omitempty"`
+	Cron   string `json:"cron,omitempty"`
+	Offset string `json:"offset,omitempty"`
+
+	AuthorizationID ID `json:"authorizationID,omitempty"`
+	// AuthorID is the ID of the user who made the change, if known.
+	AuthorID  ID     `json:"authorID,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty"`
+}
+
+// SetOptions sets the options of the revision from its Flux script.
+func (r *TaskRevision) SetOptions() error {
+	opts, err := options.FromScript(r.Flux)
+	if err != nil {
+		return err
+	}
+
+	r.Name = opts.Name
+	r.Cron = opts.Cron
+	r.Every, r.Offset = "", ""
+	if !opts.Every.IsZero() {
+		r.Every = opts.Every.String()
+	}
+	if opts.Offset != nil && !opts.Offset.IsZero() {
+		r.Offset = opts.Offset.String()
+	}
+	return nil
+}
+
+// TaskRevisionChange is a field of a task that differs between two revisions.
+type TaskRevisionChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// TaskRevisionDiff describes what changed between two revisions of a task.
+type TaskRevisionDiff struct {
+	TaskID ID  `json:"taskID"`
+	From   int `json:"from"`
+	To     int `json:"to"`
+
+	// Flux holds every line of the two scripts, prefixed by "-" for lines only in the From revision,
+	// "+" for lines only in the To revision, and " " for lines in both.
+	// It is empty if the scripts are equal.
+	Flux string `json:"flux"`
+
+	// Changes lists the options and authorization that differ between the revisions.
+	Changes []TaskRevisionChange `json:"changes"`
+}
+
+// DiffTaskRevisions returns what changed from one revision of a task to another.
+func DiffTaskRevisions(from, to *TaskRevision) *TaskRevisionDiff {
+	d := &TaskRevisionDiff{
+		TaskID:  to.TaskID,
+		From:    from.Revision,
+		To:      to.Revision,
+		Changes: []TaskRevisionChange{},
+	}
+	if from.Flux != to.Flux {
+		d.Flux = diffLines(from.Flux, to.Flux)
+	}
+
+	for _, c := range []TaskRevisionChange{
+		{Field: "name", From: from.Name, To: to.Name},
+		{Field: "every", From: from.Every, To: to.Every},
+		{Field: "cron", From: from.Cron, To: to.Cron},
+		{Field: "offset", From: from.Offset, To: to.Offset},
+		{Field: "authorizationID", From: from.AuthorizationID.String(), To: to.AuthorizationID.String()},
+	} {
+		if c.From != c.To {
+			d.Changes = append(d.Changes, c)
+		}
+	}
+	return d
+}
+
+// diffLines returns the lines of a and b, marked according to a longest common subsequence of their lines.
+func diffLines(a, b string) string {
+	al, bl := strings.Split(a, "\n"), strings.Split(b, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of al[i:] and bl[j:].
+	lcs := make([][]int, len(al)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bl)+1)
+	}
+	for i := len(al) - 1; i >= 0; i-- {
+		for j := len(bl) - 1; j >= 0; j-- {
+			if al[i] == bl[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(al) || j < len(bl) {
+		switch {
+		case i < len(al) && j < len(bl) && al[i] == bl[j]:
+			sb.WriteString(" " + al[i] + "\n")
+			i++
+			j++
+		case j == len(bl) || (i < len(al) && lcs[i+1][j] >= lcs[i][j+1]):
+			sb.WriteString("-" + al[i] + "\n")
+			i++
+		default:
+			sb.WriteString("+" + bl[j] + "\n")
+			j++
+		}
+	}
+	return sb.String()
+}
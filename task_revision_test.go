@@ -0,0 +1,50 @@
+package influxdb_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	platform "github.com/influxdata/influxdb"
+)
+
+func TestDiffTaskRevisions(t *testing.T) {
+	from := &platform.TaskRevision{
+		TaskID:          1,
+		Revision:        1,
+		Flux:            "option task = {name: \"a\", every: 1m}\nfrom(bucket: \"b\")\n|> range(start: -1m)",
+		Name:            "a",
+		Every:           "1m",
+		AuthorizationID: 2,
+	}
+	to := &platform.TaskRevision{
+		TaskID:          1,
+		Revision:        2,
+		Flux:            "option task = {name: \"a\", every: 5m}\nfrom(bucket: \"b\")\n|> range(start: -5m)",
+		Name:            "a",
+		Every:           "5m",
+		AuthorizationID: 3,
+	}
+
+	exp := &platform.TaskRevisionDiff{
+		TaskID: 1,
+		From:   1,
+		To:     2,
+		Flux: "-option task = {name: \"a\", every: 1m}\n" +
+			"+option task = {name: \"a\", every: 5m}\n" +
+			" from(bucket: \"b\")\n" +
+			"-|> range(start: -1m)\n" +
+			"+|> range(start: -5m)\n",
+		Changes: []platform.TaskRevisionChange{
+			{Field: "every", From: "1m", To: "5m"},
+			{Field: "authorizationID", From: "0000000000000002", To: "0000000000000003"},
+		},
+	}
+	if diff := cmp.Diff(exp, platform.DiffTaskRevisions(from, to)); diff != "" {
+		t.Fatalf("unexpected diff: -want/+got\n%s", diff)
+	}
+
+	same := platform.DiffTaskRevisions(from, from)
+	if same.Flux != "" || len(same.Changes) != 0 {
+		t.Fatalf("expected no changes between equal revisions, got %#v", same)
+	}
+}
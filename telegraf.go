@@ -1,15 +1,23 @@
 package influxdb
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"reflect"
 	"time"
 
+	"github.com/BurntSushi/toml"
+
 	"github.com/influxdata/influxdb/telegraf/plugins"
+	"github.com/influxdata/influxdb/telegraf/plugins/aggregators"
+	"github.com/influxdata/influxdb/telegraf/plugins/catalog"
 	"github.com/influxdata/influxdb/telegraf/plugins/inputs"
 	"github.com/influxdata/influxdb/telegraf/plugins/outputs"
+	"github.com/influxdata/influxdb/telegraf/plugins/processors"
 )
 
 // ErrTelegrafConfigInvalidOrganizationID is the error message for a missing or invalid organization ID.
@@ -25,6 +33,7 @@ var (
 	OpCreateTelegrafConfig   = "CreateTelegrafConfig"
 	OpUpdateTelegrafConfig   = "UpdateTelegrafConfig"
 	OpDeleteTelegrafConfig   = "DeleteTelegrafConfig"
+	OpImportTelegrafConfig   = "ImportTelegrafConfig"
 )
 
 // TelegrafConfigStore represents a service for managing telegraf config data.
@@ -67,6 +76,10 @@ type TelegrafConfig struct {
 
 	Agent   TelegrafAgentConfig
 	Plugins []TelegrafPlugin
+
+	// Revision is the latest revision of the config, which agents report applying when they check in.
+	// It is set by the stores keeping the revisions of the configs, and is zero otherwise.
+	Revision int
 }
 
 // TOML returns the telegraf toml config string.
@@ -76,6 +89,9 @@ func (tc TelegrafConfig) TOML() string {
 		plugins += p.Config.TOML()
 	}
 	interval := time.Duration(tc.Agent.Interval * 1000000)
+	if len(tc.Agent.Options) > 0 || len(tc.Agent.GlobalTags) > 0 {
+		return tc.Agent.toml(interval) + plugins
+	}
 	return fmt.Sprintf(`# Configuration for telegraf agent
 [agent]
   ## Default data collection interval for all inputs
@@ -139,6 +155,7 @@ type telegrafConfigEncode struct {
 	OrganizationID ID     `json:"organizationID,omitempty"`
 	Name           string `json:"name"`
 	Description    string `json:"description"`
+	Revision       int    `json:"revision,omitempty"`
 
 	Agent TelegrafAgentConfig `json:"agent"`
 
@@ -160,6 +177,7 @@ type telegrafConfigDecode struct {
 	OrganizationID ID     `json:"organizationID,omitempty"`
 	Name           string `json:"name"`
 	Description    string `json:"description"`
+	Revision       int    `json:"revision,omitempty"`
 
 	Agent TelegrafAgentConfig `json:"agent"`
 
@@ -185,6 +203,65 @@ type TelegrafPlugin struct {
 type TelegrafAgentConfig struct {
 	// Interval at which to gather information in miliseconds.
 	Interval int64 `json:"collectionInterval"`
+	// Options are the other options of the agent, like metric_batch_size, kept from imported configs.
+	// When unset, the options of the agent are the defaults of telegraf.
+	Options map[string]interface{} `json:"options,omitempty"`
+	// GlobalTags are added to all the metrics, like the global_tags of telegraf.
+	GlobalTags map[string]string `json:"globalTags,omitempty"`
+}
+
+// toml returns the global_tags and agent tables of the config.
+func (a TelegrafAgentConfig) toml(interval time.Duration) string {
+	options := make(map[string]interface{}, len(a.Options)+1)
+	for k, v := range a.Options {
+		options[k] = tomlNumbers(v)
+	}
+	options["interval"] = interval.String()
+	tables := map[string]interface{}{"agent": options}
+	if len(a.GlobalTags) > 0 {
+		tables["global_tags"] = a.GlobalTags
+	}
+
+	var buf bytes.Buffer
+	// The options are the values of a TOML table, which always encode.
+	_ = toml.NewEncoder(&buf).Encode(tables)
+	return buf.String()
+}
+
+// defaultTelegrafAgentOptions returns the options of the agent of the configs without options, but the interval.
+func defaultTelegrafAgentOptions() map[string]interface{} {
+	var data map[string]map[string]interface{}
+	if _, err := toml.Decode(TelegrafConfig{}.TOML(), &data); err != nil {
+		return nil
+	}
+	delete(data["agent"], "interval")
+	return data["agent"]
+}
+
+// tomlNumbers returns v, with the numbers decoded from JSON without a fractional part as integers,
+// so that they are not encoded as floats.
+func tomlNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v)
+		}
+		return v
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = tomlNumbers(e)
+		}
+		return a
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = tomlNumbers(e)
+		}
+		return m
+	default:
+		return v
+	}
 }
 
 // errors
@@ -203,6 +280,7 @@ func (tc *TelegrafConfig) MarshalJSON() ([]byte, error) {
 		OrganizationID: tc.OrganizationID,
 		Name:           tc.Name,
 		Description:    tc.Description,
+		Revision:       tc.Revision,
 		Agent:          tc.Agent,
 		Plugins:        make([]telegrafPluginEncode, len(tc.Plugins)),
 	}
@@ -217,20 +295,42 @@ func (tc *TelegrafConfig) MarshalJSON() ([]byte, error) {
 	return json.Marshal(tce)
 }
 
+// defaultTelegrafAgentInterval is the interval of the telegraf agents whose configuration doesn't set one.
+const defaultTelegrafAgentInterval = "10s"
+
+// ParseTelegrafConfigTOML parses a telegraf.conf file, keeping its agent options and global tags.
+// The plugins without typed configuration, or with options their typed configuration doesn't keep,
+// are kept as raw TOML.
+func ParseTelegrafConfigTOML(s string) (*TelegrafConfig, error) {
+	tc := new(TelegrafConfig)
+	if _, err := toml.Decode(s, tc); err != nil {
+		return nil, &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("cannot parse telegraf config: %v", err),
+			Op:   OpImportTelegrafConfig,
+		}
+	}
+	return tc, nil
+}
+
 // UnmarshalTOML implements toml.Unmarshaler interface.
 func (tc *TelegrafConfig) UnmarshalTOML(data interface{}) error {
 	dataOk, ok := data.(map[string]interface{})
 	if !ok {
 		return errors.New("blank string")
 	}
-	agent, ok := dataOk["agent"].(map[string]interface{})
-	if !ok {
-		return errors.New("agent is missing")
-	}
 
-	intervalStr, ok := agent["interval"].(string)
-	if !ok {
-		return errors.New("agent interval is not string")
+	agent := map[string]interface{}{}
+	if v, ok := dataOk["agent"]; ok {
+		if agent, ok = v.(map[string]interface{}); !ok {
+			return errors.New("agent is not a table")
+		}
+	}
+	intervalStr := defaultTelegrafAgentInterval
+	if v, ok := agent["interval"]; ok {
+		if intervalStr, ok = v.(string); !ok {
+			return errors.New("agent interval is not string")
+		}
 	}
 
 	interval, err := time.ParseDuration(intervalStr)
@@ -240,9 +340,31 @@ func (tc *TelegrafConfig) UnmarshalTOML(data interface{}) error {
 	tc.Agent = TelegrafAgentConfig{
 		Interval: interval.Nanoseconds() / 1000000,
 	}
+	options := make(map[string]interface{}, len(agent))
+	for k, v := range agent {
+		if k != "interval" {
+			options[k] = v
+		}
+	}
+	if len(options) > 0 && !reflect.DeepEqual(options, defaultTelegrafAgentOptions()) {
+		tc.Agent.Options = options
+	}
+
+	if v, ok := dataOk["global_tags"]; ok {
+		tags, ok := v.(map[string]interface{})
+		if !ok {
+			return errors.New("global_tags is not a table")
+		}
+		tc.Agent.GlobalTags = make(map[string]string, len(tags))
+		for k, v := range tags {
+			if tc.Agent.GlobalTags[k], ok = v.(string); !ok {
+				return fmt.Errorf("global tag %s is not a string", k)
+			}
+		}
+	}
 
 	for tp, ps := range dataOk {
-		if tp == "agent" {
+		if tp == "agent" || tp == "global_tags" {
 			continue
 		}
 		plugins, ok := ps.(map[string]interface{})
@@ -258,7 +380,13 @@ func (tc *TelegrafConfig) UnmarshalTOML(data interface{}) error {
 				}
 				continue
 			}
-			for _, configData := range configDataArray.([]map[string]interface{}) {
+			configs, ok := configDataArray.([]map[string]interface{})
+			if !ok {
+				return &Error{
+					Msg: fmt.Sprintf("%s.%s is not an array of tables", tp, name),
+				}
+			}
+			for _, configData := range configs {
 				if err := tc.parseTOMLPluginConfig(tp, name, configData); err != nil {
 					return err
 				}
@@ -277,19 +405,46 @@ func (tc *TelegrafConfig) parseTOMLPluginConfig(typ, name string, configData int
 		tpFn, ok = availableInputPlugins[name]
 	case "outputs":
 		tpFn, ok = availableOutputPlugins[name]
+	case "processors":
+		tpFn, ok = availableProcessorPlugins[name]
+	case "aggregators":
+		tpFn, ok = availableAggregatorPlugins[name]
 	default:
 		return &Error{
 			Msg: fmt.Sprintf(ErrUnsupportTelegrafPluginType, typ),
 		}
 	}
 
+	if ok {
+		p := tpFn()
+		if err := p.UnmarshalTOML(configData); err == nil && keepsTOMLOptions(p, typ, name, configData) {
+			tc.Plugins = append(tc.Plugins, TelegrafPlugin{
+				Config: p,
+			})
+			return nil
+		}
+	}
+
+	pluginType, _ := plugins.TypeOfTable(typ)
 	if !ok {
-		return &Error{
-			Msg: fmt.Sprintf(ErrUnsupportTelegrafPluginName, name, typ),
+		// The plugins of the catalog are not kept as is, since their options must match their schema.
+		if s, err := catalog.FindSchema(pluginType, name); err == nil {
+			p := catalog.NewPlugin(s)
+			if err := p.UnmarshalTOML(configData); err != nil {
+				return err
+			}
+			if err := p.Validate(); err != nil {
+				return fmt.Errorf("%s.%s: %v", typ, name, err)
+			}
+			tc.Plugins = append(tc.Plugins, TelegrafPlugin{
+				Config: p,
+			})
+			return nil
 		}
 	}
-	p := tpFn()
 
+	// The plugin is kept as is.
+	p := &plugins.Raw{PluginType: pluginType, Name: name}
 	if err := p.UnmarshalTOML(configData); err != nil {
 		return err
 	}
@@ -299,6 +454,22 @@ func (tc *TelegrafConfig) parseTOMLPluginConfig(typ, name string, configData int
 	return nil
 }
 
+// keepsTOMLOptions returns true if the TOML of the typed plugin p holds every option of its parsed data.
+func keepsTOMLOptions(p plugins.Config, typ, name string, data interface{}) bool {
+	options, _ := data.(map[string]interface{})
+	var encoded map[string]map[string][]map[string]interface{}
+	if _, err := toml.Decode(p.TOML(), &encoded); err != nil || len(encoded[typ][name]) != 1 {
+		return false
+	}
+	kept := encoded[typ][name][0]
+	for k, v := range options {
+		if !reflect.DeepEqual(kept[k], v) {
+			return false
+		}
+	}
+	return true
+}
+
 // UnmarshalJSON implement the json.Unmarshaler interface.
 func (tc *TelegrafConfig) UnmarshalJSON(b []byte) error {
 	tcd := new(telegrafConfigDecode)
@@ -310,6 +481,7 @@ func (tc *TelegrafConfig) UnmarshalJSON(b []byte) error {
 		OrganizationID: tcd.OrganizationID,
 		Name:           tcd.Name,
 		Description:    tcd.Description,
+		Revision:       tcd.Revision,
 		Agent:          tcd.Agent,
 		Plugins:        make([]TelegrafPlugin, len(tcd.Plugins)),
 	}
@@ -327,6 +499,10 @@ func decodePluginRaw(tcd *telegrafConfigDecode, tc *TelegrafConfig) (err error)
 			tpFn, ok = availableInputPlugins[pr.Name]
 		case plugins.Output:
 			tpFn, ok = availableOutputPlugins[pr.Name]
+		case plugins.Processor:
+			tpFn, ok = availableProcessorPlugins[pr.Name]
+		case plugins.Aggregator:
+			tpFn, ok = availableAggregatorPlugins[pr.Name]
 		default:
 			return &Error{
 				Code: EInvalid,
@@ -334,6 +510,34 @@ func decodePluginRaw(tcd *telegrafConfigDecode, tc *TelegrafConfig) (err error)
 				Op:   op,
 			}
 		}
+		if !ok {
+			// The plugins without typed configuration are configured with the options of their schema,
+			// which are always validated: they can't be given as raw TOML.
+			s, err := catalog.FindSchema(pr.Type, pr.Name)
+			switch err {
+			case nil:
+				if isRawPluginConfig(pr.Config) {
+					return &Error{
+						Code: EInvalid,
+						Msg:  fmt.Sprintf("telegraf plugin %s.%s is in the catalog and can't be configured with raw TOML", pr.Type, pr.Name),
+						Op:   op,
+					}
+				}
+				ok = true
+				tpFn = func() plugins.Config { return catalog.NewPlugin(s) }
+			case catalog.ErrSchemaNotFound:
+			default:
+				return &Error{
+					Code: EInternal,
+					Err:  err,
+					Op:   op,
+				}
+			}
+		}
+		if isRawPluginConfig(pr.Config) {
+			ok = true
+			tpFn = func() plugins.Config { return &plugins.Raw{PluginType: pr.Type, Name: pr.Name} }
+		}
 		if ok {
 			config = tpFn()
 			// if pr.Config if empty, make it a blank obj,
@@ -348,6 +552,15 @@ func decodePluginRaw(tcd *telegrafConfigDecode, tc *TelegrafConfig) (err error)
 					Op:   op,
 				}
 			}
+			if v, ok := config.(plugins.Validator); ok {
+				if err = v.Validate(); err != nil {
+					return &Error{
+						Code: EInvalid,
+						Msg:  err.Error(),
+						Op:   op,
+					}
+				}
+			}
 			tc.Plugins[k] = TelegrafPlugin{
 				Comment: pr.Comment,
 				Config:  config,
@@ -364,6 +577,14 @@ func decodePluginRaw(tcd *telegrafConfigDecode, tc *TelegrafConfig) (err error)
 	return nil
 }
 
+// isRawPluginConfig returns true if the JSON config is the TOML of a raw plugin.
+func isRawPluginConfig(config json.RawMessage) bool {
+	var raw struct {
+		TOML *string `json:"toml"`
+	}
+	return json.Unmarshal(config, &raw) == nil && raw.TOML != nil
+}
+
 var availableInputPlugins = map[string](func() plugins.Config){
 	"cpu":          func() plugins.Config { return &inputs.CPUStats{} },
 	"disk":         func() plugins.Config { return &inputs.DiskStats{} },
@@ -391,3 +612,18 @@ var availableOutputPlugins = map[string](func() plugins.Config){
 	"file":        func() plugins.Config { return &outputs.File{} },
 	"influxdb_v2": func() plugins.Config { return &outputs.InfluxDBV2{} },
 }
+
+var availableProcessorPlugins = map[string](func() plugins.Config){
+	"converter": func() plugins.Config { return &processors.Converter{} },
+	"enum":      func() plugins.Config { return &processors.Enum{} },
+	"override":  func() plugins.Config { return &processors.Override{} },
+	"regex":     func() plugins.Config { return &processors.Regex{} },
+	"rename":    func() plugins.Config { return &processors.Rename{} },
+	"strings":   func() plugins.Config { return &processors.Strings{} },
+}
+
+var availableAggregatorPlugins = map[string](func() plugins.Config){
+	"basicstats": func() plugins.Config { return &aggregators.BasicStats{} },
+	"histogram":  func() plugins.Config { return &aggregators.Histogram{} },
+	"minmax":     func() plugins.Config { return &aggregators.MinMax{} },
+}
@@ -0,0 +1,212 @@
+package aggregators
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/influxdata/influxdb/telegraf/plugins"
+)
+
+// local plugin
+type telegrafPluginConfig interface {
+	TOML() string
+	Type() plugins.Type
+	PluginName() string
+	UnmarshalTOML(data interface{}) error
+	Validate() error
+}
+
+func TestType(t *testing.T) {
+	b := baseAggregator(0)
+	if b.Type() != plugins.Aggregator {
+		t.Fatalf("aggregator plugins type should be aggregator, got %s", b.Type())
+	}
+}
+
+func TestEncodeTOML(t *testing.T) {
+	cases := []struct {
+		name   string
+		plugin telegrafPluginConfig
+		toml   string
+	}{
+		{
+			name:   "minmax",
+			plugin: &MinMax{},
+			toml: `[[aggregators.minmax]]
+  ## The period on which to flush & clear the aggregator.
+  period = "30s"
+  ## If true, the original metric will be dropped by the
+  ## aggregator and will not get sent to the output plugins.
+  drop_original = false
+`,
+		},
+		{
+			name:   "basicstats",
+			plugin: &BasicStats{Period: "1m0s", DropOriginal: true, Stats: []string{"count", "sum"}},
+			toml: `[[aggregators.basicstats]]
+  ## The period on which to flush & clear the aggregator.
+  period = "1m0s"
+  ## If true, the original metric will be dropped by the
+  ## aggregator and will not get sent to the output plugins.
+  drop_original = true
+  ## Configures which basic stats to push as fields
+  stats = ["count", "sum"]
+`,
+		},
+		{
+			name: "histogram",
+			plugin: &Histogram{
+				Period: "10s",
+				Reset:  true,
+				Configs: []HistogramConfig{
+					{Buckets: []float64{0, 15.6, 34.5, 100}, MeasurementName: "cpu", Fields: []string{"usage_idle"}},
+					{Buckets: []float64{1, 10}, MeasurementName: "diskio"},
+				},
+			},
+			toml: `[[aggregators.histogram]]
+  ## The period on which to flush & clear the aggregator.
+  period = "10s"
+  ## If true, the original metric will be dropped by the
+  ## aggregator and will not get sent to the output plugins.
+  drop_original = false
+  ## If true, the histogram will be reset on flush instead
+  ## of accumulating the results.
+  reset = true
+  [[aggregators.histogram.config]]
+    ## The set of buckets.
+    buckets = [0.0, 15.6, 34.5, 100.0]
+    ## The name of metric.
+    measurement_name = "cpu"
+    ## The concrete fields of metric.
+    fields = ["usage_idle"]
+  [[aggregators.histogram.config]]
+    ## The set of buckets.
+    buckets = [1.0, 10.0]
+    ## The name of metric.
+    measurement_name = "diskio"
+`,
+		},
+	}
+	for _, c := range cases {
+		if got := c.plugin.TOML(); got != c.toml {
+			t.Fatalf("%s failed want %s, got %s", c.name, c.toml, got)
+		}
+
+		var data map[string]map[string][]map[string]interface{}
+		if _, err := toml.Decode(c.toml, &data); err != nil {
+			t.Fatalf("%s failed to decode toml: %v", c.name, err)
+		}
+		decoded := reflect.New(reflect.TypeOf(c.plugin).Elem()).Interface().(telegrafPluginConfig)
+		if err := decoded.UnmarshalTOML(data["aggregators"][c.name][0]); err != nil {
+			t.Fatalf("%s failed to unmarshal toml: %v", c.name, err)
+		}
+		// The default period is encoded.
+		if reflect.DeepEqual(c.plugin, &MinMax{}) {
+			c.plugin = &MinMax{Period: defaultPeriod}
+		}
+		if !reflect.DeepEqual(decoded, c.plugin) {
+			t.Fatalf("%s failed want %v, got %v", c.name, c.plugin, decoded)
+		}
+	}
+}
+
+func TestDecodeTOML(t *testing.T) {
+	cases := []struct {
+		name       string
+		want       telegrafPluginConfig
+		wantErr    error
+		aggregator telegrafPluginConfig
+		data       interface{}
+	}{
+		{
+			name:       "minmax empty",
+			want:       &MinMax{},
+			wantErr:    errors.New("bad options for minmax aggregator plugin"),
+			aggregator: &MinMax{},
+		},
+		{
+			name:       "minmax bad period",
+			want:       &MinMax{Period: "-1s"},
+			wantErr:    errors.New("bad period \"-1s\" for minmax aggregator plugin"),
+			aggregator: &MinMax{},
+			data:       map[string]interface{}{"period": "-1s"},
+		},
+		{
+			name:       "minmax bad drop_original",
+			want:       &MinMax{},
+			wantErr:    errors.New("drop_original is not a boolean for minmax aggregator plugin"),
+			aggregator: &MinMax{},
+			data:       map[string]interface{}{"drop_original": "yes"},
+		},
+		{
+			name:       "basicstats",
+			want:       &BasicStats{},
+			aggregator: &BasicStats{},
+			data:       map[string]interface{}{},
+		},
+		{
+			name:       "basicstats unknown stat",
+			want:       &BasicStats{Stats: []string{"median"}},
+			wantErr:    errors.New("unknown stat \"median\" for basicstats aggregator plugin"),
+			aggregator: &BasicStats{},
+			data:       map[string]interface{}{"stats": []interface{}{"median"}},
+		},
+		{
+			name:       "histogram without config",
+			want:       &Histogram{},
+			wantErr:    errors.New("configs are missing for histogram aggregator plugin"),
+			aggregator: &Histogram{},
+			data:       map[string]interface{}{},
+		},
+		{
+			name: "histogram integer buckets",
+			want: &Histogram{Configs: []HistogramConfig{
+				{Buckets: []float64{1, 2}, MeasurementName: "cpu"},
+			}},
+			aggregator: &Histogram{},
+			data: map[string]interface{}{
+				"config": []map[string]interface{}{
+					{"buckets": []interface{}{int64(1), int64(2)}, "measurement_name": "cpu"},
+				},
+			},
+		},
+		{
+			name: "histogram unordered buckets",
+			want: &Histogram{Configs: []HistogramConfig{
+				{Buckets: []float64{2, 1}, MeasurementName: "cpu"},
+			}},
+			wantErr:    errors.New("buckets must be in increasing order for histogram aggregator plugin"),
+			aggregator: &Histogram{},
+			data: map[string]interface{}{
+				"config": []interface{}{
+					map[string]interface{}{"buckets": []interface{}{2.0, 1.0}, "measurement_name": "cpu"},
+				},
+			},
+		},
+		{
+			name:       "histogram without measurement name",
+			want:       &Histogram{},
+			wantErr:    errors.New("measurement_name is missing for histogram aggregator plugin"),
+			aggregator: &Histogram{},
+			data: map[string]interface{}{
+				"config": []map[string]interface{}{
+					{"buckets": []interface{}{1.0}},
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		err := c.aggregator.UnmarshalTOML(c.data)
+		if c.wantErr != nil && (err == nil || err.Error() != c.wantErr.Error()) {
+			t.Fatalf("%s failed want err %s, got %v", c.name, c.wantErr.Error(), err)
+		}
+		if c.wantErr == nil && err != nil {
+			t.Fatalf("%s failed want err nil, got %v", c.name, err)
+		}
+		if !reflect.DeepEqual(c.aggregator, c.want) {
+			t.Fatalf("%s failed want %v, got %v", c.name, c.want, c.aggregator)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package aggregators
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb/telegraf/plugins"
+)
+
+// defaultPeriod is the period of the aggregators without one.
+const defaultPeriod = "30s"
+
+type baseAggregator int
+
+func (b baseAggregator) Type() plugins.Type {
+	return plugins.Aggregator
+}
+
+// periodTOML encodes the options shared by the aggregators.
+func periodTOML(period string, dropOriginal bool) string {
+	if period == "" {
+		period = defaultPeriod
+	}
+	return fmt.Sprintf(`  ## The period on which to flush & clear the aggregator.
+  period = %q
+  ## If true, the original metric will be dropped by the
+  ## aggregator and will not get sent to the output plugins.
+  drop_original = %t
+`, period, dropOriginal)
+}
+
+// decodePeriod decodes the options shared by the aggregators.
+func decodePeriod(data map[string]interface{}, plugin string) (period string, dropOriginal bool, err error) {
+	if v, ok := data["period"]; ok {
+		if period, ok = v.(string); !ok {
+			return "", false, fmt.Errorf("period is not a string for %s aggregator plugin", plugin)
+		}
+	}
+	if v, ok := data["drop_original"]; ok {
+		if dropOriginal, ok = v.(bool); !ok {
+			return "", false, fmt.Errorf("drop_original is not a boolean for %s aggregator plugin", plugin)
+		}
+	}
+	return period, dropOriginal, nil
+}
+
+// validatePeriod returns an error if period is neither empty nor a positive duration.
+func validatePeriod(period, plugin string) error {
+	if period == "" {
+		return nil
+	}
+	if d, err := time.ParseDuration(period); err != nil || d <= 0 {
+		return fmt.Errorf("bad period %q for %s aggregator plugin", period, plugin)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package aggregators
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// basicStats are the stats the basicstats aggregator computes.
+var basicStats = map[string]bool{
+	"count":             true,
+	"min":               true,
+	"max":               true,
+	"mean":              true,
+	"stdev":             true,
+	"s2":                true,
+	"sum":               true,
+	"diff":              true,
+	"non_negative_diff": true,
+	"rate":              true,
+	"non_negative_rate": true,
+	"interval":          true,
+}
+
+// BasicStats is based on telegraf basicstats aggregator plugin.
+type BasicStats struct {
+	baseAggregator
+	Period       string `json:"period,omitempty"`
+	DropOriginal bool   `json:"dropOriginal,omitempty"`
+	// Stats are the stats pushed as fields. Defaults to count, min, max, mean, s2 and stdev.
+	Stats []string `json:"stats,omitempty"`
+}
+
+// PluginName is based on telegraf plugin name.
+func (b *BasicStats) PluginName() string {
+	return "basicstats"
+}
+
+// TOML encodes to toml string.
+func (b *BasicStats) TOML() string {
+	stats := "  ## Configures which basic stats to push as fields\n  # stats = [\"count\", \"min\", \"max\", \"mean\", \"stdev\", \"s2\"]\n"
+	if len(b.Stats) > 0 {
+		s := make([]string, len(b.Stats))
+		for i, v := range b.Stats {
+			s[i] = strconv.Quote(v)
+		}
+		stats = fmt.Sprintf("  ## Configures which basic stats to push as fields\n  stats = [%s]\n", strings.Join(s, ", "))
+	}
+	return fmt.Sprintf("[[aggregators.%s]]\n%s%s", b.PluginName(), periodTOML(b.Period, b.DropOriginal), stats)
+}
+
+// UnmarshalTOML decodes the parsed data to the object
+func (b *BasicStats) UnmarshalTOML(data interface{}) error {
+	dataOK, ok := data.(map[string]interface{})
+	if !ok {
+		return errors.New("bad options for basicstats aggregator plugin")
+	}
+	var err error
+	if b.Period, b.DropOriginal, err = decodePeriod(dataOK, b.PluginName()); err != nil {
+		return err
+	}
+	if v, ok := dataOK["stats"]; ok {
+		stats, ok := v.([]interface{})
+		if !ok {
+			return errors.New("stats is not an array for basicstats aggregator plugin")
+		}
+		for _, s := range stats {
+			stat, ok := s.(string)
+			if !ok {
+				return errors.New("stats is not an array of strings for basicstats aggregator plugin")
+			}
+			b.Stats = append(b.Stats, stat)
+		}
+	}
+	return b.Validate()
+}
+
+// Validate returns an error if the configuration is invalid.
+func (b *BasicStats) Validate() error {
+	if err := validatePeriod(b.Period, b.PluginName()); err != nil {
+		return err
+	}
+	for _, s := range b.Stats {
+		if !basicStats[s] {
+			return fmt.Errorf("unknown stat %q for basicstats aggregator plugin", s)
+		}
+	}
+	return nil
+}
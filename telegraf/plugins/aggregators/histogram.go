@@ -0,0 +1,155 @@
+package aggregators
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Histogram is based on telegraf histogram aggregator plugin.
+type Histogram struct {
+	baseAggregator
+	Period       string `json:"period,omitempty"`
+	DropOriginal bool   `json:"dropOriginal,omitempty"`
+	// Reset resets the histogram on every flush, instead of accumulating the results.
+	Reset   bool              `json:"reset,omitempty"`
+	Configs []HistogramConfig `json:"configs"`
+}
+
+// HistogramConfig are the buckets of the fields of a measurement.
+type HistogramConfig struct {
+	Buckets         []float64 `json:"buckets"`
+	MeasurementName string    `json:"measurementName"`
+	// Fields are the fields aggregated. Defaults to all the fields of the measurement.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// PluginName is based on telegraf plugin name.
+func (h *Histogram) PluginName() string {
+	return "histogram"
+}
+
+// TOML encodes to toml string.
+func (h *Histogram) TOML() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[[aggregators.%s]]\n%s", h.PluginName(), periodTOML(h.Period, h.DropOriginal))
+	fmt.Fprintf(&b, `  ## If true, the histogram will be reset on flush instead
+  ## of accumulating the results.
+  reset = %t
+`, h.Reset)
+	for _, c := range h.Configs {
+		buckets := make([]string, len(c.Buckets))
+		for i, v := range c.Buckets {
+			// Buckets are floats, which TOML tells apart from integers by their fractional part.
+			buckets[i] = strconv.FormatFloat(v, 'f', -1, 64)
+			if !strings.ContainsAny(buckets[i], ".eE") {
+				buckets[i] += ".0"
+			}
+		}
+		fmt.Fprintf(&b, "  [[aggregators.%s.config]]\n", h.PluginName())
+		fmt.Fprintf(&b, "    ## The set of buckets.\n    buckets = [%s]\n", strings.Join(buckets, ", "))
+		fmt.Fprintf(&b, "    ## The name of metric.\n    measurement_name = %s\n", strconv.Quote(c.MeasurementName))
+		if len(c.Fields) > 0 {
+			fields := make([]string, len(c.Fields))
+			for i, f := range c.Fields {
+				fields[i] = strconv.Quote(f)
+			}
+			fmt.Fprintf(&b, "    ## The concrete fields of metric.\n    fields = [%s]\n", strings.Join(fields, ", "))
+		}
+	}
+	return b.String()
+}
+
+// UnmarshalTOML decodes the parsed data to the object
+func (h *Histogram) UnmarshalTOML(data interface{}) error {
+	dataOK, ok := data.(map[string]interface{})
+	if !ok {
+		return errors.New("bad config for histogram aggregator plugin")
+	}
+	var err error
+	if h.Period, h.DropOriginal, err = decodePeriod(dataOK, h.PluginName()); err != nil {
+		return err
+	}
+	if v, ok := dataOK["reset"]; ok {
+		if h.Reset, ok = v.(bool); !ok {
+			return errors.New("reset is not a boolean for histogram aggregator plugin")
+		}
+	}
+
+	var configs []map[string]interface{}
+	switch v := dataOK["config"].(type) {
+	case nil:
+	case []map[string]interface{}:
+		configs = v
+	case []interface{}:
+		for _, c := range v {
+			m, ok := c.(map[string]interface{})
+			if !ok {
+				return errors.New("config is not an array of tables for histogram aggregator plugin")
+			}
+			configs = append(configs, m)
+		}
+	default:
+		return errors.New("config is not an array of tables for histogram aggregator plugin")
+	}
+	for _, c := range configs {
+		var hc HistogramConfig
+		if hc.MeasurementName, ok = c["measurement_name"].(string); !ok {
+			return errors.New("measurement_name is missing for histogram aggregator plugin")
+		}
+		buckets, ok := c["buckets"].([]interface{})
+		if !ok {
+			return errors.New("buckets is not an array for histogram aggregator plugin")
+		}
+		for _, b := range buckets {
+			switch b := b.(type) {
+			case float64:
+				hc.Buckets = append(hc.Buckets, b)
+			case int64:
+				hc.Buckets = append(hc.Buckets, float64(b))
+			default:
+				return errors.New("buckets is not an array of numbers for histogram aggregator plugin")
+			}
+		}
+		if v, ok := c["fields"]; ok {
+			fields, ok := v.([]interface{})
+			if !ok {
+				return errors.New("fields is not an array for histogram aggregator plugin")
+			}
+			for _, f := range fields {
+				field, ok := f.(string)
+				if !ok {
+					return errors.New("fields is not an array of strings for histogram aggregator plugin")
+				}
+				hc.Fields = append(hc.Fields, field)
+			}
+		}
+		h.Configs = append(h.Configs, hc)
+	}
+	return h.Validate()
+}
+
+// Validate returns an error if the configuration is invalid.
+func (h *Histogram) Validate() error {
+	if err := validatePeriod(h.Period, h.PluginName()); err != nil {
+		return err
+	}
+	if len(h.Configs) == 0 {
+		return errors.New("configs are missing for histogram aggregator plugin")
+	}
+	for _, c := range h.Configs {
+		if c.MeasurementName == "" {
+			return errors.New("measurement name is missing for histogram aggregator plugin")
+		}
+		if len(c.Buckets) == 0 {
+			return errors.New("buckets are missing for histogram aggregator plugin")
+		}
+		for i := 1; i < len(c.Buckets); i++ {
+			if c.Buckets[i] <= c.Buckets[i-1] {
+				return errors.New("buckets must be in increasing order for histogram aggregator plugin")
+			}
+		}
+	}
+	return nil
+}
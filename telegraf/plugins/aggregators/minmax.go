@@ -0,0 +1,41 @@
+package aggregators
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MinMax is based on telegraf minmax aggregator plugin.
+type MinMax struct {
+	baseAggregator
+	Period       string `json:"period,omitempty"`
+	DropOriginal bool   `json:"dropOriginal,omitempty"`
+}
+
+// PluginName is based on telegraf plugin name.
+func (m *MinMax) PluginName() string {
+	return "minmax"
+}
+
+// TOML encodes to toml string.
+func (m *MinMax) TOML() string {
+	return fmt.Sprintf("[[aggregators.%s]]\n%s", m.PluginName(), periodTOML(m.Period, m.DropOriginal))
+}
+
+// UnmarshalTOML decodes the parsed data to the object
+func (m *MinMax) UnmarshalTOML(data interface{}) error {
+	dataOK, ok := data.(map[string]interface{})
+	if !ok {
+		return errors.New("bad options for minmax aggregator plugin")
+	}
+	var err error
+	if m.Period, m.DropOriginal, err = decodePeriod(dataOK, m.PluginName()); err != nil {
+		return err
+	}
+	return m.Validate()
+}
+
+// Validate returns an error if the configuration is invalid.
+func (m *MinMax) Validate() error {
+	return validatePeriod(m.Period, m.PluginName())
+}
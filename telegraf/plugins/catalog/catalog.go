@@ -0,0 +1,164 @@
+// Package catalog is the catalog of the telegraf plugins without typed configuration,
+// whose options are validated against the schemas shipped with the server.
+// The schemas are OpenAPI 3 schema objects rather than JSON schemas:
+// they are decoded and validated as the openapi3.Schema of kin-openapi.
+//
+// The schema of a plugin is the file schemas/<table>/<name>.json, like schemas/inputs/mysql.json.
+// The schema schemas/<table>.json holds the options all the plugins of the table accept.
+package catalog
+
+//go:generate env GO111MODULE=on go run github.com/kevinburke/go-bindata/go-bindata -o catalog_gen.go -tags assets -nocompress -prefix schemas/ -pkg catalog ./schemas/...
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/influxdata/influxdb/telegraf/plugins"
+)
+
+// ErrSchemaNotFound is returned when the catalog has no schema for a plugin.
+var ErrSchemaNotFound = errors.New("telegraf plugin is not in the catalog")
+
+// Schema is the OpenAPI schema of the options of a plugin of the catalog.
+type Schema struct {
+	Type        plugins.Type `json:"type"`
+	Name        string       `json:"name"`
+	Title       string       `json:"title,omitempty"`
+	Description string       `json:"description,omitempty"`
+	// Options is the OpenAPI schema object of the options, including the ones all the plugins of the type accept.
+	Options *openapi3.Schema `json:"options"`
+}
+
+// Validate returns an error if options don't match the schema.
+// The options must be JSON values, like the ones decoded by encoding/json.
+func (s *Schema) Validate(options map[string]interface{}) error {
+	if options == nil {
+		options = map[string]interface{}{}
+	}
+	if err := s.Options.VisitJSON(options); err != nil {
+		if se, ok := err.(*openapi3.SchemaError); ok && se.Origin == nil {
+			reason := se.Reason
+			// The reasons of type errors describe the type of the value, rather than the one of the schema.
+			if se.SchemaField == "type" && se.Schema != nil && se.Schema.Type != "" {
+				reason = "value must be of type " + se.Schema.Type
+			}
+			err = fmt.Errorf("/%s: %s", strings.Join(se.JSONPointer(), "/"), reason)
+		}
+		return fmt.Errorf("invalid options of %s %s plugin: %v", s.Name, s.Type, err)
+	}
+	return nil
+}
+
+type catalog struct {
+	once    sync.Once
+	schemas []*Schema
+	err     error
+}
+
+var defaultCatalog catalog
+
+// load parses the schemas once.
+func (c *catalog) load() ([]*Schema, error) {
+	c.once.Do(func() {
+		c.schemas, c.err = loadSchemas(AssetNames(), Asset)
+	})
+	return c.schemas, c.err
+}
+
+// Schemas returns the schemas of the catalog, sorted by type and name.
+func Schemas() ([]*Schema, error) {
+	return defaultCatalog.load()
+}
+
+// FindSchema returns the schema of the plugin typ name, or ErrSchemaNotFound.
+func FindSchema(typ plugins.Type, name string) (*Schema, error) {
+	schemas, err := defaultCatalog.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range schemas {
+		if s.Type == typ && s.Name == name {
+			return s, nil
+		}
+	}
+	return nil, ErrSchemaNotFound
+}
+
+// schemaFile is the schema file of the options of a plugin.
+type schemaFile struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+func loadSchemas(names []string, asset func(string) ([]byte, error)) ([]*Schema, error) {
+	// The options all the plugins of a type accept.
+	common := map[plugins.Type]*openapi3.Schema{}
+	var schemas []*Schema
+	for _, name := range names {
+		if path.Ext(name) != ".json" {
+			continue
+		}
+		table, file := path.Split(name)
+		typ, ok := plugins.TypeOfTable(strings.TrimSuffix(table, "/"))
+		if table == "" {
+			typ, ok = plugins.TypeOfTable(strings.TrimSuffix(file, ".json"))
+		}
+		if !ok {
+			return nil, fmt.Errorf("telegraf plugin schema %s is not in the directory of a plugin type", name)
+		}
+
+		b, err := asset(name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read telegraf plugin schema %s: %v", name, err)
+		}
+		options := &openapi3.Schema{}
+		if err := json.Unmarshal(b, options); err != nil {
+			return nil, fmt.Errorf("bad telegraf plugin schema %s: %v", name, err)
+		}
+		if table == "" {
+			common[typ] = options
+			continue
+		}
+
+		var f schemaFile
+		if err := json.Unmarshal(b, &f); err != nil {
+			return nil, fmt.Errorf("bad telegraf plugin schema %s: %v", name, err)
+		}
+		schemas = append(schemas, &Schema{
+			Type:        typ,
+			Name:        strings.TrimSuffix(file, ".json"),
+			Title:       f.Title,
+			Description: f.Description,
+			Options:     options,
+		})
+	}
+
+	for _, s := range schemas {
+		c, ok := common[s.Type]
+		if !ok {
+			continue
+		}
+		if s.Options.Properties == nil {
+			s.Options.Properties = map[string]*openapi3.SchemaRef{}
+		}
+		for k, p := range c.Properties {
+			if _, ok := s.Options.Properties[k]; !ok {
+				s.Options.Properties[k] = p
+			}
+		}
+	}
+
+	sort.Slice(schemas, func(i, j int) bool {
+		if schemas[i].Type != schemas[j].Type {
+			return schemas[i].Type < schemas[j].Type
+		}
+		return schemas[i].Name < schemas[j].Name
+	})
+	return schemas, nil
+}
@@ -0,0 +1,258 @@
+package catalog
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/influxdata/influxdb/telegraf/plugins"
+)
+
+func TestSchemas(t *testing.T) {
+	schemas, err := Schemas()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, s := range schemas {
+		if s.Type != plugins.Input {
+			t.Errorf("expected %s to be an input, got %s", s.Name, s.Type)
+		}
+		if s.Title == "" || s.Description == "" {
+			t.Errorf("expected %s to have a title and a description", s.Name)
+		}
+		if _, ok := s.Options.Properties["name_override"]; !ok {
+			t.Errorf("expected %s to accept the options of all the inputs", s.Name)
+		}
+		names = append(names, s.Name)
+	}
+	if want := []string{"http", "mysql", "postgresql", "snmp"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("expected schemas %v, got %v", want, names)
+	}
+
+	if _, err := FindSchema(plugins.Input, "mysql"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindSchema(plugins.Output, "mysql"); err != ErrSchemaNotFound {
+		t.Fatalf("expected %v, got %v", ErrSchemaNotFound, err)
+	}
+}
+
+func TestLoadSchemas(t *testing.T) {
+	assets := map[string]string{
+		"inputs.json":         `{"properties": {"interval": {"type": "string"}}}`,
+		"inputs/b.json":       `{"title": "B", "properties": {"interval": {"type": "integer"}}}`,
+		"inputs/a.json":       `{"title": "A", "description": "a input"}`,
+		"outputs/a.json":      `{}`,
+		"inputs/README.md":    `not a schema`,
+		"processors/a/b.json": ``,
+		"serializers/a.json":  ``,
+		"processors/bad.json": `{"type": 1}`,
+	}
+	asset := func(name string) ([]byte, error) {
+		return []byte(assets[name]), nil
+	}
+
+	tests := []struct {
+		name  string
+		names []string
+		want  []*Schema
+		err   string
+	}{
+		{
+			name:  "schemas",
+			names: []string{"inputs/b.json", "inputs.json", "outputs/a.json", "inputs/a.json", "inputs/README.md"},
+			want: []*Schema{
+				{Type: plugins.Input, Name: "a", Title: "A", Description: "a input"},
+				{Type: plugins.Input, Name: "b", Title: "B"},
+				{Type: plugins.Output, Name: "a"},
+			},
+		},
+		{
+			name:  "nested schema",
+			names: []string{"processors/a/b.json"},
+			err:   "telegraf plugin schema processors/a/b.json is not in the directory of a plugin type",
+		},
+		{
+			name:  "unknown plugin type",
+			names: []string{"serializers/a.json"},
+			err:   "telegraf plugin schema serializers/a.json is not in the directory of a plugin type",
+		},
+		{
+			name:  "bad schema",
+			names: []string{"processors/bad.json"},
+			err:   "bad telegraf plugin schema processors/bad.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemas, err := loadSchemas(tt.names, asset)
+			if tt.err != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.err) {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(schemas) != len(tt.want) {
+				t.Fatalf("expected %d schemas, got %d", len(tt.want), len(schemas))
+			}
+			for i, s := range schemas {
+				want := tt.want[i]
+				if s.Type != want.Type || s.Name != want.Name || s.Title != want.Title || s.Description != want.Description {
+					t.Errorf("expected schema %+v, got %+v", want, s)
+				}
+			}
+
+			// The options of the plugins override the ones of all the plugins of the type.
+			if typ := schemas[1].Options.Properties["interval"].Value.Type; typ != "integer" {
+				t.Errorf("expected the interval of b to be an integer, got %q", typ)
+			}
+			if typ := schemas[0].Options.Properties["interval"].Value.Type; typ != "string" {
+				t.Errorf("expected the interval of a to be a string, got %q", typ)
+			}
+			if _, ok := schemas[2].Options.Properties["interval"]; ok {
+				t.Errorf("expected the outputs not to have the options of the inputs")
+			}
+		})
+	}
+}
+
+func TestPlugin_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		plugin  string
+		options string
+		err     string
+	}{
+		{
+			name:    "mysql",
+			plugin:  "mysql",
+			options: `{"servers": ["root@tcp(127.0.0.1:3306)/"], "metric_version": 2, "gather_process_list": true, "interval": "30s"}`,
+		},
+		{
+			name:    "postgresql",
+			plugin:  "postgresql",
+			options: `{"address": "host=localhost user=postgres sslmode=disable", "databases": ["app"], "tags": {"env": "prod"}}`,
+		},
+		{
+			name:    "snmp",
+			plugin:  "snmp",
+			options: `{"agents": ["udp://127.0.0.1:161"], "version": 2, "community": "public", "field": [{"name": "uptime", "oid": "RFC1213-MIB::sysUpTime.0"}], "table": [{"oid": "IF-MIB::ifTable", "field": [{"oid": "IF-MIB::ifDescr", "is_tag": true}]}]}`,
+		},
+		{
+			name:    "http",
+			plugin:  "http",
+			options: `{"urls": ["http://localhost/metrics"], "method": "GET", "headers": {"X-Special-Header": "Special-Value"}, "data_format": "json", "json_query": "metrics"}`,
+		},
+		{
+			name:    "missing option",
+			plugin:  "mysql",
+			options: `{}`,
+			err:     `invalid options of mysql input plugin: /: Property 'servers' is missing`,
+		},
+		{
+			name:    "bad type",
+			plugin:  "mysql",
+			options: `{"servers": ["root@tcp(127.0.0.1:3306)/"], "perf_events_statements_limit": 1.5}`,
+			err:     `invalid options of mysql input plugin: /perf_events_statements_limit: value must be of type integer`,
+		},
+		{
+			name:    "unknown option",
+			plugin:  "postgresql",
+			options: `{"address": "host=localhost", "servers": ["localhost"]}`,
+			err:     `invalid options of postgresql input plugin: /: Property 'servers' is unsupported`,
+		},
+		{
+			name:    "bad enum",
+			plugin:  "snmp",
+			options: `{"agents": ["udp://127.0.0.1:161"], "version": 4}`,
+			err:     `invalid options of snmp input plugin: /version: JSON value is not one of the allowed values`,
+		},
+		{
+			name:    "bad nested option",
+			plugin:  "snmp",
+			options: `{"agents": ["udp://127.0.0.1:161"], "field": [{"oid": "RFC1213-MIB::sysUpTime.0", "is_tag": "yes"}]}`,
+			err:     `invalid options of snmp input plugin: /field/0/is_tag: value must be of type boolean`,
+		},
+		{
+			name:    "bad common option",
+			plugin:  "http",
+			options: `{"urls": ["http://localhost/metrics"], "tags": {"env": 1}}`,
+			err:     `invalid options of http input plugin: /tags/env: value must be of type string`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := FindSchema(plugins.Input, tt.plugin)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p := NewPlugin(s)
+			if err := json.Unmarshal([]byte(tt.options), p); err != nil {
+				t.Fatal(err)
+			}
+			err = p.Validate()
+			if tt.err == "" && err != nil {
+				t.Fatalf("expected valid options, got %v", err)
+			}
+			if tt.err != "" && (err == nil || err.Error() != tt.err) {
+				t.Fatalf("expected error %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestPlugin_TOML(t *testing.T) {
+	s, err := FindSchema(plugins.Input, "snmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewPlugin(s)
+	if err := json.Unmarshal([]byte(`{
+  "agents": ["udp://127.0.0.1:161"],
+  "version": 2,
+  "retries": 3,
+  "table": [{"name": "interface", "oid": "IF-MIB::ifTable", "field": [{"oid": "IF-MIB::ifDescr", "is_tag": true}]}]
+}`), p); err != nil {
+		t.Fatal(err)
+	}
+
+	got := p.TOML()
+	if !strings.HasPrefix(got, "[[inputs.snmp]]\n") {
+		t.Fatalf("expected the table of the plugin, got %s", got)
+	}
+	for _, option := range []string{"version = 2\n", "retries = 3\n", "[[inputs.snmp.table]]\n", "[[inputs.snmp.table.field]]\n"} {
+		if !strings.Contains(got, option) {
+			t.Errorf("expected %q in %s", option, got)
+		}
+	}
+
+	// The TOML decodes to the same plugin.
+	var data map[string]map[string][]map[string]interface{}
+	if _, err := toml.Decode(got, &data); err != nil {
+		t.Fatalf("bad toml %s: %v", got, err)
+	}
+	decoded := NewPlugin(s)
+	if err := decoded.UnmarshalTOML(data["inputs"]["snmp"][0]); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded.Options, p.Options) {
+		t.Fatalf("expected options %v, got %v", p.Options, decoded.Options)
+	}
+	if decoded.PluginName() != "snmp" || decoded.Type() != plugins.Input {
+		t.Fatalf("expected the snmp input, got the %s %s", decoded.PluginName(), decoded.Type())
+	}
+
+	b, err := json.Marshal(NewPlugin(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "{}" {
+		t.Fatalf("expected the options of a plugin without options to encode to {}, got %s", b)
+	}
+}
@@ -0,0 +1,42 @@
+// +build !assets
+
+package catalog
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// The functions defined in this file read the schemas from the source tree when the binary
+// is compiled without assets, like the tests are.
+
+// schemasDir is the directory of the schemas in the source tree.
+var schemasDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "schemas")
+}()
+
+// Asset returns the schema name read from the source tree.
+func Asset(name string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(schemasDir, filepath.FromSlash(name)))
+}
+
+// AssetNames returns the names of the schemas in the source tree,
+// or nil if the binary was not built from it.
+func AssetNames() []string {
+	var names []string
+	filepath.Walk(schemasDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		name, err := filepath.Rel(schemasDir, p)
+		if err != nil {
+			return nil
+		}
+		names = append(names, filepath.ToSlash(name))
+		return nil
+	})
+	return names
+}
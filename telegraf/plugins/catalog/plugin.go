@@ -0,0 +1,114 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/influxdata/influxdb/telegraf/plugins"
+)
+
+// Plugin is a plugin of the catalog, configured with options validated against its schema.
+type Plugin struct {
+	schema *Schema
+	// Options are the options of the plugin, as JSON values.
+	Options map[string]interface{}
+}
+
+// NewPlugin returns the plugin of schema s, without options.
+func NewPlugin(s *Schema) *Plugin {
+	return &Plugin{schema: s}
+}
+
+// PluginName is the name of the plugin.
+func (p *Plugin) PluginName() string {
+	return p.schema.Name
+}
+
+// Type is the plugin type.
+func (p *Plugin) Type() plugins.Type {
+	return p.schema.Type
+}
+
+// TOML encodes to toml string.
+func (p *Plugin) TOML() string {
+	r := &plugins.Raw{PluginType: p.schema.Type, Name: p.schema.Name}
+	// The options are JSON values, which always encode.
+	_ = r.UnmarshalTOML(tomlValue(p.schema.Options, p.Options))
+	return r.TOML()
+}
+
+// UnmarshalTOML decodes the parsed data to the options.
+func (p *Plugin) UnmarshalTOML(data interface{}) error {
+	options, ok := data.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("bad options for %s %s plugin", p.schema.Name, p.schema.Type)
+	}
+	// The options are converted to JSON values, so that they are the same once stored.
+	b, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+	return p.UnmarshalJSON(b)
+}
+
+// MarshalJSON encodes the options.
+func (p *Plugin) MarshalJSON() ([]byte, error) {
+	if p.Options == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(p.Options)
+}
+
+// UnmarshalJSON decodes the options.
+func (p *Plugin) UnmarshalJSON(b []byte) error {
+	p.Options = nil
+	return json.Unmarshal(b, &p.Options)
+}
+
+// Validate returns an error if the options don't match the schema of the plugin.
+func (p *Plugin) Validate() error {
+	return p.schema.Validate(p.Options)
+}
+
+// tomlValue returns the JSON value v, with the numbers s or the schema of its options say are integers
+// as integers, so that they are not encoded as floats.
+func tomlValue(s *openapi3.Schema, v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		if s != nil && s.Type == "number" {
+			return v
+		}
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v)
+		}
+		return v
+	case []interface{}:
+		var items *openapi3.Schema
+		if s != nil && s.Items != nil {
+			items = s.Items.Value
+		}
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = tomlValue(items, e)
+		}
+		return a
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			var option *openapi3.Schema
+			if s != nil {
+				if ref, ok := s.Properties[k]; ok {
+					option = ref.Value
+				} else if s.AdditionalProperties != nil {
+					option = s.AdditionalProperties.Value
+				}
+			}
+			m[k] = tomlValue(option, e)
+		}
+		return m
+	default:
+		return v
+	}
+}
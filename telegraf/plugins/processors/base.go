@@ -0,0 +1,145 @@
+package processors
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/influxdb/telegraf/plugins"
+)
+
+type baseProcessor int
+
+func (b baseProcessor) Type() plugins.Type {
+	return plugins.Processor
+}
+
+// tables returns the array of tables key of data, which is missing if empty.
+func tables(data map[string]interface{}, key, plugin string) ([]map[string]interface{}, error) {
+	switch v := data[key].(type) {
+	case nil:
+		return nil, nil
+	case []map[string]interface{}:
+		return v, nil
+	case []interface{}:
+		ts := make([]map[string]interface{}, len(v))
+		for i, t := range v {
+			m, ok := t.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s is not an array of tables for %s processor plugin", key, plugin)
+			}
+			ts[i] = m
+		}
+		return ts, nil
+	default:
+		return nil, fmt.Errorf("%s is not an array of tables for %s processor plugin", key, plugin)
+	}
+}
+
+// stringValue returns the string key of data, which is empty if missing.
+func stringValue(data map[string]interface{}, key, plugin string) (string, error) {
+	v, ok := data[key]
+	if !ok {
+		return "", nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("%s is not a string for %s processor plugin", key, plugin)
+	}
+	return s, nil
+}
+
+// stringsValue returns the array of strings key of data, which is empty if missing.
+func stringsValue(data map[string]interface{}, key, plugin string) ([]string, error) {
+	v, ok := data[key]
+	if !ok {
+		return nil, nil
+	}
+	a, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is not an array for %s processor plugin", key, plugin)
+	}
+	ss := make([]string, len(a))
+	for i, s := range a {
+		if ss[i], ok = s.(string); !ok {
+			return nil, fmt.Errorf("%s is not an array of strings for %s processor plugin", key, plugin)
+		}
+	}
+	return ss, nil
+}
+
+// stringMapValue returns the table of strings key of data, which is empty if missing.
+func stringMapValue(data map[string]interface{}, key, plugin string) (map[string]string, error) {
+	v, ok := data[key]
+	if !ok {
+		return nil, nil
+	}
+	t, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is not a table for %s processor plugin", key, plugin)
+	}
+	m := make(map[string]string, len(t))
+	for k, v := range t {
+		if m[k], ok = v.(string); !ok {
+			return nil, fmt.Errorf("%s is not a table of strings for %s processor plugin", key, plugin)
+		}
+	}
+	return m, nil
+}
+
+// quoteStrings returns ss as the elements of a TOML array.
+func quoteStrings(ss []string) string {
+	q := make([]string, len(ss))
+	for i, s := range ss {
+		q[i] = strconv.Quote(s)
+	}
+	return strings.Join(q, ", ")
+}
+
+var bareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// tomlKey returns k as a TOML key, quoting it when needed.
+func tomlKey(k string) string {
+	if bareKey.MatchString(k) {
+		return k
+	}
+	return strconv.Quote(k)
+}
+
+// tomlValue returns v as a TOML value. Numbers without a fractional part are integers.
+func tomlValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		if v == float64(int64(v)) {
+			return strconv.FormatInt(int64(v), 10)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// sortedKeys returns the keys of m, sorted.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// countSet returns the number of non-empty strings of ss.
+func countSet(ss ...string) int {
+	n := 0
+	for _, s := range ss {
+		if s != "" {
+			n++
+		}
+	}
+	return n
+}
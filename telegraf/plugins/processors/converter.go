@@ -0,0 +1,127 @@
+package processors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Converter is based on telegraf converter processor plugin.
+type Converter struct {
+	baseProcessor
+	Tags   ConverterTargets `json:"tags"`
+	Fields ConverterTargets `json:"fields"`
+}
+
+// ConverterTargets are the keys of the tags or fields converted to each type. The keys may be globs.
+type ConverterTargets struct {
+	Measurement []string `json:"measurement,omitempty"`
+	// Tag is only available to fields.
+	Tag      []string `json:"tag,omitempty"`
+	String   []string `json:"string,omitempty"`
+	Integer  []string `json:"integer,omitempty"`
+	Unsigned []string `json:"unsigned,omitempty"`
+	Boolean  []string `json:"boolean,omitempty"`
+	Float    []string `json:"float,omitempty"`
+}
+
+// targets returns the keys of the targets by type, in the order of the TOML.
+func (c *ConverterTargets) targets() []struct {
+	typ  string
+	keys *[]string
+} {
+	return []struct {
+		typ  string
+		keys *[]string
+	}{
+		{typ: "measurement", keys: &c.Measurement},
+		{typ: "tag", keys: &c.Tag},
+		{typ: "string", keys: &c.String},
+		{typ: "integer", keys: &c.Integer},
+		{typ: "unsigned", keys: &c.Unsigned},
+		{typ: "boolean", keys: &c.Boolean},
+		{typ: "float", keys: &c.Float},
+	}
+}
+
+func (c *ConverterTargets) empty() bool {
+	for _, t := range c.targets() {
+		if len(*t.keys) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// PluginName is based on telegraf plugin name.
+func (c *Converter) PluginName() string {
+	return "converter"
+}
+
+// TOML encodes to toml string.
+func (c *Converter) TOML() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `[[processors.%s]]
+  ## The table key determines the target type, and the array of keys
+  ## select the tags or fields to convert. The array may contain globs.
+  ##   <target-type> = [<key>...]
+`, c.PluginName())
+	for _, table := range []struct {
+		name    string
+		targets *ConverterTargets
+	}{
+		{name: "tags", targets: &c.Tags},
+		{name: "fields", targets: &c.Fields},
+	} {
+		if table.targets.empty() {
+			continue
+		}
+		fmt.Fprintf(&b, "  [processors.%s.%s]\n", c.PluginName(), table.name)
+		for _, t := range table.targets.targets() {
+			if len(*t.keys) > 0 {
+				fmt.Fprintf(&b, "    %s = [%s]\n", t.typ, quoteStrings(*t.keys))
+			}
+		}
+	}
+	return b.String()
+}
+
+// UnmarshalTOML decodes the parsed data to the object
+func (c *Converter) UnmarshalTOML(data interface{}) error {
+	dataOK, ok := data.(map[string]interface{})
+	if !ok {
+		return errors.New("bad tags or fields for converter processor plugin")
+	}
+	for name, targets := range map[string]*ConverterTargets{
+		"tags":   &c.Tags,
+		"fields": &c.Fields,
+	} {
+		v, ok := dataOK[name]
+		if !ok {
+			continue
+		}
+		table, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s is not a table for converter processor plugin", name)
+		}
+		for _, t := range targets.targets() {
+			keys, err := stringsValue(table, t.typ, c.PluginName())
+			if err != nil {
+				return err
+			}
+			*t.keys = keys
+		}
+	}
+	return c.Validate()
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *Converter) Validate() error {
+	if c.Tags.empty() && c.Fields.empty() {
+		return errors.New("tags or fields are missing for converter processor plugin")
+	}
+	if len(c.Tags.Tag) > 0 {
+		return errors.New("tags cannot be converted to tags for converter processor plugin")
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package processors
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Enum is based on telegraf enum processor plugin.
+type Enum struct {
+	baseProcessor
+	Mappings []EnumMapping `json:"mappings"`
+}
+
+// EnumMapping maps the values of a tag or field to strings, numbers or booleans.
+type EnumMapping struct {
+	Tag   string `json:"tag,omitempty"`
+	Field string `json:"field,omitempty"`
+	// Dest is the tag or field the mapped value is stored in. Defaults to the source tag or field.
+	Dest string `json:"dest,omitempty"`
+	// Default is the value of the values without mapping. When unset, they are not modified.
+	Default       interface{}            `json:"default,omitempty"`
+	ValueMappings map[string]interface{} `json:"valueMappings"`
+}
+
+// PluginName is based on telegraf plugin name.
+func (e *Enum) PluginName() string {
+	return "enum"
+}
+
+// TOML encodes to toml string.
+func (e *Enum) TOML() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `[[processors.%s]]
+  ## The values of the tags and fields are replaced by their mappings.
+`, e.PluginName())
+	for _, m := range e.Mappings {
+		fmt.Fprintf(&b, "  [[processors.%s.mapping]]\n", e.PluginName())
+		if m.Tag != "" {
+			fmt.Fprintf(&b, "    tag = %s\n", strconv.Quote(m.Tag))
+		}
+		if m.Field != "" {
+			fmt.Fprintf(&b, "    field = %s\n", strconv.Quote(m.Field))
+		}
+		if m.Dest != "" {
+			fmt.Fprintf(&b, "    dest = %s\n", strconv.Quote(m.Dest))
+		}
+		if m.Default != nil {
+			fmt.Fprintf(&b, "    default = %s\n", tomlValue(m.Default))
+		}
+		fmt.Fprintf(&b, "    [processors.%s.mapping.value_mappings]\n", e.PluginName())
+		for _, k := range sortedKeys(m.ValueMappings) {
+			fmt.Fprintf(&b, "      %s = %s\n", tomlKey(k), tomlValue(m.ValueMappings[k]))
+		}
+	}
+	return b.String()
+}
+
+// UnmarshalTOML decodes the parsed data to the object
+func (e *Enum) UnmarshalTOML(data interface{}) error {
+	dataOK, ok := data.(map[string]interface{})
+	if !ok {
+		return errors.New("bad mapping for enum processor plugin")
+	}
+	mappings, err := tables(dataOK, "mapping", e.PluginName())
+	if err != nil {
+		return err
+	}
+	for _, t := range mappings {
+		var m EnumMapping
+		for key, dst := range map[string]*string{
+			"tag":   &m.Tag,
+			"field": &m.Field,
+			"dest":  &m.Dest,
+		} {
+			if *dst, err = stringValue(t, key, e.PluginName()); err != nil {
+				return err
+			}
+		}
+		m.Default = t["default"]
+		if v, ok := t["value_mappings"]; ok {
+			if m.ValueMappings, ok = v.(map[string]interface{}); !ok {
+				return errors.New("value_mappings is not a table for enum processor plugin")
+			}
+		}
+		e.Mappings = append(e.Mappings, m)
+	}
+	return e.Validate()
+}
+
+// Validate returns an error if the configuration is invalid.
+func (e *Enum) Validate() error {
+	if len(e.Mappings) == 0 {
+		return errors.New("mappings are missing for enum processor plugin")
+	}
+	for _, m := range e.Mappings {
+		if countSet(m.Tag, m.Field) != 1 {
+			return errors.New("mapping must map one of a tag or a field for enum processor plugin")
+		}
+		if len(m.ValueMappings) == 0 {
+			return errors.New("value mappings are missing for enum processor plugin")
+		}
+		if m.Default != nil && !validEnumValue(m.Default) {
+			return fmt.Errorf("default %v is not a string, number or boolean for enum processor plugin", m.Default)
+		}
+		for k, v := range m.ValueMappings {
+			if !validEnumValue(v) {
+				return fmt.Errorf("value mapping of %q is not a string, number or boolean for enum processor plugin", k)
+			}
+		}
+	}
+	return nil
+}
+
+// validEnumValue returns true if v, as decoded from JSON or TOML, can be a mapped value.
+func validEnumValue(v interface{}) bool {
+	switch v.(type) {
+	case string, bool, float64, int64:
+		return true
+	default:
+		return false
+	}
+}
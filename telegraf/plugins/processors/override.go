@@ -0,0 +1,87 @@
+package processors
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Override is based on telegraf override processor plugin.
+type Override struct {
+	baseProcessor
+	NameOverride string            `json:"nameOverride,omitempty"`
+	NamePrefix   string            `json:"namePrefix,omitempty"`
+	NameSuffix   string            `json:"nameSuffix,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+}
+
+// PluginName is based on telegraf plugin name.
+func (o *Override) PluginName() string {
+	return "override"
+}
+
+// TOML encodes to toml string.
+func (o *Override) TOML() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `[[processors.%s]]
+  ## The names of the measurements are overridden, and the tags added.
+`, o.PluginName())
+	for _, opt := range []struct{ key, value string }{
+		{key: "name_override", value: o.NameOverride},
+		{key: "name_prefix", value: o.NamePrefix},
+		{key: "name_suffix", value: o.NameSuffix},
+	} {
+		if opt.value != "" {
+			fmt.Fprintf(&b, "  %s = %s\n", opt.key, strconv.Quote(opt.value))
+		}
+	}
+	if len(o.Tags) > 0 {
+		keys := make([]string, 0, len(o.Tags))
+		for k := range o.Tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fmt.Fprintf(&b, "  [processors.%s.tags]\n", o.PluginName())
+		for _, k := range keys {
+			fmt.Fprintf(&b, "    %s = %s\n", tomlKey(k), strconv.Quote(o.Tags[k]))
+		}
+	}
+	return b.String()
+}
+
+// UnmarshalTOML decodes the parsed data to the object
+func (o *Override) UnmarshalTOML(data interface{}) error {
+	dataOK, ok := data.(map[string]interface{})
+	if !ok {
+		return errors.New("bad options for override processor plugin")
+	}
+	var err error
+	for key, dst := range map[string]*string{
+		"name_override": &o.NameOverride,
+		"name_prefix":   &o.NamePrefix,
+		"name_suffix":   &o.NameSuffix,
+	} {
+		if *dst, err = stringValue(dataOK, key, o.PluginName()); err != nil {
+			return err
+		}
+	}
+	if o.Tags, err = stringMapValue(dataOK, "tags", o.PluginName()); err != nil {
+		return err
+	}
+	return o.Validate()
+}
+
+// Validate returns an error if the configuration is invalid.
+func (o *Override) Validate() error {
+	if countSet(o.NameOverride, o.NamePrefix, o.NameSuffix) == 0 && len(o.Tags) == 0 {
+		return errors.New("name override, prefix, suffix or tags are missing for override processor plugin")
+	}
+	for k := range o.Tags {
+		if k == "" {
+			return errors.New("empty tag key for override processor plugin")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,360 @@
+package processors
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/influxdata/influxdb/telegraf/plugins"
+)
+
+// local plugin
+type telegrafPluginConfig interface {
+	TOML() string
+	Type() plugins.Type
+	PluginName() string
+	UnmarshalTOML(data interface{}) error
+	Validate() error
+}
+
+func TestType(t *testing.T) {
+	b := baseProcessor(0)
+	if b.Type() != plugins.Processor {
+		t.Fatalf("processor plugins type should be processor, got %s", b.Type())
+	}
+}
+
+func TestEncodeTOML(t *testing.T) {
+	cases := []struct {
+		name   string
+		plugin telegrafPluginConfig
+		toml   string
+	}{
+		{
+			name: "rename",
+			plugin: &Rename{
+				Replaces: []RenameReplace{
+					{Tag: "host", Dest: "hostname"},
+					{Measurement: "cpu", Dest: "processor"},
+				},
+			},
+			toml: `[[processors.rename]]
+  ## Measurements, tags and fields renamed, in order.
+  [[processors.rename.replace]]
+    tag = "host"
+    dest = "hostname"
+  [[processors.rename.replace]]
+    measurement = "cpu"
+    dest = "processor"
+`,
+		},
+		{
+			name: "regex",
+			plugin: &Regex{
+				Tags: []RegexConverter{
+					{Key: "resp_code", Pattern: `^(\d)\d\d$`, Replacement: "${1}xx"},
+				},
+				Fields: []RegexConverter{
+					{Key: "request", Pattern: `^/api/(\w+)`, Replacement: "${1}", ResultKey: "method"},
+				},
+			},
+			toml: `[[processors.regex]]
+  ## Matches of the patterns are replaced in the values of the tags and fields.
+  ## Use ${1} notation in replacements to use the text of the first submatch.
+  [[processors.regex.tags]]
+    key = "resp_code"
+    pattern = "^(\\d)\\d\\d$"
+    replacement = "${1}xx"
+  [[processors.regex.fields]]
+    key = "request"
+    pattern = "^/api/(\\w+)"
+    replacement = "${1}"
+    result_key = "method"
+`,
+		},
+		{
+			name: "converter",
+			plugin: &Converter{
+				Tags:   ConverterTargets{Integer: []string{"port"}},
+				Fields: ConverterTargets{Tag: []string{"host"}, Float: []string{"load*"}},
+			},
+			toml: `[[processors.converter]]
+  ## The table key determines the target type, and the array of keys
+  ## select the tags or fields to convert. The array may contain globs.
+  ##   <target-type> = [<key>...]
+  [processors.converter.tags]
+    integer = ["port"]
+  [processors.converter.fields]
+    tag = ["host"]
+    float = ["load*"]
+`,
+		},
+		{
+			name: "enum",
+			plugin: &Enum{
+				Mappings: []EnumMapping{
+					{
+						Field:         "status",
+						Dest:          "status_code",
+						Default:       float64(0),
+						ValueMappings: map[string]interface{}{"green": float64(1), "red": 2.5, "not ok": "bad"},
+					},
+				},
+			},
+			toml: `[[processors.enum]]
+  ## The values of the tags and fields are replaced by their mappings.
+  [[processors.enum.mapping]]
+    field = "status"
+    dest = "status_code"
+    default = 0
+    [processors.enum.mapping.value_mappings]
+      green = 1
+      "not ok" = "bad"
+      red = 2.5
+`,
+		},
+		{
+			name: "override",
+			plugin: &Override{
+				NamePrefix: "app_",
+				Tags:       map[string]string{"env": "prod", "dc": "eu"},
+			},
+			toml: `[[processors.override]]
+  ## The names of the measurements are overridden, and the tags added.
+  name_prefix = "app_"
+  [processors.override.tags]
+    dc = "eu"
+    env = "prod"
+`,
+		},
+		{
+			name: "strings",
+			plugin: &Strings{
+				Lowercase:  []StringsConverter{{Tag: "method"}},
+				TrimPrefix: []StringsConverter{{Field: "path", Prefix: "/api", Dest: "route"}},
+				Replace:    []StringsConverter{{Measurement: "*", Old: ":"}},
+			},
+			toml: `[[processors.strings]]
+  ## The names of measurements, or the values of tags and fields, are transformed.
+  [[processors.strings.lowercase]]
+    tag = "method"
+  [[processors.strings.trim_prefix]]
+    field = "path"
+    dest = "route"
+    prefix = "/api"
+  [[processors.strings.replace]]
+    measurement = "*"
+    old = ":"
+    new = ""
+`,
+		},
+	}
+	for _, c := range cases {
+		if got := c.plugin.TOML(); got != c.toml {
+			t.Fatalf("%s failed want %s, got %s", c.name, c.toml, got)
+		}
+
+		// The encoded plugin decodes to itself, but for the numbers of enum mappings.
+		if c.name == "enum" {
+			continue
+		}
+		var data map[string]map[string][]map[string]interface{}
+		if _, err := toml.Decode(c.toml, &data); err != nil {
+			t.Fatalf("%s failed to decode toml: %v", c.name, err)
+		}
+		decoded := reflect.New(reflect.TypeOf(c.plugin).Elem()).Interface().(telegrafPluginConfig)
+		if err := decoded.UnmarshalTOML(data["processors"][c.name][0]); err != nil {
+			t.Fatalf("%s failed to unmarshal toml: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(decoded, c.plugin) {
+			t.Fatalf("%s failed want %v, got %v", c.name, c.plugin, decoded)
+		}
+	}
+}
+
+func TestDecodeTOML(t *testing.T) {
+	cases := []struct {
+		name      string
+		want      telegrafPluginConfig
+		wantErr   error
+		processor telegrafPluginConfig
+		data      interface{}
+	}{
+		{
+			name:      "rename empty",
+			want:      &Rename{},
+			wantErr:   errors.New("bad replace for rename processor plugin"),
+			processor: &Rename{},
+		},
+		{
+			name:      "rename without replace",
+			want:      &Rename{},
+			wantErr:   errors.New("replaces are missing for rename processor plugin"),
+			processor: &Rename{},
+			data:      map[string]interface{}{},
+		},
+		{
+			name:      "rename tag and field",
+			want:      &Rename{Replaces: []RenameReplace{{Tag: "a", Field: "b", Dest: "c"}}},
+			wantErr:   errors.New("replace must rename one of a measurement, a tag or a field for rename processor plugin"),
+			processor: &Rename{},
+			data: map[string]interface{}{
+				"replace": []map[string]interface{}{{"tag": "a", "field": "b", "dest": "c"}},
+			},
+		},
+		{
+			name:      "rename without dest",
+			want:      &Rename{Replaces: []RenameReplace{{Tag: "a"}}},
+			wantErr:   errors.New("replace dest is missing for rename processor plugin"),
+			processor: &Rename{},
+			data: map[string]interface{}{
+				"replace": []interface{}{map[string]interface{}{"tag": "a"}},
+			},
+		},
+		{
+			name:      "rename bad replace",
+			want:      &Rename{},
+			wantErr:   errors.New("replace is not an array of tables for rename processor plugin"),
+			processor: &Rename{},
+			data:      map[string]interface{}{"replace": "a"},
+		},
+		{
+			name:      "regex bad pattern",
+			want:      &Regex{Tags: []RegexConverter{{Key: "a", Pattern: "("}}},
+			wantErr:   errors.New("bad pattern \"(\" for regex processor plugin: error parsing regexp: missing closing ): `(`"),
+			processor: &Regex{},
+			data: map[string]interface{}{
+				"tags": []map[string]interface{}{{"key": "a", "pattern": "("}},
+			},
+		},
+		{
+			name:      "regex without key",
+			want:      &Regex{Fields: []RegexConverter{{Pattern: "a"}}},
+			wantErr:   errors.New("key is missing for regex processor plugin"),
+			processor: &Regex{},
+			data: map[string]interface{}{
+				"fields": []map[string]interface{}{{"pattern": "a"}},
+			},
+		},
+		{
+			name:      "converter tags to tags",
+			want:      &Converter{Tags: ConverterTargets{Tag: []string{"a"}}},
+			wantErr:   errors.New("tags cannot be converted to tags for converter processor plugin"),
+			processor: &Converter{},
+			data: map[string]interface{}{
+				"tags": map[string]interface{}{"tag": []interface{}{"a"}},
+			},
+		},
+		{
+			name:      "converter bad keys",
+			want:      &Converter{},
+			wantErr:   errors.New("float is not an array of strings for converter processor plugin"),
+			processor: &Converter{},
+			data: map[string]interface{}{
+				"fields": map[string]interface{}{"float": []interface{}{1}},
+			},
+		},
+		{
+			name:      "converter empty",
+			want:      &Converter{},
+			wantErr:   errors.New("tags or fields are missing for converter processor plugin"),
+			processor: &Converter{},
+			data:      map[string]interface{}{},
+		},
+		{
+			name: "enum",
+			want: &Enum{Mappings: []EnumMapping{{
+				Tag:           "status",
+				Default:       int64(0),
+				ValueMappings: map[string]interface{}{"green": int64(1)},
+			}}},
+			processor: &Enum{},
+			data: map[string]interface{}{
+				"mapping": []map[string]interface{}{{
+					"tag":            "status",
+					"default":        int64(0),
+					"value_mappings": map[string]interface{}{"green": int64(1)},
+				}},
+			},
+		},
+		{
+			name:      "enum without value mappings",
+			want:      &Enum{Mappings: []EnumMapping{{Field: "status"}}},
+			wantErr:   errors.New("value mappings are missing for enum processor plugin"),
+			processor: &Enum{},
+			data: map[string]interface{}{
+				"mapping": []map[string]interface{}{{"field": "status"}},
+			},
+		},
+		{
+			name: "enum bad value mapping",
+			want: &Enum{Mappings: []EnumMapping{{
+				Field:         "status",
+				ValueMappings: map[string]interface{}{"green": []interface{}{}},
+			}}},
+			wantErr:   errors.New("value mapping of \"green\" is not a string, number or boolean for enum processor plugin"),
+			processor: &Enum{},
+			data: map[string]interface{}{
+				"mapping": []map[string]interface{}{{
+					"field":          "status",
+					"value_mappings": map[string]interface{}{"green": []interface{}{}},
+				}},
+			},
+		},
+		{
+			name:      "override empty",
+			want:      &Override{},
+			wantErr:   errors.New("name override, prefix, suffix or tags are missing for override processor plugin"),
+			processor: &Override{},
+			data:      map[string]interface{}{},
+		},
+		{
+			name:      "override bad tags",
+			want:      &Override{NameOverride: "a"},
+			wantErr:   errors.New("tags is not a table of strings for override processor plugin"),
+			processor: &Override{},
+			data: map[string]interface{}{
+				"name_override": "a",
+				"tags":          map[string]interface{}{"a": 1},
+			},
+		},
+		{
+			name:      "strings trim_prefix without prefix",
+			want:      &Strings{TrimPrefix: []StringsConverter{{Tag: "a"}}},
+			wantErr:   errors.New("trim_prefix prefix is missing for strings processor plugin"),
+			processor: &Strings{},
+			data: map[string]interface{}{
+				"trim_prefix": []map[string]interface{}{{"tag": "a"}},
+			},
+		},
+		{
+			name:      "strings without target",
+			want:      &Strings{Uppercase: []StringsConverter{{}}},
+			wantErr:   errors.New("uppercase must transform one of measurements, a tag or a field for strings processor plugin"),
+			processor: &Strings{},
+			data: map[string]interface{}{
+				"uppercase": []map[string]interface{}{{}},
+			},
+		},
+		{
+			name:      "strings empty",
+			want:      &Strings{},
+			wantErr:   errors.New("operations are missing for strings processor plugin"),
+			processor: &Strings{},
+			data:      map[string]interface{}{},
+		},
+	}
+	for _, c := range cases {
+		err := c.processor.UnmarshalTOML(c.data)
+		if c.wantErr != nil && (err == nil || err.Error() != c.wantErr.Error()) {
+			t.Fatalf("%s failed want err %s, got %v", c.name, c.wantErr.Error(), err)
+		}
+		if c.wantErr == nil && err != nil {
+			t.Fatalf("%s failed want err nil, got %v", c.name, err)
+		}
+		if !reflect.DeepEqual(c.processor, c.want) {
+			t.Fatalf("%s failed want %v, got %v", c.name, c.want, c.processor)
+		}
+	}
+}
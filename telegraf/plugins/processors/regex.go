@@ -0,0 +1,105 @@
+package processors
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Regex is based on telegraf regex processor plugin.
+type Regex struct {
+	baseProcessor
+	Tags   []RegexConverter `json:"tags,omitempty"`
+	Fields []RegexConverter `json:"fields,omitempty"`
+}
+
+// RegexConverter replaces the matches of a pattern in the value of a tag or field.
+type RegexConverter struct {
+	Key         string `json:"key"`
+	Pattern     string `json:"pattern"`
+	Replacement string `json:"replacement"`
+	// ResultKey is the tag or field the result is stored in, instead of Key.
+	ResultKey string `json:"resultKey,omitempty"`
+}
+
+// PluginName is based on telegraf plugin name.
+func (r *Regex) PluginName() string {
+	return "regex"
+}
+
+// TOML encodes to toml string.
+func (r *Regex) TOML() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `[[processors.%s]]
+  ## Matches of the patterns are replaced in the values of the tags and fields.
+  ## Use ${1} notation in replacements to use the text of the first submatch.
+`, r.PluginName())
+	for _, c := range []struct {
+		table      string
+		converters []RegexConverter
+	}{
+		{table: "tags", converters: r.Tags},
+		{table: "fields", converters: r.Fields},
+	} {
+		for _, rc := range c.converters {
+			fmt.Fprintf(&b, "  [[processors.%s.%s]]\n", r.PluginName(), c.table)
+			fmt.Fprintf(&b, "    key = %s\n", strconv.Quote(rc.Key))
+			fmt.Fprintf(&b, "    pattern = %s\n", strconv.Quote(rc.Pattern))
+			fmt.Fprintf(&b, "    replacement = %s\n", strconv.Quote(rc.Replacement))
+			if rc.ResultKey != "" {
+				fmt.Fprintf(&b, "    result_key = %s\n", strconv.Quote(rc.ResultKey))
+			}
+		}
+	}
+	return b.String()
+}
+
+// UnmarshalTOML decodes the parsed data to the object
+func (r *Regex) UnmarshalTOML(data interface{}) error {
+	dataOK, ok := data.(map[string]interface{})
+	if !ok {
+		return errors.New("bad tags or fields for regex processor plugin")
+	}
+	for table, dst := range map[string]*[]RegexConverter{
+		"tags":   &r.Tags,
+		"fields": &r.Fields,
+	} {
+		ts, err := tables(dataOK, table, r.PluginName())
+		if err != nil {
+			return err
+		}
+		for _, t := range ts {
+			var rc RegexConverter
+			for key, v := range map[string]*string{
+				"key":         &rc.Key,
+				"pattern":     &rc.Pattern,
+				"replacement": &rc.Replacement,
+				"result_key":  &rc.ResultKey,
+			} {
+				if *v, err = stringValue(t, key, r.PluginName()); err != nil {
+					return err
+				}
+			}
+			*dst = append(*dst, rc)
+		}
+	}
+	return r.Validate()
+}
+
+// Validate returns an error if the configuration is invalid.
+func (r *Regex) Validate() error {
+	if len(r.Tags) == 0 && len(r.Fields) == 0 {
+		return errors.New("tags or fields are missing for regex processor plugin")
+	}
+	for _, rc := range append(append([]RegexConverter(nil), r.Tags...), r.Fields...) {
+		if rc.Key == "" {
+			return errors.New("key is missing for regex processor plugin")
+		}
+		if _, err := regexp.Compile(rc.Pattern); err != nil {
+			return fmt.Errorf("bad pattern %q for regex processor plugin: %v", rc.Pattern, err)
+		}
+	}
+	return nil
+}
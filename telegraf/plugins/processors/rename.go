@@ -0,0 +1,91 @@
+package processors
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Rename is based on telegraf rename processor plugin.
+type Rename struct {
+	baseProcessor
+	Replaces []RenameReplace `json:"replaces"`
+}
+
+// RenameReplace renames a measurement, tag or field.
+type RenameReplace struct {
+	Measurement string `json:"measurement,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+	Field       string `json:"field,omitempty"`
+	Dest        string `json:"dest"`
+}
+
+// PluginName is based on telegraf plugin name.
+func (r *Rename) PluginName() string {
+	return "rename"
+}
+
+// TOML encodes to toml string.
+func (r *Rename) TOML() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `[[processors.%s]]
+  ## Measurements, tags and fields renamed, in order.
+`, r.PluginName())
+	for _, rr := range r.Replaces {
+		fmt.Fprintf(&b, "  [[processors.%s.replace]]\n", r.PluginName())
+		switch {
+		case rr.Measurement != "":
+			fmt.Fprintf(&b, "    measurement = %s\n", strconv.Quote(rr.Measurement))
+		case rr.Tag != "":
+			fmt.Fprintf(&b, "    tag = %s\n", strconv.Quote(rr.Tag))
+		case rr.Field != "":
+			fmt.Fprintf(&b, "    field = %s\n", strconv.Quote(rr.Field))
+		}
+		fmt.Fprintf(&b, "    dest = %s\n", strconv.Quote(rr.Dest))
+	}
+	return b.String()
+}
+
+// UnmarshalTOML decodes the parsed data to the object
+func (r *Rename) UnmarshalTOML(data interface{}) error {
+	dataOK, ok := data.(map[string]interface{})
+	if !ok {
+		return errors.New("bad replace for rename processor plugin")
+	}
+	replaces, err := tables(dataOK, "replace", r.PluginName())
+	if err != nil {
+		return err
+	}
+	for _, t := range replaces {
+		var rr RenameReplace
+		for key, dst := range map[string]*string{
+			"measurement": &rr.Measurement,
+			"tag":         &rr.Tag,
+			"field":       &rr.Field,
+			"dest":        &rr.Dest,
+		} {
+			if *dst, err = stringValue(t, key, r.PluginName()); err != nil {
+				return err
+			}
+		}
+		r.Replaces = append(r.Replaces, rr)
+	}
+	return r.Validate()
+}
+
+// Validate returns an error if the configuration is invalid.
+func (r *Rename) Validate() error {
+	if len(r.Replaces) == 0 {
+		return errors.New("replaces are missing for rename processor plugin")
+	}
+	for _, rr := range r.Replaces {
+		if countSet(rr.Measurement, rr.Tag, rr.Field) != 1 {
+			return errors.New("replace must rename one of a measurement, a tag or a field for rename processor plugin")
+		}
+		if rr.Dest == "" {
+			return errors.New("replace dest is missing for rename processor plugin")
+		}
+	}
+	return nil
+}
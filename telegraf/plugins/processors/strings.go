@@ -0,0 +1,154 @@
+package processors
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Strings is based on telegraf strings processor plugin.
+type Strings struct {
+	baseProcessor
+	Lowercase  []StringsConverter `json:"lowercase,omitempty"`
+	Uppercase  []StringsConverter `json:"uppercase,omitempty"`
+	Trim       []StringsConverter `json:"trim,omitempty"`
+	TrimLeft   []StringsConverter `json:"trimLeft,omitempty"`
+	TrimRight  []StringsConverter `json:"trimRight,omitempty"`
+	TrimPrefix []StringsConverter `json:"trimPrefix,omitempty"`
+	TrimSuffix []StringsConverter `json:"trimSuffix,omitempty"`
+	Replace    []StringsConverter `json:"replace,omitempty"`
+}
+
+// StringsConverter transforms the name of measurements, or the values of a tag or field.
+// Measurement, Tag and Field may be globs.
+type StringsConverter struct {
+	Measurement string `json:"measurement,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+	Field       string `json:"field,omitempty"`
+	// Dest is the tag or field the result is stored in. Defaults to the source tag or field.
+	Dest string `json:"dest,omitempty"`
+	// Cutset are the characters trimmed by trim, trimLeft and trimRight. Defaults to whitespace.
+	Cutset string `json:"cutset,omitempty"`
+	// Prefix is trimmed by trimPrefix, and Suffix by trimSuffix.
+	Prefix string `json:"prefix,omitempty"`
+	Suffix string `json:"suffix,omitempty"`
+	// Old is replaced by New by replace.
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+}
+
+// operations returns the converters by operation, in the order of the TOML.
+func (s *Strings) operations() []struct {
+	name       string
+	converters *[]StringsConverter
+} {
+	return []struct {
+		name       string
+		converters *[]StringsConverter
+	}{
+		{name: "lowercase", converters: &s.Lowercase},
+		{name: "uppercase", converters: &s.Uppercase},
+		{name: "trim", converters: &s.Trim},
+		{name: "trim_left", converters: &s.TrimLeft},
+		{name: "trim_right", converters: &s.TrimRight},
+		{name: "trim_prefix", converters: &s.TrimPrefix},
+		{name: "trim_suffix", converters: &s.TrimSuffix},
+		{name: "replace", converters: &s.Replace},
+	}
+}
+
+// options returns the options of c by TOML key, in order.
+func (c *StringsConverter) options() []struct {
+	key   string
+	value *string
+} {
+	return []struct {
+		key   string
+		value *string
+	}{
+		{key: "measurement", value: &c.Measurement},
+		{key: "tag", value: &c.Tag},
+		{key: "field", value: &c.Field},
+		{key: "dest", value: &c.Dest},
+		{key: "cutset", value: &c.Cutset},
+		{key: "prefix", value: &c.Prefix},
+		{key: "suffix", value: &c.Suffix},
+		{key: "old", value: &c.Old},
+		{key: "new", value: &c.New},
+	}
+}
+
+// PluginName is based on telegraf plugin name.
+func (s *Strings) PluginName() string {
+	return "strings"
+}
+
+// TOML encodes to toml string.
+func (s *Strings) TOML() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `[[processors.%s]]
+  ## The names of measurements, or the values of tags and fields, are transformed.
+`, s.PluginName())
+	for _, op := range s.operations() {
+		for _, c := range *op.converters {
+			fmt.Fprintf(&b, "  [[processors.%s.%s]]\n", s.PluginName(), op.name)
+			for _, opt := range c.options() {
+				// The replacement of replace may be empty.
+				if *opt.value != "" || (op.name == "replace" && opt.key == "new") {
+					fmt.Fprintf(&b, "    %s = %s\n", opt.key, strconv.Quote(*opt.value))
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+// UnmarshalTOML decodes the parsed data to the object
+func (s *Strings) UnmarshalTOML(data interface{}) error {
+	dataOK, ok := data.(map[string]interface{})
+	if !ok {
+		return errors.New("bad operations for strings processor plugin")
+	}
+	for _, op := range s.operations() {
+		ts, err := tables(dataOK, op.name, s.PluginName())
+		if err != nil {
+			return err
+		}
+		for _, t := range ts {
+			var c StringsConverter
+			for _, opt := range c.options() {
+				if *opt.value, err = stringValue(t, opt.key, s.PluginName()); err != nil {
+					return err
+				}
+			}
+			*op.converters = append(*op.converters, c)
+		}
+	}
+	return s.Validate()
+}
+
+// Validate returns an error if the configuration is invalid.
+func (s *Strings) Validate() error {
+	n := 0
+	for _, op := range s.operations() {
+		for _, c := range *op.converters {
+			n++
+			if countSet(c.Measurement, c.Tag, c.Field) != 1 {
+				return fmt.Errorf("%s must transform one of measurements, a tag or a field for strings processor plugin", op.name)
+			}
+			switch {
+			case op.name == "trim_prefix" && c.Prefix == "":
+				return errors.New("trim_prefix prefix is missing for strings processor plugin")
+			case op.name == "trim_suffix" && c.Suffix == "":
+				return errors.New("trim_suffix suffix is missing for strings processor plugin")
+			case op.name == "replace" && c.Old == "":
+				return errors.New("replace old is missing for strings processor plugin")
+			}
+		}
+	}
+	if n == 0 {
+		return errors.New("operations are missing for strings processor plugin")
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package plugins
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// tables are the TOML tables of the plugins of each type.
+var tables = map[Type]string{
+	Input:      "inputs",
+	Output:     "outputs",
+	Processor:  "processors",
+	Aggregator: "aggregators",
+}
+
+// TypeOfTable returns the type of the plugins of a TOML table, like inputs, and false if there is none.
+func TypeOfTable(table string) (Type, bool) {
+	for typ, t := range tables {
+		if t == table {
+			return typ, true
+		}
+	}
+	return "", false
+}
+
+// Raw is a plugin without typed configuration, kept as the TOML of its table.
+type Raw struct {
+	PluginType Type   `json:"-"`
+	Name       string `json:"-"`
+	// Config is the TOML of the plugin, like [[inputs.kafka_consumer]] followed by its options.
+	Config string `json:"toml"`
+}
+
+// PluginName is the name of the plugin.
+func (r *Raw) PluginName() string {
+	return r.Name
+}
+
+// Type is the plugin type.
+func (r *Raw) Type() Type {
+	return r.PluginType
+}
+
+// TOML encodes to toml string.
+func (r *Raw) TOML() string {
+	if r.Config == "" || r.Config[len(r.Config)-1] == '\n' {
+		return r.Config
+	}
+	return r.Config + "\n"
+}
+
+// UnmarshalTOML encodes the parsed data of the plugin, whose type and name must be set, to its TOML.
+func (r *Raw) UnmarshalTOML(data interface{}) error {
+	table, ok := tables[r.PluginType]
+	if !ok || r.Name == "" {
+		return errors.New("raw plugin requires a type and a name")
+	}
+	config, ok := data.(map[string]interface{})
+	if !ok {
+		config = map[string]interface{}{}
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(map[string]interface{}{
+		table: map[string]interface{}{
+			r.Name: []map[string]interface{}{config},
+		},
+	}); err != nil {
+		return fmt.Errorf("cannot encode %s %s plugin: %v", r.Name, r.PluginType, err)
+	}
+	// The encoder nests the plugin in the table of its type, which the configuration defines once per plugin.
+	// Strings are encoded on a single line, so that only the table headers and keys are indented.
+	lines := strings.Split(strings.TrimPrefix(buf.String(), "["+table+"]\n"), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimPrefix(l, "  ")
+	}
+	r.Config = strings.TrimLeft(strings.Join(lines, "\n"), "\n")
+	return nil
+}
+
+// Validate returns an error if the TOML isn't the table of the plugin only.
+func (r *Raw) Validate() error {
+	table, ok := tables[r.PluginType]
+	if !ok {
+		return fmt.Errorf("unsupported telegraf plugin type %s", r.PluginType)
+	}
+	var data map[string]map[string][]map[string]interface{}
+	if _, err := toml.Decode(r.Config, &data); err != nil {
+		return fmt.Errorf("bad toml for %s %s plugin: %v", r.Name, r.PluginType, err)
+	}
+	if len(data) != 1 || len(data[table]) != 1 || len(data[table][r.Name]) != 1 {
+		return fmt.Errorf("toml of %s %s plugin must hold its [[%s.%s]] table only", r.Name, r.PluginType, table, r.Name)
+	}
+	return nil
+}
@@ -22,3 +22,9 @@ type Config interface {
 	// PluginName is the string value of telegraf plugin package name.
 	PluginName() string
 }
+
+// Validator is implemented by the plugins whose configurations can be invalid.
+type Validator interface {
+	// Validate returns an error if the configuration is invalid.
+	Validate() error
+}
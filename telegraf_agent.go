@@ -0,0 +1,82 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// ops for telegraf agents error and logs.
+var (
+	OpCheckInTelegrafAgent        = "CheckInTelegrafAgent"
+	OpFindTelegrafAgents          = "FindTelegrafAgents"
+	OpFindTelegrafConfigRevisions = "FindTelegrafConfigRevisions"
+)
+
+// TelegrafConfigRevision is the telegraf config as of one of its revisions.
+// Creating a telegraf config stores revision 1, and each update changing its TOML stores the next revision.
+type TelegrafConfigRevision struct {
+	TelegrafConfigID ID        `json:"telegrafConfigID"`
+	Revision         int       `json:"revision"`
+	UserID           ID        `json:"userID"`
+	CreatedAt        time.Time `json:"createdAt"`
+	// TOML is the config the agents were given as of the revision.
+	TOML string `json:"toml"`
+}
+
+// TelegrafAgentCheckIn is what a telegraf agent reports when it checks in.
+type TelegrafAgentCheckIn struct {
+	TelegrafConfigID ID     `json:"telegrafConfigID"`
+	Hostname         string `json:"hostname"`
+	// Version is the version of telegraf the agent runs.
+	Version string `json:"version"`
+	// Revision is the revision of the telegraf config the agent applied.
+	Revision int `json:"revision"`
+}
+
+// Valid returns an error if the check in has no hostname or a negative revision.
+func (c TelegrafAgentCheckIn) Valid() error {
+	if !c.TelegrafConfigID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "telegraf config ID is invalid",
+			Op:   OpCheckInTelegrafAgent,
+		}
+	}
+	if c.Hostname == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "telegraf agent hostname is required",
+			Op:   OpCheckInTelegrafAgent,
+		}
+	}
+	if c.Revision < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "telegraf config revision must not be negative",
+			Op:   OpCheckInTelegrafAgent,
+		}
+	}
+	return nil
+}
+
+// TelegrafAgent is a telegraf agent using a telegraf config, as of its last check in.
+// Agents are told apart by their hostname.
+type TelegrafAgent struct {
+	TelegrafAgentCheckIn
+	LastSeen time.Time `json:"lastSeen"`
+	// Stale is whether the agent applied an older revision than the latest one of the telegraf config.
+	Stale bool `json:"stale"`
+}
+
+// TelegrafAgentService records the telegraf agents using telegraf configs,
+// and the revisions of the telegraf configs.
+type TelegrafAgentService interface {
+	// CheckInTelegrafAgent records the check in of an agent, and returns the agent.
+	CheckInTelegrafAgent(ctx context.Context, c TelegrafAgentCheckIn) (*TelegrafAgent, error)
+
+	// FindTelegrafAgents returns the agents that checked in for the telegraf config id, by hostname.
+	FindTelegrafAgents(ctx context.Context, id ID) ([]*TelegrafAgent, error)
+
+	// FindTelegrafConfigRevisions returns the revisions of the telegraf config id, latest first.
+	FindTelegrafConfigRevisions(ctx context.Context, id ID) ([]*TelegrafConfigRevision, error)
+}
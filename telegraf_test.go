@@ -11,8 +11,11 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/influxdata/influxdb/telegraf/plugins"
+	"github.com/influxdata/influxdb/telegraf/plugins/aggregators"
+	"github.com/influxdata/influxdb/telegraf/plugins/catalog"
 	"github.com/influxdata/influxdb/telegraf/plugins/inputs"
 	"github.com/influxdata/influxdb/telegraf/plugins/outputs"
+	"github.com/influxdata/influxdb/telegraf/plugins/processors"
 )
 
 var telegrafCmpOptions = cmp.Options{
@@ -23,6 +26,11 @@ var telegrafCmpOptions = cmp.Options{
 		inputs.File{},
 		outputs.File{},
 		outputs.InfluxDBV2{},
+		processors.Rename{},
+		processors.Strings{},
+		aggregators.BasicStats{},
+		aggregators.Histogram{},
+		catalog.Plugin{},
 		unsupportedPlugin{},
 	),
 	cmp.Transformer("Sort", func(in []*TelegrafConfig) []*TelegrafConfig {
@@ -47,7 +55,7 @@ func (u *unsupportedPluginType) PluginName() string {
 }
 
 func (u *unsupportedPluginType) Type() plugins.Type {
-	return plugins.Aggregator
+	return plugins.Type("serializer")
 }
 
 func (u *unsupportedPluginType) UnmarshalTOML(data interface{}) error {
@@ -198,6 +206,105 @@ func TestTelegrafConfigJSON(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "processors and aggregators",
+			cfg: &TelegrafConfig{
+				ID:             *id1,
+				OrganizationID: *id2,
+				Name:           "n1",
+				Agent: TelegrafAgentConfig{
+					Interval: 4000,
+				},
+				Plugins: []TelegrafPlugin{
+					{
+						Config: &processors.Rename{
+							Replaces: []processors.RenameReplace{{Tag: "host", Dest: "hostname"}},
+						},
+					},
+					{
+						Config: &processors.Strings{
+							Lowercase: []processors.StringsConverter{{Tag: "method"}},
+						},
+					},
+					{
+						Config: &aggregators.BasicStats{Period: "1m", Stats: []string{"count"}},
+					},
+					{
+						Config: &aggregators.Histogram{
+							Configs: []aggregators.HistogramConfig{
+								{Buckets: []float64{0, 50, 100}, MeasurementName: "cpu"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid processor",
+			cfg: &TelegrafConfig{
+				ID:             *id1,
+				OrganizationID: *id2,
+				Name:           "n1",
+				Plugins: []TelegrafPlugin{
+					{
+						Config: &processors.Rename{
+							Replaces: []processors.RenameReplace{{Tag: "host"}},
+						},
+					},
+				},
+			},
+			err: &Error{
+				Code: EInvalid,
+				Msg:  "replace dest is missing for rename processor plugin",
+				Op:   "unmarshal telegraf config raw plugin",
+			},
+		},
+		{
+			name: "catalog plugins",
+			cfg: &TelegrafConfig{
+				ID:             *id1,
+				OrganizationID: *id2,
+				Name:           "n1",
+				Agent: TelegrafAgentConfig{
+					Interval: 4000,
+				},
+				Plugins: []TelegrafPlugin{
+					{
+						Comment: "comment1",
+						Config: catalogPlugin("mysql", map[string]interface{}{
+							"servers":        []interface{}{"root@tcp(127.0.0.1:3306)/"},
+							"metric_version": float64(2),
+						}),
+					},
+					{
+						Config: catalogPlugin("http", map[string]interface{}{
+							"urls":        []interface{}{"http://localhost/metrics"},
+							"data_format": "json",
+						}),
+					},
+				},
+			},
+		},
+		{
+			name: "invalid catalog plugin",
+			cfg: &TelegrafConfig{
+				ID:             *id1,
+				OrganizationID: *id2,
+				Name:           "n1",
+				Plugins: []TelegrafPlugin{
+					{
+						Config: catalogPlugin("postgresql", map[string]interface{}{
+							"servers": []interface{}{"localhost"},
+						}),
+					},
+				},
+			},
+			err: &Error{
+				Code: EInvalid,
+				Msg:  "invalid options of postgresql input plugin: /: Property 'servers' is unsupported",
+				Op:   "unmarshal telegraf config raw plugin",
+			},
+		},
 		{
 			name: "unsupported plugin type",
 			cfg: &TelegrafConfig{
@@ -215,7 +322,7 @@ func TestTelegrafConfigJSON(t *testing.T) {
 			},
 			err: &Error{
 				Code: EInvalid,
-				Msg:  fmt.Sprintf(ErrUnsupportTelegrafPluginType, "aggregator"),
+				Msg:  fmt.Sprintf(ErrUnsupportTelegrafPluginType, "serializer"),
 				Op:   "unmarshal telegraf config raw plugin",
 			},
 		},
@@ -258,6 +365,17 @@ func TestTelegrafConfigJSON(t *testing.T) {
 	}
 }
 
+// catalogPlugin returns the input name of the catalog with options.
+func catalogPlugin(name string, options map[string]interface{}) *catalog.Plugin {
+	s, err := catalog.FindSchema(plugins.Input, name)
+	if err != nil {
+		panic(err)
+	}
+	p := catalog.NewPlugin(s)
+	p.Options = options
+	return p
+}
+
 func TestTOML(t *testing.T) {
 	id1, _ := IDFromString("020f755c3c082000")
 
@@ -407,3 +525,190 @@ func TestTOML(t *testing.T) {
 		t.Fatalf("telegraf toml parsing issue, want %q, got %q", tc, tcr)
 	}
 }
+
+func TestTOML_ProcessorsAndAggregators(t *testing.T) {
+	tc := &TelegrafConfig{
+		Agent: TelegrafAgentConfig{
+			Interval: 10000,
+		},
+		Plugins: []TelegrafPlugin{
+			{
+				Config: &processors.Rename{
+					Replaces: []processors.RenameReplace{{Measurement: "cpu", Dest: "processor"}},
+				},
+			},
+			{
+				Config: &aggregators.Histogram{
+					Period: "1m",
+					Configs: []aggregators.HistogramConfig{
+						{Buckets: []float64{0, 50.5, 100}, MeasurementName: "cpu", Fields: []string{"usage_idle"}},
+					},
+				},
+			},
+		},
+	}
+
+	got := new(TelegrafConfig)
+	if err := toml.Unmarshal([]byte(tc.TOML()), got); err != nil {
+		t.Fatalf("telegraf toml parsing issue %s", err.Error())
+	}
+	// The plugins of the TOML are decoded by type, in no particular order.
+	byName := cmpopts.SortSlices(func(a, b TelegrafPlugin) bool {
+		return a.Config.PluginName() < b.Config.PluginName()
+	})
+	if diff := cmp.Diff(got, tc, telegrafCmpOptions, byName); diff != "" {
+		t.Fatalf("telegraf configs are different -got/+want\ndiff %s", diff)
+	}
+}
+
+func TestParseTelegrafConfigTOML(t *testing.T) {
+	conf := `
+[global_tags]
+  dc = "us-east-1"
+
+[agent]
+  interval = "30s"
+  metric_batch_size = 5000
+  flush_interval = "15s"
+
+[[inputs.cpu]]
+  percpu = true
+  totalcpu = true
+  collect_cpu_time = false
+  report_active = false
+
+[[inputs.cpu]]
+  percpu = false
+
+[[inputs.kafka_consumer]]
+  brokers = ["localhost:9092"]
+  topics = ["telegraf"]
+  [inputs.kafka_consumer.tags]
+    source = "kafka"
+
+[[inputs.mysql]]
+  servers = ["root@tcp(127.0.0.1:3306)/"]
+  metric_version = 2
+
+[[processors.rename]]
+  [[processors.rename.replace]]
+    tag = "host"
+    dest = "hostname"
+
+[[outputs.influxdb_v2]]
+  urls = ["http://127.0.0.1:9999"]
+  token = "token1"
+  organization = "org1"
+  bucket = "bucket1"
+`
+	tc, err := ParseTelegrafConfigTOML(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &TelegrafConfig{
+		Agent: TelegrafAgentConfig{
+			Interval: 30000,
+			Options: map[string]interface{}{
+				"metric_batch_size": int64(5000),
+				"flush_interval":    "15s",
+			},
+			GlobalTags: map[string]string{"dc": "us-east-1"},
+		},
+		Plugins: []TelegrafPlugin{
+			{Config: &inputs.CPUStats{}},
+			{Config: &plugins.Raw{
+				PluginType: plugins.Input,
+				Name:       "cpu",
+				Config: `[[inputs.cpu]]
+  percpu = false
+`,
+			}},
+			{Config: &plugins.Raw{
+				PluginType: plugins.Input,
+				Name:       "kafka_consumer",
+				Config: `[[inputs.kafka_consumer]]
+  brokers = ["localhost:9092"]
+  topics = ["telegraf"]
+  [inputs.kafka_consumer.tags]
+    source = "kafka"
+`,
+			}},
+			{Config: catalogPlugin("mysql", map[string]interface{}{
+				"servers":        []interface{}{"root@tcp(127.0.0.1:3306)/"},
+				"metric_version": float64(2),
+			})},
+			{Config: &outputs.InfluxDBV2{
+				URLs:         []string{"http://127.0.0.1:9999"},
+				Token:        "token1",
+				Organization: "org1",
+				Bucket:       "bucket1",
+			}},
+			{Config: &processors.Rename{
+				Replaces: []processors.RenameReplace{{Tag: "host", Dest: "hostname"}},
+			}},
+		},
+	}
+	// The plugins of the TOML are decoded by type, in no particular order.
+	byTOML := cmpopts.SortSlices(func(a, b TelegrafPlugin) bool {
+		return string(a.Config.Type())+a.Config.TOML() < string(b.Config.Type())+b.Config.TOML()
+	})
+	if diff := cmp.Diff(tc, want, telegrafCmpOptions, byTOML); diff != "" {
+		t.Fatalf("telegraf configs are different -got/+want\ndiff %s", diff)
+	}
+
+	// The config round-trips through its TOML, and through JSON.
+	again, err := ParseTelegrafConfigTOML(tc.TOML())
+	if err != nil {
+		t.Fatalf("cannot parse the toml of the imported config: %v", err)
+	}
+	if diff := cmp.Diff(again, want, telegrafCmpOptions, byTOML); diff != "" {
+		t.Fatalf("telegraf configs are different -got/+want\ndiff %s", diff)
+	}
+
+	id, _ := IDFromString("020f755c3c082000")
+	tc.ID = *id
+	b, err := json.Marshal(tc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := new(TelegrafConfig)
+	if err := json.Unmarshal(b, decoded); err != nil {
+		t.Fatal(err)
+	}
+	again, err = ParseTelegrafConfigTOML(decoded.TOML())
+	if err != nil {
+		t.Fatalf("cannot parse the toml of the decoded config: %v", err)
+	}
+	if diff := cmp.Diff(again, want, telegrafCmpOptions, byTOML); diff != "" {
+		t.Fatalf("telegraf configs are different -got/+want\ndiff %s", diff)
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, conf := range []string{
+			"[agent\n",
+			"[agent]\n  interval = 10\n",
+			"[[serializers.json]]\n",
+			// The options of the catalog plugin don't match its schema.
+			"[[inputs.postgresql]]\n  address = \"host=localhost\"\n  unknown_option = true\n",
+		} {
+			_, err := ParseTelegrafConfigTOML(conf)
+			if ErrorCode(err) != EInvalid {
+				t.Fatalf("expected an invalid config error for %q, got %v", conf, err)
+			}
+		}
+	})
+
+	t.Run("invalid raw plugin", func(t *testing.T) {
+		for _, s := range []string{
+			`{"plugins": [{"name": "kafka_consumer", "type": "input", "config": {"toml": "[[outputs.file]]"}}]}`,
+			// The options of the catalog plugins are validated against their schema.
+			`{"plugins": [{"name": "mysql", "type": "input", "config": {"toml": "[[inputs.mysql]]\n  unknown_option = true\n"}}]}`,
+		} {
+			err := json.Unmarshal([]byte(s), new(TelegrafConfig))
+			if ErrorCode(err) != EInvalid {
+				t.Fatalf("expected an invalid config error for %s, got %v", s, err)
+			}
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	platform "github.com/influxdata/influxdb"
@@ -291,6 +292,165 @@ func AddTarget(
 				},
 			},
 		},
+		{
+			name: "create target with scrape options",
+			fields: TargetFields{
+				IDGenerator:          mock.NewIDGenerator(targetOneID, t),
+				Targets:              []*platform.ScraperTarget{},
+				UserResourceMappings: []*platform.UserResourceMapping{},
+			},
+			args: args{
+				userID: MustIDBase16(threeID),
+				target: &platform.ScraperTarget{
+					Name:              "name1",
+					Type:              platform.PrometheusScraperType,
+					OrgID:             MustIDBase16(orgOneID),
+					BucketID:          MustIDBase16(bucketOneID),
+					URL:               "url1",
+					Interval:          &platform.Duration{Duration: time.Minute},
+					Timeout:           &platform.Duration{Duration: 10 * time.Second},
+					BearerTokenSecret: "token",
+					Headers:           map[string]string{"X-Scope": "metrics"},
+					TLS:               &platform.ScraperTLSConfig{InsecureSkipVerify: true},
+				},
+			},
+			wants: wants{
+				userResourceMappings: []*platform.UserResourceMapping{
+					{
+						ResourceID:   MustIDBase16(oneID),
+						ResourceType: platform.ScraperResourceType,
+						UserID:       MustIDBase16(threeID),
+						UserType:     platform.Owner,
+					},
+				},
+				targets: []platform.ScraperTarget{
+					{
+						Name:              "name1",
+						Type:              platform.PrometheusScraperType,
+						OrgID:             MustIDBase16(orgOneID),
+						BucketID:          MustIDBase16(bucketOneID),
+						URL:               "url1",
+						ID:                MustIDBase16(targetOneID),
+						Interval:          &platform.Duration{Duration: time.Minute},
+						Timeout:           &platform.Duration{Duration: 10 * time.Second},
+						BearerTokenSecret: "token",
+						Headers:           map[string]string{"X-Scope": "metrics"},
+						TLS:               &platform.ScraperTLSConfig{InsecureSkipVerify: true},
+					},
+				},
+			},
+		},
+		{
+			name: "create target with timeout longer than interval",
+			fields: TargetFields{
+				IDGenerator:          mock.NewIDGenerator(targetOneID, t),
+				Targets:              []*platform.ScraperTarget{},
+				UserResourceMappings: []*platform.UserResourceMapping{},
+			},
+			args: args{
+				target: &platform.ScraperTarget{
+					Name:     "name1",
+					Type:     platform.PrometheusScraperType,
+					OrgID:    MustIDBase16(orgOneID),
+					BucketID: MustIDBase16(bucketOneID),
+					URL:      "url1",
+					Interval: &platform.Duration{Duration: time.Second},
+					Timeout:  &platform.Duration{Duration: time.Minute},
+				},
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.EInvalid,
+					Msg:  "scraper timeout 1m0s is longer than its interval 1s",
+					Op:   platform.OpAddTarget,
+				},
+				userResourceMappings: []*platform.UserResourceMapping{},
+				targets:              []platform.ScraperTarget{},
+			},
+		},
+		{
+			name: "create target with discovery file outside of the discovery directory",
+			fields: TargetFields{
+				IDGenerator:          mock.NewIDGenerator(targetOneID, t),
+				Targets:              []*platform.ScraperTarget{},
+				UserResourceMappings: []*platform.UserResourceMapping{},
+			},
+			args: args{
+				target: &platform.ScraperTarget{
+					Name:     "name1",
+					Type:     platform.PrometheusScraperType,
+					OrgID:    MustIDBase16(orgOneID),
+					BucketID: MustIDBase16(bucketOneID),
+					Discovery: &platform.ScraperDiscovery{
+						Type:  platform.FileScraperDiscovery,
+						Files: []string{"../targets.json"},
+					},
+				},
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.EInvalid,
+					Msg:  `invalid discovery file "../targets.json", must be relative to the discovery directory`,
+					Op:   platform.OpAddTarget,
+				},
+				userResourceMappings: []*platform.UserResourceMapping{},
+				targets:              []platform.ScraperTarget{},
+			},
+		},
+		{
+			name: "create json target with an invalid field path",
+			fields: TargetFields{
+				IDGenerator:          mock.NewIDGenerator(targetOneID, t),
+				Targets:              []*platform.ScraperTarget{},
+				UserResourceMappings: []*platform.UserResourceMapping{},
+			},
+			args: args{
+				target: &platform.ScraperTarget{
+					Name:     "name1",
+					Type:     platform.JSONScraperType,
+					OrgID:    MustIDBase16(orgOneID),
+					BucketID: MustIDBase16(bucketOneID),
+					JSON: &platform.ScraperJSONConfig{
+						Measurement: "jvm",
+						Fields:      map[string]string{"used": "memory.used"},
+					},
+				},
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.EInvalid,
+					Msg:  `json scraper field "used": invalid JSONPath "memory.used": must start with $`,
+					Op:   platform.OpAddTarget,
+				},
+				userResourceMappings: []*platform.UserResourceMapping{},
+				targets:              []platform.ScraperTarget{},
+			},
+		},
+		{
+			name: "create target of an unknown type",
+			fields: TargetFields{
+				IDGenerator:          mock.NewIDGenerator(targetOneID, t),
+				Targets:              []*platform.ScraperTarget{},
+				UserResourceMappings: []*platform.UserResourceMapping{},
+			},
+			args: args{
+				target: &platform.ScraperTarget{
+					Name:     "name1",
+					Type:     "graphite",
+					OrgID:    MustIDBase16(orgOneID),
+					BucketID: MustIDBase16(bucketOneID),
+				},
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.EInvalid,
+					Msg:  `unknown scraper type "graphite"`,
+					Op:   platform.OpAddTarget,
+				},
+				userResourceMappings: []*platform.UserResourceMapping{},
+				targets:              []platform.ScraperTarget{},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
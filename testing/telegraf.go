@@ -31,6 +31,8 @@ var telegrafCmpOptions = cmp.Options{
 		outputs.File{},
 		outputs.InfluxDBV2{},
 	),
+	// Only stores keeping revisions set it.
+	cmpopts.IgnoreFields(platform.TelegrafConfig{}, "Revision"),
 	cmp.Transformer("Sort", func(in []*platform.TelegrafConfig) []*platform.TelegrafConfig {
 		out := append([]*platform.TelegrafConfig(nil), in...)
 		sort.Slice(out, func(i, j int) bool {